@@ -84,3 +84,74 @@ func (ac *AdminController) GetAdminProfile(ctx *gin.Context) {
 	response := helper.SuccessResponse(res, "Admin profile retrieved successfully", "Lấy thông tin admin thành công")
 	helper.GinResponse(ctx, 200, response)
 }
+
+// CreateGeofenceOverride lets the driver start or end a ride once without the location checks
+// @Summary Override the geofence of a ride
+// @Description Let the driver start or end a ride once without the location checks, the note is kept for the audit
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.CreateGeofenceOverrideRequest true "Geofence override request"
+// @Success 200 {object} helper.Response{data=schemas.GeofenceOverrideDetail} "Geofence override created successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/ride/geofence-override [post]
+func (ac *AdminController) CreateGeofenceOverride(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToAdminPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.CreateGeofenceOverrideRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ac.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	override, err := ac.AdminService.CreateGeofenceOverride(req, data.AdminID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to create geofence override",
+			"Không thể bỏ qua kiểm tra vị trí",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GeofenceOverrideDetail{
+		ID:        override.ID,
+		RideID:    override.RideID,
+		AdminID:   override.AdminID,
+		Action:    override.Action,
+		Note:      override.Note,
+		CreatedAt: override.CreatedAt,
+		UsedAt:    override.UsedAt,
+	}
+
+	response := helper.SuccessResponse(res, "Geofence override created successfully", "Bỏ qua kiểm tra vị trí thành công")
+	helper.GinResponse(ctx, 200, response)
+}
@@ -469,18 +469,21 @@ func (ctrl *AuthController) VerifyCCCD(ctx *gin.Context) {
 
 	res := schemas.VerifyCCCDResponse{
 		User: schemas.UserResponse{
-			ID:           user.ID,
-			AvatarURL:    user.AvatarURL,
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
-			PhoneNumber:  user.PhoneNumber,
-			Email:        user.Email,
-			FullName:     user.FullName,
-			IsVerified:   user.IsVerified,
-			IsActivated:  user.IsActivated,
-			Role:         user.Role,
-			Gender:       user.Gender,
-			IsMomoLinked: user.IsMomoLinked,
+			ID:              user.ID,
+			AvatarURL:       user.AvatarURL,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+			PhoneNumber:     user.PhoneNumber,
+			Email:           user.Email,
+			FullName:        user.FullName,
+			IsVerified:      user.IsVerified,
+			IsActivated:     user.IsActivated,
+			Role:            user.Role,
+			RidePreferences: helper.ToRidePreferencesDetail(user.RidePreferences),
+			Gender:          user.Gender,
+			IsMomoLinked:    user.IsMomoLinked,
+			AverageRating:   user.AverageRating,
+			RatingCount:     user.RatingCount,
 		},
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -647,18 +650,21 @@ func (ctrl *AuthController) VerifyLoginOTP(ctx *gin.Context) {
 
 	res := schemas.VerifyLoginOTPResponse{
 		User: schemas.UserResponse{
-			ID:           user.ID,
-			AvatarURL:    user.AvatarURL,
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
-			PhoneNumber:  user.PhoneNumber,
-			Email:        user.Email,
-			FullName:     user.FullName,
-			Gender:       user.Gender,
-			IsVerified:   user.IsVerified,
-			IsActivated:  user.IsActivated,
-			Role:         user.Role,
-			IsMomoLinked: user.IsMomoLinked,
+			ID:              user.ID,
+			AvatarURL:       user.AvatarURL,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+			PhoneNumber:     user.PhoneNumber,
+			Email:           user.Email,
+			FullName:        user.FullName,
+			Gender:          user.Gender,
+			IsVerified:      user.IsVerified,
+			IsActivated:     user.IsActivated,
+			Role:            user.Role,
+			RidePreferences: helper.ToRidePreferencesDetail(user.RidePreferences),
+			IsMomoLinked:    user.IsMomoLinked,
+			AverageRating:   user.AverageRating,
+			RatingCount:     user.RatingCount,
 		},
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -54,6 +55,7 @@ func NewChatController(validate *validator.Validate, rideService service.IRideSe
 // @Param request body schemas.SendMessageRequest true "Send message request"
 // @Success 200 {object} helper.Response{data=schemas.SendMessageResponse} "Message sent successfully"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "One of the users blocked the other"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /chat/send-message [post]
 func (cc *ChatController) SendMessage(ctx *gin.Context) {
@@ -99,12 +101,16 @@ func (cc *ChatController) SendMessage(ctx *gin.Context) {
 	// Send message
 	message, err := cc.ChatService.SendMessage(req, data.UserID)
 	if err != nil {
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
 		response := helper.ErrorResponseWithMessage(
 			err,
 			"Failed to send message",
 			"Không thể gửi tin nhắn",
 		)
-		helper.GinResponse(ctx, 500, response)
+		helper.GinResponse(ctx, status, response)
 		return
 	}
 
@@ -208,6 +214,7 @@ func (cc *ChatController) SendMessage(ctx *gin.Context) {
 // @Param receiverID formData string true "Receiver ID"
 // @Success 200 {object} helper.Response{data=schemas.SendImageResponse} "Image sent successfully"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "One of the users blocked the other"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /chat/send-image [post]
 func (cc *ChatController) SendImage(ctx *gin.Context) {
@@ -287,12 +294,16 @@ func (cc *ChatController) SendImage(ctx *gin.Context) {
 	// Get image and upload to cloud storage
 	chat, err := cc.ChatService.UploadImage(ctx.Request.Context(), req, data.UserID)
 	if err != nil {
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
 		response := helper.ErrorResponseWithMessage(
 			err,
 			"Failed to upload image",
 			"Không thể upload ảnh",
 		)
-		helper.GinResponse(ctx, 500, response)
+		helper.GinResponse(ctx, status, response)
 		return
 	}
 
@@ -448,12 +459,14 @@ func (cc *ChatController) GetAllChatRooms(ctx *gin.Context) {
 		}
 
 		userInfos[i] = schemas.UserInfo{
-			ID:           receiver.ID,
-			FullName:     receiver.FullName,
-			PhoneNumber:  receiver.PhoneNumber,
-			Gender:       receiver.Gender,
-			AvatarURL:    receiver.AvatarURL,
-			IsMomoLinked: receiver.IsMomoLinked,
+			ID:            receiver.ID,
+			FullName:      receiver.FullName,
+			PhoneNumber:   receiver.PhoneNumber,
+			Gender:        receiver.Gender,
+			AvatarURL:     receiver.AvatarURL,
+			IsMomoLinked:  receiver.IsMomoLinked,
+			AverageRating: receiver.AverageRating,
+			RatingCount:   receiver.RatingCount,
 		}
 	}
 
@@ -579,6 +592,7 @@ func (cc *ChatController) GetChatMessages(ctx *gin.Context) {
 // @Param receiverID query string true "Receiver ID"
 // @Success 200 {object} helper.Response{data=schemas.InitiateCallResponse} "Call initiated successfully"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "One of the users blocked the other"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /chat/initiate-call [get]
 func (cc *ChatController) InitiateCall(ctx *gin.Context) {
@@ -665,12 +679,16 @@ func (cc *ChatController) InitiateCall(ctx *gin.Context) {
 	// The message type is call or missed_call
 	chat, err := cc.ChatService.InitiateCall(req, data.UserID)
 	if err != nil {
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
 		response := helper.ErrorResponseWithMessage(
 			err,
 			"Failed to initiate call",
 			"Không thể khởi tạo cuộc gọi",
 		)
-		helper.GinResponse(ctx, 500, response)
+		helper.GinResponse(ctx, status, response)
 		return
 	}
 
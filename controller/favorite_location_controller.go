@@ -0,0 +1,368 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/middleware"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type FavoriteLocationController struct {
+	validate *validator.Validate
+	service  service.IFavoriteLocationService
+}
+
+func NewFavoriteLocationController(validate *validator.Validate, service service.IFavoriteLocationService) *FavoriteLocationController {
+	return &FavoriteLocationController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// CreateFavoriteLocation godoc
+// @Summary Create a favorite location
+// @Description Save a place as the home, the work or a custom favorite location of the user, it is added at the end of the list and can be used as favorite:<favorite_location_id> in the place list of a ride
+// @Tags favorite-location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.CreateFavoriteLocationRequest true "Favorite location request"
+// @Success 200 {object} helper.Response{data=schemas.FavoriteLocationResponse} "Favorite location created successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 409 {object} helper.Response "Home or work already saved, or too many favorite locations"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /favorite-location/create [post]
+func (ctrl *FavoriteLocationController) CreateFavoriteLocation(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.CreateFavoriteLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	favoriteLocation, err := ctrl.service.CreateFavoriteLocation(ctx.Request.Context(), req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to create favorite location",
+			"Không thể lưu địa điểm yêu thích",
+		)
+		helper.GinResponse(ctx, favoriteLocationErrorStatus(err), response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toFavoriteLocationResponse(favoriteLocation),
+		"Favorite location created successfully",
+		"Lưu địa điểm yêu thích thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetFavoriteLocations godoc
+// @Summary Get favorite locations
+// @Description Get all favorite locations of the current user in the order the user chose
+// @Tags favorite-location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helper.Response{data=schemas.GetFavoriteLocationsResponse} "Favorite locations retrieved successfully"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /favorite-location/get-all [get]
+func (ctrl *FavoriteLocationController) GetFavoriteLocations(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	favoriteLocations, err := ctrl.service.GetFavoriteLocations(data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get favorite locations",
+			"Không thể lấy danh sách địa điểm yêu thích",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toGetFavoriteLocationsResponse(favoriteLocations),
+		"Favorite locations retrieved successfully",
+		"Lấy danh sách địa điểm yêu thích thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// UpdateFavoriteLocation godoc
+// @Summary Update a favorite location
+// @Description Rename a favorite location or move it to another place, the place ID and the address are changed together
+// @Tags favorite-location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.UpdateFavoriteLocationRequest true "Update favorite location request"
+// @Success 200 {object} helper.Response{data=schemas.FavoriteLocationResponse} "Favorite location updated successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "Favorite location not found"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /favorite-location/update [post]
+func (ctrl *FavoriteLocationController) UpdateFavoriteLocation(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.UpdateFavoriteLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	favoriteLocation, err := ctrl.service.UpdateFavoriteLocation(ctx.Request.Context(), req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to update favorite location",
+			"Không thể cập nhật địa điểm yêu thích",
+		)
+		helper.GinResponse(ctx, favoriteLocationErrorStatus(err), response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toFavoriteLocationResponse(favoriteLocation),
+		"Favorite location updated successfully",
+		"Cập nhật địa điểm yêu thích thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// ReorderFavoriteLocations godoc
+// @Summary Reorder favorite locations
+// @Description Save a new order of the favorite locations, the list must contain every favorite location of the user exactly once
+// @Tags favorite-location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.ReorderFavoriteLocationsRequest true "Reorder favorite locations request"
+// @Success 200 {object} helper.Response{data=schemas.GetFavoriteLocationsResponse} "Favorite locations reordered successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /favorite-location/reorder [post]
+func (ctrl *FavoriteLocationController) ReorderFavoriteLocations(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.ReorderFavoriteLocationsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	favoriteLocations, err := ctrl.service.ReorderFavoriteLocations(req.FavoriteLocationIDs, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to reorder favorite locations",
+			"Không thể sắp xếp địa điểm yêu thích",
+		)
+		helper.GinResponse(ctx, favoriteLocationErrorStatus(err), response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toGetFavoriteLocationsResponse(favoriteLocations),
+		"Favorite locations reordered successfully",
+		"Sắp xếp địa điểm yêu thích thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// DeleteFavoriteLocation godoc
+// @Summary Delete a favorite location
+// @Description Delete a favorite location of the current user
+// @Tags favorite-location
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.DeleteFavoriteLocationRequest true "Delete favorite location request"
+// @Success 200 {object} helper.Response "Favorite location deleted successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "Favorite location not found"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /favorite-location/delete [post]
+func (ctrl *FavoriteLocationController) DeleteFavoriteLocation(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.DeleteFavoriteLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.DeleteFavoriteLocation(req.FavoriteLocationID, data.UserID); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to delete favorite location",
+			"Không thể xóa địa điểm yêu thích",
+		)
+		helper.GinResponse(ctx, favoriteLocationErrorStatus(err), response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		nil,
+		"Favorite location deleted successfully",
+		"Xóa địa điểm yêu thích thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// favoriteLocationErrorStatus maps the errors of the favorite locations to their status code
+func favoriteLocationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrFavoriteLocationOrder):
+		return 400
+	case errors.Is(err, repository.ErrFavoriteLocationNotFound):
+		return 404
+	case errors.Is(err, service.ErrFavoriteLocationTypeTaken), errors.Is(err, service.ErrFavoriteLocationLimit):
+		return 409
+	}
+	return 500
+}
+
+func toFavoriteLocationResponse(favoriteLocation migration.FavoriteLocation) schemas.FavoriteLocationResponse {
+	return schemas.FavoriteLocationResponse{
+		ID:        favoriteLocation.ID,
+		CreatedAt: favoriteLocation.CreatedAt,
+		Type:      favoriteLocation.Type,
+		Name:      favoriteLocation.Name,
+		PlaceID:   favoriteLocation.PlaceID,
+		Address:   favoriteLocation.Address,
+		Latitude:  favoriteLocation.Latitude,
+		Longitude: favoriteLocation.Longitude,
+		Position:  favoriteLocation.Position,
+	}
+}
+
+func toGetFavoriteLocationsResponse(favoriteLocations []migration.FavoriteLocation) schemas.GetFavoriteLocationsResponse {
+	res := schemas.GetFavoriteLocationsResponse{
+		FavoriteLocations: make([]schemas.FavoriteLocationResponse, len(favoriteLocations)),
+	}
+	for i, favoriteLocation := range favoriteLocations {
+		res.FavoriteLocations[i] = toFavoriteLocationResponse(favoriteLocation)
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"shareway/helper"
+	"shareway/schemas"
+	"shareway/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ImpactController struct {
+	validate *validator.Validate
+	service  service.IImpactService
+}
+
+func NewImpactController(validate *validator.Validate, service service.IImpactService) *ImpactController {
+	return &ImpactController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// AdminGetPlatformImpact godoc
+// @Summary Get the impact of the platform
+// @Description Get the kilometers shared, the fuel saved and the CO2 avoided by all the completed rides, per fuel type. The numbers are computed with the current emission factors
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day of the rides, YYYY-MM-DD"
+// @Param to query string false "Last day of the rides, YYYY-MM-DD"
+// @Success 200 {object} helper.Response{data=schemas.GetPlatformImpactResponse} "Impact retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/impact [get]
+func (ctrl *ImpactController) AdminGetPlatformImpact(ctx *gin.Context) {
+	var req schemas.GetPlatformImpactRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	res, err := ctrl.service.GetPlatformImpact(req)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get impact",
+			"Không thể lấy thống kê tác động môi trường",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(res, "Impact retrieved successfully", "Lấy thống kê tác động môi trường thành công")
+	helper.GinResponse(ctx, 200, response)
+}
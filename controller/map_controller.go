@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"shareway/helper"
+	"shareway/infra/db/migration"
 	"shareway/middleware"
+	"shareway/repository"
 	"shareway/schemas"
 	"shareway/service"
 
@@ -30,7 +33,7 @@ func NewMapController(mapsService service.IMapService, validate *validator.Valid
 // GetAutoComplete returns a list of places that match the query string
 // GetAutoComplete godoc
 // @Summary Get autocomplete suggestions for places
-// @Description Returns a list of places that match the query string
+// @Description Returns a list of places that match the query string, the matching favorite locations and recent destinations of the user come before the Goong predictions
 // @Tags map
 // @Accept json
 // @Produce json
@@ -46,6 +49,17 @@ func NewMapController(mapsService service.IMapService, validate *validator.Valid
 // @Failure 500 {object} helper.Response "Failed to get autocomplete data"
 // @Router /map/autocomplete [get]
 func (ctrl *MapController) GetAutoComplete(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
 
 	var req schemas.AutoCompleteRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -58,7 +72,7 @@ func (ctrl *MapController) GetAutoComplete(ctx *gin.Context) {
 		return
 	}
 
-	places, err := ctrl.MapsService.GetAutoComplete(ctx.Request.Context(), req.Input, req.Limit, req.Location, req.Radius, req.MoreCompound, req.CurrentLocation)
+	places, err := ctrl.MapsService.GetAutoComplete(ctx.Request.Context(), data.UserID, req.Input, req.Limit, req.Location, req.Radius, req.MoreCompound, req.CurrentLocation)
 	if err != nil {
 		response := helper.ErrorResponseWithMessage(
 			err,
@@ -88,6 +102,7 @@ func (ctrl *MapController) GetAutoComplete(ctx *gin.Context) {
 // @Param request body schemas.GiveRideRequest true "Give ride request details"
 // @Success 200 {object} helper.Response{data=schemas.GiveRideResponse} "Successfully created route"
 // @Failure 400 {object} helper.Response "Invalid request body"
+// @Failure 404 {object} helper.Response "Favorite location not found"
 // @Failure 500 {object} helper.Response "Failed to create route"
 // @Router /map/give-ride [post]
 func (ctrl *MapController) CreateGiveRide(ctx *gin.Context) {
@@ -132,12 +147,16 @@ func (ctrl *MapController) CreateGiveRide(ctx *gin.Context) {
 	// Create a route for the driver
 	route, rideOfferID, err := ctrl.MapsService.CreateGiveRide(ctx.Request.Context(), req, data.UserID)
 	if err != nil {
+		status := 500
+		if errors.Is(err, repository.ErrFavoriteLocationNotFound) {
+			status = 404
+		}
 		response := helper.ErrorResponseWithMessage(
 			err,
 			"Failed to create route",
 			"Không thể tạo tuyến đường",
 		)
-		helper.GinResponse(ctx, 500, response)
+		helper.GinResponse(ctx, status, response)
 		return
 	}
 
@@ -193,15 +212,17 @@ func (ctrl *MapController) CreateGiveRide(ctx *gin.Context) {
 
 	// Create a response with the route and ride offer ID
 	res := schemas.GiveRideResponse{
-		Route:       route,
-		RideOfferID: rideOfferID,
-		Distance:    rideOffer.Distance,
-		Duration:    rideOffer.Duration,
-		StartTime:   rideOffer.StartTime,
-		EndTime:     rideOffer.EndTime,
-		Fare:        rideOffer.Fare,
-		Vehicle:     vehicle,
-		Waypoints:   waypointDetails,
+		Route:          route,
+		RideOfferID:    rideOfferID,
+		Distance:       rideOffer.Distance,
+		Duration:       rideOffer.Duration,
+		StartTime:      rideOffer.StartTime,
+		EndTime:        rideOffer.EndTime,
+		Fare:           rideOffer.Fare,
+		TotalSeats:     rideOffer.TotalSeats,
+		AvailableSeats: rideOffer.AvailableSeats,
+		Vehicle:        vehicle,
+		Waypoints:      waypointDetails,
 	}
 
 	response := helper.SuccessResponse(
@@ -223,6 +244,7 @@ func (ctrl *MapController) CreateGiveRide(ctx *gin.Context) {
 // @Param request body schemas.HitchRideRequest true "Hitch ride request details"
 // @Success 200 {object} helper.Response{data=schemas.HitchRideResponse} "Successfully created route"
 // @Failure 400 {object} helper.Response "Invalid request body"
+// @Failure 404 {object} helper.Response "Favorite location not found"
 // @Failure 500 {object} helper.Response "Failed to create route"
 // @Router /map/hitch-ride [post]
 func (ctrl *MapController) CreateHitchRide(ctx *gin.Context) {
@@ -267,12 +289,16 @@ func (ctrl *MapController) CreateHitchRide(ctx *gin.Context) {
 	// Create a route for the hitcher
 	route, rideRequestID, err := ctrl.MapsService.CreateHitchRide(ctx.Request.Context(), req, data.UserID)
 	if err != nil {
+		status := 500
+		if errors.Is(err, repository.ErrFavoriteLocationNotFound) {
+			status = 404
+		}
 		response := helper.ErrorResponseWithMessage(
 			err,
 			"Failed to create route",
 			"Không thể tạo tuyến đường",
 		)
-		helper.GinResponse(ctx, 500, response)
+		helper.GinResponse(ctx, status, response)
 		return
 	}
 
@@ -361,10 +387,82 @@ func (ctrl *MapController) GetGeoCode(ctx *gin.Context) {
 	helper.GinResponse(ctx, 200, response)
 }
 
+// GetFareQuote returns the price of a route and how it was computed
+// GetFareQuote godoc
+// @Summary Get the fare of a route
+// @Description Returns the fare of a route with its breakdown (strategy, inputs, commission) before any ride offer or request is created
+// @Tags map
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.FareQuoteRequest true "Fare quote request details"
+// @Success 200 {object} helper.Response{data=schemas.FareQuote} "Successfully computed fare"
+// @Failure 400 {object} helper.Response "Invalid request body"
+// @Failure 404 {object} helper.Response "Favorite location not found"
+// @Failure 500 {object} helper.Response "Failed to compute fare"
+// @Router /map/fare-quote [post]
+func (ctrl *MapController) GetFareQuote(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.FareQuoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	// Validate the request body
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	fareQuote, err := ctrl.MapsService.GetFareQuote(ctx.Request.Context(), req, data.UserID)
+	if err != nil {
+		status := 500
+		if errors.Is(err, repository.ErrFavoriteLocationNotFound) {
+			status = 404
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to compute fare",
+			"Không thể tính giá chuyến đi",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		fareQuote,
+		"Successfully computed fare",
+		"Tính giá chuyến đi thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
 // SuggestHitchRides returns a list of ride requests that match the business rules for the rider (ride offer)
 // SuggestHitchRides godoc
 // @Summary Suggest ride requests for a rider (ride offer)
-// @Description Returns a list of ride requests that match the business rules for the rider (ride offer)
+// @Description Returns a list of ride requests that match the business rules for the rider (ride offer), ranked by match score with a per-factor breakdown
 // @Tags map
 // @Accept json
 // @Produce json
@@ -416,7 +514,7 @@ func (ctrl *MapController) SuggestHitchRides(ctx *gin.Context) {
 	}
 
 	// Get the ride requests that match the business rules
-	rideRequests, err := ctrl.MapsService.SuggestRideRequests(ctx.Request.Context(), data.UserID, req.RideOfferID)
+	rideRequests, scores, err := ctrl.MapsService.SuggestRideRequests(ctx.Request.Context(), data.UserID, req.RideOfferID)
 	if err != nil {
 		response := helper.ErrorResponseWithMessage(
 			err,
@@ -444,12 +542,14 @@ func (ctrl *MapController) SuggestHitchRides(ctx *gin.Context) {
 		rideRequestDetail := schemas.RideRequestDetail{
 			ID: rideRequest.ID,
 			User: schemas.UserInfo{
-				ID:           user.ID,
-				FullName:     user.FullName,
-				PhoneNumber:  user.PhoneNumber,
-				AvatarURL:    user.AvatarURL,
-				Gender:       user.Gender,
-				IsMomoLinked: user.IsMomoLinked,
+				ID:            user.ID,
+				FullName:      user.FullName,
+				PhoneNumber:   user.PhoneNumber,
+				AvatarURL:     user.AvatarURL,
+				Gender:        user.Gender,
+				IsMomoLinked:  user.IsMomoLinked,
+				AverageRating: user.AverageRating,
+				RatingCount:   user.RatingCount,
 			},
 			EncodedPolyline:       string(rideRequest.EncodedPolyline),
 			Distance:              rideRequest.Distance,
@@ -465,6 +565,10 @@ func (ctrl *MapController) SuggestHitchRides(ctx *gin.Context) {
 			RiderCurrentLatitude:  rideRequest.RiderCurrentLatitude,
 			RiderCurrentLongitude: rideRequest.RiderCurrentLongitude,
 			Weight:                rideRequest.Weight,
+			Preferences:           helper.ToRidePreferencesDetail(rideRequest.RidePreferences),
+		}
+		if score, ok := scores[rideRequest.ID]; ok {
+			rideRequestDetail.MatchScore = &score
 		}
 		rideRequestDetails = append(rideRequestDetails, rideRequestDetail)
 	}
@@ -484,7 +588,7 @@ func (ctrl *MapController) SuggestHitchRides(ctx *gin.Context) {
 // SuggestGiveRides returns a list of ride offers that match the business rules for the hitcher (ride request)
 // SuggestGiveRides godoc
 // @Summary Suggest ride offers for a hitcher
-// @Description Returns a list of ride offers that match the business rules for the hitcher (ride request)
+// @Description Returns a list of ride offers that match the business rules for the hitcher (ride request), ranked by match score with a per-factor breakdown
 // @Tags map
 // @Accept json
 // @Produce json
@@ -536,7 +640,7 @@ func (ctrl *MapController) SuggestGiveRides(ctx *gin.Context) {
 	}
 
 	// Get the ride offers that match the business rules
-	rideOffers, err := ctrl.MapsService.SuggestRideOffers(ctx.Request.Context(), data.UserID, req.RideRequestID)
+	rideOffers, scores, err := ctrl.MapsService.SuggestRideOffers(ctx.Request.Context(), data.UserID, req.RideRequestID)
 	if err != nil {
 		response := helper.ErrorResponseWithMessage(
 			err,
@@ -599,12 +703,14 @@ func (ctrl *MapController) SuggestGiveRides(ctx *gin.Context) {
 		rideOfferDetail := schemas.RideOfferDetail{
 			ID: rideOffer.ID,
 			User: schemas.UserInfo{
-				ID:           user.ID,
-				FullName:     user.FullName,
-				PhoneNumber:  user.PhoneNumber,
-				AvatarURL:    user.AvatarURL,
-				Gender:       user.Gender,
-				IsMomoLinked: user.IsMomoLinked,
+				ID:            user.ID,
+				FullName:      user.FullName,
+				PhoneNumber:   user.PhoneNumber,
+				AvatarURL:     user.AvatarURL,
+				Gender:        user.Gender,
+				IsMomoLinked:  user.IsMomoLinked,
+				AverageRating: user.AverageRating,
+				RatingCount:   user.RatingCount,
 			},
 			Vehicle:                vehicle,
 			EncodedPolyline:        string(rideOffer.EncodedPolyline),
@@ -622,7 +728,13 @@ func (ctrl *MapController) SuggestGiveRides(ctx *gin.Context) {
 			DriverCurrentLongitude: rideOffer.DriverCurrentLongitude,
 			Status:                 rideOffer.Status,
 			Fare:                   rideOffer.Fare,
+			TotalSeats:             rideOffer.TotalSeats,
+			AvailableSeats:         rideOffer.AvailableSeats,
 			Waypoints:              waypointDetails,
+			Preferences:            helper.ToRidePreferencesDetail(rideOffer.RidePreferences),
+		}
+		if score, ok := scores[rideOffer.ID]; ok {
+			rideOfferDetail.MatchScore = &score
 		}
 		// Append the ride offer detail to the list
 		rideOfferDetails = append(rideOfferDetails, rideOfferDetail)
@@ -639,3 +751,140 @@ func (ctrl *MapController) SuggestGiveRides(ctx *gin.Context) {
 	)
 	helper.GinResponse(ctx, 200, response)
 }
+
+// BrowseGiveRides returns the open ride offers going from an origin to a destination without creating a ride request
+// BrowseGiveRides godoc
+// @Summary Browse open ride offers
+// @Description Returns the open ride offers whose route passes near the origin then the destination within the departure window, sorted and paginated with a cursor. Nothing is created, so users can look at the offers before requesting a ride
+// @Tags map
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.BrowseRideOffersRequest true "Search details"
+// @Success 200 {object} helper.Response{data=schemas.BrowseRideOffersResponse} "Successfully retrieved ride offers"
+// @Failure 400 {object} helper.Response "Invalid request body"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /map/browse-give-rides [post]
+func (ctrl *MapController) BrowseGiveRides(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.BrowseRideOffersRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	// Validate the request body
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	rideOffers, proximities, nextCursor, err := ctrl.MapsService.BrowseRideOffers(ctx.Request.Context(), req, data.UserID)
+	if err != nil {
+		status := 500
+		if errors.Is(err, helper.ErrInvalidBrowseCursor) {
+			status = 400
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to browse ride offers",
+			"Không thể tìm kiếm chuyến đi",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	res := schemas.BrowseRideOffersResponse{
+		RideOffers: make([]schemas.RideOfferDetail, len(rideOffers)),
+		NextCursor: nextCursor,
+	}
+	for i, rideOffer := range rideOffers {
+		res.RideOffers[i] = toRideOfferDetail(rideOffer)
+		if proximity, ok := proximities[rideOffer.ID]; ok {
+			res.RideOffers[i].Proximity = &proximity
+		}
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Successfully retrieved ride offers",
+		"Lấy danh sách chuyến đi thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// toRideOfferDetail converts a ride offer loaded with its user, vehicle and waypoints
+func toRideOfferDetail(rideOffer migration.RideOffer) schemas.RideOfferDetail {
+	waypoints := make([]schemas.Waypoint, len(rideOffer.Waypoints))
+	for i, waypoint := range rideOffer.Waypoints {
+		waypoints[i] = schemas.Waypoint{
+			Latitude:  waypoint.Latitude,
+			Longitude: waypoint.Longitude,
+			Address:   waypoint.Address,
+			ID:        waypoint.ID,
+			Order:     waypoint.WaypointOrder,
+		}
+	}
+
+	return schemas.RideOfferDetail{
+		ID: rideOffer.ID,
+		User: schemas.UserInfo{
+			ID:            rideOffer.User.ID,
+			FullName:      rideOffer.User.FullName,
+			PhoneNumber:   rideOffer.User.PhoneNumber,
+			AvatarURL:     rideOffer.User.AvatarURL,
+			Gender:        rideOffer.User.Gender,
+			IsMomoLinked:  rideOffer.User.IsMomoLinked,
+			AverageRating: rideOffer.User.AverageRating,
+			RatingCount:   rideOffer.User.RatingCount,
+		},
+		Vehicle: schemas.VehicleDetail{
+			VehicleID:    rideOffer.Vehicle.ID,
+			Name:         rideOffer.Vehicle.Name,
+			FuelType:     rideOffer.Vehicle.FuelType,
+			FuelConsumed: rideOffer.Vehicle.FuelConsumed,
+			LicensePlate: rideOffer.Vehicle.LicensePlate,
+			SeatCapacity: rideOffer.Vehicle.SeatCapacity,
+		},
+		EncodedPolyline:        string(rideOffer.EncodedPolyline),
+		Distance:               rideOffer.Distance,
+		Duration:               rideOffer.Duration,
+		StartTime:              rideOffer.StartTime,
+		EndTime:                rideOffer.EndTime,
+		StartLatitude:          rideOffer.StartLatitude,
+		StartLongitude:         rideOffer.StartLongitude,
+		EndLatitude:            rideOffer.EndLatitude,
+		EndLongitude:           rideOffer.EndLongitude,
+		StartAddress:           rideOffer.StartAddress,
+		EndAddress:             rideOffer.EndAddress,
+		DriverCurrentLatitude:  rideOffer.DriverCurrentLatitude,
+		DriverCurrentLongitude: rideOffer.DriverCurrentLongitude,
+		Status:                 rideOffer.Status,
+		Fare:                   rideOffer.Fare,
+		TotalSeats:             rideOffer.TotalSeats,
+		AvailableSeats:         rideOffer.AvailableSeats,
+		Waypoints:              waypoints,
+		Preferences:            helper.ToRidePreferencesDetail(rideOffer.RidePreferences),
+	}
+}
@@ -0,0 +1,550 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/middleware"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ModerationController struct {
+	validate *validator.Validate
+	service  service.IModerationService
+}
+
+func NewModerationController(validate *validator.Validate, service service.IModerationService) *ModerationController {
+	return &ModerationController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// BlockUser godoc
+// @Summary Block a user
+// @Description Block a user, the two users no longer see each other in the suggestions and can no longer send each other ride requests, messages or calls
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.BlockUserRequest true "Block user request"
+// @Success 200 {object} helper.Response "User blocked successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "User not found"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /user/block [post]
+func (ctrl *ModerationController) BlockUser(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.BlockUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.BlockUser(data.UserID, req.UserID); err != nil {
+		status := 500
+		switch {
+		case errors.Is(err, service.ErrSelfModeration):
+			status = 400
+		case errors.Is(err, repository.ErrUserNotFound):
+			status = 404
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to block user",
+			"Không thể chặn người dùng",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		nil,
+		"User blocked successfully",
+		"Chặn người dùng thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// UnblockUser godoc
+// @Summary Unblock a user
+// @Description Lift a block the user created, a block created by the other user stays
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.BlockUserRequest true "Unblock user request"
+// @Success 200 {object} helper.Response "User unblocked successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "User not blocked"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /user/unblock [post]
+func (ctrl *ModerationController) UnblockUser(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.BlockUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.UnblockUser(data.UserID, req.UserID); err != nil {
+		status := 500
+		if errors.Is(err, repository.ErrUserNotBlocked) {
+			status = 404
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to unblock user",
+			"Không thể bỏ chặn người dùng",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		nil,
+		"User unblocked successfully",
+		"Bỏ chặn người dùng thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetBlockedUsers godoc
+// @Summary Get the blocked users
+// @Description Get the users the user blocked, newest first
+// @Tags user
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helper.Response{data=schemas.GetBlockedUsersResponse} "Blocked users retrieved successfully"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /user/blocked-users [get]
+func (ctrl *ModerationController) GetBlockedUsers(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	blocks, err := ctrl.service.GetBlockedUsers(data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get blocked users",
+			"Không thể lấy danh sách người dùng bị chặn",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GetBlockedUsersResponse{
+		BlockedUsers: make([]schemas.BlockedUserDetail, len(blocks)),
+	}
+	for i, block := range blocks {
+		res.BlockedUsers[i] = schemas.BlockedUserDetail{
+			User: schemas.UserInfo{
+				ID:            block.Blocked.ID,
+				FullName:      block.Blocked.FullName,
+				AvatarURL:     block.Blocked.AvatarURL,
+				Gender:        block.Blocked.Gender,
+				IsMomoLinked:  block.Blocked.IsMomoLinked,
+				AverageRating: block.Blocked.AverageRating,
+				RatingCount:   block.Blocked.RatingCount,
+			},
+			BlockedAt: block.CreatedAt,
+		}
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Blocked users retrieved successfully",
+		"Lấy danh sách người dùng bị chặn thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// ReportUser godoc
+// @Summary Report a user
+// @Description Open a case against a user for the admins, optionally about a ride or a chat message shared with the user
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.ReportUserRequest true "Report user request"
+// @Success 200 {object} helper.Response{data=schemas.UserReportDetail} "User reported successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "User not found"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /user/report [post]
+func (ctrl *ModerationController) ReportUser(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.ReportUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	report, err := ctrl.service.ReportUser(req, data.UserID)
+	if err != nil {
+		status := 500
+		switch {
+		case errors.Is(err, service.ErrSelfModeration), errors.Is(err, repository.ErrUserReportUnreachable):
+			status = 400
+		case errors.Is(err, repository.ErrUserNotFound):
+			status = 404
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to report user",
+			"Không thể báo cáo người dùng",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	res := schemas.UserReportDetail{
+		ID:          report.ID,
+		Status:      report.Status,
+		Category:    report.Category,
+		Description: report.Description,
+		ReporterID:  report.ReporterID,
+		ReportedID:  report.ReportedID,
+		RideID:      report.RideID,
+		ChatID:      report.ChatID,
+		CreatedAt:   report.CreatedAt,
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"User reported successfully",
+		"Báo cáo người dùng thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// AdminGetUserReports godoc
+// @Summary Get the user reports
+// @Description Get the latest reports against the users, filtered by status, category and reported user when set
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status of the reports" Enums(open, reviewing, resolved, dismissed)
+// @Param category query string false "Category of the reports" Enums(harassment, inappropriate_behavior, unsafe_driving, fraud, no_show, other)
+// @Param reportedID query string false "Reported user ID"
+// @Param limit query int false "Maximum number of reports, 50 by default"
+// @Success 200 {object} helper.Response{data=schemas.GetUserReportsResponse} "User reports retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/report/get-all [get]
+func (ctrl *ModerationController) AdminGetUserReports(ctx *gin.Context) {
+	var req schemas.GetUserReportsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	reports, err := ctrl.service.GetUserReports(req)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get user reports",
+			"Không thể lấy danh sách báo cáo",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GetUserReportsResponse{
+		Reports: make([]schemas.AdminUserReportDetail, len(reports)),
+	}
+	for i, report := range reports {
+		res.Reports[i] = toAdminUserReportDetail(report)
+	}
+
+	response := helper.SuccessResponse(res, "User reports retrieved successfully", "Lấy danh sách báo cáo thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// AdminReviewUserReport godoc
+// @Summary Review a user report
+// @Description Record that the current admin is looking into an open report
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.UserReportRequest true "User report request"
+// @Success 200 {object} helper.Response{data=schemas.AdminUserReportDetail} "User report reviewed successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "User report not found"
+// @Failure 409 {object} helper.Response "User report not open"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/report/review [post]
+func (ctrl *ModerationController) AdminReviewUserReport(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToAdminPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.UserReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	report, err := ctrl.service.ReviewUserReport(req.ReportID, data.AdminID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to review user report",
+			"Không thể tiếp nhận báo cáo",
+		)
+		helper.GinResponse(ctx, userReportErrorStatus(err), response)
+		return
+	}
+
+	response := helper.SuccessResponse(toAdminUserReportDetail(report), "User report reviewed successfully", "Tiếp nhận báo cáo thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// AdminCloseUserReport godoc
+// @Summary Close a user report
+// @Description Close an open or reviewed report by dismissing it or by warning the reported user, the note on how it was handled is kept for the audit. Restricting or suspending the reported user is not supported from a report
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.CloseUserReportRequest true "Close user report request"
+// @Success 200 {object} helper.Response{data=schemas.AdminUserReportDetail} "User report closed successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "User report not found"
+// @Failure 409 {object} helper.Response "User report already closed"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/report/close [post]
+func (ctrl *ModerationController) AdminCloseUserReport(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToAdminPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.CloseUserReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	report, err := ctrl.service.CloseUserReport(req, data.AdminID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to close user report",
+			"Không thể xử lý báo cáo",
+		)
+		helper.GinResponse(ctx, userReportErrorStatus(err), response)
+		return
+	}
+
+	response := helper.SuccessResponse(toAdminUserReportDetail(report), "User report closed successfully", "Xử lý báo cáo thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// userReportErrorStatus maps the errors of the report lifecycle to their status code
+func userReportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrUserReportNotFound):
+		return 404
+	case errors.Is(err, repository.ErrUserReportTransition):
+		return 409
+	}
+	return 500
+}
+
+func toAdminUserReportDetail(report migration.UserReport) schemas.AdminUserReportDetail {
+	detail := schemas.AdminUserReportDetail{
+		ID:          report.ID,
+		Status:      report.Status,
+		Category:    report.Category,
+		Description: report.Description,
+		Reporter: schemas.UserInfo{
+			ID:            report.Reporter.ID,
+			PhoneNumber:   report.Reporter.PhoneNumber,
+			FullName:      report.Reporter.FullName,
+			AvatarURL:     report.Reporter.AvatarURL,
+			Gender:        report.Reporter.Gender,
+			IsMomoLinked:  report.Reporter.IsMomoLinked,
+			AverageRating: report.Reporter.AverageRating,
+			RatingCount:   report.Reporter.RatingCount,
+		},
+		Reported: schemas.UserInfo{
+			ID:            report.Reported.ID,
+			PhoneNumber:   report.Reported.PhoneNumber,
+			FullName:      report.Reported.FullName,
+			AvatarURL:     report.Reported.AvatarURL,
+			Gender:        report.Reported.Gender,
+			IsMomoLinked:  report.Reported.IsMomoLinked,
+			AverageRating: report.Reported.AverageRating,
+			RatingCount:   report.Reported.RatingCount,
+		},
+		RideID:     report.RideID,
+		ChatID:     report.ChatID,
+		Action:     report.Action,
+		CreatedAt:  report.CreatedAt,
+		ReviewedAt: report.ReviewedAt,
+		ReviewedBy: report.ReviewedBy,
+		ClosedAt:   report.ClosedAt,
+		ClosedBy:   report.ClosedBy,
+		AdminNote:  report.AdminNote,
+	}
+	if report.Chat != nil {
+		detail.ChatMessage = report.Chat.Message
+	}
+	return detail
+}
@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/middleware"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/service"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type RatingController struct {
+	validate *validator.Validate
+	service  service.IRatingService
+}
+
+func NewRatingController(validate *validator.Validate, service service.IRatingService) *RatingController {
+	return &RatingController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// CreateRating godoc
+// @Summary Rate the counterpart of a ride
+// @Description Rate the passenger as the driver or the driver as the passenger of a completed ride, once per ride and within the rating window after the end of the ride
+// @Tags rating
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.CreateRatingRequest true "Rating request"
+// @Success 200 {object} helper.Response{data=schemas.CreateRatingResponse} "Rating created successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "Ride not found"
+// @Failure 409 {object} helper.Response "Ride not completed, already rated or rating window closed"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /rating/create [post]
+func (ctrl *RatingController) CreateRating(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.CreateRatingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	rating, ratee, err := ctrl.service.CreateRating(req, data.UserID)
+	if err != nil {
+		status := 500
+		switch {
+		case errors.Is(err, repository.ErrRideNotFound), errors.Is(err, service.ErrNotRideParticipant):
+			// The rides of the other users are not found for the user
+			status = 404
+		case errors.Is(err, service.ErrRideNotRateable), errors.Is(err, service.ErrRatingWindowClosed), errors.Is(err, repository.ErrAlreadyRated):
+			status = 409
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to create rating",
+			"Không thể đánh giá chuyến đi",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	res := schemas.CreateRatingResponse{
+		Rating:        toRatingDetail(rating),
+		RateeID:       ratee.ID,
+		AverageRating: ratee.AverageRating,
+		RatingCount:   ratee.RatingCount,
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Rating created successfully",
+		"Đánh giá chuyến đi thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetRatingsReceived godoc
+// @Summary Get the ratings received by a user
+// @Description Get the ratings received by a user as a driver and as a passenger, newest first, with the average rating and the number of ratings
+// @Tags rating
+// @Produce json
+// @Security BearerAuth
+// @Param userID query string false "User ID, the caller by default"
+// @Param page query int false "Page number starting at 1 (default: 1)"
+// @Param limit query int false "Number of ratings per page (default: 10)"
+// @Success 200 {object} helper.Response{data=schemas.GetRatingsReceivedResponse} "Ratings retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "User not found"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /rating/received [get]
+func (ctrl *RatingController) GetRatingsReceived(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.GetRatingsReceivedRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if req.UserID == uuid.Nil {
+		req.UserID = data.UserID
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	ratings, ratee, total, err := ctrl.service.GetRatingsReceived(req.UserID, req.Page, req.Limit)
+	if err != nil {
+		status := 500
+		if errors.Is(err, repository.ErrUserNotFound) {
+			status = 404
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get ratings",
+			"Không thể lấy danh sách đánh giá",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	res := schemas.GetRatingsReceivedResponse{
+		Ratings:       make([]schemas.RatingDetail, 0, len(ratings)),
+		AverageRating: ratee.AverageRating,
+		RatingCount:   ratee.RatingCount,
+		Page:          req.Page,
+		Limit:         req.Limit,
+		Total:         int(total),
+	}
+	for _, rating := range ratings {
+		res.Ratings = append(res.Ratings, toRatingDetail(rating))
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Ratings retrieved successfully",
+		"Lấy danh sách đánh giá thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// toRatingDetail maps a rating with its rater, the phone number of the rater is not shared with the other users
+func toRatingDetail(rating migration.Rating) schemas.RatingDetail {
+	tags := []string{}
+	if rating.Tags != "" {
+		tags = strings.Split(rating.Tags, ",")
+	}
+	return schemas.RatingDetail{
+		ID:      rating.ID,
+		RideID:  rating.RideID,
+		Rating:  rating.Rating,
+		Comment: rating.Comment,
+		Tags:    tags,
+		Rater: schemas.UserInfo{
+			ID:            rating.Rater.ID,
+			FullName:      rating.Rater.FullName,
+			AvatarURL:     rating.Rater.AvatarURL,
+			Gender:        rating.Rater.Gender,
+			IsMomoLinked:  rating.Rater.IsMomoLinked,
+			AverageRating: rating.Rater.AverageRating,
+			RatingCount:   rating.Rater.RatingCount,
+		},
+		CreatedAt: rating.CreatedAt,
+	}
+}
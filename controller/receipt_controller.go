@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"shareway/helper"
+	"shareway/middleware"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ReceiptController struct {
+	validate *validator.Validate
+	service  service.IReceiptService
+}
+
+func NewReceiptController(validate *validator.Validate, service service.IReceiptService) *ReceiptController {
+	return &ReceiptController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// GetRideReceipt godoc
+// @Summary Download the receipt of a ride
+// @Description Download the receipt of a completed ride of the user with the route, the fare breakdown, the payment and the driver and vehicle. The receipt is built from the stored ride so it never changes
+// @Tags ride
+// @Produce application/pdf
+// @Produce text/html
+// @Produce json
+// @Security BearerAuth
+// @Param rideID query string true "Ride ID"
+// @Param format query string false "Format of the receipt, pdf by default" Enums(pdf, html, json)
+// @Param lang query string false "Language of the receipt, vi by default" Enums(vi, en)
+// @Success 200 {object} helper.Response{data=schemas.RideReceipt} "Receipt of the ride, as JSON when format is json"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "Ride not found"
+// @Failure 409 {object} helper.Response "Ride not completed"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/receipt [get]
+func (ctrl *ReceiptController) GetRideReceipt(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.GetRideReceiptRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	receipt, err := ctrl.service.GetRideReceipt(req.RideID, data.UserID, req.Lang)
+	if err != nil {
+		status := 500
+		switch {
+		case errors.Is(err, repository.ErrRideNotFound), errors.Is(err, service.ErrNotRideParticipant):
+			// The rides of the other users are not found for the user
+			status = 404
+		case errors.Is(err, service.ErrReceiptNotAvailable):
+			status = 409
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get ride receipt",
+			"Không thể lấy biên lai chuyến đi",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	if req.Format == "json" {
+		response := helper.SuccessResponse(
+			receipt,
+			"Ride receipt retrieved successfully",
+			"Lấy biên lai chuyến đi thành công",
+		)
+		helper.GinResponse(ctx, 200, response)
+		return
+	}
+
+	content, err := ctrl.service.RenderRideReceipt(receipt, req.Format)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to render ride receipt",
+			"Không thể tạo biên lai chuyến đi",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	if req.Format == "html" {
+		ctx.Data(200, "text/html; charset=utf-8", content)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", receipt.ReceiptNumber+".pdf"))
+	ctx.Data(200, "application/pdf", content)
+}
@@ -0,0 +1,354 @@
+package controller
+
+import (
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/middleware"
+	"shareway/schemas"
+	"shareway/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type RecurringRideController struct {
+	validate *validator.Validate
+	service  service.IRecurringRideService
+}
+
+func NewRecurringRideController(validate *validator.Validate, service service.IRecurringRideService) *RecurringRideController {
+	return &RecurringRideController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// CreateRecurringRideOffer godoc
+// @Summary Create a recurring ride offer
+// @Description Create a commute template, the ride offers of the series are published automatically ahead of time
+// @Tags recurring-ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.CreateRecurringRideOfferRequest true "Recurring ride offer request"
+// @Success 200 {object} helper.Response{data=schemas.RecurringRideOfferDetail} "Recurring ride offer created successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /recurring-ride/create [post]
+func (ctrl *RecurringRideController) CreateRecurringRideOffer(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.CreateRecurringRideOfferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	recurringRideOffer, err := ctrl.service.CreateRecurringRideOffer(ctx.Request.Context(), req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to create recurring ride offer",
+			"Không thể tạo chuyến đi định kỳ",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toRecurringRideOfferDetail(recurringRideOffer),
+		"Recurring ride offer created successfully",
+		"Tạo chuyến đi định kỳ thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetRecurringRideOffers godoc
+// @Summary Get recurring ride offers
+// @Description Get all recurring ride offers of the current driver
+// @Tags recurring-ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helper.Response{data=schemas.GetRecurringRideOffersResponse} "Recurring ride offers retrieved successfully"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /recurring-ride/get-all [get]
+func (ctrl *RecurringRideController) GetRecurringRideOffers(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	recurringRideOffers, err := ctrl.service.GetRecurringRideOffers(data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get recurring ride offers",
+			"Không thể lấy danh sách chuyến đi định kỳ",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GetRecurringRideOffersResponse{
+		RecurringRideOffers: make([]schemas.RecurringRideOfferDetail, len(recurringRideOffers)),
+	}
+	for i, recurringRideOffer := range recurringRideOffers {
+		res.RecurringRideOffers[i] = toRecurringRideOfferDetail(recurringRideOffer)
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Recurring ride offers retrieved successfully",
+		"Lấy danh sách chuyến đi định kỳ thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// UpdateRecurringRideOffer godoc
+// @Summary Update a recurring ride offer
+// @Description Edit the series, future occurrences without bookings are republished with the new settings
+// @Tags recurring-ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.UpdateRecurringRideOfferRequest true "Update recurring ride offer request"
+// @Success 200 {object} helper.Response{data=schemas.RecurringRideOfferDetail} "Recurring ride offer updated successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /recurring-ride/update [post]
+func (ctrl *RecurringRideController) UpdateRecurringRideOffer(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.UpdateRecurringRideOfferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	recurringRideOffer, err := ctrl.service.UpdateRecurringRideOffer(ctx.Request.Context(), req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to update recurring ride offer",
+			"Không thể cập nhật chuyến đi định kỳ",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toRecurringRideOfferDetail(recurringRideOffer),
+		"Recurring ride offer updated successfully",
+		"Cập nhật chuyến đi định kỳ thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// PauseRecurringRideOffer godoc
+// @Summary Pause or resume a recurring ride offer
+// @Description Pausing withdraws the future occurrences without bookings, resuming publishes them again
+// @Tags recurring-ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.PauseRecurringRideOfferRequest true "Pause recurring ride offer request"
+// @Success 200 {object} helper.Response "Recurring ride offer updated successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /recurring-ride/pause [post]
+func (ctrl *RecurringRideController) PauseRecurringRideOffer(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.PauseRecurringRideOfferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.PauseRecurringRideOffer(req.RecurringRideOfferID, data.UserID, req.IsPaused); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to update recurring ride offer",
+			"Không thể cập nhật chuyến đi định kỳ",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		nil,
+		"Recurring ride offer updated successfully",
+		"Cập nhật chuyến đi định kỳ thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// SkipOccurrence godoc
+// @Summary Cancel a single occurrence of a recurring ride offer
+// @Description Cancel the ride offer of the series on the given day, occurrences that already have bookings must be cancelled ride by ride
+// @Tags recurring-ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.SkipRecurringRideOccurrenceRequest true "Skip occurrence request"
+// @Success 200 {object} helper.Response "Occurrence cancelled successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /recurring-ride/skip-occurrence [post]
+func (ctrl *RecurringRideController) SkipOccurrence(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.SkipRecurringRideOccurrenceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.SkipOccurrence(req, data.UserID); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to cancel occurrence",
+			"Không thể hủy chuyến đi trong ngày",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		nil,
+		"Occurrence cancelled successfully",
+		"Hủy chuyến đi trong ngày thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+func toRecurringRideOfferDetail(recurringRideOffer migration.RecurringRideOffer) schemas.RecurringRideOfferDetail {
+	skipDates := make([]string, len(recurringRideOffer.SkipDates))
+	for i, skipDate := range recurringRideOffer.SkipDates {
+		skipDates[i] = skipDate.Date.Format("2006-01-02")
+	}
+
+	return schemas.RecurringRideOfferDetail{
+		ID:            recurringRideOffer.ID,
+		VehicleID:     recurringRideOffer.VehicleID,
+		Seats:         recurringRideOffer.Seats,
+		StartAddress:  recurringRideOffer.StartAddress,
+		EndAddress:    recurringRideOffer.EndAddress,
+		DepartureTime: recurringRideOffer.DepartureTime,
+		Frequency:     recurringRideOffer.Frequency,
+		DaysOfWeek:    service.ParseDaysOfWeek(recurringRideOffer.DaysOfWeek),
+		StartDate:     recurringRideOffer.StartDate,
+		UntilDate:     recurringRideOffer.UntilDate,
+		SkipDates:     skipDates,
+		IsPaused:      recurringRideOffer.IsPaused,
+	}
+}
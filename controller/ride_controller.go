@@ -1,40 +1,47 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"shareway/helper"
+	"shareway/infra/db/migration"
 	"shareway/infra/task"
 	"shareway/infra/ws"
 	"shareway/middleware"
+	"shareway/repository"
 	"shareway/schemas"
 	"shareway/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 )
 
 type RideController struct {
-	validate       *validator.Validate
-	hub            *ws.Hub
-	RideService    service.IRideService
-	MapsService    service.IMapService
-	UserService    service.IUsersService
-	VehicleService service.IVehicleService
-	asyncClient    *task.AsyncClient
+	validate          *validator.Validate
+	hub               *ws.Hub
+	RideService       service.IRideService
+	MapsService       service.IMapService
+	UserService       service.IUsersService
+	VehicleService    service.IVehicleService
+	ModerationService service.IModerationService
+	asyncClient       *task.AsyncClient
 }
 
 func NewRideController(validate *validator.Validate, hub *ws.Hub, rideService service.IRideService,
 	mapService service.IMapService, userService service.IUsersService, vehicleService service.IVehicleService,
-	asyncClient *task.AsyncClient) *RideController {
+	moderationService service.IModerationService, asyncClient *task.AsyncClient) *RideController {
 	return &RideController{
-		validate:       validate,
-		hub:            hub,
-		RideService:    rideService,
-		MapsService:    mapService,
-		UserService:    userService,
-		VehicleService: vehicleService,
-		asyncClient:    asyncClient,
+		validate:          validate,
+		hub:               hub,
+		RideService:       rideService,
+		MapsService:       mapService,
+		UserService:       userService,
+		VehicleService:    vehicleService,
+		ModerationService: moderationService,
+		asyncClient:       asyncClient,
 	}
 }
 
@@ -49,6 +56,7 @@ func NewRideController(validate *validator.Validate, hub *ws.Hub, rideService se
 // @Param request body schemas.SendGiveRideRequestRequest true "Give ride request details"
 // @Success 200 {object} helper.Response "Successfully sent ride offer request"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "One of the users blocked the other"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /ride/give-ride-request [post]
 func (ctrl *RideController) SendGiveRideRequest(ctx *gin.Context) {
@@ -91,6 +99,21 @@ func (ctrl *RideController) SendGiveRideRequest(ctx *gin.Context) {
 		return
 	}
 
+	// The users who blocked each other cannot send or accept ride requests between them
+	if err := ctrl.ModerationService.CheckNotBlocked(data.UserID, req.ReceiverID); err != nil {
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to send ride offer request",
+			"Không thể gửi yêu cầu chia sẻ chuyến đi",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
 	// Get user details from user_id
 	user, err := ctrl.UserService.GetUserByID(data.UserID)
 	if err != nil {
@@ -153,15 +176,56 @@ func (ctrl *RideController) SendGiveRideRequest(ctx *gin.Context) {
 		}
 	}
 
+	// Get ride request details from ride_request_id
+	rideRequest, err := ctrl.RideService.GetRideRequestByID(req.RideRequestID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get ride request details",
+			"Không thể lấy thông tin yêu cầu chuyến đi",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	// The receiver comes from the client, the owners of the ride offer and the ride request must not have blocked each other either
+	if err := ctrl.ModerationService.CheckNotBlocked(rideOffer.UserID, rideRequest.UserID); err != nil {
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to send ride offer request",
+			"Không thể gửi yêu cầu chia sẻ chuyến đi",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	// Suggest where the hitcher meets the driver on the route
+	meetingPoints, err := ctrl.MapsService.SuggestMeetingPoints(ctx.Request.Context(), rideOffer, rideRequest)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to suggest meeting points",
+			"Không thể gợi ý điểm đón và điểm trả",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
 	res := schemas.SendGiveRideRequestResponse{
 		ID: rideOffer.ID,
 		User: schemas.UserInfo{
-			ID:           user.ID,
-			FullName:     user.FullName,
-			PhoneNumber:  user.PhoneNumber,
-			AvatarURL:    user.AvatarURL,
-			Gender:       user.Gender,
-			IsMomoLinked: user.IsMomoLinked,
+			ID:            user.ID,
+			FullName:      user.FullName,
+			PhoneNumber:   user.PhoneNumber,
+			AvatarURL:     user.AvatarURL,
+			Gender:        user.Gender,
+			IsMomoLinked:  user.IsMomoLinked,
+			AverageRating: user.AverageRating,
+			RatingCount:   user.RatingCount,
 		},
 		Vehicle:                vehicle,
 		StartLatitude:          rideOffer.StartLatitude,
@@ -179,9 +243,12 @@ func (ctrl *RideController) SendGiveRideRequest(ctx *gin.Context) {
 		EndTime:                rideOffer.EndTime,
 		Status:                 rideOffer.Status,
 		Fare:                   rideOffer.Fare,
+		AvailableSeats:         rideOffer.AvailableSeats,
 		ReceiverID:             req.ReceiverID,
 		RideRequestID:          req.RideRequestID,
 		Waypoints:              waypointDetails,
+		MeetingPoints:          meetingPoints,
+		Preferences:            helper.ToRidePreferencesDetail(rideOffer.RidePreferences),
 	}
 
 	// Send ride offer request to the receiver
@@ -280,6 +347,7 @@ func (ctrl *RideController) SendGiveRideRequest(ctx *gin.Context) {
 // @Param request body schemas.SendHitchRideRequestRequest true "Hitch ride request details"
 // @Success 200 {object} helper.Response "Successfully sent ride request"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "One of the users blocked the other"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /ride/hitch-ride-request [post]
 func (ctrl *RideController) SendHitchRideRequest(ctx *gin.Context) {
@@ -322,6 +390,21 @@ func (ctrl *RideController) SendHitchRideRequest(ctx *gin.Context) {
 		return
 	}
 
+	// The users who blocked each other cannot send or accept ride requests between them
+	if err := ctrl.ModerationService.CheckNotBlocked(data.UserID, req.ReceiverID); err != nil {
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to send ride request",
+			"Không thể gửi yêu cầu đi nhờ",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
 	// Get user details from user_id
 	user, err := ctrl.UserService.GetUserByID(data.UserID)
 	if err != nil {
@@ -358,6 +441,21 @@ func (ctrl *RideController) SendHitchRideRequest(ctx *gin.Context) {
 		return
 	}
 
+	// The receiver comes from the client, the owners of the ride offer and the ride request must not have blocked each other either
+	if err := ctrl.ModerationService.CheckNotBlocked(rideOffer.UserID, rideRequest.UserID); err != nil {
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to send ride request",
+			"Không thể gửi yêu cầu đi nhờ",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
 	// Get vehicle details from user_id
 	vehicle, err := ctrl.VehicleService.GetVehicleFromID(rideOffer.VehicleID)
 	if err != nil {
@@ -370,15 +468,29 @@ func (ctrl *RideController) SendHitchRideRequest(ctx *gin.Context) {
 		return
 	}
 
+	// Suggest where the hitcher meets the driver on the route
+	meetingPoints, err := ctrl.MapsService.SuggestMeetingPoints(ctx.Request.Context(), rideOffer, rideRequest)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to suggest meeting points",
+			"Không thể gợi ý điểm đón và điểm trả",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
 	res := schemas.SendHitchRideRequestResponse{
 		ID: rideRequest.ID,
 		User: schemas.UserInfo{
-			ID:           user.ID,
-			FullName:     user.FullName,
-			PhoneNumber:  user.PhoneNumber,
-			AvatarURL:    user.AvatarURL,
-			Gender:       user.Gender,
-			IsMomoLinked: user.IsMomoLinked,
+			ID:            user.ID,
+			FullName:      user.FullName,
+			PhoneNumber:   user.PhoneNumber,
+			AvatarURL:     user.AvatarURL,
+			Gender:        user.Gender,
+			IsMomoLinked:  user.IsMomoLinked,
+			AverageRating: user.AverageRating,
+			RatingCount:   user.RatingCount,
 		},
 		StartLatitude:         rideRequest.StartLatitude,
 		StartLongitude:        rideRequest.StartLongitude,
@@ -397,6 +509,8 @@ func (ctrl *RideController) SendHitchRideRequest(ctx *gin.Context) {
 		ReceiverID:            req.ReceiverID,
 		RideOfferID:           req.RideOfferID,
 		Vehicle:               vehicle,
+		MeetingPoints:         meetingPoints,
+		Preferences:           helper.ToRidePreferencesDetail(rideRequest.RidePreferences),
 	}
 
 	// Send ride request to the receiver
@@ -493,6 +607,7 @@ func (ctrl *RideController) SendHitchRideRequest(ctx *gin.Context) {
 // @Param request body schemas.AcceptGiveRideRequestRequest true "Accept give ride request details"
 // @Success 200 {object} helper.Response{data=schemas.AcceptGiveRideRequestResponse} "Successfully accepted ride offer request"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "One of the users blocked the other"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /ride/accept-give-ride-request [post]
 func (ctrl *RideController) AcceptGiveRideRequest(ctx *gin.Context) {
@@ -536,14 +651,21 @@ func (ctrl *RideController) AcceptGiveRideRequest(ctx *gin.Context) {
 	}
 
 	// Create ride between driver and hitcher (because the hitcher accepted the ride offer from the driver means ride is engaged)
-	ride, err := ctrl.RideService.AcceptRideRequest(req.RideOfferID, req.RideRequestID, req.VehicleID)
-	if err != nil {
+	ride, err := ctrl.RideService.AcceptRideRequest(req.RideOfferID, req.RideRequestID, req.VehicleID, data.UserID, func(rideOffer migration.RideOffer, rideRequest migration.RideRequest) (schemas.MeetingPoints, error) {
+		return ctrl.MapsService.SuggestMeetingPoints(ctx.Request.Context(), rideOffer, rideRequest)
+	})
+	if err != nil {
+		// The users who blocked each other cannot ride together
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
 		response := helper.ErrorResponseWithMessage(
 			err,
 			"Failed to create ride",
 			"Không thể tạo chuyến đi",
 		)
-		helper.GinResponse(ctx, 500, response)
+		helper.GinResponse(ctx, status, response)
 		return
 	}
 
@@ -659,15 +781,18 @@ func (ctrl *RideController) AcceptGiveRideRequest(ctx *gin.Context) {
 		Vehicle:                vehicle,
 		ReceiverID:             req.ReceiverID,
 		UserInfo: schemas.UserInfo{
-			ID:           receiver.ID,
-			PhoneNumber:  receiver.PhoneNumber,
-			FullName:     receiver.FullName,
-			AvatarURL:    receiver.AvatarURL,
-			Gender:       receiver.Gender,
-			IsMomoLinked: receiver.IsMomoLinked,
+			ID:            receiver.ID,
+			PhoneNumber:   receiver.PhoneNumber,
+			FullName:      receiver.FullName,
+			AvatarURL:     receiver.AvatarURL,
+			Gender:        receiver.Gender,
+			IsMomoLinked:  receiver.IsMomoLinked,
+			AverageRating: receiver.AverageRating,
+			RatingCount:   receiver.RatingCount,
 		},
 		RideRequestID: req.RideRequestID,
 		Waypoints:     waypointDetails,
+		MeetingPoints: toMeetingPoints(ride),
 	}
 
 	// Send the accepted ride offer to the driver (match the ride successfully)
@@ -752,6 +877,7 @@ func (ctrl *RideController) AcceptGiveRideRequest(ctx *gin.Context) {
 // @Param request body schemas.AcceptHitchRideRequestRequest true "Accept hitch ride request details"
 // @Success 200 {object} helper.Response{data=schemas.AcceptHitchRideRequestResponse} "Successfully accepted ride request"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "One of the users blocked the other"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /ride/accept-hitch-ride-request [post]
 func (ctrl *RideController) AcceptHitchRideRequest(ctx *gin.Context) {
@@ -793,14 +919,21 @@ func (ctrl *RideController) AcceptHitchRideRequest(ctx *gin.Context) {
 	}
 
 	// Create ride between driver and hitcher (because the driver accepted the ride request from the hitcher means ride is engaged)
-	ride, err := ctrl.RideService.AcceptRideRequest(req.RideOfferID, req.RideRequestID, req.VehicleID)
-	if err != nil {
+	ride, err := ctrl.RideService.AcceptRideRequest(req.RideOfferID, req.RideRequestID, req.VehicleID, data.UserID, func(rideOffer migration.RideOffer, rideRequest migration.RideRequest) (schemas.MeetingPoints, error) {
+		return ctrl.MapsService.SuggestMeetingPoints(ctx.Request.Context(), rideOffer, rideRequest)
+	})
+	if err != nil {
+		// The users who blocked each other cannot ride together
+		status := 500
+		if errors.Is(err, service.ErrUserBlocked) {
+			status = 403
+		}
 		response := helper.ErrorResponseWithMessage(
 			err,
 			"Failed to create ride",
 			"Không thể tạo chuyến đi",
 		)
-		helper.GinResponse(ctx, 500, response)
+		helper.GinResponse(ctx, status, response)
 		return
 	}
 
@@ -917,15 +1050,18 @@ func (ctrl *RideController) AcceptHitchRideRequest(ctx *gin.Context) {
 		EndLongitude:           ride.EndLongitude,
 		ReceiverID:             req.ReceiverID,
 		UserInfo: schemas.UserInfo{
-			ID:           receiver.ID,
-			PhoneNumber:  receiver.PhoneNumber,
-			FullName:     receiver.FullName,
-			AvatarURL:    receiver.AvatarURL,
-			Gender:       receiver.Gender,
-			IsMomoLinked: receiver.IsMomoLinked,
+			ID:            receiver.ID,
+			PhoneNumber:   receiver.PhoneNumber,
+			FullName:      receiver.FullName,
+			AvatarURL:     receiver.AvatarURL,
+			Gender:        receiver.Gender,
+			IsMomoLinked:  receiver.IsMomoLinked,
+			AverageRating: receiver.AverageRating,
+			RatingCount:   receiver.RatingCount,
 		},
-		Vehicle:   vehicle,
-		Waypoints: waypointDetails,
+		Vehicle:       vehicle,
+		Waypoints:     waypointDetails,
+		MeetingPoints: toMeetingPoints(ride),
 	}
 
 	// Send the accepted ride request to the hitcher (match the ride successfully)
@@ -1280,10 +1416,75 @@ func (ctrl *RideController) CancelHitchRideRequest(ctx *gin.Context) {
 	))
 }
 
+// IssuePickupCode issues the pickup code the hitcher shows to the driver before the ride starts
+// IssuePickupCode godoc
+// @Summary Issue a pickup code
+// @Description Issues a new pickup PIN and QR payload for the hitcher of a scheduled ride, the driver must submit one of them to start the ride
+// @Tags ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.IssuePickupCodeRequest true "Issue pickup code request"
+// @Success 200 {object} helper.Response{data=schemas.IssuePickupCodeResponse} "Successfully issued pickup code"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/pickup-code [post]
+func (ctrl *RideController) IssuePickupCode(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.IssuePickupCodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind JSON",
+			"Không thể bind JSON",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	res, err := ctrl.RideService.IssuePickupCode(req.RideID, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to issue pickup code",
+			"Không thể tạo mã đón khách",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Successfully issued pickup code",
+		"Đã tạo mã đón khách thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
 // StartRide starts the ride between the driver and the hitcher (the driver must starts the ride)
 // StartRide starts the ride between the driver and the hitcher (the driver must starts the ride)
 // @Summary Start a ride
-// @Description Starts the ride between the driver and the hitcher
+// @Description Starts the ride between the driver and the hitcher, the driver must submit the pickup PIN or QR payload shown by the hitcher
 // @Tags ride
 // @Accept json
 // @Produce json
@@ -1291,6 +1492,7 @@ func (ctrl *RideController) CancelHitchRideRequest(ctx *gin.Context) {
 // @Param request body schemas.StartRideRequest true "Start ride request"
 // @Success 200 {object} helper.Response{data=schemas.StartRideResponse} "Successfully started ride"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "Location of the driver could not be verified"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /ride/start-ride [post]
 func (ctrl *RideController) StartRide(ctx *gin.Context) {
@@ -1333,6 +1535,16 @@ func (ctrl *RideController) StartRide(ctx *gin.Context) {
 
 	// Start the ride
 	ride, err := ctrl.RideService.StartRide(req, data.UserID)
+	var geofenceErr *helper.GeofenceError
+	if errors.As(err, &geofenceErr) {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Your location could not be verified",
+			"Không thể xác minh vị trí của bạn",
+		)
+		helper.GinResponse(ctx, 403, response)
+		return
+	}
 	if err != nil {
 		response := helper.ErrorResponseWithMessage(
 			err,
@@ -1439,12 +1651,14 @@ func (ctrl *RideController) StartRide(ctx *gin.Context) {
 			PaymentMethod: transaction.PaymentMethod,
 		},
 		User: schemas.UserInfo{
-			ID:           driver.ID,
-			FullName:     driver.FullName,
-			PhoneNumber:  driver.PhoneNumber,
-			AvatarURL:    driver.AvatarURL,
-			Gender:       driver.Gender,
-			IsMomoLinked: driver.IsMomoLinked,
+			ID:            driver.ID,
+			FullName:      driver.FullName,
+			PhoneNumber:   driver.PhoneNumber,
+			AvatarURL:     driver.AvatarURL,
+			Gender:        driver.Gender,
+			IsMomoLinked:  driver.IsMomoLinked,
+			AverageRating: driver.AverageRating,
+			RatingCount:   driver.RatingCount,
 		},
 		Status:                 ride.Status,
 		StartTime:              ride.StartTime,
@@ -1466,6 +1680,8 @@ func (ctrl *RideController) StartRide(ctx *gin.Context) {
 		Vehicle:                vehicle,
 		ReceiverID:             rideRequest.UserID, // ReceiverID is the hitcher's user_id
 		Waypoints:              waypointDetails,
+		PassengerVerifiedAt:    ride.PassengerVerifiedAt,
+		PassengerVerification:  ride.PassengerVerification,
 	}
 
 	// Get receiver device token to send notification
@@ -1560,6 +1776,7 @@ func (ctrl *RideController) StartRide(ctx *gin.Context) {
 // @Param request body schemas.EndRideRequest true "End ride request"
 // @Success 200 {object} helper.Response{data=schemas.EndRideResponse} "Successfully ended ride"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "User is not the driver of the ride or location of the driver could not be verified"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /ride/end-ride [post]
 func (ctrl *RideController) EndRide(ctx *gin.Context) {
@@ -1602,6 +1819,25 @@ func (ctrl *RideController) EndRide(ctx *gin.Context) {
 
 	// End the ride
 	ride, err := ctrl.RideService.EndRide(req, data.UserID)
+	if errors.Is(err, repository.ErrNotRideDriver) {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Only the driver can end the ride",
+			"Chỉ tài xế mới có thể kết thúc chuyến đi",
+		)
+		helper.GinResponse(ctx, 403, response)
+		return
+	}
+	var geofenceErr *helper.GeofenceError
+	if errors.As(err, &geofenceErr) {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Your location could not be verified",
+			"Không thể xác minh vị trí của bạn",
+		)
+		helper.GinResponse(ctx, 403, response)
+		return
+	}
 	if err != nil {
 		response := helper.ErrorResponseWithMessage(
 			err,
@@ -1708,12 +1944,14 @@ func (ctrl *RideController) EndRide(ctx *gin.Context) {
 			PaymentMethod: transaction.PaymentMethod,
 		},
 		User: schemas.UserInfo{
-			ID:           driver.ID,
-			FullName:     driver.FullName,
-			PhoneNumber:  driver.PhoneNumber,
-			AvatarURL:    driver.AvatarURL,
-			Gender:       driver.Gender,
-			IsMomoLinked: driver.IsMomoLinked,
+			ID:            driver.ID,
+			FullName:      driver.FullName,
+			PhoneNumber:   driver.PhoneNumber,
+			AvatarURL:     driver.AvatarURL,
+			Gender:        driver.Gender,
+			IsMomoLinked:  driver.IsMomoLinked,
+			AverageRating: driver.AverageRating,
+			RatingCount:   driver.RatingCount,
 		},
 		Status:                 ride.Status,
 		StartTime:              ride.StartTime,
@@ -1818,6 +2056,78 @@ func (ctrl *RideController) EndRide(ctx *gin.Context) {
 	helper.GinResponse(ctx, 200, response)
 }
 
+// UpdateRiderLocation godoc
+// @Summary Update the current location of the passenger during the ride
+// @Description Updates the current location the passenger shares with the driver, it is returned as the rider location of the ride and is not used by the geofences
+// @Tags ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.UpdateRiderLocationRequest true "Update rider location request"
+// @Success 200 {object} helper.Response{data=schemas.UpdateRiderLocationResponse} "Successfully updated rider location"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "Not the passenger of the ride"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/update-rider-location [post]
+func (ctrl *RideController) UpdateRiderLocation(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.UpdateRiderLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind JSON",
+			"Không thể bind JSON",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	ride, err := ctrl.RideService.UpdateRiderLocation(req, data.UserID)
+	if err != nil {
+		status := 500
+		if errors.Is(err, repository.ErrNotRidePassenger) {
+			status = 403
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to update rider location",
+			"Không thể cập nhật vị trí hành khách",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	res := schemas.UpdateRiderLocationResponse{
+		ID:                    ride.ID,
+		Status:                ride.Status,
+		RiderCurrentLatitude:  req.CurrentLocation.Lat,
+		RiderCurrentLongitude: req.CurrentLocation.Lng,
+	}
+
+	response := helper.SuccessResponse(res, "Successfully updated rider location", "Cập nhật vị trí hành khách thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
 // UpdateRideLocation updates the current location of the driver during the ride (the driver must update the location)
 // UpdateRideLocation godoc
 // @Summary Update the current location of the driver during the ride
@@ -1829,6 +2139,7 @@ func (ctrl *RideController) EndRide(ctx *gin.Context) {
 // @Param request body schemas.UpdateRideLocationRequest true "Update ride location request"
 // @Success 200 {object} helper.Response{data=schemas.UpdateRideLocationResponse} "Successfully updated ride location"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "Not the driver of the ride, or location of the driver could not be verified"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /ride/update-ride-location [post]
 func (ctrl *RideController) UpdateRideLocation(ctx *gin.Context) {
@@ -1871,6 +2182,25 @@ func (ctrl *RideController) UpdateRideLocation(ctx *gin.Context) {
 
 	// Update the ride location
 	ride, err := ctrl.RideService.UpdateRideLocation(req, data.UserID)
+	if errors.Is(err, repository.ErrNotRideDriver) {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Only the driver can update the location of the ride",
+			"Chỉ tài xế mới có thể cập nhật vị trí chuyến đi",
+		)
+		helper.GinResponse(ctx, 403, response)
+		return
+	}
+	var geofenceErr *helper.GeofenceError
+	if errors.As(err, &geofenceErr) {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Your location could not be verified",
+			"Không thể xác minh vị trí của bạn",
+		)
+		helper.GinResponse(ctx, 403, response)
+		return
+	}
 	if err != nil {
 		response := helper.ErrorResponseWithMessage(
 			err,
@@ -1975,12 +2305,14 @@ func (ctrl *RideController) UpdateRideLocation(ctx *gin.Context) {
 			PaymentMethod: transaction.PaymentMethod,
 		},
 		User: schemas.UserInfo{
-			ID:           driver.ID,
-			FullName:     driver.FullName,
-			PhoneNumber:  driver.PhoneNumber,
-			AvatarURL:    driver.AvatarURL,
-			Gender:       driver.Gender,
-			IsMomoLinked: driver.IsMomoLinked,
+			ID:            driver.ID,
+			FullName:      driver.FullName,
+			PhoneNumber:   driver.PhoneNumber,
+			AvatarURL:     driver.AvatarURL,
+			Gender:        driver.Gender,
+			IsMomoLinked:  driver.IsMomoLinked,
+			AverageRating: driver.AverageRating,
+			RatingCount:   driver.RatingCount,
 		},
 		Status:                 ride.Status,
 		StartTime:              ride.StartTime,
@@ -2076,6 +2408,18 @@ func (ctrl *RideController) UpdateRideLocation(ctx *gin.Context) {
 	// 	}
 	// }()
 
+	// Push the live ETA to the hitcher, it must not fail the location update
+	go func() {
+		eta, err := ctrl.MapsService.EstimateRideETA(context.Background(), ride, rideOffer, rideRequest, req.CurrentLocation)
+		if err != nil {
+			log.Printf("Failed to estimate ride ETA: %v", err)
+			return
+		}
+		if err := ctrl.hub.SendToUser(rideRequest.UserID.String(), "ride-eta-update", eta); err != nil {
+			log.Printf("Failed to send ride ETA: %v", err)
+		}
+	}()
+
 	// Return success response
 	response := helper.SuccessResponse(
 		res,
@@ -2096,6 +2440,7 @@ func (ctrl *RideController) UpdateRideLocation(ctx *gin.Context) {
 // @Param request body schemas.CancelRideRequest true "Cancel ride request"
 // @Success 200 {object} helper.Response{data=schemas.CancelRideResponse} "Successfully canceled ride by driver"
 // @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 403 {object} helper.Response "User is neither the driver nor the passenger of the ride"
 // @Failure 500 {object} helper.Response "Internal server error"
 // @Router /ride/cancel-ride [post]
 func (ctrl *RideController) CancelRide(ctx *gin.Context) {
@@ -2139,12 +2484,16 @@ func (ctrl *RideController) CancelRide(ctx *gin.Context) {
 	// Cancel the ride by the driver
 	ride, err := ctrl.RideService.CancelRide(req, data.UserID)
 	if err != nil {
+		status := 500
+		if errors.Is(err, repository.ErrNotRideParticipant) {
+			status = 403
+		}
 		response := helper.ErrorResponseWithMessage(
 			err,
 			"Failed to cancel ride by driver",
 			"Không thể hủy chuyến đi bởi tài xế",
 		)
-		helper.GinResponse(ctx, 500, response)
+		helper.GinResponse(ctx, status, response)
 		return
 	}
 
@@ -2512,12 +2861,14 @@ func (ctrl *RideController) GetAllPendingRide(ctx *gin.Context) {
 			ID:      rideOffer.ID,
 			Vehicle: vehicle,
 			User: schemas.UserInfo{
-				ID:           driver.ID,
-				FullName:     driver.FullName,
-				PhoneNumber:  driver.PhoneNumber,
-				Gender:       driver.Gender,
-				AvatarURL:    driver.AvatarURL,
-				IsMomoLinked: driver.IsMomoLinked,
+				ID:            driver.ID,
+				FullName:      driver.FullName,
+				PhoneNumber:   driver.PhoneNumber,
+				Gender:        driver.Gender,
+				AvatarURL:     driver.AvatarURL,
+				IsMomoLinked:  driver.IsMomoLinked,
+				AverageRating: driver.AverageRating,
+				RatingCount:   driver.RatingCount,
 			},
 			StartTime:              rideOffer.StartTime,
 			StartLatitude:          rideOffer.StartLatitude,
@@ -2534,6 +2885,8 @@ func (ctrl *RideController) GetAllPendingRide(ctx *gin.Context) {
 			Status:                 rideOffer.Status,
 			EndTime:                rideOffer.EndTime,
 			Fare:                   rideOffer.Fare,
+			TotalSeats:             rideOffer.TotalSeats,
+			AvailableSeats:         rideOffer.AvailableSeats,
 			Waypoints:              waypointDetails,
 		})
 	}
@@ -2553,12 +2906,14 @@ func (ctrl *RideController) GetAllPendingRide(ctx *gin.Context) {
 		pendingRideRequestDetails = append(pendingRideRequestDetails, schemas.RideRequestDetail{
 			ID: rideRequest.ID,
 			User: schemas.UserInfo{
-				ID:           rider.ID,
-				FullName:     rider.FullName,
-				PhoneNumber:  rider.PhoneNumber,
-				AvatarURL:    rider.AvatarURL,
-				Gender:       rider.Gender,
-				IsMomoLinked: rider.IsMomoLinked,
+				ID:            rider.ID,
+				FullName:      rider.FullName,
+				PhoneNumber:   rider.PhoneNumber,
+				AvatarURL:     rider.AvatarURL,
+				Gender:        rider.Gender,
+				IsMomoLinked:  rider.IsMomoLinked,
+				AverageRating: rider.AverageRating,
+				RatingCount:   rider.RatingCount,
 			},
 			StartTime:             rideRequest.StartTime,
 			StartLatitude:         rideRequest.StartLatitude,
@@ -2590,3 +2945,227 @@ func (ctrl *RideController) GetAllPendingRide(ctx *gin.Context) {
 	helper.GinResponse(ctx, 200, response)
 
 }
+
+// GetRideHistory godoc
+// @Summary Get the ride history
+// @Description Get the rides of the user as driver or passenger, newest first, with the other party, the vehicle and the transaction. Pass the next_cursor of a page to get the next one
+// @Tags ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param role query string false "Role of the user in the rides" Enums(driver, passenger)
+// @Param status query string false "Status of the rides" Enums(scheduled, ongoing, completed, cancelled, no_show)
+// @Param from query string false "First day of the start time, YYYY-MM-DD"
+// @Param to query string false "Last day of the start time, YYYY-MM-DD"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Number of rides per page, 20 by default"
+// @Success 200 {object} helper.Response{data=schemas.GetRideHistoryResponse} "Ride history retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/history [get]
+func (ctrl *RideController) GetRideHistory(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.GetRideHistoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	rides, nextCursor, err := ctrl.RideService.GetRideHistory(req, data.UserID)
+	if err != nil {
+		status := 500
+		if errors.Is(err, helper.ErrInvalidRideHistoryCursor) {
+			status = 400
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get ride history",
+			"Không thể lấy lịch sử chuyến đi",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	res := schemas.GetRideHistoryResponse{
+		Rides:      make([]schemas.RideHistoryItem, len(rides)),
+		NextCursor: nextCursor,
+	}
+	for i, ride := range rides {
+		res.Rides[i] = toRideHistoryItem(ride, data.UserID)
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Ride history retrieved successfully",
+		"Lấy lịch sử chuyến đi thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetRideHistorySummary godoc
+// @Summary Get the monthly summary of the ride history
+// @Description Get the trips, the kilometers shared and the money earned and spent per month over the completed rides of the user, the last 12 months by default
+// @Tags ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First month, YYYY-MM"
+// @Param to query string false "Last month, YYYY-MM"
+// @Success 200 {object} helper.Response{data=schemas.GetRideHistorySummaryResponse} "Ride history summary retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/history-summary [get]
+func (ctrl *RideController) GetRideHistorySummary(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.GetRideHistorySummaryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	res, err := ctrl.RideService.GetRideHistorySummary(req, data.UserID)
+	if err != nil {
+		status := 500
+		if errors.Is(err, service.ErrInvalidSummaryRange) {
+			status = 400
+		}
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get ride history summary",
+			"Không thể lấy thống kê chuyến đi",
+		)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Ride history summary retrieved successfully",
+		"Lấy thống kê chuyến đi thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// toRideHistoryItem describes a ride from the side of the given user
+func toRideHistoryItem(ride migration.Ride, userID uuid.UUID) schemas.RideHistoryItem {
+	role := "passenger"
+	counterpart := ride.RideOffer.User
+	if ride.RideOffer.UserID == userID {
+		role = "driver"
+		counterpart = ride.RideRequest.User
+	}
+
+	item := schemas.RideHistoryItem{
+		RideID:         ride.ID,
+		Role:           role,
+		Status:         ride.Status,
+		StartTime:      ride.StartTime,
+		EndTime:        ride.EndTime,
+		StartAddress:   ride.StartAddress,
+		EndAddress:     ride.EndAddress,
+		PickupAddress:  ride.PickupAddress,
+		DropoffAddress: ride.DropoffAddress,
+		Distance:       ride.RideRequest.Distance,
+		Duration:       ride.RideRequest.Duration,
+		Fare:           ride.Fare,
+		Counterpart: schemas.UserInfo{
+			ID:            counterpart.ID,
+			PhoneNumber:   counterpart.PhoneNumber,
+			FullName:      counterpart.FullName,
+			AvatarURL:     counterpart.AvatarURL,
+			Gender:        counterpart.Gender,
+			IsMomoLinked:  counterpart.IsMomoLinked,
+			AverageRating: counterpart.AverageRating,
+			RatingCount:   counterpart.RatingCount,
+		},
+		Vehicle: schemas.VehicleDetail{
+			VehicleID:    ride.Vehicle.ID,
+			Name:         ride.Vehicle.Name,
+			FuelType:     ride.Vehicle.FuelType,
+			FuelConsumed: ride.Vehicle.FuelConsumed,
+			LicensePlate: ride.Vehicle.LicensePlate,
+			SeatCapacity: ride.Vehicle.SeatCapacity,
+		},
+	}
+
+	// A ride has a single payment, the latest one if it was ever recreated
+	if latest := helper.LatestTransaction(ride.Transactions); latest != nil {
+		item.Transaction = &schemas.TransactionDetail{
+			ID:            latest.ID,
+			Amount:        latest.Amount,
+			Status:        latest.Status,
+			PaymentMethod: latest.PaymentMethod,
+		}
+	}
+	return item
+}
+
+// toMeetingPoints returns the meeting points stored on the ride when it was accepted
+func toMeetingPoints(ride migration.Ride) schemas.MeetingPoints {
+	return schemas.MeetingPoints{
+		Pickup: schemas.MeetingPoint{
+			Latitude:        ride.PickupLatitude,
+			Longitude:       ride.PickupLongitude,
+			Address:         ride.PickupAddress,
+			WalkingDistance: ride.PickupWalkingDistance,
+		},
+		Dropoff: schemas.MeetingPoint{
+			Latitude:        ride.DropoffLatitude,
+			Longitude:       ride.DropoffLongitude,
+			Address:         ride.DropoffAddress,
+			WalkingDistance: ride.DropoffWalkingDistance,
+		},
+	}
+}
@@ -0,0 +1,314 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/middleware"
+	"shareway/schemas"
+	"shareway/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// Interval of the keep-alive comments on the deviation stream, so the proxies do not close it
+const deviationStreamKeepAlive = 30 * time.Second
+
+type RideTrailController struct {
+	validate *validator.Validate
+	service  service.IRideTrailService
+}
+
+func NewRideTrailController(validate *validator.Validate, service service.IRideTrailService) *RideTrailController {
+	return &RideTrailController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// GetRideTrail godoc
+// @Summary Export the trail of a ride
+// @Description Export the positions recorded during a ride as a GeoJSON line or a GPX track, only for the driver and the passenger of the ride
+// @Tags ride
+// @Accept json
+// @Produce json,xml
+// @Security BearerAuth
+// @Param rideID query string true "Ride ID"
+// @Param format query string false "Format of the export" Enums(geojson, gpx)
+// @Success 200 {object} schemas.RideTrailFeatureCollection "GeoJSON trail, or a GPX document when format is gpx"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/trail [get]
+func (ctrl *RideTrailController) GetRideTrail(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	req, ok := ctrl.bindRideTrailRequest(ctx)
+	if !ok {
+		return
+	}
+
+	rideID := uuid.MustParse(req.RideID)
+	samples, err := ctrl.service.GetRideTrail(rideID, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get ride trail",
+			"Không thể lấy lộ trình chuyến đi",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	writeRideTrail(ctx, rideID, req.Format, samples)
+}
+
+// AdminGetRideTrail godoc
+// @Summary Export the trail of a ride
+// @Description Export the positions recorded during any ride as a GeoJSON line or a GPX track, to handle the disputes
+// @Tags admin
+// @Accept json
+// @Produce json,xml
+// @Security BearerAuth
+// @Param rideID query string true "Ride ID"
+// @Param format query string false "Format of the export" Enums(geojson, gpx)
+// @Success 200 {object} schemas.RideTrailFeatureCollection "GeoJSON trail, or a GPX document when format is gpx"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/ride/trail [get]
+func (ctrl *RideTrailController) AdminGetRideTrail(ctx *gin.Context) {
+	req, ok := ctrl.bindRideTrailRequest(ctx)
+	if !ok {
+		return
+	}
+
+	rideID := uuid.MustParse(req.RideID)
+	samples, err := ctrl.service.GetRideTrailForAdmin(rideID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get ride trail",
+			"Không thể lấy lộ trình chuyến đi",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	writeRideTrail(ctx, rideID, req.Format, samples)
+}
+
+// AdminGetDeviationEvents godoc
+// @Summary Get the deviation events of the rides
+// @Description Get the latest times a driver left the planned route or stopped for long during a ride, to review them
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rideID query string false "Ride ID, all the rides when empty"
+// @Param unacknowledged query bool false "Only the events not reviewed yet"
+// @Param limit query int false "Maximum number of events, 50 by default"
+// @Success 200 {object} helper.Response{data=schemas.GetDeviationEventsResponse} "Deviation events retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/ride/deviation-events [get]
+func (ctrl *RideTrailController) AdminGetDeviationEvents(ctx *gin.Context) {
+	var req schemas.GetDeviationEventsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	events, err := ctrl.service.GetDeviationEvents(req)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get deviation events",
+			"Không thể lấy danh sách sự cố lệch lộ trình",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GetDeviationEventsResponse{
+		Events: make([]schemas.DeviationEventDetail, len(events)),
+	}
+	for i, event := range events {
+		res.Events[i] = helper.ToDeviationEventDetail(event)
+	}
+
+	response := helper.SuccessResponse(res, "Deviation events retrieved successfully", "Lấy danh sách sự cố lệch lộ trình thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// AdminStreamDeviationEvents godoc
+// @Summary Stream the deviation events
+// @Description Server-sent events of the route deviations and the long stops as they are detected. Each event is named deviation and its data is a schemas.DeviationEventDetail
+// @Tags admin
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} schemas.DeviationEventDetail "Stream of deviation events"
+// @Router /admin/ride/deviation-events/stream [get]
+func (ctrl *RideTrailController) AdminStreamDeviationEvents(ctx *gin.Context) {
+	pubsub := ctrl.service.SubscribeDeviationEvents(ctx.Request.Context())
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	keepAlive := time.NewTicker(deviationStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case message, ok := <-messages:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent("deviation", message.Payload)
+			return true
+		case <-keepAlive.C:
+			ctx.SSEvent("ping", time.Now().Unix())
+			return true
+		}
+	})
+}
+
+// AdminAcknowledgeDeviationEvent godoc
+// @Summary Acknowledge a deviation event
+// @Description Mark a deviation event as reviewed, with an optional note kept for the audit
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.AcknowledgeDeviationEventRequest true "Acknowledge deviation event request"
+// @Success 200 {object} helper.Response{data=schemas.DeviationEventDetail} "Deviation event acknowledged successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/ride/deviation-events/acknowledge [post]
+func (ctrl *RideTrailController) AdminAcknowledgeDeviationEvent(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToAdminPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.AcknowledgeDeviationEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	event, err := ctrl.service.AcknowledgeDeviationEvent(req, data.AdminID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to acknowledge deviation event",
+			"Không thể xác nhận sự cố lệch lộ trình",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(helper.ToDeviationEventDetail(event), "Deviation event acknowledged successfully", "Xác nhận sự cố lệch lộ trình thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+func (ctrl *RideTrailController) bindRideTrailRequest(ctx *gin.Context) (schemas.GetRideTrailRequest, bool) {
+	var req schemas.GetRideTrailRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return req, false
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return req, false
+	}
+
+	return req, true
+}
+
+// writeRideTrail sends the trail as a downloadable GeoJSON or GPX file
+func writeRideTrail(ctx *gin.Context, rideID uuid.UUID, format string, samples []migration.RideLocationSample) {
+	if format != "gpx" {
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=ride-%s.geojson", rideID))
+		ctx.Header("Content-Type", "application/geo+json")
+		ctx.JSON(200, helper.ToRideTrailGeoJSON(rideID, samples))
+		return
+	}
+
+	data, err := helper.ToRideTrailGPX(rideID, samples)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to export ride trail",
+			"Không thể xuất lộ trình chuyến đi",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=ride-%s.gpx", rideID))
+	ctx.Data(200, "application/gpx+xml", data)
+}
@@ -0,0 +1,290 @@
+package controller
+
+import (
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/middleware"
+	"shareway/schemas"
+	"shareway/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type RouteAlertController struct {
+	validate *validator.Validate
+	service  service.IRouteAlertService
+}
+
+func NewRouteAlertController(validate *validator.Validate, service service.IRouteAlertService) *RouteAlertController {
+	return &RouteAlertController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// CreateRouteAlert godoc
+// @Summary Create a route alert
+// @Description Save a route and a recurring time window, the user is notified when a matching ride offer is created
+// @Tags route-alert
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.CreateRouteAlertRequest true "Route alert request"
+// @Success 200 {object} helper.Response{data=schemas.RouteAlertDetail} "Route alert created successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /route-alert/create [post]
+func (ctrl *RouteAlertController) CreateRouteAlert(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.CreateRouteAlertRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	routeAlert, err := ctrl.service.CreateRouteAlert(req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to create route alert",
+			"Không thể tạo thông báo tuyến đường",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toRouteAlertDetail(routeAlert),
+		"Route alert created successfully",
+		"Tạo thông báo tuyến đường thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetRouteAlerts godoc
+// @Summary Get route alerts
+// @Description Get all route alerts of the current user
+// @Tags route-alert
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helper.Response{data=schemas.GetRouteAlertsResponse} "Route alerts retrieved successfully"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /route-alert/get-all [get]
+func (ctrl *RouteAlertController) GetRouteAlerts(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	routeAlerts, err := ctrl.service.GetRouteAlerts(data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get route alerts",
+			"Không thể lấy danh sách thông báo tuyến đường",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GetRouteAlertsResponse{
+		RouteAlerts: make([]schemas.RouteAlertDetail, len(routeAlerts)),
+	}
+	for i, routeAlert := range routeAlerts {
+		res.RouteAlerts[i] = toRouteAlertDetail(routeAlert)
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Route alerts retrieved successfully",
+		"Lấy danh sách thông báo tuyến đường thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// UpdateRouteAlert godoc
+// @Summary Update a route alert
+// @Description Edit a route alert, set is_active to false to stop the notifications without deleting it
+// @Tags route-alert
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.UpdateRouteAlertRequest true "Update route alert request"
+// @Success 200 {object} helper.Response{data=schemas.RouteAlertDetail} "Route alert updated successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /route-alert/update [post]
+func (ctrl *RouteAlertController) UpdateRouteAlert(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.UpdateRouteAlertRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	routeAlert, err := ctrl.service.UpdateRouteAlert(req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to update route alert",
+			"Không thể cập nhật thông báo tuyến đường",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toRouteAlertDetail(routeAlert),
+		"Route alert updated successfully",
+		"Cập nhật thông báo tuyến đường thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// DeleteRouteAlert godoc
+// @Summary Delete a route alert
+// @Description Delete a route alert of the current user
+// @Tags route-alert
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.DeleteRouteAlertRequest true "Delete route alert request"
+// @Success 200 {object} helper.Response "Route alert deleted successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /route-alert/delete [post]
+func (ctrl *RouteAlertController) DeleteRouteAlert(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.DeleteRouteAlertRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.DeleteRouteAlert(req.RouteAlertID, data.UserID); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to delete route alert",
+			"Không thể xóa thông báo tuyến đường",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		nil,
+		"Route alert deleted successfully",
+		"Xóa thông báo tuyến đường thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+func toRouteAlertDetail(routeAlert migration.RouteAlert) schemas.RouteAlertDetail {
+	return schemas.RouteAlertDetail{
+		ID:   routeAlert.ID,
+		Name: routeAlert.Name,
+		Origin: schemas.Point{
+			Lat: routeAlert.OriginLatitude,
+			Lng: routeAlert.OriginLongitude,
+		},
+		OriginAddress: routeAlert.OriginAddress,
+		Destination: schemas.Point{
+			Lat: routeAlert.DestinationLatitude,
+			Lng: routeAlert.DestinationLongitude,
+		},
+		DestinationAddress: routeAlert.DestinationAddress,
+		DaysOfWeek:         service.ParseDaysOfWeek(routeAlert.DaysOfWeek),
+		WindowStart:        routeAlert.WindowStart,
+		WindowEnd:          routeAlert.WindowEnd,
+		IsActive:           routeAlert.IsActive,
+		LastNotifiedAt:     routeAlert.LastNotifiedAt,
+	}
+}
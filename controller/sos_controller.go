@@ -0,0 +1,627 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/middleware"
+	"shareway/schemas"
+	"shareway/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Interval of the keep-alive comments on the SOS stream, so the proxies do not close it
+const sosStreamKeepAlive = 30 * time.Second
+
+type SOSController struct {
+	validate *validator.Validate
+	service  service.ISOSService
+}
+
+func NewSOSController(validate *validator.Validate, service service.ISOSService) *SOSController {
+	return &SOSController{
+		validate: validate,
+		service:  service,
+	}
+}
+
+// CreateEmergencyContact godoc
+// @Summary Add an emergency contact
+// @Description Add a person alerted when the user raises an SOS, an OTP is sent to the phone number to verify it
+// @Tags sos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.CreateEmergencyContactRequest true "Emergency contact request"
+// @Success 200 {object} helper.Response{data=schemas.EmergencyContactDetail} "Emergency contact created successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /sos/emergency-contact/create [post]
+func (ctrl *SOSController) CreateEmergencyContact(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.CreateEmergencyContactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	contact, err := ctrl.service.CreateEmergencyContact(req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to create emergency contact",
+			"Không thể thêm liên hệ khẩn cấp",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toEmergencyContactDetail(contact),
+		"Emergency contact created successfully",
+		"Thêm liên hệ khẩn cấp thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// SendEmergencyContactOTP godoc
+// @Summary Resend the OTP of an emergency contact
+// @Description Send again an OTP to verify the phone number of an emergency contact
+// @Tags sos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.EmergencyContactRequest true "Emergency contact request"
+// @Success 200 {object} helper.Response "OTP sent successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /sos/emergency-contact/send-otp [post]
+func (ctrl *SOSController) SendEmergencyContactOTP(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.EmergencyContactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.SendEmergencyContactOTP(req.EmergencyContactID, data.UserID); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to send OTP",
+			"Không thể gửi mã OTP",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		nil,
+		"OTP sent successfully",
+		"Gửi mã OTP thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// VerifyEmergencyContact godoc
+// @Summary Verify an emergency contact
+// @Description Check the OTP sent to an emergency contact, only the verified contacts are alerted of an SOS
+// @Tags sos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.VerifyEmergencyContactRequest true "Verify emergency contact request"
+// @Success 200 {object} helper.Response{data=schemas.EmergencyContactDetail} "Emergency contact verified successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /sos/emergency-contact/verify [post]
+func (ctrl *SOSController) VerifyEmergencyContact(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.VerifyEmergencyContactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	contact, err := ctrl.service.VerifyEmergencyContact(req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to verify emergency contact",
+			"Không thể xác minh liên hệ khẩn cấp",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		toEmergencyContactDetail(contact),
+		"Emergency contact verified successfully",
+		"Xác minh liên hệ khẩn cấp thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetEmergencyContacts godoc
+// @Summary Get emergency contacts
+// @Description Get all emergency contacts of the current user
+// @Tags sos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helper.Response{data=schemas.GetEmergencyContactsResponse} "Emergency contacts retrieved successfully"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /sos/emergency-contact/get-all [get]
+func (ctrl *SOSController) GetEmergencyContacts(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	contacts, err := ctrl.service.GetEmergencyContacts(data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get emergency contacts",
+			"Không thể lấy danh sách liên hệ khẩn cấp",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GetEmergencyContactsResponse{
+		EmergencyContacts: make([]schemas.EmergencyContactDetail, len(contacts)),
+	}
+	for i, contact := range contacts {
+		res.EmergencyContacts[i] = toEmergencyContactDetail(contact)
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"Emergency contacts retrieved successfully",
+		"Lấy danh sách liên hệ khẩn cấp thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// DeleteEmergencyContact godoc
+// @Summary Delete an emergency contact
+// @Description Delete an emergency contact of the current user
+// @Tags sos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.EmergencyContactRequest true "Emergency contact request"
+// @Success 200 {object} helper.Response "Emergency contact deleted successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /sos/emergency-contact/delete [post]
+func (ctrl *SOSController) DeleteEmergencyContact(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.EmergencyContactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.DeleteEmergencyContact(req.EmergencyContactID, data.UserID); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to delete emergency contact",
+			"Không thể xóa liên hệ khẩn cấp",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		nil,
+		"Emergency contact deleted successfully",
+		"Xóa liên hệ khẩn cấp thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// TriggerSOS godoc
+// @Summary Raise an SOS
+// @Description Raise an emergency during a ride: the state and the last positions of the ride are kept, the admins are alerted right away and the verified emergency contacts get a time-limited link to the live trip
+// @Tags sos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.TriggerSOSRequest true "SOS request"
+// @Success 200 {object} helper.Response{data=schemas.TriggerSOSResponse} "SOS raised successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /sos/trigger [post]
+func (ctrl *SOSController) TriggerSOS(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.TriggerSOSRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	res, err := ctrl.service.TriggerSOS(req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to raise SOS",
+			"Không thể gửi yêu cầu khẩn cấp",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(
+		res,
+		"SOS raised successfully",
+		"Gửi yêu cầu khẩn cấp thành công",
+	)
+	helper.GinResponse(ctx, 200, response)
+}
+
+// AdminGetSOSEvents godoc
+// @Summary Get the SOS events
+// @Description Get the latest SOS events, with the given status when set
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status of the events" Enums(open, acknowledged, resolved)
+// @Param limit query int false "Maximum number of events, 50 by default"
+// @Success 200 {object} helper.Response{data=schemas.GetSOSEventsResponse} "SOS events retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/sos/get-all [get]
+func (ctrl *SOSController) AdminGetSOSEvents(ctx *gin.Context) {
+	var req schemas.GetSOSEventsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	events, err := ctrl.service.GetSOSEvents(req)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get SOS events",
+			"Không thể lấy danh sách yêu cầu khẩn cấp",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GetSOSEventsResponse{
+		Events: make([]schemas.SOSEventDetail, len(events)),
+	}
+	for i, event := range events {
+		res.Events[i] = helper.ToSOSEventDetail(event)
+	}
+
+	response := helper.SuccessResponse(res, "SOS events retrieved successfully", "Lấy danh sách yêu cầu khẩn cấp thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// AdminStreamSOSEvents godoc
+// @Summary Stream the SOS events
+// @Description Server-sent events of the SOS events as they are raised, acknowledged and resolved. Each event is named sos and its data is a schemas.SOSEventDetail
+// @Tags admin
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Success 200 {object} schemas.SOSEventDetail "Stream of SOS events"
+// @Router /admin/sos/stream [get]
+func (ctrl *SOSController) AdminStreamSOSEvents(ctx *gin.Context) {
+	pubsub := ctrl.service.SubscribeSOSEvents(ctx.Request.Context())
+	defer pubsub.Close()
+
+	messages := pubsub.Channel()
+	keepAlive := time.NewTicker(sosStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case message, ok := <-messages:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent("sos", message.Payload)
+			return true
+		case <-keepAlive.C:
+			ctx.SSEvent("ping", time.Now().Unix())
+			return true
+		}
+	})
+}
+
+// AdminAcknowledgeSOSEvent godoc
+// @Summary Acknowledge an SOS event
+// @Description Record that the current admin is handling an open SOS event
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.SOSEventRequest true "SOS event request"
+// @Success 200 {object} helper.Response{data=schemas.SOSEventDetail} "SOS event acknowledged successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/sos/acknowledge [post]
+func (ctrl *SOSController) AdminAcknowledgeSOSEvent(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToAdminPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.SOSEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	event, err := ctrl.service.AcknowledgeSOSEvent(req.SOSEventID, data.AdminID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to acknowledge SOS event",
+			"Không thể tiếp nhận yêu cầu khẩn cấp",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(helper.ToSOSEventDetail(event), "SOS event acknowledged successfully", "Tiếp nhận yêu cầu khẩn cấp thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// AdminResolveSOSEvent godoc
+// @Summary Resolve an SOS event
+// @Description Close an open or acknowledged SOS event, the note on how it was handled is kept for the audit
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.ResolveSOSEventRequest true "Resolve SOS event request"
+// @Success 200 {object} helper.Response{data=schemas.SOSEventDetail} "SOS event resolved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /admin/sos/resolve [post]
+func (ctrl *SOSController) AdminResolveSOSEvent(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToAdminPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.ResolveSOSEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	event, err := ctrl.service.ResolveSOSEvent(req, data.AdminID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to resolve SOS event",
+			"Không thể đóng yêu cầu khẩn cấp",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(helper.ToSOSEventDetail(event), "SOS event resolved successfully", "Đóng yêu cầu khẩn cấp thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+func toEmergencyContactDetail(contact migration.EmergencyContact) schemas.EmergencyContactDetail {
+	return schemas.EmergencyContactDetail{
+		ID:           contact.ID,
+		FullName:     contact.FullName,
+		PhoneNumber:  contact.PhoneNumber,
+		Relationship: contact.Relationship,
+		IsVerified:   contact.VerifiedAt != nil,
+		VerifiedAt:   contact.VerifiedAt,
+	}
+}
@@ -0,0 +1,381 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/middleware"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/service"
+	"shareway/util"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type TripShareController struct {
+	validate *validator.Validate
+	service  service.ITripShareService
+	cfg      util.Config
+}
+
+func NewTripShareController(validate *validator.Validate, service service.ITripShareService, cfg util.Config) *TripShareController {
+	return &TripShareController{
+		validate: validate,
+		service:  service,
+		cfg:      cfg,
+	}
+}
+
+// ShareTrip godoc
+// @Summary Share a ride
+// @Description Issue a public link to follow the ride live without the app, it works until it expires, it is revoked or the ride ends. Only the passenger can share the ride
+// @Tags ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.ShareTripRequest true "Share trip request"
+// @Success 200 {object} helper.Response{data=schemas.ShareTripResponse} "Trip shared successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/share-trip [post]
+func (ctrl *TripShareController) ShareTrip(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.ShareTripRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	res, err := ctrl.service.ShareTrip(req, data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to share trip",
+			"Không thể chia sẻ chuyến đi",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(res, "Trip shared successfully", "Chia sẻ chuyến đi thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetTripShares godoc
+// @Summary Get the shared links of a ride
+// @Description Get the public links the current user created for a ride, to revoke them
+// @Tags ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rideID query string true "Ride ID"
+// @Success 200 {object} helper.Response{data=schemas.GetTripSharesResponse} "Trip shares retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/trip-shares [get]
+func (ctrl *TripShareController) GetTripShares(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.GetTripSharesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	tripShares, err := ctrl.service.GetTripShares(uuid.MustParse(req.RideID), data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get trip shares",
+			"Không thể lấy danh sách liên kết chia sẻ",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.GetTripSharesResponse{
+		TripShares: make([]schemas.TripShareDetail, len(tripShares)),
+	}
+	for i, tripShare := range tripShares {
+		res.TripShares[i] = toTripShareDetail(tripShare)
+	}
+
+	response := helper.SuccessResponse(res, "Trip shares retrieved successfully", "Lấy danh sách liên kết chia sẻ thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// RevokeTripShare godoc
+// @Summary Revoke a shared link
+// @Description Stop a public link of the current user from working
+// @Tags ride
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.RevokeTripShareRequest true "Revoke trip share request"
+// @Success 200 {object} helper.Response "Trip share revoked successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /ride/revoke-trip-share [post]
+func (ctrl *TripShareController) RevokeTripShare(ctx *gin.Context) {
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+	data, err := helper.ConvertToPayload(payload)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	var req schemas.RevokeTripShareRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Invalid request body",
+			"Dữ liệu không hợp lệ",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.service.RevokeTripShare(req.TripShareID, data.UserID); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to revoke trip share",
+			"Không thể thu hồi liên kết chia sẻ",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	response := helper.SuccessResponse(nil, "Trip share revoked successfully", "Thu hồi liên kết chia sẻ thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// GetSharedTrip godoc
+// @Summary Follow a shared trip
+// @Description Get the driver position, the route, the ETA and the vehicle plate of the ride a public link points to, no account needed
+// @Tags trip-share
+// @Accept json
+// @Produce json
+// @Param token query string true "Token of the public link"
+// @Success 200 {object} helper.Response{data=schemas.SharedTripResponse} "Shared trip retrieved successfully"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "Link not found"
+// @Failure 410 {object} helper.Response "Link expired or revoked, or ride ended"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /trip-share/live [get]
+func (ctrl *TripShareController) GetSharedTrip(ctx *gin.Context) {
+	var req schemas.GetSharedTripRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	res, err := ctrl.service.GetSharedTrip(ctx.Request.Context(), req.Token)
+	if err != nil {
+		status, response := sharedTripErrorResponse(err)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	response := helper.SuccessResponse(res, "Shared trip retrieved successfully", "Lấy thông tin chuyến đi thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
+// StreamSharedTrip godoc
+// @Summary Stream a shared trip
+// @Description Server-sent events of the ride a public link points to, no account needed. A trip event with a schemas.SharedTripResponse is sent every TRIP_SHARE_STREAM_INTERVAL seconds, then an end event with a schemas.SharedTripEnd once the ride ends or the link stops working
+// @Tags trip-share
+// @Produce text/event-stream
+// @Param token query string true "Token of the public link"
+// @Success 200 {object} schemas.SharedTripResponse "Stream of the shared trip"
+// @Failure 400 {object} helper.Response "Invalid request"
+// @Failure 404 {object} helper.Response "Link not found"
+// @Failure 410 {object} helper.Response "Link expired or revoked, or ride ended"
+// @Router /trip-share/stream [get]
+func (ctrl *TripShareController) StreamSharedTrip(ctx *gin.Context) {
+	var req schemas.GetSharedTripRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to bind query",
+			"Không thể bind query",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	// Check the link before opening the stream so the client gets a proper error
+	res, err := ctrl.service.GetSharedTrip(ctx.Request.Context(), req.Token)
+	if err != nil {
+		status, response := sharedTripErrorResponse(err)
+		helper.GinResponse(ctx, status, response)
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(ctrl.cfg.TripShareStreamInterval) * time.Second)
+	defer ticker.Stop()
+
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.SSEvent("trip", res)
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-ticker.C:
+			res, err := ctrl.service.GetSharedTrip(ctx.Request.Context(), req.Token)
+			switch {
+			case err == nil:
+				ctx.SSEvent("trip", res)
+			case errors.Is(err, service.ErrTripShareRideEnded):
+				ctx.SSEvent("end", schemas.SharedTripEnd{Reason: "ended"})
+				return false
+			case errors.Is(err, service.ErrTripShareExpired), errors.Is(err, repository.ErrTripShareNotFound):
+				ctx.SSEvent("end", schemas.SharedTripEnd{Reason: "unavailable"})
+				return false
+			default:
+				// Keep the stream open, the next tick may succeed
+				log.Printf("Failed to get shared trip: %v", err)
+			}
+			return true
+		}
+	})
+}
+
+// sharedTripErrorResponse tells the viewers of a public link why the trip cannot be shown
+func sharedTripErrorResponse(err error) (int, helper.Response) {
+	switch {
+	case errors.Is(err, repository.ErrTripShareNotFound):
+		return 404, helper.ErrorResponseWithMessage(
+			err,
+			"This link does not exist",
+			"Liên kết không tồn tại",
+		)
+	case errors.Is(err, service.ErrTripShareExpired):
+		return 410, helper.ErrorResponseWithMessage(
+			err,
+			"This link is no longer available",
+			"Liên kết không còn khả dụng",
+		)
+	case errors.Is(err, service.ErrTripShareRideEnded):
+		return 410, helper.ErrorResponseWithMessage(
+			err,
+			"The trip has ended",
+			"Chuyến đi đã kết thúc",
+		)
+	default:
+		return 500, helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get shared trip",
+			"Không thể lấy thông tin chuyến đi",
+		)
+	}
+}
+
+func toTripShareDetail(tripShare migration.TripShare) schemas.TripShareDetail {
+	return schemas.TripShareDetail{
+		ID:        tripShare.ID,
+		RideID:    tripShare.RideID,
+		CreatedAt: tripShare.CreatedAt,
+		ExpiresAt: tripShare.ExpiresAt,
+		RevokedAt: tripShare.RevokedAt,
+		IsSOS:     tripShare.SOSEventID != nil,
+	}
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"log"
 	"shareway/helper"
 	"shareway/middleware"
 	"shareway/schemas"
@@ -12,21 +13,23 @@ import (
 )
 
 type UserController struct {
-	UserService service.IUsersService
-	validate    *validator.Validate
+	UserService   service.IUsersService
+	ImpactService service.IImpactService
+	validate      *validator.Validate
 }
 
-func NewUserController(userService service.IUsersService, validate *validator.Validate) *UserController {
+func NewUserController(userService service.IUsersService, impactService service.IImpactService, validate *validator.Validate) *UserController {
 	return &UserController{
-		UserService: userService,
-		validate:    validate,
+		UserService:   userService,
+		ImpactService: impactService,
+		validate:      validate,
 	}
 }
 
 // GetUserProfile receives access token and returns user profile information
 // GetUserProfile retrieves and returns the user profile information based on the access token.
 // @Summary Get user profile
-// @Description Retrieves the profile information of the authenticated user
+// @Description Retrieves the profile information of the authenticated user, with the fuel and the CO2 its shared rides saved
 // @Tags user
 // @Accept json
 // @Produce json
@@ -67,21 +70,32 @@ func (ctrl *UserController) GetUserProfile(ctx *gin.Context) {
 
 	res := schemas.GetUserProfileResponse{
 		User: schemas.UserResponse{
-			ID:           user.ID,
-			AvatarURL:    user.AvatarURL,
-			Gender:       user.Gender,
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
-			PhoneNumber:  user.PhoneNumber,
-			Email:        user.Email,
-			FullName:     user.FullName,
-			IsVerified:   user.IsVerified,
-			IsMomoLinked: user.IsMomoLinked,
-			IsActivated:  user.IsActivated,
-			Role:         user.Role,
+			ID:              user.ID,
+			AvatarURL:       user.AvatarURL,
+			Gender:          user.Gender,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+			PhoneNumber:     user.PhoneNumber,
+			Email:           user.Email,
+			FullName:        user.FullName,
+			IsVerified:      user.IsVerified,
+			IsMomoLinked:    user.IsMomoLinked,
+			AverageRating:   user.AverageRating,
+			RatingCount:     user.RatingCount,
+			IsActivated:     user.IsActivated,
+			Role:            user.Role,
+			RidePreferences: helper.ToRidePreferencesDetail(user.RidePreferences),
 		},
 	}
 
+	// The profile is still returned if the impact of the rides cannot be computed
+	impact, err := ctrl.ImpactService.GetUserImpact(data.UserID)
+	if err != nil {
+		log.Printf("Failed to get ride impact of user %s: %v", data.UserID, err)
+	} else {
+		res.Impact = &impact
+	}
+
 	response := helper.SuccessResponse(res, "Successfully authenticated", "Xác thực thành công")
 	helper.GinResponse(ctx, 200, response)
 }
@@ -236,18 +250,21 @@ func (ctrl *UserController) UpdateUserProfile(ctx *gin.Context) {
 
 	res := schemas.UpdateUserProfileResponse{
 		User: schemas.UserResponse{
-			ID:           user.ID,
-			Gender:       user.Gender,
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
-			AvatarURL:    user.AvatarURL,
-			IsMomoLinked: user.IsMomoLinked,
-			PhoneNumber:  user.PhoneNumber,
-			Email:        user.Email,
-			FullName:     user.FullName,
-			IsVerified:   user.IsVerified,
-			IsActivated:  user.IsActivated,
-			Role:         user.Role,
+			ID:              user.ID,
+			Gender:          user.Gender,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+			AvatarURL:       user.AvatarURL,
+			IsMomoLinked:    user.IsMomoLinked,
+			AverageRating:   user.AverageRating,
+			RatingCount:     user.RatingCount,
+			PhoneNumber:     user.PhoneNumber,
+			Email:           user.Email,
+			FullName:        user.FullName,
+			IsVerified:      user.IsVerified,
+			IsActivated:     user.IsActivated,
+			Role:            user.Role,
+			RidePreferences: helper.ToRidePreferencesDetail(user.RidePreferences),
 		},
 	}
 
@@ -255,6 +272,107 @@ func (ctrl *UserController) UpdateUserProfile(ctx *gin.Context) {
 	helper.GinResponse(ctx, 200, response)
 }
 
+// UpdateRidePreferences receives the default ride preferences of the user and saves them in the database
+// UpdateRidePreferences godoc
+// @Summary Update default ride preferences
+// @Description Update the preferences used for the new ride offers and ride requests of the authenticated user (unless a ride gives its own)
+// @Tags user
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body schemas.RidePreferences true "Default ride preferences"
+// @Success 200 {object} helper.Response{data=schemas.UpdateRidePreferencesResponse} "Successfully updated ride preferences"
+// @Failure 400 {object} helper.Response "Invalid input"
+// @Failure 500 {object} helper.Response "Internal server error"
+// @Router /user/update-ride-preferences [post]
+func (ctrl *UserController) UpdateRidePreferences(ctx *gin.Context) {
+	// Get payload from context
+	payload := ctx.MustGet((middleware.AuthorizationPayloadKey))
+
+	// Convert payload to map
+	data, err := helper.ConvertToPayload(payload)
+
+	// If error occurs, return error response
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to convert payload"),
+			"Failed to convert payload",
+			"Không thể chuyển đổi payload",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	// Bind request to schema
+	var req schemas.RidePreferences
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to bind request"),
+			"Failed to bind request",
+			"Không thể bind request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	// Validate request
+	if err := ctrl.validate.Struct(req); err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to validate request",
+			"Không thể validate request",
+		)
+		helper.GinResponse(ctx, 400, response)
+		return
+	}
+
+	// Update the default ride preferences
+	err = ctrl.UserService.UpdateRidePreferences(data.UserID, req)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			fmt.Errorf("failed to update ride preferences"),
+			"Failed to update ride preferences",
+			"Không thể cập nhật tùy chọn chuyến đi",
+		)
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	// Retrieve updated user information and return it
+	user, err := ctrl.UserService.GetUserByID(data.UserID)
+	if err != nil {
+		response := helper.ErrorResponseWithMessage(
+			err,
+			"Failed to get user information",
+			"Không thể lấy thông tin người dùng")
+		helper.GinResponse(ctx, 500, response)
+		return
+	}
+
+	res := schemas.UpdateRidePreferencesResponse{
+		User: schemas.UserResponse{
+			ID:              user.ID,
+			Gender:          user.Gender,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+			AvatarURL:       user.AvatarURL,
+			IsMomoLinked:    user.IsMomoLinked,
+			AverageRating:   user.AverageRating,
+			RatingCount:     user.RatingCount,
+			PhoneNumber:     user.PhoneNumber,
+			Email:           user.Email,
+			FullName:        user.FullName,
+			IsVerified:      user.IsVerified,
+			IsActivated:     user.IsActivated,
+			Role:            user.Role,
+			RidePreferences: helper.ToRidePreferencesDetail(user.RidePreferences),
+		},
+	}
+
+	response := helper.SuccessResponse(res, "Successfully updated ride preferences", "Cập nhật tùy chọn chuyến đi thành công")
+	helper.GinResponse(ctx, 200, response)
+}
+
 // UpdateAvatar receives avatar image and updates it in the database
 // UpdateAvatar godoc
 // @Summary Update user avatar
@@ -334,18 +452,21 @@ func (ctrl *UserController) UpdateAvatar(ctx *gin.Context) {
 
 	res := schemas.UpdateAvatarResponse{
 		User: schemas.UserResponse{
-			ID:           user.ID,
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
-			AvatarURL:    avatarURL,
-			PhoneNumber:  user.PhoneNumber,
-			Email:        user.Email,
-			IsMomoLinked: user.IsMomoLinked,
-			FullName:     user.FullName,
-			IsVerified:   user.IsVerified,
-			IsActivated:  user.IsActivated,
-			Role:         user.Role,
-			Gender:       user.Gender,
+			ID:              user.ID,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+			AvatarURL:       avatarURL,
+			PhoneNumber:     user.PhoneNumber,
+			Email:           user.Email,
+			IsMomoLinked:    user.IsMomoLinked,
+			AverageRating:   user.AverageRating,
+			RatingCount:     user.RatingCount,
+			FullName:        user.FullName,
+			IsVerified:      user.IsVerified,
+			IsActivated:     user.IsActivated,
+			Role:            user.Role,
+			RidePreferences: helper.ToRidePreferencesDetail(user.RidePreferences),
+			Gender:          user.Gender,
 		}}
 
 	response := helper.SuccessResponse(res, "Successfully updated avatar", "Cập nhật ảnh đại diện thành công")
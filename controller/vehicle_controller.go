@@ -179,7 +179,7 @@ func (ctrl *VehicleController) RegisterVehicle(ctx *gin.Context) {
 	}
 
 	// Register the vehicle
-	err = ctrl.service.RegisterVehicle(data.UserID, req.VehicleID, req.LicensePlate, req.CaVet)
+	err = ctrl.service.RegisterVehicle(data.UserID, req.VehicleID, req.LicensePlate, req.CaVet, req.SeatCapacity, req.FuelType)
 	if err != nil {
 		response := helper.ErrorResponseWithMessage(
 			err,
@@ -113,9 +113,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/delete-user": {
-            "post": {
-                "description": "Delete the user from the provided phone number in the database (only available in dev environment)",
+        "/admin/impact": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the kilometers shared, the fuel saved and the CO2 avoided by all the completed rides, per fuel type. The numbers are computed with the current emission factors",
                 "consumes": [
                     "application/json"
                 ],
@@ -123,35 +128,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Delete a user",
+                "summary": "Get the impact of the platform",
                 "parameters": [
                     {
-                        "description": "Delete user request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.DeleteUserRequest"
-                        }
+                        "type": "string",
+                        "description": "First day of the rides, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the rides, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Impact retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetPlatformImpactResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or input",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete user",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -159,9 +179,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/init-register": {
+        "/admin/report/close": {
             "post": {
-                "description": "Start the registration process by sending an OTP to the provided phone number",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close an open or reviewed report by dismissing it or by warning the reported user, the note on how it was handled is kept for the audit. Restricting or suspending the reported user is not supported from a report",
                 "consumes": [
                     "application/json"
                 ],
@@ -169,23 +194,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Initialize user registration",
+                "summary": "Close a user report",
                 "parameters": [
                     {
-                        "description": "Registration initialization request",
+                        "description": "Close user report request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.InitRegisterRequest"
+                            "$ref": "#/definitions/schemas.CloseUserReportRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP sent successfully",
+                        "description": "User report closed successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -195,7 +220,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.InitRegisterResponse"
+                                            "$ref": "#/definitions/schemas.AdminUserReportDetail"
                                         }
                                     }
                                 }
@@ -203,13 +228,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or input",
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User report not found",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "409": {
-                        "description": "User already exists",
+                        "description": "User report already closed",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -223,9 +254,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login-oauth": {
-            "post": {
-                "description": "Authenticates a user using OAuth2 and sends an OTP to their phone number",
+        "/admin/report/get-all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the latest reports against the users, filtered by status, category and reported user when set",
                 "consumes": [
                     "application/json"
                 ],
@@ -233,23 +269,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Login with OAuth2",
+                "summary": "Get the user reports",
                 "parameters": [
                     {
-                        "description": "OAuth2 login details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.LoginWithOAuthRequest"
-                        }
+                        "enum": [
+                            "open",
+                            "reviewing",
+                            "resolved",
+                            "dismissed"
+                        ],
+                        "type": "string",
+                        "description": "Status of the reports",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "harassment",
+                            "inappropriate_behavior",
+                            "unsafe_driving",
+                            "fraud",
+                            "no_show",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "Category of the reports",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reported user ID",
+                        "name": "reportedID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of reports, 50 by default",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP sent successfully",
+                        "description": "User reports retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -259,7 +324,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.LoginWithOAuthResponse"
+                                            "$ref": "#/definitions/schemas.GetUserReportsResponse"
                                         }
                                     }
                                 }
@@ -267,13 +332,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "User does not exist",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -287,9 +346,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login-phone": {
+        "/admin/report/review": {
             "post": {
-                "description": "Initiates login process by sending OTP to the provided phone number",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that the current admin is looking into an open report",
                 "consumes": [
                     "application/json"
                 ],
@@ -297,23 +361,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Login with phone number",
+                "summary": "Review a user report",
                 "parameters": [
                     {
-                        "description": "Phone number for login",
+                        "description": "User report request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.LoginRequest"
+                            "$ref": "#/definitions/schemas.UserReportRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP sent successfully",
+                        "description": "User report reviewed successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -323,7 +387,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.LoginResponse"
+                                            "$ref": "#/definitions/schemas.AdminUserReportDetail"
                                         }
                                     }
                                 }
@@ -331,13 +395,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "404": {
-                        "description": "User does not exist",
+                        "description": "User report not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "User report not open",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -351,14 +421,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
+        "/admin/ride/deviation-events": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logs out the user by revoking their refresh token from the database",
+                "description": "Get the latest times a driver left the planned route or stopped for long during a ride, to review them",
                 "consumes": [
                     "application/json"
                 ],
@@ -366,58 +436,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Logout user and revoke the token",
-                "responses": {
-                    "200": {
-                        "description": "Logout successful",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad request",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
+                "summary": "Get the deviation events of the rides",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ride ID, all the rides when empty",
+                        "name": "rideID",
+                        "in": "query"
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
+                    {
+                        "type": "boolean",
+                        "description": "Only the events not reviewed yet",
+                        "name": "unacknowledged",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/refresh-token": {
-            "post": {
-                "security": [
                     {
-                        "BearerAuth": []
+                        "type": "integer",
+                        "description": "Maximum number of events, 50 by default",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
-                "description": "Validates the refresh token and issues a new access token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "auth"
-                ],
-                "summary": "Refresh token and return new access token for the user",
                 "responses": {
                     "200": {
-                        "description": "Access token refreshed successfully",
+                        "description": "Deviation events retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -427,7 +471,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.RefreshTokenResponse"
+                                            "$ref": "#/definitions/schemas.GetDeviationEventsResponse"
                                         }
                                     }
                                 }
@@ -435,7 +479,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid refresh token or authorization header",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -449,9 +493,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
+        "/admin/ride/deviation-events/acknowledge": {
             "post": {
-                "description": "Starts the registration process and creates a user account",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a deviation event as reviewed, with an optional note kept for the audit",
                 "consumes": [
                     "application/json"
                 ],
@@ -459,23 +508,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Acknowledge a deviation event",
                 "parameters": [
                     {
-                        "description": "Registration request containing phone number, full name, and optional email",
+                        "description": "Acknowledge deviation event request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.RegisterUserRequest"
+                            "$ref": "#/definitions/schemas.AcknowledgeDeviationEventRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User created successfully",
+                        "description": "Deviation event acknowledged successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -485,7 +534,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.RegisterUserResponse"
+                                            "$ref": "#/definitions/schemas.DeviationEventDetail"
                                         }
                                     }
                                 }
@@ -493,13 +542,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or input",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
-                    },
-                    "409": {
-                        "description": "User already exists",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -513,9 +556,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/resend-otp": {
+        "/admin/ride/deviation-events/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Server-sent events of the route deviations and the long stops as they are detected. Each event is named deviation and its data is a schemas.DeviationEventDetail",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Stream the deviation events",
+                "responses": {
+                    "200": {
+                        "description": "Stream of deviation events",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DeviationEventDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/ride/geofence-override": {
             "post": {
-                "description": "Resends the OTP to the provided phone number for user verification",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Let the driver start or end a ride once without the location checks, the note is kept for the audit",
                 "consumes": [
                     "application/json"
                 ],
@@ -523,23 +596,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Resend OTP",
+                "summary": "Override the geofence of a ride",
                 "parameters": [
                     {
-                        "description": "OTP resend request containing phone number",
+                        "description": "Geofence override request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.ResendOTPRequest"
+                            "$ref": "#/definitions/schemas.CreateGeofenceOverrideRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP sent successfully",
+                        "description": "Geofence override created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -549,7 +622,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/schemas.GeofenceOverrideDetail"
                                         }
                                     }
                                 }
@@ -557,7 +630,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or input",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -571,70 +644,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/verify-cccd": {
-            "post": {
-                "description": "Verifies the front and back images of a user's CCCD, saves the information, and updates user status",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
+        "/admin/ride/trail": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the positions recorded during any ride as a GeoJSON line or a GPX track, to handle the disputes",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Verify user's CCCD (Citizen Identity Card)",
+                "summary": "Export the trail of a ride",
                 "parameters": [
-                    {
-                        "type": "file",
-                        "description": "Front image of CCCD",
-                        "name": "front_image",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Back image of CCCD",
-                        "name": "back_image",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "User ID (UUID format)",
-                        "name": "user_id",
-                        "in": "formData",
+                        "description": "Ride ID",
+                        "name": "rideID",
+                        "in": "query",
                         "required": true
                     },
                     {
+                        "enum": [
+                            "geojson",
+                            "gpx"
+                        ],
                         "type": "string",
-                        "description": "User's phone number (9-11 digits)",
-                        "name": "phone_number",
-                        "in": "formData",
-                        "required": true
+                        "description": "Format of the export",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "CCCD verified successfully",
+                        "description": "GeoJSON trail, or a GPX document when format is gpx",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schemas.VerifyCCCDResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/schemas.RideTrailFeatureCollection"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or CCCD info",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -648,9 +704,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/verify-login-otp": {
+        "/admin/sos/acknowledge": {
             "post": {
-                "description": "Verifies the OTP for login, creates a user session, and returns user info with access and refresh tokens",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that the current admin is handling an open SOS event",
                 "consumes": [
                     "application/json"
                 ],
@@ -658,23 +719,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Verify login OTP and create user session",
+                "summary": "Acknowledge an SOS event",
                 "parameters": [
                     {
-                        "description": "OTP verification details",
+                        "description": "SOS event request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.VerifyLoginOTPRequest"
+                            "$ref": "#/definitions/schemas.SOSEventRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP verified successfully",
+                        "description": "SOS event acknowledged successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -684,7 +745,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.VerifyLoginOTPResponse"
+                                            "$ref": "#/definitions/schemas.SOSEventDetail"
                                         }
                                     }
                                 }
@@ -692,13 +753,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or OTP verification failed",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to create session",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -706,9 +767,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/verify-register-otp": {
-            "post": {
-                "description": "Verifies the OTP sent during registration",
+        "/admin/sos/get-all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the latest SOS events, with the given status when set",
                 "consumes": [
                     "application/json"
                 ],
@@ -716,23 +782,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Verify registration OTP",
+                "summary": "Get the SOS events",
                 "parameters": [
                     {
-                        "description": "OTP verification request containing phone number and OTP",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.VerifyRegisterOTPRequest"
-                        }
+                        "enum": [
+                            "open",
+                            "acknowledged",
+                            "resolved"
+                        ],
+                        "type": "string",
+                        "description": "Status of the events",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events, 50 by default",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OTP verified successfully",
+                        "description": "SOS events retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -742,7 +816,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/schemas.GetSOSEventsResponse"
                                         }
                                     }
                                 }
@@ -750,7 +824,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or OTP verification failed",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -764,14 +838,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/get-chat-messages": {
+        "/admin/sos/resolve": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all messages of a chat room",
+                "description": "Close an open or acknowledged SOS event, the note on how it was handled is kept for the audit",
                 "consumes": [
                     "application/json"
                 ],
@@ -779,23 +853,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "admin"
                 ],
-                "summary": "Get all messages of a chat room",
+                "summary": "Resolve an SOS event",
                 "parameters": [
                     {
-                        "description": "Get chat messages request",
+                        "description": "Resolve SOS event request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetChatMessagesRequest"
+                            "$ref": "#/definitions/schemas.ResolveSOSEventRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Chat messages fetched successfully",
+                        "description": "SOS event resolved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -805,7 +879,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.GetChatMessagesResponse"
+                                            "$ref": "#/definitions/schemas.SOSEventDetail"
                                         }
                                     }
                                 }
@@ -827,14 +901,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/get-chat-rooms": {
-            "post": {
+        "/admin/sos/stream": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all chat rooms of a user",
+                "description": "Server-sent events of the SOS events as they are raised, acknowledged and resolved. Each event is named sos and its data is a schemas.SOSEventDetail",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Stream the SOS events",
+                "responses": {
+                    "200": {
+                        "description": "Stream of SOS events",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SOSEventDetail"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/delete-user": {
+            "post": {
+                "description": "Delete the user from the provided phone number in the database (only available in dev environment)",
                 "consumes": [
                     "application/json"
                 ],
@@ -842,47 +936,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "auth"
                 ],
-                "summary": "Get all chat rooms of a user",
+                "summary": "Delete a user",
                 "parameters": [
                     {
-                        "description": "Get all chat rooms request",
+                        "description": "Delete user request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetAllChatRoomsRequest"
+                            "$ref": "#/definitions/schemas.DeleteUserRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Chat rooms fetched successfully",
+                        "description": "User deleted successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schemas.GetAllChatRoomsResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body or input",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to delete user",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -890,14 +972,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/initiate-call": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Initiate a call with a user (from user to user 1:1) using Agora RTC",
+        "/auth/init-register": {
+            "post": {
+                "description": "Start the registration process by sending an OTP to the provided phone number",
                 "consumes": [
                     "application/json"
                 ],
@@ -905,28 +982,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "auth"
                 ],
-                "summary": "Initiate a call with a user (from user to user 1:1) using Agora RTC",
+                "summary": "Initialize user registration",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Chat room ID",
-                        "name": "chatRoomID",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Receiver ID",
-                        "name": "receiverID",
-                        "in": "query",
-                        "required": true
+                        "description": "Registration initialization request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.InitRegisterRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Call initiated successfully",
+                        "description": "OTP sent successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -936,7 +1008,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.InitiateCallResponse"
+                                            "$ref": "#/definitions/schemas.InitRegisterResponse"
                                         }
                                     }
                                 }
@@ -944,7 +1016,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body or input",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -958,50 +1036,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/send-image": {
+        "/auth/login-oauth": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Send an image to the chat room",
+                "description": "Authenticates a user using OAuth2 and sends an OTP to their phone number",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "auth"
                 ],
-                "summary": "Send an image to the chat room",
+                "summary": "Login with OAuth2",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Chat room ID",
-                        "name": "chatRoomID",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Receiver ID",
-                        "name": "receiverID",
-                        "in": "formData",
-                        "required": true
+                        "description": "OAuth2 login details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.LoginWithOAuthRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Image sent successfully",
+                        "description": "OTP sent successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1011,7 +1072,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.SendImageResponse"
+                                            "$ref": "#/definitions/schemas.LoginWithOAuthResponse"
                                         }
                                     }
                                 }
@@ -1019,7 +1080,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1033,14 +1100,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/send-message": {
+        "/auth/login-phone": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Send a message to the chat room",
+                "description": "Initiates login process by sending OTP to the provided phone number",
                 "consumes": [
                     "application/json"
                 ],
@@ -1048,23 +1110,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
+                    "auth"
                 ],
-                "summary": "Send a message to the chat room",
+                "summary": "Login with phone number",
                 "parameters": [
                     {
-                        "description": "Send message request",
+                        "description": "Phone number for login",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.SendMessageRequest"
+                            "$ref": "#/definitions/schemas.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Message sent successfully",
+                        "description": "OTP sent successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1074,7 +1136,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.SendMessageResponse"
+                                            "$ref": "#/definitions/schemas.LoginResponse"
                                         }
                                     }
                                 }
@@ -1082,7 +1144,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User does not exist",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1096,14 +1164,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chat/update-call-status": {
+        "/auth/logout": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the call status of a chat room (missed, rejected, ended)",
+                "description": "Logs out the user by revoking their refresh token from the database",
                 "consumes": [
                     "application/json"
                 ],
@@ -1111,84 +1179,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chat"
-                ],
-                "summary": "Update the call status of a chat room (missed, rejected, ended)",
-                "parameters": [
-                    {
-                        "description": "Update call status request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.UpdateCallStatusRequest"
-                        }
-                    }
+                    "auth"
                 ],
+                "summary": "Logout user and revoke the token",
                 "responses": {
                     "200": {
-                        "description": "Call status updated successfully",
+                        "description": "Logout successful",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schemas.UpdateCallStatusResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Bad request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/ipn/handle-ipn": {
-            "post": {
-                "description": "Handle IPN from payment gateway",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "ipn"
-                ],
-                "summary": "Handle IPN from payment gateway",
-                "parameters": [
-                    {
-                        "description": "MoMo IPN",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.MoMoIPN"
-                        }
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No content",
-                        "schema": {
-                            "type": "string"
-                        }
                     },
                     "500": {
                         "description": "Internal server error",
@@ -1199,14 +1210,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/autocomplete": {
-            "get": {
+        "/auth/refresh-token": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of places that match the query string",
+                "description": "Validates the refresh token and issues a new access token",
                 "consumes": [
                     "application/json"
                 ],
@@ -1214,51 +1225,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "map"
-                ],
-                "summary": "Get autocomplete suggestions for places",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Input string to search for",
-                        "name": "input",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit the number of results",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Location coordinates (lat,lng)",
-                        "name": "location",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Search radius in meters",
-                        "name": "radius",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Include more compound results",
-                        "name": "more_compound",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Current location coordinates (lat,lng)",
-                        "name": "current_location",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "Refresh token and return new access token for the user",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved autocomplete data",
+                        "description": "Access token refreshed successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1268,7 +1240,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.GoongAutoCompleteResponse"
+                                            "$ref": "#/definitions/schemas.RefreshTokenResponse"
                                         }
                                     }
                                 }
@@ -1276,13 +1248,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request query",
+                        "description": "Invalid refresh token or authorization header",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to get autocomplete data",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1290,14 +1262,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/geocode": {
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieves geocode information for a specified latitude and longitude",
+                "description": "Starts the registration process and creates a user account",
                 "consumes": [
                     "application/json"
                 ],
@@ -1305,23 +1272,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "auth"
                 ],
-                "summary": "Get geocode data for a given point",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "description": "Geocode request parameters",
+                        "description": "Registration request containing phone number, full name, and optional email",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.GeoCodeRequest"
+                            "$ref": "#/definitions/schemas.RegisterUserRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved geocode data",
+                        "description": "User created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1331,7 +1298,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.GeoCodeLocationResponse"
+                                            "$ref": "#/definitions/schemas.RegisterUserResponse"
                                         }
                                     }
                                 }
@@ -1339,13 +1306,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or input",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to get geocode data",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1353,14 +1326,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/give-ride": {
+        "/auth/resend-otp": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Receives a list of points and returns a route and polyline encoded string for the driver",
+                "description": "Resends the OTP to the provided phone number for user verification",
                 "consumes": [
                     "application/json"
                 ],
@@ -1368,23 +1336,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "auth"
                 ],
-                "summary": "Create a route for a driver's give ride",
+                "summary": "Resend OTP",
                 "parameters": [
                     {
-                        "description": "Give ride request details",
+                        "description": "OTP resend request containing phone number",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.GiveRideRequest"
+                            "$ref": "#/definitions/schemas.ResendOTPRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully created route",
+                        "description": "OTP sent successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1394,7 +1362,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.GiveRideResponse"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -1402,13 +1370,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or input",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to create route",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1416,62 +1384,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/hitch-ride": {
+        "/auth/verify-cccd": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Receives a list of points and returns a route and polyline encoded string for the passenger",
+                "description": "Verifies the front and back images of a user's CCCD, saves the information, and updates user status",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "auth"
                 ],
-                "summary": "Create a route for a passenger's hitch ride",
+                "summary": "Verify user's CCCD (Citizen Identity Card)",
                 "parameters": [
                     {
-                        "description": "Hitch ride request details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.HitchRideRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Successfully created route",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schemas.HitchRideResponse"
-                                        }
-                                    }
-                                }
+                        "type": "file",
+                        "description": "Front image of CCCD",
+                        "name": "front_image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Back image of CCCD",
+                        "name": "back_image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID format)",
+                        "name": "user_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User's phone number (9-11 digits)",
+                        "name": "phone_number",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CCCD verified successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.VerifyCCCDResponse"
+                                        }
+                                    }
+                                }
                             ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request or CCCD info",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Failed to create route",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1479,14 +1461,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/suggest-give-rides": {
+        "/auth/verify-login-otp": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns a list of ride offers that match the business rules for the hitcher (ride request)",
+                "description": "Verifies the OTP for login, creates a user session, and returns user info with access and refresh tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -1494,23 +1471,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "auth"
                 ],
-                "summary": "Suggest ride offers for a hitcher",
+                "summary": "Verify login OTP and create user session",
                 "parameters": [
                     {
-                        "description": "Ride request details",
+                        "description": "OTP verification details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.SuggestRideOfferRequest"
+                            "$ref": "#/definitions/schemas.VerifyLoginOTPRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved suggested ride offers",
+                        "description": "OTP verified successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1520,7 +1497,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.SuggestRideOfferResponse"
+                                            "$ref": "#/definitions/schemas.VerifyLoginOTPResponse"
                                         }
                                     }
                                 }
@@ -1528,13 +1505,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or OTP verification failed",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to create session",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1542,14 +1519,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/map/suggest-hitch-rides": {
+        "/auth/verify-register-otp": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns a list of ride requests that match the business rules for the rider (ride offer)",
+                "description": "Verifies the OTP sent during registration",
                 "consumes": [
                     "application/json"
                 ],
@@ -1557,23 +1529,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "map"
+                    "auth"
                 ],
-                "summary": "Suggest ride requests for a rider (ride offer)",
+                "summary": "Verify registration OTP",
                 "parameters": [
                     {
-                        "description": "Ride offer ID",
+                        "description": "OTP verification request containing phone number and OTP",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.SuggestRideRequestRequest"
+                            "$ref": "#/definitions/schemas.VerifyRegisterOTPRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved suggested ride requests",
+                        "description": "OTP verified successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1583,7 +1555,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.SuggestRideRequestResponse"
+                                            "type": "object"
                                         }
                                     }
                                 }
@@ -1591,7 +1563,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid request body or OTP verification failed",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1605,14 +1577,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notification/create-notification": {
+        "/chat/get-chat-messages": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new notification to be sent to the user device using the FCM service",
+                "description": "Get all messages of a chat room",
                 "consumes": [
                     "application/json"
                 ],
@@ -1620,25 +1592,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "chat"
                 ],
-                "summary": "Create a new notification",
+                "summary": "Get all messages of a chat room",
                 "parameters": [
                     {
-                        "description": "Create notification request",
+                        "description": "Get chat messages request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.CreateNotificationRequest"
+                            "$ref": "#/definitions/schemas.GetChatMessagesRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully created notification",
+                        "description": "Chat messages fetched successfully",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetChatMessagesResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1656,14 +1640,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notification/create-test-websocket": {
+        "/chat/get-chat-rooms": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a test websocket for testing connection",
+                "description": "Get all chat rooms of a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -1671,25 +1655,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "chat"
                 ],
-                "summary": "Create a test websocket",
+                "summary": "Get all chat rooms of a user",
                 "parameters": [
                     {
-                        "description": "Create test websocket request",
+                        "description": "Get all chat rooms request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.CreateTestWebsocketRequest"
+                            "$ref": "#/definitions/schemas.GetAllChatRoomsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully created test websocket",
+                        "description": "Chat rooms fetched successfully",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetAllChatRoomsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -1707,14 +1703,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/checkout-ride": {
-            "post": {
+        "/chat/initiate-call": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Checkout ride with momo",
+                "description": "Initiate a call with a user (from user to user 1:1) using Agora RTC",
                 "consumes": [
                     "application/json"
                 ],
@@ -1722,23 +1718,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payment"
+                    "chat"
                 ],
-                "summary": "Checkout ride with momo",
+                "summary": "Initiate a call with a user (from user to user 1:1) using Agora RTC",
                 "parameters": [
                     {
-                        "description": "Checkout ride request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.CheckoutRideRequest"
-                        }
+                        "type": "string",
+                        "description": "Chat room ID",
+                        "name": "chatRoomID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Receiver ID",
+                        "name": "receiverID",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Link wallet response",
+                        "description": "Call initiated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1748,7 +1749,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/schemas.InitiateCallResponse"
                                         }
                                     }
                                 }
@@ -1756,7 +1757,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "One of the users blocked the other",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1770,44 +1777,74 @@ const docTemplate = `{
                 }
             }
         },
-        "/payment/link-momo-wallet": {
+        "/chat/send-image": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Link momo wallet to user account",
+                "description": "Send an image to the chat room",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payment"
+                    "chat"
                 ],
-                "summary": "Link momo wallet to user account",
+                "summary": "Send an image to the chat room",
                 "parameters": [
                     {
-                        "description": "Link momo wallet request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.LinkMomoRequest"
-                        }
+                        "type": "string",
+                        "description": "Chat room ID",
+                        "name": "chatRoomID",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Receiver ID",
+                        "name": "receiverID",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Link momo wallet response",
+                        "description": "Image sent successfully",
                         "schema": {
-                            "$ref": "#/definitions/schemas.LinkMomoWalletResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.SendImageResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "One of the users blocked the other",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -1821,14 +1858,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/protected/test": {
-            "get": {
+        "/chat/send-message": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "This endpoint tests the authentication middleware",
+                "description": "Send a message to the chat room",
                 "consumes": [
                     "application/json"
                 ],
@@ -1836,37 +1873,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Protected"
+                    "chat"
+                ],
+                "summary": "Send a message to the chat room",
+                "parameters": [
+                    {
+                        "description": "Send message request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SendMessageRequest"
+                        }
+                    }
                 ],
-                "summary": "Test protected endpoint",
                 "responses": {
                     "200": {
-                        "description": "Successfully authenticated",
+                        "description": "Message sent successfully",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.SendMessageResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "One of the users blocked the other",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
                         }
                     }
                 }
             }
         },
-        "/ride/accept-give-ride-request": {
+        "/chat/update-call-status": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Accept a ride offer request from the driver",
+                "description": "Update the call status of a chat room (missed, rejected, ended)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1874,23 +1942,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "chat"
                 ],
-                "summary": "Accept a ride offer request from the driver",
+                "summary": "Update the call status of a chat room (missed, rejected, ended)",
                 "parameters": [
                     {
-                        "description": "Accept give ride request details",
+                        "description": "Update call status request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.AcceptGiveRideRequestRequest"
+                            "$ref": "#/definitions/schemas.UpdateCallStatusRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully accepted ride offer request",
+                        "description": "Call status updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1900,7 +1968,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.AcceptGiveRideRequestResponse"
+                                            "$ref": "#/definitions/schemas.UpdateCallStatusResponse"
                                         }
                                     }
                                 }
@@ -1922,14 +1990,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/accept-hitch-ride-request": {
+        "/favorite-location/create": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Accept a ride request from the hitcher",
+                "description": "Save a place as the home, the work or a custom favorite location of the user, it is added at the end of the list and can be used as favorite:\u003cfavorite_location_id\u003e in the place list of a ride",
                 "consumes": [
                     "application/json"
                 ],
@@ -1937,23 +2005,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "favorite-location"
                 ],
-                "summary": "Accept a ride request from the hitcher",
+                "summary": "Create a favorite location",
                 "parameters": [
                     {
-                        "description": "Accept hitch ride request details",
+                        "description": "Favorite location request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.AcceptHitchRideRequestRequest"
+                            "$ref": "#/definitions/schemas.CreateFavoriteLocationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully accepted ride request",
+                        "description": "Favorite location created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1963,7 +2031,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.AcceptHitchRideRequestResponse"
+                                            "$ref": "#/definitions/schemas.FavoriteLocationResponse"
                                         }
                                     }
                                 }
@@ -1976,6 +2044,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
+                    "409": {
+                        "description": "Home or work already saved, or too many favorite locations",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1985,14 +2059,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/cancel-give-ride-request": {
+        "/favorite-location/delete": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancel a ride offer request from the driver",
+                "description": "Delete a favorite location of the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -2000,23 +2074,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "favorite-location"
                 ],
-                "summary": "Cancel a ride offer request from the driver",
+                "summary": "Delete a favorite location",
                 "parameters": [
                     {
-                        "description": "Cancel give ride request details",
+                        "description": "Delete favorite location request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.CancelGiveRideRequestRequest"
+                            "$ref": "#/definitions/schemas.DeleteFavoriteLocationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully canceled ride offer request",
+                        "description": "Favorite location deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2027,6 +2101,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
+                    "404": {
+                        "description": "Favorite location not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2036,14 +2116,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/cancel-hitch-ride-request": {
-            "post": {
+        "/favorite-location/get-all": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancel a ride request from the hitcher",
+                "description": "Get all favorite locations of the current user in the order the user chose",
                 "consumes": [
                     "application/json"
                 ],
@@ -2051,31 +2131,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
-                ],
-                "summary": "Cancel a ride request from the hitcher",
-                "parameters": [
-                    {
-                        "description": "Cancel hitch ride request details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.CancelHitchRideRequestRequest"
-                        }
-                    }
+                    "favorite-location"
                 ],
+                "summary": "Get favorite locations",
                 "responses": {
                     "200": {
-                        "description": "Successfully canceled ride request",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "Favorite locations retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetFavoriteLocationsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
@@ -2087,14 +2162,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/cancel-ride": {
+        "/favorite-location/reorder": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancels the ride",
+                "description": "Save a new order of the favorite locations, the list must contain every favorite location of the user exactly once",
                 "consumes": [
                     "application/json"
                 ],
@@ -2102,23 +2177,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "favorite-location"
                 ],
-                "summary": "Cancel a ride",
+                "summary": "Reorder favorite locations",
                 "parameters": [
                     {
-                        "description": "Cancel ride request",
+                        "description": "Reorder favorite locations request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.CancelRideRequest"
+                            "$ref": "#/definitions/schemas.ReorderFavoriteLocationsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully canceled ride by driver",
+                        "description": "Favorite locations reordered successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2128,7 +2203,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.CancelRideResponse"
+                                            "$ref": "#/definitions/schemas.GetFavoriteLocationsResponse"
                                         }
                                     }
                                 }
@@ -2150,14 +2225,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/end-ride": {
+        "/favorite-location/update": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Ends the ride between the driver and the hitcher",
+                "description": "Rename a favorite location or move it to another place, the place ID and the address are changed together",
                 "consumes": [
                     "application/json"
                 ],
@@ -2165,23 +2240,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "favorite-location"
                 ],
-                "summary": "End a ride",
+                "summary": "Update a favorite location",
                 "parameters": [
                     {
-                        "description": "End ride request",
+                        "description": "Update favorite location request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.EndRideRequest"
+                            "$ref": "#/definitions/schemas.UpdateFavoriteLocationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully ended ride",
+                        "description": "Favorite location updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -2191,7 +2266,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.EndRideResponse"
+                                            "$ref": "#/definitions/schemas.FavoriteLocationResponse"
                                         }
                                     }
                                 }
@@ -2204,6 +2279,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
+                    "404": {
+                        "description": "Favorite location not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2213,14 +2294,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/get-all-pending-ride": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all ride request and ride offer that are not cancelled of the user",
+        "/ipn/handle-ipn": {
+            "post": {
+                "description": "Handle IPN from payment gateway",
                 "consumes": [
                     "application/json"
                 ],
@@ -2228,14 +2304,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "ipn"
+                ],
+                "summary": "Handle IPN from payment gateway",
+                "parameters": [
+                    {
+                        "description": "MoMo IPN",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.MoMoIPN"
+                        }
+                    }
                 ],
-                "summary": "Get all pending ride",
                 "responses": {
-                    "200": {
-                        "description": "Successfully got all pending ride",
+                    "204": {
+                        "description": "No content",
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetAllPendingRideResponse"
+                            "type": "string"
                         }
                     },
                     "500": {
@@ -2247,14 +2334,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/give-ride-request": {
-            "post": {
+        "/map/autocomplete": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send a ride offer request from the driver to the hitcher",
+                "description": "Returns a list of places that match the query string, the matching favorite locations and recent destinations of the user come before the Goong predictions",
                 "consumes": [
                     "application/json"
                 ],
@@ -2262,35 +2349,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "map"
                 ],
-                "summary": "Send a ride offer request from the driver to the hitcher",
+                "summary": "Get autocomplete suggestions for places",
                 "parameters": [
                     {
-                        "description": "Give ride request details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/schemas.SendGiveRideRequestRequest"
-                        }
+                        "type": "string",
+                        "description": "Input string to search for",
+                        "name": "input",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of results",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Location coordinates (lat,lng)",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Search radius in meters",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include more compound results",
+                        "name": "more_compound",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Current location coordinates (lat,lng)",
+                        "name": "current_location",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully sent ride offer request",
+                        "description": "Successfully retrieved autocomplete data",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GoongAutoCompleteResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request query",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to get autocomplete data",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2298,14 +2425,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/hitch-ride-request": {
+        "/map/browse-give-rides": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send a ride request from the hitcher to the driver",
+                "description": "Returns the open ride offers whose route passes near the origin then the destination within the departure window, sorted and paginated with a cursor. Nothing is created, so users can look at the offers before requesting a ride",
                 "consumes": [
                     "application/json"
                 ],
@@ -2313,29 +2440,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "map"
                 ],
-                "summary": "Send a ride request from the hitcher to the driver",
+                "summary": "Browse open ride offers",
                 "parameters": [
                     {
-                        "description": "Hitch ride request details",
+                        "description": "Search details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.SendHitchRideRequestRequest"
+                            "$ref": "#/definitions/schemas.BrowseRideOffersRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully sent ride request",
+                        "description": "Successfully retrieved ride offers",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.BrowseRideOffersResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2349,14 +2488,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/start-ride": {
+        "/map/fare-quote": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Starts the ride between the driver and the hitcher",
+                "description": "Returns the fare of a route with its breakdown (strategy, inputs, commission) before any ride offer or request is created",
                 "consumes": [
                     "application/json"
                 ],
@@ -2364,23 +2503,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "map"
                 ],
-                "summary": "Start a ride",
+                "summary": "Get the fare of a route",
                 "parameters": [
                     {
-                        "description": "Start ride request",
+                        "description": "Fare quote request details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.StartRideRequest"
+                            "$ref": "#/definitions/schemas.FareQuoteRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully started ride",
+                        "description": "Successfully computed fare",
                         "schema": {
                             "allOf": [
                                 {
@@ -2390,7 +2529,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.StartRideResponse"
+                                            "$ref": "#/definitions/schemas.FareQuote"
                                         }
                                     }
                                 }
@@ -2398,13 +2537,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Favorite location not found",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to compute fare",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2412,14 +2557,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ride/update-ride-location": {
+        "/map/geocode": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the current location of the driver during the ride",
+                "description": "Retrieves geocode information for a specified latitude and longitude",
                 "consumes": [
                     "application/json"
                 ],
@@ -2427,23 +2572,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ride"
+                    "map"
                 ],
-                "summary": "Update the current location of the driver during the ride",
+                "summary": "Get geocode data for a given point",
                 "parameters": [
                     {
-                        "description": "Update ride location request",
+                        "description": "Geocode request parameters",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.UpdateRideLocationRequest"
+                            "$ref": "#/definitions/schemas.GeoCodeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated ride location",
+                        "description": "Successfully retrieved geocode data",
                         "schema": {
                             "allOf": [
                                 {
@@ -2453,7 +2598,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.UpdateRideLocationResponse"
+                                            "$ref": "#/definitions/schemas.GeoCodeLocationResponse"
                                         }
                                     }
                                 }
@@ -2461,13 +2606,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to get geocode data",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2475,14 +2620,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/get-profile": {
-            "get": {
+        "/map/give-ride": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the profile information of the authenticated user",
+                "description": "Receives a list of points and returns a route and polyline encoded string for the driver",
                 "consumes": [
                     "application/json"
                 ],
@@ -2490,18 +2635,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "map"
+                ],
+                "summary": "Create a route for a driver's give ride",
+                "parameters": [
+                    {
+                        "description": "Give ride request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GiveRideRequest"
+                        }
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Successfully created route",
                         "schema": {
-                            "$ref": "#/definitions/schemas.GetUserProfileResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GiveRideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Favorite location not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to create route",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2509,14 +2689,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/register-device-token": {
+        "/map/hitch-ride": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Registers the device token for the authenticated user to enable push notifications via Firebase Cloud Messaging",
+                "description": "Receives a list of points and returns a route and polyline encoded string for the passenger",
                 "consumes": [
                     "application/json"
                 ],
@@ -2524,35 +2704,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "map"
                 ],
-                "summary": "Register device token for push notifications",
+                "summary": "Create a route for a passenger's hitch ride",
                 "parameters": [
                     {
-                        "description": "Device token registration request",
+                        "description": "Hitch ride request details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.RegisterDeviceTokenRequest"
+                            "$ref": "#/definitions/schemas.HitchRideRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully registered device token",
+                        "description": "Successfully created route",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.HitchRideResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request or validation error",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Favorite location not found",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to create route",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2560,14 +2758,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/update-avatar": {
+        "/map/suggest-give-rides": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the avatar image of the authenticated user",
+                "description": "Returns a list of ride offers that match the business rules for the hitcher (ride request), ranked by match score with a per-factor breakdown",
                 "consumes": [
                     "application/json"
                 ],
@@ -2575,21 +2773,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "map"
                 ],
-                "summary": "Update user avatar",
+                "summary": "Suggest ride offers for a hitcher",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Avatar image file",
-                        "name": "avatar_image",
-                        "in": "formData",
-                        "required": true
+                        "description": "Ride request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SuggestRideOfferRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated avatar",
+                        "description": "Successfully retrieved suggested ride offers",
                         "schema": {
                             "allOf": [
                                 {
@@ -2599,7 +2799,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.UpdateAvatarResponse"
+                                            "$ref": "#/definitions/schemas.SuggestRideOfferResponse"
                                         }
                                     }
                                 }
@@ -2607,7 +2807,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2621,14 +2821,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/update-profile": {
+        "/map/suggest-hitch-rides": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the profile information of the authenticated user",
+                "description": "Returns a list of ride requests that match the business rules for the rider (ride offer), ranked by match score with a per-factor breakdown",
                 "consumes": [
                     "application/json"
                 ],
@@ -2636,23 +2836,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "map"
                 ],
-                "summary": "Update user profile",
+                "summary": "Suggest ride requests for a rider (ride offer)",
                 "parameters": [
                     {
-                        "description": "User profile update information",
+                        "description": "Ride offer ID",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.UpdateUserProfileRequest"
+                            "$ref": "#/definitions/schemas.SuggestRideRequestRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated user profile",
+                        "description": "Successfully retrieved suggested ride requests",
                         "schema": {
                             "allOf": [
                                 {
@@ -2662,7 +2862,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.UpdateUserProfileResponse"
+                                            "$ref": "#/definitions/schemas.SuggestRideRequestResponse"
                                         }
                                     }
                                 }
@@ -2670,7 +2870,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid request body",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2684,14 +2884,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/get-vehicle": {
-            "get": {
+        "/notification/create-notification": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves and returns a detailed list of vehicles for the authenticated user",
+                "description": "Create a new notification to be sent to the user device using the FCM service",
                 "consumes": [
                     "application/json"
                 ],
@@ -2699,26 +2899,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vehicle"
+                    "notification"
+                ],
+                "summary": "Create a new notification",
+                "parameters": [
+                    {
+                        "description": "Create notification request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateNotificationRequest"
+                        }
+                    }
                 ],
-                "summary": "Get user's vehicles",
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved vehicles",
+                        "description": "Successfully created notification",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/schemas.GetVehicleResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "500": {
@@ -2730,14 +2935,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/register-vehicle": {
+        "/notification/create-test-websocket": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Register a new vehicle for the authenticated user",
+                "description": "Create a test websocket for testing connection",
                 "consumes": [
                     "application/json"
                 ],
@@ -2745,29 +2950,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vehicle"
+                    "notification"
                 ],
-                "summary": "Register a new vehicle",
+                "summary": "Create a test websocket",
                 "parameters": [
                     {
-                        "description": "Vehicle registration details",
+                        "description": "Create test websocket request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/schemas.RegisterVehicleRequest"
+                            "$ref": "#/definitions/schemas.CreateTestWebsocketRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully registered vehicle",
+                        "description": "Successfully created test websocket",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
@@ -2781,14 +2986,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/vehicle/vehicles": {
-            "get": {
+        "/payment/checkout-ride": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves and returns the list of vehicles for user to select when registering a vehicle",
+                "description": "Checkout ride with momo",
                 "consumes": [
                     "application/json"
                 ],
@@ -2796,34 +3001,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "vehicle"
+                    "payment"
                 ],
-                "summary": "Get list of vehicles",
+                "summary": "Checkout ride with momo",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Search input for vehicle name",
-                        "name": "input",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Limit the number of results",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number for pagination purposes",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
+                        "description": "Checkout ride request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CheckoutRideRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved vehicles",
+                        "description": "Link wallet response",
                         "schema": {
                             "allOf": [
                                 {
@@ -2833,1627 +3027,6593 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/schemas.GetVehiclesResponse"
+                                            "type": "object"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to get vehicles",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "helper.Response": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "error": {
-                    "type": "string"
-                },
-                "message_en": {
-                    "type": "string"
-                },
-                "message_vi": {
-                    "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
-                }
-            }
         },
-        "schemas.AcceptGiveRideRequestRequest": {
-            "type": "object",
-            "required": [
-                "paymentMethod",
-                "receiverID",
-                "rideOfferID",
-                "rideRequestID",
-                "vehicleID"
-            ],
-            "properties": {
-                "paymentMethod": {
-                    "description": "Payment method (cash or momo)",
-                    "type": "string",
-                    "enum": [
-                        "cash",
-                        "momo"
-                    ]
-                },
-                "receiverID": {
-                    "description": "The ID of the receiver (the user who received the request) aka the hitcher",
-                    "type": "string"
-                },
-                "rideOfferID": {
-                    "description": "The ID of the ride offer (current user is the driver)",
-                    "type": "string"
-                },
-                "rideRequestID": {
-                    "description": "The ID of the ride request (the user received request is the hitcher)",
-                    "type": "string"
-                },
-                "vehicleID": {
-                    "description": "The ID of the vehicle",
-                    "type": "string"
+        "/payment/link-momo-wallet": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Link momo wallet to user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Link momo wallet to user account",
+                "parameters": [
+                    {
+                        "description": "Link momo wallet request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.LinkMomoRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Link momo wallet response",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.LinkMomoWalletResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
                 }
             }
         },
-        "schemas.AcceptGiveRideRequestResponse": {
-            "type": "object",
-            "properties": {
-                "distance": {
-                    "type": "number"
-                },
-                "driver_current_latitude": {
-                    "type": "number"
-                },
-                "driver_current_longitude": {
-                    "type": "number"
-                },
-                "duration": {
-                    "type": "integer"
-                },
-                "encoded_polyline": {
-                    "type": "string"
-                },
-                "end_address": {
-                    "type": "string"
-                },
-                "end_latitude": {
-                    "type": "number"
-                },
-                "end_longitude": {
-                    "type": "number"
-                },
-                "end_time": {
-                    "type": "string"
-                },
-                "fare": {
-                    "type": "number"
-                },
-                "receiver_id": {
-                    "type": "string"
-                },
-                "ride_id": {
-                    "type": "string"
-                },
-                "ride_offer_id": {
-                    "type": "string"
-                },
-                "ride_request_id": {
-                    "type": "string"
-                },
-                "rider_current_latitude": {
-                    "type": "number"
-                },
-                "rider_current_longitude": {
-                    "type": "number"
-                },
-                "start_address": {
-                    "type": "string"
-                },
-                "start_latitude": {
-                    "type": "number"
-                },
-                "start_longitude": {
-                    "type": "number"
-                },
-                "start_time": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "transaction": {
-                    "$ref": "#/definitions/schemas.TransactionDetail"
-                },
-                "user": {
-                    "$ref": "#/definitions/schemas.UserInfo"
-                },
-                "vehicle": {
-                    "$ref": "#/definitions/schemas.VehicleDetail"
-                },
-                "waypoints": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.Waypoint"
+        "/protected/test": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "This endpoint tests the authentication middleware",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Protected"
+                ],
+                "summary": "Test protected endpoint",
+                "responses": {
+                    "200": {
+                        "description": "Successfully authenticated",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "schemas.AcceptHitchRideRequestRequest": {
-            "type": "object",
-            "required": [
-                "paymentMethod",
-                "receiverID",
-                "rideOfferID",
-                "rideRequestID",
-                "vehicleID"
-            ],
-            "properties": {
-                "paymentMethod": {
+        "/rating/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rate the passenger as the driver or the driver as the passenger of a completed ride, once per ride and within the rating window after the end of the ride",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rating"
+                ],
+                "summary": "Rate the counterpart of a ride",
+                "parameters": [
+                    {
+                        "description": "Rating request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateRatingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rating created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.CreateRatingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Ride not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Ride not completed, already rated or rating window closed",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/rating/received": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the ratings received by a user as a driver and as a passenger, newest first, with the average rating and the number of ratings",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rating"
+                ],
+                "summary": "Get the ratings received by a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, the caller by default",
+                        "name": "userID",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number starting at 1 (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of ratings per page (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ratings retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetRatingsReceivedResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-ride/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a commute template, the ride offers of the series are published automatically ahead of time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-ride"
+                ],
+                "summary": "Create a recurring ride offer",
+                "parameters": [
+                    {
+                        "description": "Recurring ride offer request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateRecurringRideOfferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recurring ride offer created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.RecurringRideOfferDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-ride/get-all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all recurring ride offers of the current driver",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-ride"
+                ],
+                "summary": "Get recurring ride offers",
+                "responses": {
+                    "200": {
+                        "description": "Recurring ride offers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetRecurringRideOffersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-ride/pause": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pausing withdraws the future occurrences without bookings, resuming publishes them again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-ride"
+                ],
+                "summary": "Pause or resume a recurring ride offer",
+                "parameters": [
+                    {
+                        "description": "Pause recurring ride offer request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.PauseRecurringRideOfferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recurring ride offer updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-ride/skip-occurrence": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel the ride offer of the series on the given day, occurrences that already have bookings must be cancelled ride by ride",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-ride"
+                ],
+                "summary": "Cancel a single occurrence of a recurring ride offer",
+                "parameters": [
+                    {
+                        "description": "Skip occurrence request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SkipRecurringRideOccurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Occurrence cancelled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-ride/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edit the series, future occurrences without bookings are republished with the new settings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurring-ride"
+                ],
+                "summary": "Update a recurring ride offer",
+                "parameters": [
+                    {
+                        "description": "Update recurring ride offer request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateRecurringRideOfferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recurring ride offer updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.RecurringRideOfferDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/accept-give-ride-request": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept a ride offer request from the driver",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Accept a ride offer request from the driver",
+                "parameters": [
+                    {
+                        "description": "Accept give ride request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.AcceptGiveRideRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully accepted ride offer request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.AcceptGiveRideRequestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "One of the users blocked the other",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/accept-hitch-ride-request": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Accept a ride request from the hitcher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Accept a ride request from the hitcher",
+                "parameters": [
+                    {
+                        "description": "Accept hitch ride request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.AcceptHitchRideRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully accepted ride request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.AcceptHitchRideRequestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "One of the users blocked the other",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/cancel-give-ride-request": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a ride offer request from the driver",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Cancel a ride offer request from the driver",
+                "parameters": [
+                    {
+                        "description": "Cancel give ride request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CancelGiveRideRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully canceled ride offer request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/cancel-hitch-ride-request": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a ride request from the hitcher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Cancel a ride request from the hitcher",
+                "parameters": [
+                    {
+                        "description": "Cancel hitch ride request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CancelHitchRideRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully canceled ride request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/cancel-ride": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels the ride",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Cancel a ride",
+                "parameters": [
+                    {
+                        "description": "Cancel ride request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CancelRideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully canceled ride by driver",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.CancelRideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "User is neither the driver nor the passenger of the ride",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/end-ride": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends the ride between the driver and the hitcher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "End a ride",
+                "parameters": [
+                    {
+                        "description": "End ride request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.EndRideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully ended ride",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.EndRideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the driver of the ride or location of the driver could not be verified",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/get-all-pending-ride": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all ride request and ride offer that are not cancelled of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Get all pending ride",
+                "responses": {
+                    "200": {
+                        "description": "Successfully got all pending ride",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetAllPendingRideResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/give-ride-request": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a ride offer request from the driver to the hitcher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Send a ride offer request from the driver to the hitcher",
+                "parameters": [
+                    {
+                        "description": "Give ride request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SendGiveRideRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully sent ride offer request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "One of the users blocked the other",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the rides of the user as driver or passenger, newest first, with the other party, the vehicle and the transaction. Pass the next_cursor of a page to get the next one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Get the ride history",
+                "parameters": [
+                    {
+                        "enum": [
+                            "driver",
+                            "passenger"
+                        ],
+                        "type": "string",
+                        "description": "Role of the user in the rides",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "scheduled",
+                            "ongoing",
+                            "completed",
+                            "cancelled",
+                            "no_show"
+                        ],
+                        "type": "string",
+                        "description": "Status of the rides",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the start time, YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the start time, YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of rides per page, 20 by default",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ride history retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetRideHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/history-summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the trips, the kilometers shared and the money earned and spent per month over the completed rides of the user, the last 12 months by default",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Get the monthly summary of the ride history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First month, YYYY-MM",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last month, YYYY-MM",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ride history summary retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetRideHistorySummaryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/hitch-ride-request": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a ride request from the hitcher to the driver",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Send a ride request from the hitcher to the driver",
+                "parameters": [
+                    {
+                        "description": "Hitch ride request details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SendHitchRideRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully sent ride request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "One of the users blocked the other",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/pickup-code": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a new pickup PIN and QR payload for the hitcher of a scheduled ride, the driver must submit one of them to start the ride",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Issue a pickup code",
+                "parameters": [
+                    {
+                        "description": "Issue pickup code request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.IssuePickupCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully issued pickup code",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.IssuePickupCodeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/receipt": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the receipt of a completed ride of the user with the route, the fare breakdown, the payment and the driver and vehicle. The receipt is built from the stored ride so it never changes",
+                "produces": [
+                    "application/pdf",
+                    "text/html",
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Download the receipt of a ride",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ride ID",
+                        "name": "rideID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pdf",
+                            "html",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "Format of the receipt, pdf by default",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "vi",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "Language of the receipt, vi by default",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt of the ride, as JSON when format is json",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.RideReceipt"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Ride not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Ride not completed",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/revoke-trip-share": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop a public link of the current user from working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Revoke a shared link",
+                "parameters": [
+                    {
+                        "description": "Revoke trip share request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RevokeTripShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trip share revoked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/share-trip": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a public link to follow the ride live without the app, it works until it expires, it is revoked or the ride ends. Only the passenger can share the ride",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Share a ride",
+                "parameters": [
+                    {
+                        "description": "Share trip request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ShareTripRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trip shared successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.ShareTripResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/start-ride": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts the ride between the driver and the hitcher, the driver must submit the pickup PIN or QR payload shown by the hitcher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Start a ride",
+                "parameters": [
+                    {
+                        "description": "Start ride request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.StartRideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully started ride",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.StartRideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Location of the driver could not be verified",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/trail": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the positions recorded during a ride as a GeoJSON line or a GPX track, only for the driver and the passenger of the ride",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Export the trail of a ride",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ride ID",
+                        "name": "rideID",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "geojson",
+                            "gpx"
+                        ],
+                        "type": "string",
+                        "description": "Format of the export",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GeoJSON trail, or a GPX document when format is gpx",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RideTrailFeatureCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/trip-shares": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the public links the current user created for a ride, to revoke them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Get the shared links of a ride",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ride ID",
+                        "name": "rideID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trip shares retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetTripSharesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/update-ride-location": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the current location of the driver during the ride",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Update the current location of the driver during the ride",
+                "parameters": [
+                    {
+                        "description": "Update ride location request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateRideLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated ride location",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.UpdateRideLocationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the driver of the ride, or location of the driver could not be verified",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/ride/update-rider-location": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the current location the passenger shares with the driver, it is returned as the rider location of the ride and is not used by the geofences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ride"
+                ],
+                "summary": "Update the current location of the passenger during the ride",
+                "parameters": [
+                    {
+                        "description": "Update rider location request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateRiderLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated rider location",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.UpdateRiderLocationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the passenger of the ride",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/route-alert/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save a route and a recurring time window, the user is notified when a matching ride offer is created",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "route-alert"
+                ],
+                "summary": "Create a route alert",
+                "parameters": [
+                    {
+                        "description": "Route alert request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateRouteAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Route alert created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.RouteAlertDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/route-alert/delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a route alert of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "route-alert"
+                ],
+                "summary": "Delete a route alert",
+                "parameters": [
+                    {
+                        "description": "Delete route alert request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.DeleteRouteAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Route alert deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/route-alert/get-all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all route alerts of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "route-alert"
+                ],
+                "summary": "Get route alerts",
+                "responses": {
+                    "200": {
+                        "description": "Route alerts retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetRouteAlertsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/route-alert/update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edit a route alert, set is_active to false to stop the notifications without deleting it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "route-alert"
+                ],
+                "summary": "Update a route alert",
+                "parameters": [
+                    {
+                        "description": "Update route alert request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateRouteAlertRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Route alert updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.RouteAlertDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sos/emergency-contact/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a person alerted when the user raises an SOS, an OTP is sent to the phone number to verify it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sos"
+                ],
+                "summary": "Add an emergency contact",
+                "parameters": [
+                    {
+                        "description": "Emergency contact request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.CreateEmergencyContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Emergency contact created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.EmergencyContactDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sos/emergency-contact/delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an emergency contact of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sos"
+                ],
+                "summary": "Delete an emergency contact",
+                "parameters": [
+                    {
+                        "description": "Emergency contact request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.EmergencyContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Emergency contact deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sos/emergency-contact/get-all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all emergency contacts of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sos"
+                ],
+                "summary": "Get emergency contacts",
+                "responses": {
+                    "200": {
+                        "description": "Emergency contacts retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetEmergencyContactsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sos/emergency-contact/send-otp": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send again an OTP to verify the phone number of an emergency contact",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sos"
+                ],
+                "summary": "Resend the OTP of an emergency contact",
+                "parameters": [
+                    {
+                        "description": "Emergency contact request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.EmergencyContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OTP sent successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sos/emergency-contact/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check the OTP sent to an emergency contact, only the verified contacts are alerted of an SOS",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sos"
+                ],
+                "summary": "Verify an emergency contact",
+                "parameters": [
+                    {
+                        "description": "Verify emergency contact request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.VerifyEmergencyContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Emergency contact verified successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.EmergencyContactDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/sos/trigger": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Raise an emergency during a ride: the state and the last positions of the ride are kept, the admins are alerted right away and the verified emergency contacts get a time-limited link to the live trip",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sos"
+                ],
+                "summary": "Raise an SOS",
+                "parameters": [
+                    {
+                        "description": "SOS request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.TriggerSOSRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SOS raised successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.TriggerSOSResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trip-share/live": {
+            "get": {
+                "description": "Get the driver position, the route, the ETA and the vehicle plate of the ride a public link points to, no account needed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trip-share"
+                ],
+                "summary": "Follow a shared trip",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token of the public link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shared trip retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.SharedTripResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Link expired or revoked, or ride ended",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/trip-share/stream": {
+            "get": {
+                "description": "Server-sent events of the ride a public link points to, no account needed. A trip event with a schemas.SharedTripResponse is sent every TRIP_SHARE_STREAM_INTERVAL seconds, then an end event with a schemas.SharedTripEnd once the ride ends or the link stops working",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "trip-share"
+                ],
+                "summary": "Stream a shared trip",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token of the public link",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of the shared trip",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.SharedTripResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "Link expired or revoked, or ride ended",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/block": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Block a user, the two users no longer see each other in the suggestions and can no longer send each other ride requests, messages or calls",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Block a user",
+                "parameters": [
+                    {
+                        "description": "Block user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.BlockUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User blocked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/blocked-users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the users the user blocked, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get the blocked users",
+                "responses": {
+                    "200": {
+                        "description": "Blocked users retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetBlockedUsersResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/get-profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the profile information of the authenticated user, with the fuel and the CO2 its shared rides saved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/schemas.GetUserProfileResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/register-device-token": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers the device token for the authenticated user to enable push notifications via Firebase Cloud Messaging",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Register device token for push notifications",
+                "parameters": [
+                    {
+                        "description": "Device token registration request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RegisterDeviceTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully registered device token",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/report": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Open a case against a user for the admins, optionally about a ride or a chat message shared with the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Report a user",
+                "parameters": [
+                    {
+                        "description": "Report user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.ReportUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User reported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.UserReportDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/unblock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lift a block the user created, a block created by the other user stays",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unblock a user",
+                "parameters": [
+                    {
+                        "description": "Unblock user request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.BlockUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unblocked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not blocked",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update-avatar": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the avatar image of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Avatar image file",
+                        "name": "avatar_image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated avatar",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.UpdateAvatarResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update-profile": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the profile information of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "User profile update information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.UpdateUserProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated user profile",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.UpdateUserProfileResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update-ride-preferences": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the preferences used for the new ride offers and ride requests of the authenticated user (unless a ride gives its own)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update default ride preferences",
+                "parameters": [
+                    {
+                        "description": "Default ride preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RidePreferences"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated ride preferences",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.UpdateRidePreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/get-vehicle": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves and returns a detailed list of vehicles for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle"
+                ],
+                "summary": "Get user's vehicles",
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved vehicles",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetVehicleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/register-vehicle": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a new vehicle for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle"
+                ],
+                "summary": "Register a new vehicle",
+                "parameters": [
+                    {
+                        "description": "Vehicle registration details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/schemas.RegisterVehicleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully registered vehicle",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/vehicles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves and returns the list of vehicles for user to select when registering a vehicle",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vehicle"
+                ],
+                "summary": "Get list of vehicles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search input for vehicle name",
+                        "name": "input",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit the number of results",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination purposes",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved vehicles",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/schemas.GetVehiclesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to get vehicles",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "helper.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message_en": {
+                    "type": "string"
+                },
+                "message_vi": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "schemas.AcceptGiveRideRequestRequest": {
+            "type": "object",
+            "required": [
+                "paymentMethod",
+                "receiverID",
+                "rideOfferID",
+                "rideRequestID",
+                "vehicleID"
+            ],
+            "properties": {
+                "paymentMethod": {
+                    "description": "Payment method (cash or momo)",
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "momo"
+                    ]
+                },
+                "receiverID": {
+                    "description": "The ID of the receiver (the user who received the request) aka the hitcher",
+                    "type": "string"
+                },
+                "rideOfferID": {
+                    "description": "The ID of the ride offer (current user is the driver)",
+                    "type": "string"
+                },
+                "rideRequestID": {
+                    "description": "The ID of the ride request (the user received request is the hitcher)",
+                    "type": "string"
+                },
+                "vehicleID": {
+                    "description": "The ID of the vehicle",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.AcceptGiveRideRequestResponse": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "type": "number"
+                },
+                "driver_current_latitude": {
+                    "type": "number"
+                },
+                "driver_current_longitude": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "encoded_polyline": {
+                    "type": "string"
+                },
+                "end_address": {
+                    "type": "string"
+                },
+                "end_latitude": {
+                    "type": "number"
+                },
+                "end_longitude": {
+                    "type": "number"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "fare": {
+                    "type": "number"
+                },
+                "meeting_points": {
+                    "description": "Agreed pickup and dropoff points on the route",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MeetingPoints"
+                        }
+                    ]
+                },
+                "receiver_id": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "ride_offer_id": {
+                    "type": "string"
+                },
+                "ride_request_id": {
+                    "type": "string"
+                },
+                "rider_current_latitude": {
+                    "type": "number"
+                },
+                "rider_current_longitude": {
+                    "type": "number"
+                },
+                "start_address": {
+                    "type": "string"
+                },
+                "start_latitude": {
+                    "type": "number"
+                },
+                "start_longitude": {
+                    "type": "number"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/schemas.TransactionDetail"
+                },
+                "user": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "vehicle": {
+                    "$ref": "#/definitions/schemas.VehicleDetail"
+                },
+                "waypoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Waypoint"
+                    }
+                }
+            }
+        },
+        "schemas.AcceptHitchRideRequestRequest": {
+            "type": "object",
+            "required": [
+                "paymentMethod",
+                "receiverID",
+                "rideOfferID",
+                "rideRequestID",
+                "vehicleID"
+            ],
+            "properties": {
+                "paymentMethod": {
                     "description": "Payment method (cash or momo)",
                     "type": "string",
                     "enum": [
-                        "cash",
-                        "momo"
+                        "cash",
+                        "momo"
+                    ]
+                },
+                "receiverID": {
+                    "description": "The ID of the receiver (the user who received the request) aka the driver",
+                    "type": "string"
+                },
+                "rideOfferID": {
+                    "description": "The ID of the ride offer (the user who received request is the driver)",
+                    "type": "string"
+                },
+                "rideRequestID": {
+                    "description": "The ID of the ride request (current user is the hitcher)",
+                    "type": "string"
+                },
+                "vehicleID": {
+                    "description": "The ID of the vehicle",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.AcceptHitchRideRequestResponse": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "type": "number"
+                },
+                "driver_current_latitude": {
+                    "type": "number"
+                },
+                "driver_current_longitude": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "encoded_polyline": {
+                    "type": "string"
+                },
+                "end_address": {
+                    "type": "string"
+                },
+                "end_latitude": {
+                    "type": "number"
+                },
+                "end_longitude": {
+                    "type": "number"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "fare": {
+                    "type": "number"
+                },
+                "meeting_points": {
+                    "description": "Agreed pickup and dropoff points on the route",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MeetingPoints"
+                        }
+                    ]
+                },
+                "receiver_id": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "ride_offer_id": {
+                    "type": "string"
+                },
+                "ride_request_id": {
+                    "type": "string"
+                },
+                "rider_current_latitude": {
+                    "type": "number"
+                },
+                "rider_current_longitude": {
+                    "type": "number"
+                },
+                "start_address": {
+                    "type": "string"
+                },
+                "start_latitude": {
+                    "type": "number"
+                },
+                "start_longitude": {
+                    "type": "number"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/schemas.TransactionDetail"
+                },
+                "user": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "vehicle": {
+                    "$ref": "#/definitions/schemas.VehicleDetail"
+                },
+                "waypoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Waypoint"
+                    }
+                }
+            }
+        },
+        "schemas.AcknowledgeDeviationEventRequest": {
+            "type": "object",
+            "required": [
+                "event_id"
+            ],
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "schemas.AdminInfo": {
+            "type": "object",
+            "properties": {
+                "admin_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.AdminUserReportDetail": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "admin_note": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "chat_id": {
+                    "type": "string"
+                },
+                "chat_message": {
+                    "description": "Content of the reported chat message",
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "report_id": {
+                    "type": "string"
+                },
+                "reported": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "reporter": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.BlockUserRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.BlockedUserDetail": {
+            "type": "object",
+            "properties": {
+                "blocked_at": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                }
+            }
+        },
+        "schemas.BrowseRideOffersRequest": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "description": "next_cursor of the previous page, for the same sort",
+                    "type": "string"
+                },
+                "departure_from": {
+                    "description": "Earliest start time of the ride offers (default: now)",
+                    "type": "string"
+                },
+                "departure_to": {
+                    "description": "Latest start time of the ride offers (default: 24 hours after departure_from)",
+                    "type": "string"
+                },
+                "destination": {
+                    "$ref": "#/definitions/schemas.Point"
+                },
+                "destination_radius": {
+                    "description": "Maximum distance (km) between the destination and the route (default: 2 km)",
+                    "type": "number",
+                    "maximum": 20
+                },
+                "limit": {
+                    "description": "Number of ride offers per page (default: 10)",
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 1
+                },
+                "origin": {
+                    "$ref": "#/definitions/schemas.Point"
+                },
+                "origin_radius": {
+                    "description": "Maximum distance (km) between the origin and the route (default: 2 km)",
+                    "type": "number",
+                    "maximum": 20
+                },
+                "seats": {
+                    "description": "Minimum number of available seats (default: 1)",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "sort_by": {
+                    "description": "Sort of the ride offers (default: start_time)",
+                    "type": "string",
+                    "enum": [
+                        "start_time",
+                        "fare",
+                        "available_seats"
+                    ]
+                }
+            }
+        },
+        "schemas.BrowseRideOffersResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "Empty on the last page",
+                    "type": "string"
+                },
+                "ride_offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RideOfferDetail"
+                    }
+                }
+            }
+        },
+        "schemas.CancelGiveRideRequestRequest": {
+            "type": "object",
+            "required": [
+                "receiverID",
+                "rideOfferID",
+                "rideRequestID"
+            ],
+            "properties": {
+                "receiverID": {
+                    "type": "string"
+                },
+                "rideOfferID": {
+                    "type": "string"
+                },
+                "rideRequestID": {
+                    "type": "string"
+                },
+                "vehicleID": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CancelHitchRideRequestRequest": {
+            "type": "object",
+            "required": [
+                "receiverID",
+                "rideOfferID",
+                "rideRequestID"
+            ],
+            "properties": {
+                "receiverID": {
+                    "description": "The hitcher who received the cancel request",
+                    "type": "string"
+                },
+                "rideOfferID": {
+                    "description": "The hitcher who received the request",
+                    "type": "string"
+                },
+                "rideRequestID": {
+                    "description": "The driver who want to cancel the ride request",
+                    "type": "string"
+                },
+                "vehicleID": {
+                    "description": "The vehicle id",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CancelRideRequest": {
+            "type": "object",
+            "required": [
+                "receiverID",
+                "rideID"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "Why the ride is cancelled, kept in the ride status history",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "receiverID": {
+                    "description": "The receiver id (the hitcher) who received the cancel request",
+                    "type": "string"
+                },
+                "rideID": {
+                    "description": "The ride id to cancel",
+                    "type": "string"
+                },
+                "vehicleID": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CancelRideResponse": {
+            "type": "object",
+            "properties": {
+                "receiver_id": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "description": "Send back id of ride offer ride request to update ui",
+                    "type": "string"
+                },
+                "ride_offer_id": {
+                    "type": "string"
+                },
+                "ride_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.ChatRoomResponse": {
+            "type": "object",
+            "properties": {
+                "last_message": {
+                    "type": "string"
+                },
+                "last_message_at": {
+                    "type": "string"
+                },
+                "last_message_id": {
+                    "type": "string"
+                },
+                "receiver_info": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "room_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CheckoutRideRequest": {
+            "type": "object",
+            "required": [
+                "receiverID",
+                "rideOfferID",
+                "rideRequestID"
+            ],
+            "properties": {
+                "receiverID": {
+                    "description": "The ID of the receiver (the user who received the request) aka the driver",
+                    "type": "string"
+                },
+                "rideOfferID": {
+                    "description": "The ID of the ride offer (the user who received request is the driver)",
+                    "type": "string"
+                },
+                "rideRequestID": {
+                    "description": "Use for checkout with momo\nThe ID of the ride request (current user is the hitcher)",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CloseUserReportRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "note",
+                "report_id"
+            ],
+            "properties": {
+                "action": {
+                    "description": "dismiss or warn",
+                    "type": "string",
+                    "enum": [
+                        "dismiss",
+                        "warn"
+                    ]
+                },
+                "note": {
+                    "description": "How the report was handled, kept for the audit",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "report_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateEmergencyContactRequest": {
+            "type": "object",
+            "required": [
+                "full_name",
+                "phone_number"
+            ],
+            "properties": {
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "phone_number": {
+                    "description": "An OTP is sent to this number to verify it",
+                    "type": "string"
+                },
+                "relationship": {
+                    "description": "e.g. parent, partner, friend",
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "schemas.CreateFavoriteLocationRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "name",
+                "place_id",
+                "type"
+            ],
+            "properties": {
+                "address": {
+                    "description": "Address shown to the user, usually the description of the prediction",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "description": "Name given by the user, e.g. \"Gym\"",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "place_id": {
+                    "description": "Place ID from goong api",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "A user has at most one home and one work",
+                    "type": "string",
+                    "enum": [
+                        "home",
+                        "work",
+                        "custom"
+                    ]
+                }
+            }
+        },
+        "schemas.CreateGeofenceOverrideRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "note",
+                "ride_id"
+            ],
+            "properties": {
+                "action": {
+                    "description": "Which location check is skipped",
+                    "type": "string",
+                    "enum": [
+                        "start",
+                        "end"
+                    ]
+                },
+                "note": {
+                    "description": "Why the location check is skipped, kept for the audit",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "ride_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateNotificationRequest": {
+            "type": "object",
+            "required": [
+                "body",
+                "title"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "Additional data to be sent with the notification (optional)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateRatingRequest": {
+            "type": "object",
+            "required": [
+                "rating",
+                "ride_id"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "schemas.CreateRatingResponse": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "description": "Average of the rated user after this rating",
+                    "type": "number"
+                },
+                "ratee_id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "$ref": "#/definitions/schemas.RatingDetail"
+                },
+                "rating_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.CreateRecurringRideOfferRequest": {
+            "type": "object",
+            "required": [
+                "frequency",
+                "place_list",
+                "start_time",
+                "vehicle_id"
+            ],
+            "properties": {
+                "days_of_week": {
+                    "description": "Days of the week for the weekly frequency (0 = Sunday)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "frequency": {
+                    "description": "daily, weekdays (Monday to Friday) or weekly (on days_of_week)",
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekdays",
+                        "weekly"
+                    ]
+                },
+                "place_list": {
+                    "description": "List of places for the route (place_id) from goong api",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "seats": {
+                    "description": "Number of seats offered (defaults to the vehicle seat capacity)",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "skip_dates": {
+                    "description": "Days on which the ride is not offered (2006-01-02)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start_time": {
+                    "description": "First departure of the series (2006-01-02T15:04:05.999999)",
+                    "type": "string"
+                },
+                "until_date": {
+                    "description": "Last day of the series (2006-01-02), empty means no end",
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "description": "Vehicle ID for the ride that user has registered",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateRouteAlertRequest": {
+            "type": "object",
+            "required": [
+                "window_end",
+                "window_start"
+            ],
+            "properties": {
+                "days_of_week": {
+                    "description": "Days of the week of the departure (0 = Sunday), empty means every day",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "destination": {
+                    "description": "Where the user wants to be dropped off",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                },
+                "destination_address": {
+                    "description": "Address of the destination, shown in the notification",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name given by the user, e.g. \"Home to work\"",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "origin": {
+                    "description": "Where the user wants to be picked up",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                },
+                "origin_address": {
+                    "description": "Address of the origin, shown in the notification",
+                    "type": "string"
+                },
+                "window_end": {
+                    "description": "Latest departure time of the day (HH:MM in local time), before window_start for a window past midnight",
+                    "type": "string"
+                },
+                "window_start": {
+                    "description": "Earliest departure time of the day (HH:MM in local time)",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.CreateTestWebsocketRequest": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.DeleteFavoriteLocationRequest": {
+            "type": "object",
+            "required": [
+                "favorite_location_id"
+            ],
+            "properties": {
+                "favorite_location_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.DeleteRouteAlertRequest": {
+            "type": "object",
+            "required": [
+                "route_alert_id"
+            ],
+            "properties": {
+                "route_alert_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.DeleteUserRequest": {
+            "type": "object",
+            "required": [
+                "phone_number"
+            ],
+            "properties": {
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.DeviationEventDetail": {
+            "type": "object",
+            "properties": {
+                "acknowledged_at": {
+                    "type": "string"
+                },
+                "acknowledged_by": {
+                    "type": "string"
+                },
+                "deviation_event_id": {
+                    "type": "string"
+                },
+                "distance": {
+                    "description": "in meters",
+                    "type": "number"
+                },
+                "ended_at": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "max_distance": {
+                    "description": "in meters",
+                    "type": "number"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.EmergencyContactDetail": {
+            "type": "object",
+            "properties": {
+                "emergency_contact_id": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "is_verified": {
+                    "description": "Only the verified contacts are alerted",
+                    "type": "boolean"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "relationship": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.EmergencyContactRequest": {
+            "type": "object",
+            "required": [
+                "emergency_contact_id"
+            ],
+            "properties": {
+                "emergency_contact_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.EndRideRequest": {
+            "type": "object",
+            "required": [
+                "currentLocation",
+                "rideID"
+            ],
+            "properties": {
+                "currentLocation": {
+                    "description": "Current user location",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                },
+                "rideID": {
+                    "description": "Ride ID of the ride to end",
+                    "type": "string"
+                },
+                "vehicleID": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.EndRideResponse": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "type": "number"
+                },
+                "driver_current_latitude": {
+                    "type": "number"
+                },
+                "driver_current_longitude": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "encoded_polyline": {
+                    "type": "string"
+                },
+                "end_address": {
+                    "type": "string"
+                },
+                "end_latitude": {
+                    "type": "number"
+                },
+                "end_longitude": {
+                    "type": "number"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "fare": {
+                    "type": "number"
+                },
+                "receiver_id": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "ride_offer_id": {
+                    "type": "string"
+                },
+                "ride_request_id": {
+                    "type": "string"
+                },
+                "rider_current_latitude": {
+                    "type": "number"
+                },
+                "rider_current_longitude": {
+                    "type": "number"
+                },
+                "start_address": {
+                    "type": "string"
+                },
+                "start_latitude": {
+                    "type": "number"
+                },
+                "start_longitude": {
+                    "type": "number"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/schemas.TransactionDetail"
+                },
+                "user": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "vehicle": {
+                    "$ref": "#/definitions/schemas.VehicleDetail"
+                },
+                "waypoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Waypoint"
+                    }
+                }
+            }
+        },
+        "schemas.FareComponent": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount the step added to the fare",
+                    "type": "number"
+                },
+                "strategy": {
+                    "description": "Strategy that applied the step",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.FareInput": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "description": "in kilometers",
+                    "type": "number"
+                },
+                "duration": {
+                    "description": "in seconds",
+                    "type": "integer"
+                },
+                "fuel_consumed": {
+                    "description": "liters (kWh for electric vehicles) per 100 kilometers",
+                    "type": "number"
+                },
+                "fuel_price": {
+                    "description": "per liter (per kWh for electric vehicles)",
+                    "type": "number"
+                },
+                "fuel_type": {
+                    "description": "ron95, e5_ron92, diesel or electric",
+                    "type": "string"
+                },
+                "per_km_rate": {
+                    "description": "Rate of the vehicle type (0 if the default rate applies)",
+                    "type": "number"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "vehicle_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.FareQuote": {
+            "type": "object",
+            "properties": {
+                "breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.FareComponent"
+                    }
+                },
+                "commission": {
+                    "description": "Part of the fare kept by the platform",
+                    "type": "number"
+                },
+                "driver_earning": {
+                    "description": "Part of the fare paid out to the driver",
+                    "type": "number"
+                },
+                "fare": {
+                    "description": "Price paid by the hitcher",
+                    "type": "number"
+                },
+                "input": {
+                    "$ref": "#/definitions/schemas.FareInput"
+                },
+                "strategy": {
+                    "description": "Strategy that computed the base fare",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.FareQuoteRequest": {
+            "type": "object",
+            "required": [
+                "place_list"
+            ],
+            "properties": {
+                "place_list": {
+                    "description": "List of places for the route (place_id) from goong api, or favorite:\u003cfavorite_location_id\u003e",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start_time": {
+                    "description": "Start time of the ride (if not provided, the ride is immediate)",
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "description": "Vehicle of the driver (if not provided, the default fuel consumption is used)",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.FavoriteLocationResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "place_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GeoCodeLocation": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "description": "Distance from the location (in kilometers) for which the geocode is performed",
+                    "type": "number"
+                },
+                "formatted_address": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "main_address": {
+                    "description": "Main address of the location",
+                    "type": "string"
+                },
+                "place_id": {
+                    "type": "string"
+                },
+                "secondary_address": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GeoCodeLocationResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.GeoCodeLocation"
+                    }
+                }
+            }
+        },
+        "schemas.GeoCodeRequest": {
+            "type": "object",
+            "required": [
+                "current_location",
+                "point"
+            ],
+            "properties": {
+                "current_location": {
+                    "description": "Current location of the user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                },
+                "point": {
+                    "description": "Point for which the geocode is performed",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                }
+            }
+        },
+        "schemas.GeofenceOverrideDetail": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "admin_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "geofence_override_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "used_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetAdminProfileResponse": {
+            "type": "object",
+            "properties": {
+                "admin_info": {
+                    "$ref": "#/definitions/schemas.AdminInfo"
+                }
+            }
+        },
+        "schemas.GetAllChatRoomsRequest": {
+            "type": "object",
+            "required": [
+                "userID"
+            ],
+            "properties": {
+                "userID": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetAllChatRoomsResponse": {
+            "type": "object",
+            "properties": {
+                "chatRooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ChatRoomResponse"
+                    }
+                }
+            }
+        },
+        "schemas.GetAllPendingRideResponse": {
+            "type": "object",
+            "properties": {
+                "pending_ride_offer": {
+                    "description": "The pending ride offer of the user",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RideOfferDetail"
+                    }
+                },
+                "pending_ride_request": {
+                    "description": "The pending ride request of the user",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RideRequestDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetBlockedUsersResponse": {
+            "type": "object",
+            "properties": {
+                "blocked_users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.BlockedUserDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetChatMessagesRequest": {
+            "type": "object",
+            "required": [
+                "chatRoomID"
+            ],
+            "properties": {
+                "chatRoomID": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GetChatMessagesResponse": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.MessageResponse"
+                    }
+                }
+            }
+        },
+        "schemas.GetDeviationEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.DeviationEventDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetEmergencyContactsResponse": {
+            "type": "object",
+            "properties": {
+                "emergency_contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.EmergencyContactDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetFavoriteLocationsResponse": {
+            "type": "object",
+            "properties": {
+                "favorite_locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.FavoriteLocationResponse"
+                    }
+                }
+            }
+        },
+        "schemas.GetPlatformImpactResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "impact": {
+                    "$ref": "#/definitions/schemas.ImpactDetail"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "users": {
+                    "description": "Drivers and passengers of the rides",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.GetRatingsReceivedResponse": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "ratings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RatingDetail"
+                    }
+                },
+                "total": {
+                    "description": "Number of ratings over all pages",
+                    "type": "integer"
+                }
+            }
+        },
+        "schemas.GetRecurringRideOffersResponse": {
+            "type": "object",
+            "properties": {
+                "recurring_ride_offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RecurringRideOfferDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetRideHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "Empty on the last page",
+                    "type": "string"
+                },
+                "rides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RideHistoryItem"
+                    }
+                }
+            }
+        },
+        "schemas.GetRideHistorySummaryResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "months": {
+                    "description": "Newest first, only the months with rides",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RideHistoryMonth"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Month is empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.RideHistoryMonth"
+                        }
+                    ]
+                }
+            }
+        },
+        "schemas.GetRouteAlertsResponse": {
+            "type": "object",
+            "properties": {
+                "route_alerts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RouteAlertDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetSOSEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.SOSEventDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetTripSharesResponse": {
+            "type": "object",
+            "properties": {
+                "trip_shares": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.TripShareDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetUserProfileResponse": {
+            "type": "object",
+            "required": [
+                "user"
+            ],
+            "properties": {
+                "impact": {
+                    "description": "What the completed rides of the user saved",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.ImpactDetail"
+                        }
+                    ]
+                },
+                "user": {
+                    "$ref": "#/definitions/schemas.UserResponse"
+                }
+            }
+        },
+        "schemas.GetUserReportsResponse": {
+            "type": "object",
+            "properties": {
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.AdminUserReportDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetVehicleResponse": {
+            "type": "object",
+            "properties": {
+                "vehicle": {
+                    "description": "this is an array because a user can have multiple vehicles",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.VehicleDetail"
+                    }
+                }
+            }
+        },
+        "schemas.GetVehiclesResponse": {
+            "type": "object",
+            "properties": {
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Vehicle"
+                    }
+                }
+            }
+        },
+        "schemas.GiveRideRequest": {
+            "type": "object",
+            "required": [
+                "place_list",
+                "vehicle_id"
+            ],
+            "properties": {
+                "place_list": {
+                    "description": "Points []Point ` + "`" + `json:\"points\" binding:\"required\"` + "`" + ` // List of points for the route",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "preferences": {
+                    "description": "Preferences for this ride (defaults to the preferences of the user profile)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.RidePreferences"
+                        }
                     ]
                 },
-                "receiverID": {
-                    "description": "The ID of the receiver (the user who received the request) aka the driver",
+                "seats": {
+                    "description": "Number of seats offered (defaults to the vehicle seat capacity)",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "start_time": {
+                    "description": "Start time of the ride (if not provided, the ride is immediate)",
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "description": "Vehicle ID for the ride that user has registered",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.GiveRideResponse": {
+            "type": "object",
+            "properties": {
+                "available_seats": {
+                    "type": "integer"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "fare": {
+                    "type": "number"
+                },
+                "ride_offer_id": {
+                    "type": "string"
+                },
+                "route": {
+                    "$ref": "#/definitions/schemas.GoongDirectionsResponse"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "total_seats": {
+                    "type": "integer"
+                },
+                "vehicle": {
+                    "$ref": "#/definitions/schemas.VehicleDetail"
+                },
+                "waypoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Waypoint"
+                    }
+                }
+            }
+        },
+        "schemas.GoongAutoCompleteResponse": {
+            "type": "object",
+            "properties": {
+                "executed_time": {
+                    "type": "integer"
+                },
+                "executed_time_all": {
+                    "type": "integer"
+                },
+                "predictions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Prediction"
+                    }
+                },
+                "status": {
                     "type": "string"
+                }
+            }
+        },
+        "schemas.GoongDirectionsResponse": {
+            "type": "object",
+            "properties": {
+                "geocoded_waypoints": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "geocoder_status": {
+                                "type": "string"
+                            },
+                            "place_id": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                },
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "properties": {
+                            "bounds": {
+                                "description": "Empty struct as the bounds are empty in the example",
+                                "type": "object"
+                            },
+                            "legs": {
+                                "type": "array",
+                                "items": {
+                                    "type": "object",
+                                    "properties": {
+                                        "distance": {
+                                            "type": "object",
+                                            "properties": {
+                                                "text": {
+                                                    "type": "string"
+                                                },
+                                                "value": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        },
+                                        "duration": {
+                                            "type": "object",
+                                            "properties": {
+                                                "text": {
+                                                    "type": "string"
+                                                },
+                                                "value": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        },
+                                        "end_address": {
+                                            "type": "string"
+                                        },
+                                        "end_location": {
+                                            "type": "object",
+                                            "properties": {
+                                                "lat": {
+                                                    "type": "number"
+                                                },
+                                                "lng": {
+                                                    "type": "number"
+                                                }
+                                            }
+                                        },
+                                        "start_address": {
+                                            "type": "string"
+                                        },
+                                        "start_location": {
+                                            "type": "object",
+                                            "properties": {
+                                                "lat": {
+                                                    "type": "number"
+                                                },
+                                                "lng": {
+                                                    "type": "number"
+                                                }
+                                            }
+                                        },
+                                        "steps": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "object",
+                                                "properties": {
+                                                    "distance": {
+                                                        "type": "object",
+                                                        "properties": {
+                                                            "text": {
+                                                                "type": "string"
+                                                            },
+                                                            "value": {
+                                                                "type": "integer"
+                                                            }
+                                                        }
+                                                    },
+                                                    "duration": {
+                                                        "type": "object",
+                                                        "properties": {
+                                                            "text": {
+                                                                "type": "string"
+                                                            },
+                                                            "value": {
+                                                                "type": "integer"
+                                                            }
+                                                        }
+                                                    },
+                                                    "end_location": {
+                                                        "type": "object",
+                                                        "properties": {
+                                                            "lat": {
+                                                                "type": "number"
+                                                            },
+                                                            "lng": {
+                                                                "type": "number"
+                                                            }
+                                                        }
+                                                    },
+                                                    "html_instructions": {
+                                                        "type": "string"
+                                                    },
+                                                    "maneuver": {
+                                                        "type": "string"
+                                                    },
+                                                    "polyline": {
+                                                        "type": "object",
+                                                        "properties": {
+                                                            "points": {
+                                                                "type": "string"
+                                                            }
+                                                        }
+                                                    },
+                                                    "start_location": {
+                                                        "type": "object",
+                                                        "properties": {
+                                                            "lat": {
+                                                                "type": "number"
+                                                            },
+                                                            "lng": {
+                                                                "type": "number"
+                                                            }
+                                                        }
+                                                    },
+                                                    "travel_mode": {
+                                                        "type": "string"
+                                                    }
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            },
+                            "overview_polyline": {
+                                "type": "object",
+                                "properties": {
+                                    "points": {
+                                        "type": "string"
+                                    }
+                                }
+                            },
+                            "summary": {
+                                "type": "string"
+                            },
+                            "warnings": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            },
+                            "waypoint_order": {
+                                "type": "array",
+                                "items": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "schemas.HitchRideRequest": {
+            "type": "object",
+            "required": [
+                "place_list",
+                "weight"
+            ],
+            "properties": {
+                "place_list": {
+                    "description": "Points []Point ` + "`" + `json:\"points\" binding:\"required\"` + "`" + ` // List of points for the route",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "rideOfferID": {
-                    "description": "The ID of the ride offer (the user who received request is the driver)",
-                    "type": "string"
+                "preferences": {
+                    "description": "Preferences for this ride (defaults to the preferences of the user profile)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.RidePreferences"
+                        }
+                    ]
                 },
-                "rideRequestID": {
-                    "description": "The ID of the ride request (current user is the hitcher)",
+                "start_time": {
+                    "description": "Start time of the ride (if not provided, the ride is immediate)",
                     "type": "string"
                 },
-                "vehicleID": {
-                    "description": "The ID of the vehicle",
-                    "type": "string"
+                "weight": {
+                    "description": "Weight of the rider to consider",
+                    "type": "integer"
                 }
             }
         },
-        "schemas.AcceptHitchRideRequestResponse": {
+        "schemas.HitchRideResponse": {
             "type": "object",
             "properties": {
                 "distance": {
                     "type": "number"
                 },
-                "driver_current_latitude": {
-                    "type": "number"
-                },
-                "driver_current_longitude": {
-                    "type": "number"
-                },
                 "duration": {
                     "type": "integer"
                 },
-                "encoded_polyline": {
-                    "type": "string"
-                },
-                "end_address": {
-                    "type": "string"
-                },
-                "end_latitude": {
-                    "type": "number"
-                },
-                "end_longitude": {
-                    "type": "number"
-                },
                 "end_time": {
                     "type": "string"
                 },
-                "fare": {
-                    "type": "number"
-                },
-                "receiver_id": {
-                    "type": "string"
-                },
-                "ride_id": {
+                "ride_request_id": {
                     "type": "string"
                 },
-                "ride_offer_id": {
-                    "type": "string"
+                "route": {
+                    "$ref": "#/definitions/schemas.GoongDirectionsResponse"
                 },
-                "ride_request_id": {
+                "start_time": {
                     "type": "string"
+                }
+            }
+        },
+        "schemas.ImpactDetail": {
+            "type": "object",
+            "properties": {
+                "by_fuel_type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.ImpactFuelDetail"
+                    }
                 },
-                "rider_current_latitude": {
+                "co2_avoided": {
+                    "description": "in kilograms",
                     "type": "number"
                 },
-                "rider_current_longitude": {
+                "energy_saved": {
+                    "description": "in kWh, by the electric vehicles",
                     "type": "number"
                 },
-                "start_address": {
-                    "type": "string"
-                },
-                "start_latitude": {
+                "fuel_saved": {
+                    "description": "in liters",
                     "type": "number"
                 },
-                "start_longitude": {
+                "km_shared": {
                     "type": "number"
                 },
-                "start_time": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "transaction": {
-                    "$ref": "#/definitions/schemas.TransactionDetail"
-                },
-                "user": {
-                    "$ref": "#/definitions/schemas.UserInfo"
-                },
-                "vehicle": {
-                    "$ref": "#/definitions/schemas.VehicleDetail"
-                },
-                "waypoints": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.Waypoint"
-                    }
+                "rides": {
+                    "type": "integer"
                 }
             }
         },
-        "schemas.AdminInfo": {
+        "schemas.ImpactFuelDetail": {
             "type": "object",
             "properties": {
-                "admin_id": {
-                    "type": "string"
+                "co2_avoided": {
+                    "description": "in kilograms",
+                    "type": "number"
                 },
-                "created_at": {
-                    "type": "string"
+                "emission_factor": {
+                    "description": "kg of CO2 per liter, per kWh for electric vehicles",
+                    "type": "number"
                 },
-                "full_name": {
+                "fuel_type": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "string"
+                "km_shared": {
+                    "type": "number"
                 },
-                "updated_at": {
-                    "type": "string"
+                "rides": {
+                    "type": "integer"
                 },
-                "username": {
-                    "type": "string"
+                "saved": {
+                    "description": "in liters, in kWh for electric vehicles",
+                    "type": "number"
                 }
             }
         },
-        "schemas.CancelGiveRideRequestRequest": {
+        "schemas.InitRegisterRequest": {
             "type": "object",
             "required": [
-                "receiverID",
-                "rideOfferID",
-                "rideRequestID"
+                "phone_number"
             ],
             "properties": {
-                "receiverID": {
-                    "type": "string"
-                },
-                "rideOfferID": {
+                "phone_number": {
                     "type": "string"
+                }
+            }
+        },
+        "schemas.InitRegisterResponse": {
+            "type": "object",
+            "required": [
+                "is_activated",
+                "is_verified",
+                "phone_number"
+            ],
+            "properties": {
+                "is_activated": {
+                    "type": "boolean"
                 },
-                "rideRequestID": {
-                    "type": "string"
+                "is_verified": {
+                    "type": "boolean"
                 },
-                "vehicleID": {
+                "phone_number": {
                     "type": "string"
                 }
             }
         },
-        "schemas.CancelHitchRideRequestRequest": {
+        "schemas.InitiateCallResponse": {
             "type": "object",
-            "required": [
-                "receiverID",
-                "rideOfferID",
-                "rideRequestID"
-            ],
             "properties": {
-                "receiverID": {
-                    "description": "The hitcher who received the cancel request",
+                "call_id": {
+                    "description": "the call id is the message id of the chat message",
                     "type": "string"
                 },
-                "rideOfferID": {
-                    "description": "The hitcher who received the request",
+                "caller_id": {
                     "type": "string"
                 },
-                "rideRequestID": {
-                    "description": "The driver who want to cancel the ride request",
+                "chatroom_id": {
                     "type": "string"
                 },
-                "vehicleID": {
-                    "description": "The vehicle id",
+                "receiver_id": {
+                    "type": "string"
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "schemas.CancelRideRequest": {
+        "schemas.IssuePickupCodeRequest": {
             "type": "object",
             "required": [
-                "receiverID",
                 "rideID"
             ],
             "properties": {
-                "receiverID": {
-                    "description": "The receiver id (the hitcher) who received the cancel request",
-                    "type": "string"
-                },
                 "rideID": {
-                    "description": "The ride id to cancel",
-                    "type": "string"
-                },
-                "vehicleID": {
+                    "description": "Ride ID of the ride to issue a pickup code for",
                     "type": "string"
                 }
             }
         },
-        "schemas.CancelRideResponse": {
+        "schemas.IssuePickupCodeResponse": {
             "type": "object",
             "properties": {
-                "receiver_id": {
+                "expires_at": {
                     "type": "string"
                 },
-                "ride_id": {
-                    "description": "Send back id of ride offer ride request to update ui",
+                "pin": {
+                    "description": "Shown to the driver to type in",
                     "type": "string"
                 },
-                "ride_offer_id": {
+                "qr_payload": {
+                    "description": "Shown to the driver as a QR code to scan",
                     "type": "string"
                 },
-                "ride_request_id": {
+                "ride_id": {
                     "type": "string"
                 }
             }
         },
-        "schemas.ChatRoomResponse": {
+        "schemas.LinkMomoRequest": {
             "type": "object",
+            "required": [
+                "walletPhoneNumber"
+            ],
             "properties": {
-                "last_message": {
-                    "type": "string"
-                },
-                "last_message_at": {
-                    "type": "string"
-                },
-                "last_message_id": {
-                    "type": "string"
-                },
-                "receiver_info": {
-                    "$ref": "#/definitions/schemas.UserInfo"
-                },
-                "room_id": {
+                "walletPhoneNumber": {
+                    "description": "Different from phoneNumber that is used for login\nThis is the phone number that is registered with momo wallet",
+                    "type": "string",
+                    "maxLength": 10,
+                    "minLength": 10
+                }
+            }
+        },
+        "schemas.LinkMomoWalletResponse": {
+            "type": "object",
+            "properties": {
+                "deeplink": {
+                    "description": "send this to fe flutter app for open momo and perform linked",
                     "type": "string"
                 }
             }
         },
-        "schemas.CheckoutRideRequest": {
+        "schemas.LoginAdminRequest": {
             "type": "object",
             "required": [
-                "receiverID",
-                "rideOfferID",
-                "rideRequestID"
+                "password",
+                "username"
             ],
             "properties": {
-                "receiverID": {
-                    "description": "The ID of the receiver (the user who received the request) aka the driver",
-                    "type": "string"
-                },
-                "rideOfferID": {
-                    "description": "The ID of the ride offer (the user who received request is the driver)",
-                    "type": "string"
+                "password": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 6
                 },
-                "rideRequestID": {
-                    "description": "Use for checkout with momo\nThe ID of the ride request (current user is the hitcher)",
-                    "type": "string"
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
                 }
             }
         },
-        "schemas.CreateNotificationRequest": {
+        "schemas.LoginAdminResponse": {
             "type": "object",
-            "required": [
-                "body",
-                "title"
-            ],
             "properties": {
-                "body": {
-                    "type": "string"
-                },
-                "data": {
-                    "description": "Additional data to be sent with the notification (optional)",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "admin_info": {
+                    "$ref": "#/definitions/schemas.AdminInfo"
                 },
-                "title": {
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "schemas.CreateTestWebsocketRequest": {
+        "schemas.LoginRequest": {
             "type": "object",
             "required": [
-                "message"
+                "phone_number"
             ],
             "properties": {
-                "message": {
+                "phone_number": {
                     "type": "string"
                 }
             }
         },
-        "schemas.DeleteUserRequest": {
+        "schemas.LoginResponse": {
             "type": "object",
             "required": [
-                "phone_number"
+                "is_activated",
+                "is_verified",
+                "phone_number",
+                "user_id"
             ],
             "properties": {
+                "is_activated": {
+                    "type": "boolean"
+                },
+                "is_verified": {
+                    "type": "boolean"
+                },
                 "phone_number": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.EndRideRequest": {
+        "schemas.LoginWithOAuthRequest": {
             "type": "object",
             "required": [
-                "currentLocation",
-                "rideID"
+                "email"
             ],
             "properties": {
-                "currentLocation": {
-                    "description": "Current user location",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/schemas.Point"
-                        }
-                    ]
-                },
-                "rideID": {
-                    "description": "Ride ID of the ride to end",
-                    "type": "string"
-                },
-                "vehicleID": {
-                    "type": "string"
+                "email": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 3
                 }
             }
         },
-        "schemas.EndRideResponse": {
+        "schemas.LoginWithOAuthResponse": {
             "type": "object",
+            "required": [
+                "full_name",
+                "is_activated",
+                "is_verified",
+                "phone_number",
+                "user_id"
+            ],
             "properties": {
-                "distance": {
-                    "type": "number"
-                },
-                "driver_current_latitude": {
-                    "type": "number"
-                },
-                "driver_current_longitude": {
-                    "type": "number"
-                },
-                "duration": {
-                    "type": "integer"
-                },
-                "encoded_polyline": {
-                    "type": "string"
-                },
-                "end_address": {
-                    "type": "string"
-                },
-                "end_latitude": {
-                    "type": "number"
-                },
-                "end_longitude": {
-                    "type": "number"
-                },
-                "end_time": {
-                    "type": "string"
-                },
-                "fare": {
-                    "type": "number"
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 3
                 },
-                "receiver_id": {
-                    "type": "string"
+                "is_activated": {
+                    "type": "boolean"
                 },
-                "ride_id": {
-                    "type": "string"
+                "is_verified": {
+                    "type": "boolean"
                 },
-                "ride_offer_id": {
-                    "type": "string"
+                "phone_number": {
+                    "type": "string",
+                    "maxLength": 11,
+                    "minLength": 9
                 },
-                "ride_request_id": {
+                "user_id": {
                     "type": "string"
-                },
-                "rider_current_latitude": {
-                    "type": "number"
-                },
-                "rider_current_longitude": {
+                }
+            }
+        },
+        "schemas.MatchFactor": {
+            "type": "object",
+            "properties": {
+                "score": {
+                    "description": "Normalized score between 0 and 1 (1 is best)",
                     "type": "number"
                 },
-                "start_address": {
-                    "type": "string"
-                },
-                "start_latitude": {
+                "value": {
+                    "description": "Measured value of the factor",
                     "type": "number"
                 },
-                "start_longitude": {
+                "weight": {
+                    "description": "Weight of the factor in the final score",
                     "type": "number"
-                },
-                "start_time": {
-                    "type": "string"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "transaction": {
-                    "$ref": "#/definitions/schemas.TransactionDetail"
-                },
-                "user": {
-                    "$ref": "#/definitions/schemas.UserInfo"
-                },
-                "vehicle": {
-                    "$ref": "#/definitions/schemas.VehicleDetail"
-                },
-                "waypoints": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.Waypoint"
-                    }
                 }
             }
         },
-        "schemas.GeoCodeLocation": {
+        "schemas.MatchScore": {
             "type": "object",
             "properties": {
-                "distance": {
-                    "description": "Distance from the location (in kilometers) for which the geocode is performed",
-                    "type": "number"
+                "detour": {
+                    "description": "Extra distance (km) the driver travels",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MatchFactor"
+                        }
+                    ]
                 },
-                "formatted_address": {
-                    "type": "string"
+                "dropoff_distance": {
+                    "description": "Distance (km) from the dropoff to the offer route",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MatchFactor"
+                        }
+                    ]
                 },
-                "latitude": {
-                    "type": "number"
+                "pickup_distance": {
+                    "description": "Distance (km) from the pickup to the offer route",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MatchFactor"
+                        }
+                    ]
                 },
-                "longitude": {
-                    "type": "number"
+                "preferences": {
+                    "description": "Share of the soft preferences (no smoking, quiet ride) both users agree on",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MatchFactor"
+                        }
+                    ]
                 },
-                "main_address": {
-                    "description": "Main address of the location",
-                    "type": "string"
+                "rating": {
+                    "description": "Average rating of the suggested user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MatchFactor"
+                        }
+                    ]
                 },
-                "place_id": {
-                    "type": "string"
+                "score": {
+                    "description": "Weighted score between 0 and 1",
+                    "type": "number"
                 },
-                "secondary_address": {
-                    "type": "string"
-                }
-            }
-        },
-        "schemas.GeoCodeLocationResponse": {
-            "type": "object",
-            "properties": {
-                "results": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.GeoCodeLocation"
-                    }
-                }
-            }
-        },
-        "schemas.GeoCodeRequest": {
-            "type": "object",
-            "required": [
-                "current_location",
-                "point"
-            ],
-            "properties": {
-                "current_location": {
-                    "description": "Current location of the user",
+                "shared_route": {
+                    "description": "Fraction of the offer route shared with the hitcher",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/schemas.Point"
+                            "$ref": "#/definitions/schemas.MatchFactor"
                         }
                     ]
                 },
-                "point": {
-                    "description": "Point for which the geocode is performed",
+                "time_gap": {
+                    "description": "Gap (minutes) between the requested start and the estimated pickup",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/schemas.Point"
+                            "$ref": "#/definitions/schemas.MatchFactor"
                         }
                     ]
                 }
             }
         },
-        "schemas.GetAdminProfileResponse": {
-            "type": "object",
-            "properties": {
-                "admin_info": {
-                    "$ref": "#/definitions/schemas.AdminInfo"
-                }
-            }
-        },
-        "schemas.GetAllChatRoomsRequest": {
+        "schemas.MatchedSubstring": {
             "type": "object",
-            "required": [
-                "userID"
-            ],
             "properties": {
-                "userID": {
-                    "type": "string"
+                "length": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
                 }
             }
         },
-        "schemas.GetAllChatRoomsResponse": {
+        "schemas.MeetingPoint": {
             "type": "object",
             "properties": {
-                "chatRooms": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.ChatRoomResponse"
-                    }
+                "address": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "walking_distance": {
+                    "description": "Walking distance of the hitcher (in kilometers)",
+                    "type": "number"
                 }
             }
         },
-        "schemas.GetAllPendingRideResponse": {
+        "schemas.MeetingPoints": {
             "type": "object",
             "properties": {
-                "pending_ride_offer": {
-                    "description": "The pending ride offer of the user",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.RideOfferDetail"
-                    }
+                "dropoff": {
+                    "$ref": "#/definitions/schemas.MeetingPoint"
                 },
-                "pending_ride_request": {
-                    "description": "The pending ride request of the user",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.RideRequestDetail"
-                    }
+                "pickup": {
+                    "$ref": "#/definitions/schemas.MeetingPoint"
                 }
             }
         },
-        "schemas.GetChatMessagesRequest": {
+        "schemas.MessageResponse": {
             "type": "object",
-            "required": [
-                "chatRoomID"
-            ],
             "properties": {
-                "chatRoomID": {
+                "created_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "message_type": {
+                    "description": "text or image or call and missed_call",
+                    "type": "string"
+                },
+                "receiver_id": {
+                    "type": "string"
+                },
+                "sender_id": {
                     "type": "string"
                 }
             }
         },
-        "schemas.GetChatMessagesResponse": {
+        "schemas.MoMoIPN": {
             "type": "object",
             "properties": {
-                "messages": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.MessageResponse"
-                    }
+                "amount": {
+                    "type": "integer"
+                },
+                "callbackToken": {
+                    "type": "string"
+                },
+                "extraData": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "orderInfo": {
+                    "type": "string"
+                },
+                "orderType": {
+                    "type": "string"
+                },
+                "partnerClientId": {
+                    "type": "string"
+                },
+                "partnerCode": {
+                    "type": "string"
+                },
+                "payType": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "responseTime": {
+                    "type": "integer"
+                },
+                "resultCode": {
+                    "type": "integer"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "transId": {
+                    "type": "integer"
                 }
             }
         },
-        "schemas.GetUserProfileResponse": {
+        "schemas.PauseRecurringRideOfferRequest": {
             "type": "object",
             "required": [
-                "user"
+                "recurring_ride_offer_id"
             ],
             "properties": {
-                "user": {
-                    "$ref": "#/definitions/schemas.UserResponse"
+                "is_paused": {
+                    "description": "true to pause the series, false to resume it",
+                    "type": "boolean"
+                },
+                "recurring_ride_offer_id": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.GetVehicleResponse": {
+        "schemas.PlusCode": {
             "type": "object",
             "properties": {
-                "vehicle": {
-                    "description": "this is an array because a user can have multiple vehicles",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.VehicleDetail"
-                    }
+                "compound_code": {
+                    "type": "string"
+                },
+                "global_code": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.GetVehiclesResponse": {
+        "schemas.Point": {
             "type": "object",
             "properties": {
-                "vehicles": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.Vehicle"
-                    }
+                "lat": {
+                    "description": "Latitude",
+                    "type": "number"
+                },
+                "lng": {
+                    "description": "Longitude",
+                    "type": "number"
                 }
             }
         },
-        "schemas.GiveRideRequest": {
+        "schemas.Prediction": {
             "type": "object",
-            "required": [
-                "place_list",
-                "vehicle_id"
-            ],
             "properties": {
-                "place_list": {
-                    "description": "Points []Point ` + "`" + `json:\"points\" binding:\"required\"` + "`" + ` // List of points for the route",
+                "description": {
+                    "type": "string"
+                },
+                "display_type": {
+                    "type": "string"
+                },
+                "distance": {
+                    "description": "Distance from the location (in kilometers) for which the autocomplete is performed",
+                    "type": "number"
+                },
+                "favorite_location_id": {
+                    "description": "Set for the favorite locations of the user",
+                    "type": "string"
+                },
+                "has_children": {
+                    "type": "boolean"
+                },
+                "matched_substrings": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/schemas.MatchedSubstring"
                     }
                 },
-                "start_time": {
-                    "description": "Start time of the ride (if not provided, the ride is immediate)",
+                "place_id": {
                     "type": "string"
                 },
-                "vehicle_id": {
-                    "description": "Vehicle ID for the ride that user has registered",
+                "plus_code": {
+                    "$ref": "#/definitions/schemas.PlusCode"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "source": {
+                    "description": "favorite, recent or goong",
                     "type": "string"
+                },
+                "structured_formatting": {
+                    "$ref": "#/definitions/schemas.StructuredFormatting"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Term"
+                    }
                 }
             }
         },
-        "schemas.GiveRideResponse": {
+        "schemas.RatingDetail": {
             "type": "object",
             "properties": {
-                "distance": {
-                    "type": "number"
-                },
-                "duration": {
-                    "type": "integer"
+                "comment": {
+                    "type": "string"
                 },
-                "end_time": {
+                "created_at": {
                     "type": "string"
                 },
-                "fare": {
+                "rater": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "rating": {
                     "type": "number"
                 },
-                "ride_offer_id": {
+                "rating_id": {
                     "type": "string"
                 },
-                "route": {
-                    "$ref": "#/definitions/schemas.GoongDirectionsResponse"
-                },
-                "start_time": {
+                "ride_id": {
                     "type": "string"
                 },
-                "vehicle": {
-                    "$ref": "#/definitions/schemas.VehicleDetail"
-                },
-                "waypoints": {
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schemas.Waypoint"
+                        "type": "string"
                     }
                 }
             }
         },
-        "schemas.GoongAutoCompleteResponse": {
+        "schemas.RecurringRideOfferDetail": {
             "type": "object",
             "properties": {
-                "executed_time": {
-                    "type": "integer"
+                "days_of_week": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "executed_time_all": {
+                "departure_time": {
+                    "type": "string"
+                },
+                "end_address": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "is_paused": {
+                    "type": "boolean"
+                },
+                "recurring_ride_offer_id": {
+                    "type": "string"
+                },
+                "seats": {
                     "type": "integer"
                 },
-                "predictions": {
+                "skip_dates": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/schemas.Prediction"
+                        "type": "string"
                     }
                 },
-                "status": {
+                "start_address": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "until_date": {
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.RefreshTokenResponse": {
+            "type": "object",
+            "required": [
+                "access_token",
+                "refresh_token",
+                "user_id"
+            ],
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.RegisterDeviceTokenRequest": {
+            "type": "object",
+            "required": [
+                "device_token"
+            ],
+            "properties": {
+                "device_token": {
                     "type": "string"
                 }
             }
         },
-        "schemas.GoongDirectionsResponse": {
+        "schemas.RegisterUserRequest": {
             "type": "object",
+            "required": [
+                "full_name",
+                "phone_number"
+            ],
             "properties": {
-                "geocoded_waypoints": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "geocoder_status": {
-                                "type": "string"
-                            },
-                            "place_id": {
-                                "type": "string"
-                            }
-                        }
-                    }
+                "email": {
+                    "type": "string",
+                    "maxLength": 256
                 },
-                "routes": {
-                    "type": "array",
-                    "items": {
-                        "type": "object",
-                        "properties": {
-                            "bounds": {
-                                "description": "Empty struct as the bounds are empty in the example",
-                                "type": "object"
-                            },
-                            "legs": {
-                                "type": "array",
-                                "items": {
-                                    "type": "object",
-                                    "properties": {
-                                        "distance": {
-                                            "type": "object",
-                                            "properties": {
-                                                "text": {
-                                                    "type": "string"
-                                                },
-                                                "value": {
-                                                    "type": "integer"
-                                                }
-                                            }
-                                        },
-                                        "duration": {
-                                            "type": "object",
-                                            "properties": {
-                                                "text": {
-                                                    "type": "string"
-                                                },
-                                                "value": {
-                                                    "type": "integer"
-                                                }
-                                            }
-                                        },
-                                        "end_address": {
-                                            "type": "string"
-                                        },
-                                        "end_location": {
-                                            "type": "object",
-                                            "properties": {
-                                                "lat": {
-                                                    "type": "number"
-                                                },
-                                                "lng": {
-                                                    "type": "number"
-                                                }
-                                            }
-                                        },
-                                        "start_address": {
-                                            "type": "string"
-                                        },
-                                        "start_location": {
-                                            "type": "object",
-                                            "properties": {
-                                                "lat": {
-                                                    "type": "number"
-                                                },
-                                                "lng": {
-                                                    "type": "number"
-                                                }
-                                            }
-                                        },
-                                        "steps": {
-                                            "type": "array",
-                                            "items": {
-                                                "type": "object",
-                                                "properties": {
-                                                    "distance": {
-                                                        "type": "object",
-                                                        "properties": {
-                                                            "text": {
-                                                                "type": "string"
-                                                            },
-                                                            "value": {
-                                                                "type": "integer"
-                                                            }
-                                                        }
-                                                    },
-                                                    "duration": {
-                                                        "type": "object",
-                                                        "properties": {
-                                                            "text": {
-                                                                "type": "string"
-                                                            },
-                                                            "value": {
-                                                                "type": "integer"
-                                                            }
-                                                        }
-                                                    },
-                                                    "end_location": {
-                                                        "type": "object",
-                                                        "properties": {
-                                                            "lat": {
-                                                                "type": "number"
-                                                            },
-                                                            "lng": {
-                                                                "type": "number"
-                                                            }
-                                                        }
-                                                    },
-                                                    "html_instructions": {
-                                                        "type": "string"
-                                                    },
-                                                    "maneuver": {
-                                                        "type": "string"
-                                                    },
-                                                    "polyline": {
-                                                        "type": "object",
-                                                        "properties": {
-                                                            "points": {
-                                                                "type": "string"
-                                                            }
-                                                        }
-                                                    },
-                                                    "start_location": {
-                                                        "type": "object",
-                                                        "properties": {
-                                                            "lat": {
-                                                                "type": "number"
-                                                            },
-                                                            "lng": {
-                                                                "type": "number"
-                                                            }
-                                                        }
-                                                    },
-                                                    "travel_mode": {
-                                                        "type": "string"
-                                                    }
-                                                }
-                                            }
-                                        }
-                                    }
-                                }
-                            },
-                            "overview_polyline": {
-                                "type": "object",
-                                "properties": {
-                                    "points": {
-                                        "type": "string"
-                                    }
-                                }
-                            },
-                            "summary": {
-                                "type": "string"
-                            },
-                            "warnings": {
-                                "type": "array",
-                                "items": {
-                                    "type": "string"
-                                }
-                            },
-                            "waypoint_order": {
-                                "type": "array",
-                                "items": {
-                                    "type": "integer"
-                                }
-                            }
-                        }
-                    }
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 3
+                },
+                "phone_number": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.HitchRideRequest": {
+        "schemas.RegisterUserResponse": {
             "type": "object",
             "required": [
-                "place_list",
-                "weight"
+                "full_name",
+                "is_activated",
+                "is_verified",
+                "phone_number",
+                "user_id"
             ],
             "properties": {
-                "place_list": {
-                    "description": "Points []Point ` + "`" + `json:\"points\" binding:\"required\"` + "`" + ` // List of points for the route",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 3
                 },
-                "start_time": {
-                    "description": "Start time of the ride (if not provided, the ride is immediate)",
+                "is_activated": {
+                    "type": "boolean"
+                },
+                "is_verified": {
+                    "type": "boolean"
+                },
+                "phone_number": {
                     "type": "string"
                 },
-                "weight": {
-                    "description": "Weight of the rider to consider",
-                    "type": "integer"
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.HitchRideResponse": {
+        "schemas.RegisterVehicleRequest": {
             "type": "object",
+            "required": [
+                "ca_vet",
+                "license_plate",
+                "user_id",
+                "vehicle_id"
+            ],
             "properties": {
-                "distance": {
-                    "type": "number"
+                "ca_vet": {
+                    "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
+                "fuel_type": {
+                    "description": "Fuel the vehicle is filled with (defaults to the fuel of the vehicle type, ignored for electric vehicles)",
+                    "type": "string",
+                    "enum": [
+                        "ron95",
+                        "e5_ron92",
+                        "diesel"
+                    ]
                 },
-                "end_time": {
+                "license_plate": {
                     "type": "string"
                 },
-                "ride_request_id": {
-                    "type": "string"
+                "seat_capacity": {
+                    "description": "Number of passenger seats (defaults to 1)",
+                    "type": "integer",
+                    "maximum": 8,
+                    "minimum": 1
                 },
-                "route": {
-                    "$ref": "#/definitions/schemas.GoongDirectionsResponse"
+                "user_id": {
+                    "type": "string"
                 },
-                "start_time": {
+                "vehicle_id": {
+                    "description": "this id from the vehicle_type table in the database",
                     "type": "string"
                 }
             }
         },
-        "schemas.InitRegisterRequest": {
+        "schemas.ReorderFavoriteLocationsRequest": {
             "type": "object",
             "required": [
-                "phone_number"
+                "favorite_location_ids"
             ],
             "properties": {
-                "phone_number": {
-                    "type": "string"
+                "favorite_location_ids": {
+                    "description": "All the favorite locations of the user in the new order",
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "schemas.InitRegisterResponse": {
+        "schemas.ReportUserRequest": {
             "type": "object",
             "required": [
-                "is_activated",
-                "is_verified",
-                "phone_number"
+                "category",
+                "description",
+                "user_id"
             ],
             "properties": {
-                "is_activated": {
-                    "type": "boolean"
-                },
-                "is_verified": {
-                    "type": "boolean"
-                },
-                "phone_number": {
-                    "type": "string"
-                }
-            }
-        },
-        "schemas.InitiateCallResponse": {
-            "type": "object",
-            "properties": {
-                "call_id": {
-                    "description": "the call id is the message id of the chat message",
-                    "type": "string"
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "harassment",
+                        "inappropriate_behavior",
+                        "unsafe_driving",
+                        "fraud",
+                        "no_show",
+                        "other"
+                    ]
                 },
-                "caller_id": {
+                "chat_id": {
+                    "description": "Message exchanged with the reported user",
                     "type": "string"
                 },
-                "chatroom_id": {
-                    "type": "string"
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
                 },
-                "receiver_id": {
+                "ride_id": {
+                    "description": "Ride shared with the reported user",
                     "type": "string"
                 },
-                "token": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "schemas.LinkMomoRequest": {
+        "schemas.ResendOTPRequest": {
             "type": "object",
             "required": [
-                "walletPhoneNumber"
+                "phone_number"
             ],
             "properties": {
-                "walletPhoneNumber": {
-                    "description": "Different from phoneNumber that is used for login\nThis is the phone number that is registered with momo wallet",
-                    "type": "string",
-                    "maxLength": 10,
-                    "minLength": 10
+                "phone_number": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.LinkMomoWalletResponse": {
+        "schemas.ResolveSOSEventRequest": {
             "type": "object",
+            "required": [
+                "note",
+                "sos_event_id"
+            ],
             "properties": {
-                "deeplink": {
-                    "description": "send this to fe flutter app for open momo and perform linked",
+                "note": {
+                    "description": "How the emergency was handled, kept for the audit",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "sos_event_id": {
                     "type": "string"
                 }
             }
         },
-        "schemas.LoginAdminRequest": {
+        "schemas.RevokeTripShareRequest": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "trip_share_id"
             ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 6
-                },
-                "username": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 1
+                "trip_share_id": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.LoginAdminResponse": {
+        "schemas.RideETAUpdate": {
             "type": "object",
             "properties": {
-                "admin_info": {
-                    "$ref": "#/definitions/schemas.AdminInfo"
+                "driver_location": {
+                    "$ref": "#/definitions/schemas.Point"
                 },
-                "token": {
+                "dropoff_distance": {
+                    "description": "in kilometers",
+                    "type": "number"
+                },
+                "dropoff_duration": {
+                    "description": "in seconds",
+                    "type": "integer"
+                },
+                "dropoff_eta": {
+                    "type": "string"
+                },
+                "off_route": {
+                    "description": "The driver has left the planned route",
+                    "type": "boolean"
+                },
+                "pickup_distance": {
+                    "description": "in kilometers, only before the pickup",
+                    "type": "number"
+                },
+                "pickup_duration": {
+                    "description": "in seconds, only before the pickup",
+                    "type": "integer"
+                },
+                "pickup_eta": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "route when estimated along the planned route, provider when asked to Goong",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "schemas.LoginRequest": {
+        "schemas.RideHistoryItem": {
             "type": "object",
-            "required": [
-                "phone_number"
-            ],
             "properties": {
-                "phone_number": {
+                "counterpart": {
+                    "description": "The passenger for the driver, the driver for the passenger",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.UserInfo"
+                        }
+                    ]
+                },
+                "distance": {
+                    "description": "Distance shared with the passenger in kilometers",
+                    "type": "number"
+                },
+                "dropoff_address": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "in seconds",
+                    "type": "integer"
+                },
+                "end_address": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "fare": {
+                    "type": "number"
+                },
+                "pickup_address": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "driver or passenger",
+                    "type": "string"
+                },
+                "start_address": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
+                },
+                "transaction": {
+                    "$ref": "#/definitions/schemas.TransactionDetail"
+                },
+                "vehicle": {
+                    "$ref": "#/definitions/schemas.VehicleDetail"
                 }
             }
         },
-        "schemas.LoginResponse": {
+        "schemas.RideHistoryMonth": {
             "type": "object",
-            "required": [
-                "is_activated",
-                "is_verified",
-                "phone_number",
-                "user_id"
-            ],
             "properties": {
-                "is_activated": {
-                    "type": "boolean"
+                "earned": {
+                    "description": "Completed payments received as the driver",
+                    "type": "number"
                 },
-                "is_verified": {
-                    "type": "boolean"
+                "km_shared": {
+                    "description": "Kilometers travelled with the other party",
+                    "type": "number"
                 },
-                "phone_number": {
+                "month": {
+                    "description": "YYYY-MM",
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "spent": {
+                    "description": "Completed payments made as the passenger",
+                    "type": "number"
+                },
+                "trips": {
+                    "type": "integer"
+                },
+                "trips_as_driver": {
+                    "type": "integer"
+                },
+                "trips_as_passenger": {
+                    "type": "integer"
                 }
             }
         },
-        "schemas.LoginWithOAuthRequest": {
+        "schemas.RideOfferDetail": {
             "type": "object",
-            "required": [
-                "email"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "maxLength": 256,
-                    "minLength": 3
+                "available_seats": {
+                    "type": "integer"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "driver_current_latitude": {
+                    "type": "number"
+                },
+                "driver_current_longitude": {
+                    "type": "number"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "encoded_polyline": {
+                    "type": "string"
+                },
+                "end_address": {
+                    "type": "string"
+                },
+                "end_latitude": {
+                    "type": "number"
+                },
+                "end_longitude": {
+                    "type": "number"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "fare": {
+                    "type": "number"
+                },
+                "match_score": {
+                    "description": "Only set on suggestions",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MatchScore"
+                        }
+                    ]
+                },
+                "preferences": {
+                    "$ref": "#/definitions/schemas.RidePreferences"
+                },
+                "proximity": {
+                    "description": "Only set when browsing",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.RouteProximity"
+                        }
+                    ]
+                },
+                "ride_offer_id": {
+                    "type": "string"
+                },
+                "start_address": {
+                    "type": "string"
+                },
+                "start_latitude": {
+                    "type": "number"
+                },
+                "start_longitude": {
+                    "type": "number"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_seats": {
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "vehicle": {
+                    "$ref": "#/definitions/schemas.VehicleDetail"
+                },
+                "waypoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Waypoint"
+                    }
                 }
             }
         },
-        "schemas.LoginWithOAuthResponse": {
+        "schemas.RidePreferences": {
             "type": "object",
-            "required": [
-                "full_name",
-                "is_activated",
-                "is_verified",
-                "phone_number",
-                "user_id"
-            ],
             "properties": {
-                "full_name": {
+                "luggage_size": {
+                    "description": "Largest luggage accepted by the driver, or carried by the hitcher (default: small)",
                     "type": "string",
-                    "maxLength": 256,
-                    "minLength": 3
+                    "enum": [
+                        "none",
+                        "small",
+                        "medium",
+                        "large"
+                    ]
                 },
-                "is_activated": {
+                "no_smoking": {
+                    "description": "Prefers a no-smoking ride",
                     "type": "boolean"
                 },
-                "is_verified": {
+                "pets_allowed": {
+                    "description": "Driver accepts pets, or hitcher travels with a pet",
                     "type": "boolean"
                 },
-                "phone_number": {
-                    "type": "string",
-                    "maxLength": 11,
-                    "minLength": 9
+                "quiet_ride": {
+                    "description": "Prefers a quiet ride",
+                    "type": "boolean"
                 },
-                "user_id": {
-                    "type": "string"
+                "same_gender": {
+                    "description": "Only ride with users of the same gender",
+                    "type": "boolean"
                 }
             }
         },
-        "schemas.MatchedSubstring": {
+        "schemas.RideReceipt": {
             "type": "object",
             "properties": {
-                "length": {
-                    "type": "integer"
+                "amount_paid": {
+                    "type": "number"
                 },
-                "offset": {
-                    "type": "integer"
-                }
-            }
-        },
-        "schemas.MessageResponse": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
+                "distance": {
+                    "description": "in kilometers",
+                    "type": "number"
                 },
-                "message": {
+                "driver_name": {
                     "type": "string"
                 },
-                "message_id": {
+                "dropoff_address": {
                     "type": "string"
                 },
-                "message_type": {
-                    "description": "text or image or call and missed_call",
-                    "type": "string"
+                "duration": {
+                    "description": "in seconds",
+                    "type": "integer"
                 },
-                "receiver_id": {
+                "end_address": {
                     "type": "string"
                 },
-                "sender_id": {
+                "end_time": {
                     "type": "string"
-                }
-            }
-        },
-        "schemas.MoMoIPN": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "integer"
                 },
-                "callbackToken": {
-                    "type": "string"
+                "fare": {
+                    "type": "number"
                 },
-                "extraData": {
+                "fare_breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.FareComponent"
+                    }
+                },
+                "fare_strategy": {
                     "type": "string"
                 },
-                "message": {
+                "issued_at": {
+                    "description": "End of the ride",
                     "type": "string"
                 },
-                "orderId": {
+                "lang": {
                     "type": "string"
                 },
-                "orderInfo": {
+                "license_plate": {
                     "type": "string"
                 },
-                "orderType": {
+                "momo_trans_id": {
+                    "type": "integer"
+                },
+                "passenger_name": {
                     "type": "string"
                 },
-                "partnerClientId": {
+                "payment_method": {
+                    "description": "cash or momo",
                     "type": "string"
                 },
-                "partnerCode": {
+                "payment_status": {
                     "type": "string"
                 },
-                "payType": {
+                "pickup_address": {
                     "type": "string"
                 },
-                "requestId": {
+                "receipt_number": {
                     "type": "string"
                 },
-                "responseTime": {
-                    "type": "integer"
+                "ride_id": {
+                    "type": "string"
                 },
-                "resultCode": {
-                    "type": "integer"
+                "route": {
+                    "description": "Decoded polyline of the ride",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.Point"
+                    }
                 },
-                "signature": {
+                "start_address": {
                     "type": "string"
                 },
-                "transId": {
-                    "type": "integer"
-                }
-            }
-        },
-        "schemas.PlusCode": {
-            "type": "object",
-            "properties": {
-                "compound_code": {
+                "start_time": {
                     "type": "string"
                 },
-                "global_code": {
+                "vehicle_name": {
                     "type": "string"
                 }
             }
         },
-        "schemas.Point": {
+        "schemas.RideRequestDetail": {
             "type": "object",
             "properties": {
-                "lat": {
-                    "description": "Latitude",
+                "distance": {
                     "type": "number"
                 },
-                "lng": {
-                    "description": "Longitude",
-                    "type": "number"
-                }
-            }
-        },
-        "schemas.Prediction": {
-            "type": "object",
-            "properties": {
-                "description": {
+                "duration": {
+                    "type": "integer"
+                },
+                "encoded_polyline": {
                     "type": "string"
                 },
-                "display_type": {
+                "end_address": {
                     "type": "string"
                 },
-                "distance": {
-                    "description": "Distance from the location (in kilometers) for which the autocomplete is performed",
+                "end_latitude": {
                     "type": "number"
                 },
-                "has_children": {
-                    "type": "boolean"
-                },
-                "matched_substrings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.MatchedSubstring"
-                    }
+                "end_longitude": {
+                    "type": "number"
                 },
-                "place_id": {
+                "end_time": {
                     "type": "string"
                 },
-                "plus_code": {
-                    "$ref": "#/definitions/schemas.PlusCode"
+                "match_score": {
+                    "description": "Only set on suggestions",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.MatchScore"
+                        }
+                    ]
                 },
-                "reference": {
+                "preferences": {
+                    "$ref": "#/definitions/schemas.RidePreferences"
+                },
+                "ride_request_id": {
                     "type": "string"
                 },
-                "score": {
+                "rider_current_latitude": {
                     "type": "number"
                 },
-                "structured_formatting": {
-                    "$ref": "#/definitions/schemas.StructuredFormatting"
-                },
-                "terms": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.Term"
-                    }
-                }
-            }
-        },
-        "schemas.RefreshTokenResponse": {
-            "type": "object",
-            "required": [
-                "access_token",
-                "refresh_token",
-                "user_id"
-            ],
-            "properties": {
-                "access_token": {
-                    "type": "string"
+                "rider_current_longitude": {
+                    "type": "number"
                 },
-                "refresh_token": {
+                "start_address": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "schemas.RegisterDeviceTokenRequest": {
-            "type": "object",
-            "required": [
-                "device_token"
-            ],
-            "properties": {
-                "device_token": {
-                    "type": "string"
-                }
-            }
-        },
-        "schemas.RegisterUserRequest": {
-            "type": "object",
-            "required": [
-                "full_name",
-                "phone_number"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "maxLength": 256
+                "start_latitude": {
+                    "type": "number"
                 },
-                "full_name": {
-                    "type": "string",
-                    "maxLength": 256,
-                    "minLength": 3
+                "start_longitude": {
+                    "type": "number"
                 },
-                "phone_number": {
+                "start_time": {
                     "type": "string"
-                }
-            }
-        },
-        "schemas.RegisterUserResponse": {
-            "type": "object",
-            "required": [
-                "full_name",
-                "is_activated",
-                "is_verified",
-                "phone_number",
-                "user_id"
-            ],
-            "properties": {
-                "full_name": {
-                    "type": "string",
-                    "maxLength": 256,
-                    "minLength": 3
-                },
-                "is_activated": {
-                    "type": "boolean"
                 },
-                "is_verified": {
-                    "type": "boolean"
-                },
-                "phone_number": {
+                "status": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "user": {
+                    "$ref": "#/definitions/schemas.UserInfo"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "schemas.RegisterVehicleRequest": {
+        "schemas.RideTrailFeature": {
             "type": "object",
-            "required": [
-                "ca_vet",
-                "license_plate",
-                "user_id",
-                "vehicle_id"
-            ],
             "properties": {
-                "ca_vet": {
-                    "type": "string"
-                },
-                "license_plate": {
-                    "type": "string"
+                "geometry": {
+                    "$ref": "#/definitions/schemas.RideTrailGeometry"
                 },
-                "user_id": {
-                    "type": "string"
+                "properties": {
+                    "$ref": "#/definitions/schemas.RideTrailFeatureProperties"
                 },
-                "vehicle_id": {
-                    "description": "this id from the vehicle_type table in the database",
+                "type": {
+                    "description": "Always Feature",
                     "type": "string"
                 }
             }
         },
-        "schemas.ResendOTPRequest": {
+        "schemas.RideTrailFeatureCollection": {
             "type": "object",
-            "required": [
-                "phone_number"
-            ],
             "properties": {
-                "phone_number": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RideTrailFeature"
+                    }
+                },
+                "type": {
+                    "description": "Always FeatureCollection",
                     "type": "string"
                 }
             }
         },
-        "schemas.RideOfferDetail": {
+        "schemas.RideTrailFeatureProperties": {
             "type": "object",
             "properties": {
-                "distance": {
-                    "type": "number"
+                "accuracies": {
+                    "description": "in meters",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
                 },
-                "driver_current_latitude": {
-                    "type": "number"
+                "coordinate_times": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "driver_current_longitude": {
+                "distance": {
+                    "description": "Driven distance in kilometers",
                     "type": "number"
                 },
-                "duration": {
-                    "type": "integer"
-                },
-                "encoded_polyline": {
+                "end_time": {
                     "type": "string"
                 },
-                "end_address": {
+                "headings": {
+                    "description": "in degrees",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "ride_id": {
                     "type": "string"
                 },
-                "end_latitude": {
-                    "type": "number"
+                "speeds": {
+                    "description": "in meters per second",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
                 },
-                "end_longitude": {
-                    "type": "number"
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.RideTrailGeometry": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "description": "[longitude, latitude] of each sample",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
                 },
-                "end_time": {
+                "type": {
+                    "description": "Always LineString",
                     "type": "string"
+                }
+            }
+        },
+        "schemas.RouteAlertDetail": {
+            "type": "object",
+            "properties": {
+                "days_of_week": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "fare": {
-                    "type": "number"
+                "destination": {
+                    "$ref": "#/definitions/schemas.Point"
                 },
-                "ride_offer_id": {
+                "destination_address": {
                     "type": "string"
                 },
-                "start_address": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "last_notified_at": {
                     "type": "string"
                 },
-                "start_latitude": {
-                    "type": "number"
+                "name": {
+                    "type": "string"
                 },
-                "start_longitude": {
-                    "type": "number"
+                "origin": {
+                    "$ref": "#/definitions/schemas.Point"
                 },
-                "start_time": {
+                "origin_address": {
                     "type": "string"
                 },
-                "status": {
+                "route_alert_id": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/schemas.UserInfo"
-                },
-                "vehicle": {
-                    "$ref": "#/definitions/schemas.VehicleDetail"
+                "window_end": {
+                    "type": "string"
                 },
-                "waypoints": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.Waypoint"
-                    }
+                "window_start": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.RideRequestDetail": {
+        "schemas.RouteProximity": {
             "type": "object",
             "properties": {
-                "distance": {
+                "dropoff_distance": {
+                    "description": "Distance (km) from the destination to the closest point of the route after the pickup",
                     "type": "number"
                 },
-                "duration": {
-                    "type": "integer"
-                },
-                "encoded_polyline": {
+                "pickup_distance": {
+                    "description": "Distance (km) from the origin to the closest point of the route",
+                    "type": "number"
+                }
+            }
+        },
+        "schemas.SOSEventDetail": {
+            "type": "object",
+            "properties": {
+                "acknowledged_at": {
                     "type": "string"
                 },
-                "end_address": {
+                "acknowledged_by": {
                     "type": "string"
                 },
-                "end_latitude": {
-                    "type": "number"
-                },
-                "end_longitude": {
-                    "type": "number"
+                "contacts_alerted": {
+                    "type": "integer"
                 },
-                "end_time": {
+                "created_at": {
                     "type": "string"
                 },
-                "ride_request_id": {
+                "location": {
+                    "$ref": "#/definitions/schemas.Point"
+                },
+                "message": {
                     "type": "string"
                 },
-                "rider_current_latitude": {
-                    "type": "number"
+                "resolution_note": {
+                    "type": "string"
                 },
-                "rider_current_longitude": {
-                    "type": "number"
+                "resolved_at": {
+                    "type": "string"
                 },
-                "start_address": {
+                "resolved_by": {
                     "type": "string"
                 },
-                "start_latitude": {
-                    "type": "number"
+                "ride_id": {
+                    "type": "string"
                 },
-                "start_longitude": {
-                    "type": "number"
+                "ride_snapshot": {
+                    "type": "object"
                 },
-                "start_time": {
+                "sos_event_id": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/schemas.UserInfo"
+                "user_full_name": {
+                    "type": "string"
                 },
-                "weight": {
-                    "type": "integer"
+                "user_id": {
+                    "type": "string"
+                },
+                "user_phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.SOSEventRequest": {
+            "type": "object",
+            "required": [
+                "sos_event_id"
+            ],
+            "properties": {
+                "sos_event_id": {
+                    "type": "string"
                 }
             }
         },
@@ -4576,6 +9736,102 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.ShareTripRequest": {
+            "type": "object",
+            "required": [
+                "ride_id"
+            ],
+            "properties": {
+                "expires_in": {
+                    "description": "in minutes, TRIP_SHARE_TTL by default and at most TRIP_SHARE_MAX_TTL",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "ride_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.ShareTripResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "trip_share_id": {
+                    "type": "string"
+                },
+                "trip_share_url": {
+                    "description": "Public link, the token cannot be retrieved again",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.SharedTripResponse": {
+            "type": "object",
+            "properties": {
+                "driver_location": {
+                    "$ref": "#/definitions/schemas.Point"
+                },
+                "encoded_polyline": {
+                    "description": "Planned route of the driver",
+                    "type": "string"
+                },
+                "end_address": {
+                    "type": "string"
+                },
+                "eta": {
+                    "description": "Remaining distance and time to the pickup and the dropoff",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.RideETAUpdate"
+                        }
+                    ]
+                },
+                "expires_at": {
+                    "description": "When the link stops working",
+                    "type": "string"
+                },
+                "license_plate": {
+                    "type": "string"
+                },
+                "location_at": {
+                    "description": "When the driver location was reported",
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "start_address": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "vehicle_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.SkipRecurringRideOccurrenceRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "recurring_ride_offer_id"
+            ],
+            "properties": {
+                "date": {
+                    "description": "Day of the occurrence to cancel",
+                    "type": "string"
+                },
+                "recurring_ride_offer_id": {
+                    "type": "string"
+                }
+            }
+        },
         "schemas.StartRideRequest": {
             "type": "object",
             "required": [
@@ -4591,6 +9847,11 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "pickupCode": {
+                    "description": "PIN or QR payload shown by the hitcher, optional in ride test mode",
+                    "type": "string",
+                    "maxLength": 256
+                },
                 "rideID": {
                     "description": "Ride ID of the ride to start",
                     "type": "string"
@@ -4633,6 +9894,13 @@ const docTemplate = `{
                 "fare": {
                     "type": "number"
                 },
+                "passenger_verification": {
+                    "description": "pin, qr or test_mode",
+                    "type": "string"
+                },
+                "passenger_verified_at": {
+                    "type": "string"
+                },
                 "receiver_id": {
                     "type": "string"
                 },
@@ -4729,41 +9997,110 @@ const docTemplate = `{
                 }
             }
         },
-        "schemas.SuggestRideRequestResponse": {
+        "schemas.SuggestRideRequestResponse": {
+            "type": "object",
+            "properties": {
+                "ride_requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/schemas.RideRequestDetail"
+                    }
+                }
+            }
+        },
+        "schemas.Term": {
+            "type": "object",
+            "properties": {
+                "offset": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.TransactionDetail": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.TriggerSOSRequest": {
             "type": "object",
+            "required": [
+                "ride_id"
+            ],
             "properties": {
-                "ride_requests": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/schemas.RideRequestDetail"
-                    }
+                "current_location": {
+                    "description": "Location of the user when raising the SOS",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                },
+                "message": {
+                    "description": "What happens, if the user can tell",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "ride_id": {
+                    "type": "string"
                 }
             }
         },
-        "schemas.Term": {
+        "schemas.TriggerSOSResponse": {
             "type": "object",
             "properties": {
-                "offset": {
+                "contacts_alerted": {
                     "type": "integer"
                 },
-                "value": {
+                "sos_event_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "trip_share_expiry": {
+                    "type": "string"
+                },
+                "trip_share_url": {
+                    "description": "Public link to the live trip sent to the emergency contacts",
                     "type": "string"
                 }
             }
         },
-        "schemas.TransactionDetail": {
+        "schemas.TripShareDetail": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "created_at": {
+                    "type": "string"
                 },
-                "payment_method": {
+                "expires_at": {
                     "type": "string"
                 },
-                "status": {
+                "is_sos": {
+                    "description": "Sent to the emergency contacts when an SOS was raised",
+                    "type": "boolean"
+                },
+                "revoked_at": {
                     "type": "string"
                 },
-                "transaction_id": {
+                "ride_id": {
+                    "type": "string"
+                },
+                "trip_share_id": {
                     "type": "string"
                 }
             }
@@ -4842,6 +10179,90 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.UpdateFavoriteLocationRequest": {
+            "type": "object",
+            "required": [
+                "favorite_location_id"
+            ],
+            "properties": {
+                "address": {
+                    "description": "New address of the location",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "favorite_location_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "New name of the location",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "place_id": {
+                    "description": "New place ID from goong api",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.UpdateRecurringRideOfferRequest": {
+            "type": "object",
+            "required": [
+                "recurring_ride_offer_id"
+            ],
+            "properties": {
+                "days_of_week": {
+                    "description": "New days of the week for the weekly frequency",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "departure_time": {
+                    "description": "New time of day of the departure (HH:MM in local time)",
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "New frequency",
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekdays",
+                        "weekly"
+                    ]
+                },
+                "place_list": {
+                    "description": "New route (place_id) from goong api",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "recurring_ride_offer_id": {
+                    "type": "string"
+                },
+                "seats": {
+                    "description": "New number of seats offered",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "skip_dates": {
+                    "description": "Replaces the days on which the ride is not offered",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "until_date": {
+                    "description": "New last day of the series, empty string removes the end",
+                    "type": "string"
+                },
+                "vehicle_id": {
+                    "description": "New vehicle for the ride",
+                    "type": "string"
+                }
+            }
+        },
         "schemas.UpdateRideLocationRequest": {
             "type": "object",
             "required": [
@@ -4849,6 +10270,11 @@ const docTemplate = `{
                 "rideID"
             ],
             "properties": {
+                "accuracy": {
+                    "description": "Accuracy of the location in meters, as reported by the device",
+                    "type": "number",
+                    "minimum": 0
+                },
                 "currentLocation": {
                     "description": "Current driver location",
                     "allOf": [
@@ -4857,10 +10283,25 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "heading": {
+                    "description": "Heading in degrees clockwise from the north, as reported by the device",
+                    "type": "number",
+                    "maximum": 360,
+                    "minimum": 0
+                },
+                "recordedAt": {
+                    "description": "When the location was recorded by the device, the time of the request when empty",
+                    "type": "string"
+                },
                 "rideID": {
                     "description": "Ride ID of the ride to update location",
                     "type": "string"
                 },
+                "speed": {
+                    "description": "Speed in meters per second, as reported by the device",
+                    "type": "number",
+                    "minimum": 0
+                },
                 "vehicleID": {
                     "type": "string"
                 }
@@ -4949,6 +10390,114 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.UpdateRidePreferencesResponse": {
+            "type": "object",
+            "required": [
+                "user"
+            ],
+            "properties": {
+                "user": {
+                    "$ref": "#/definitions/schemas.UserResponse"
+                }
+            }
+        },
+        "schemas.UpdateRiderLocationRequest": {
+            "type": "object",
+            "required": [
+                "currentLocation",
+                "rideID"
+            ],
+            "properties": {
+                "currentLocation": {
+                    "description": "Current passenger location",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                },
+                "rideID": {
+                    "description": "Ride ID of the ride to update the location of the passenger",
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.UpdateRiderLocationResponse": {
+            "type": "object",
+            "properties": {
+                "ride_id": {
+                    "type": "string"
+                },
+                "rider_current_latitude": {
+                    "type": "number"
+                },
+                "rider_current_longitude": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.UpdateRouteAlertRequest": {
+            "type": "object",
+            "required": [
+                "route_alert_id"
+            ],
+            "properties": {
+                "days_of_week": {
+                    "description": "New days of the week of the departure",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "destination": {
+                    "description": "New destination",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                },
+                "destination_address": {
+                    "description": "New address of the destination",
+                    "type": "string"
+                },
+                "is_active": {
+                    "description": "false to stop the notifications without deleting the alert",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "New name of the alert",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "origin": {
+                    "description": "New origin",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.Point"
+                        }
+                    ]
+                },
+                "origin_address": {
+                    "description": "New address of the origin",
+                    "type": "string"
+                },
+                "route_alert_id": {
+                    "type": "string"
+                },
+                "window_end": {
+                    "description": "New latest departure time of the day",
+                    "type": "string"
+                },
+                "window_start": {
+                    "description": "New earliest departure time of the day",
+                    "type": "string"
+                }
+            }
+        },
         "schemas.UpdateUserProfileRequest": {
             "type": "object",
             "required": [
@@ -4991,6 +10540,9 @@ const docTemplate = `{
                 "avatar_url": {
                     "type": "string"
                 },
+                "average_rating": {
+                    "type": "number"
+                },
                 "full_name": {
                     "type": "string"
                 },
@@ -5003,11 +10555,57 @@ const docTemplate = `{
                 "phone_number": {
                     "type": "string"
                 },
+                "rating_count": {
+                    "type": "integer"
+                },
                 "user_id": {
                     "type": "string"
                 }
             }
         },
+        "schemas.UserReportDetail": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "chat_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "report_id": {
+                    "type": "string"
+                },
+                "reported_id": {
+                    "type": "string"
+                },
+                "reporter_id": {
+                    "type": "string"
+                },
+                "ride_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "schemas.UserReportRequest": {
+            "type": "object",
+            "required": [
+                "report_id"
+            ],
+            "properties": {
+                "report_id": {
+                    "type": "string"
+                }
+            }
+        },
         "schemas.UserResponse": {
             "type": "object",
             "required": [
@@ -5017,6 +10615,9 @@ const docTemplate = `{
                 "avatar_url": {
                     "type": "string"
                 },
+                "average_rating": {
+                    "type": "number"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -5044,6 +10645,17 @@ const docTemplate = `{
                 "phone_number": {
                     "type": "string"
                 },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "ride_preferences": {
+                    "description": "Defaults for the new ride offers and ride requests",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/schemas.RidePreferences"
+                        }
+                    ]
+                },
                 "role": {
                     "type": "string"
                 },
@@ -5059,8 +10671,13 @@ const docTemplate = `{
             ],
             "properties": {
                 "fuel_consumed": {
+                    "description": "liters (kWh for electric vehicles) per 100 kilometers",
                     "type": "number"
                 },
+                "fuel_type": {
+                    "description": "ron95, e5_ron92, diesel or electric",
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
@@ -5078,12 +10695,18 @@ const docTemplate = `{
                 "fuel_consumed": {
                     "type": "number"
                 },
+                "fuel_type": {
+                    "type": "string"
+                },
                 "license_plate": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
+                "seat_capacity": {
+                    "type": "integer"
+                },
                 "vehicle_id": {
                     "type": "string"
                 }
@@ -5108,6 +10731,23 @@ const docTemplate = `{
                 }
             }
         },
+        "schemas.VerifyEmergencyContactRequest": {
+            "type": "object",
+            "required": [
+                "emergency_contact_id",
+                "otp"
+            ],
+            "properties": {
+                "emergency_contact_id": {
+                    "type": "string"
+                },
+                "otp": {
+                    "type": "string",
+                    "maxLength": 6,
+                    "minLength": 6
+                }
+            }
+        },
         "schemas.VerifyLoginOTPRequest": {
             "type": "object",
             "required": [
@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"shareway/infra/db/migration"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBrowseCursor is returned when a cursor was not issued by EncodeBrowseCursor for the same sort
+var ErrInvalidBrowseCursor = errors.New("invalid browse cursor")
+
+// EncodeBrowseCursor builds the opaque cursor pointing after the given ride offer of the browsed ones.
+// The ride offers are ordered by the sort value (fare or available seats, unused when sorted by start time)
+// then by start time and ID so the cursor is unique
+func EncodeBrowseCursor(sortBy string, sortValue float64, startTime time.Time, rideOfferID uuid.UUID) string {
+	raw := sortBy + "|" + strconv.FormatFloat(sortValue, 'g', -1, 64) + "|" + startTime.UTC().Format(time.RFC3339Nano) + "|" + rideOfferID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeBrowseCursor reads back the sort value, the start time and the ID of the ride offer a cursor points after,
+// the cursor must have been issued for the same sort
+func DecodeBrowseCursor(cursor string, sortBy string) (float64, time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, time.Time{}, uuid.Nil, ErrInvalidBrowseCursor
+	}
+
+	parts := strings.SplitN(string(raw), "|", 4)
+	if len(parts) != 4 || parts[0] != sortBy {
+		return 0, time.Time{}, uuid.Nil, ErrInvalidBrowseCursor
+	}
+	sortValue, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, time.Time{}, uuid.Nil, ErrInvalidBrowseCursor
+	}
+	startTime, err := time.Parse(time.RFC3339Nano, parts[2])
+	if err != nil {
+		return 0, time.Time{}, uuid.Nil, ErrInvalidBrowseCursor
+	}
+	rideOfferID, err := uuid.Parse(parts[3])
+	if err != nil {
+		return 0, time.Time{}, uuid.Nil, ErrInvalidBrowseCursor
+	}
+	return sortValue, startTime, rideOfferID, nil
+}
+
+// BrowseSortValue returns the value a ride offer is sorted by when browsing, 0 when sorted by start time only
+func BrowseSortValue(rideOffer migration.RideOffer, sortBy string) float64 {
+	switch sortBy {
+	case "fare":
+		return rideOffer.Fare
+	case "available_seats":
+		return float64(rideOffer.AvailableSeats)
+	}
+	return 0
+}
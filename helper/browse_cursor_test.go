@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBrowseCursor(t *testing.T) {
+	rideOfferID := uuid.MustParse("6f1c2b4e-8d3a-4c5e-9f7a-1b2c3d4e5f60")
+	startTime := time.Date(2024, 11, 4, 7, 30, 0, 0, UTCOffsetLocation(7))
+
+	tests := []struct {
+		sortBy    string
+		sortValue float64
+	}{
+		{"start_time", 0},
+		{"fare", 123456.5},
+		{"available_seats", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			cursor := EncodeBrowseCursor(tt.sortBy, tt.sortValue, startTime, rideOfferID)
+
+			sortValue, gotStartTime, gotRideOfferID, err := DecodeBrowseCursor(cursor, tt.sortBy)
+			if err != nil {
+				t.Fatalf("DecodeBrowseCursor() error = %v", err)
+			}
+			if sortValue != tt.sortValue || !gotStartTime.Equal(startTime) || gotRideOfferID != rideOfferID {
+				t.Errorf("DecodeBrowseCursor() = %v, %v, %v, want %v, %v, %v", sortValue, gotStartTime, gotRideOfferID, tt.sortValue, startTime, rideOfferID)
+			}
+
+			// A cursor only pages the sort it was issued for
+			if _, _, _, err := DecodeBrowseCursor(cursor, "other"); !errors.Is(err, ErrInvalidBrowseCursor) {
+				t.Errorf("DecodeBrowseCursor() with another sort error = %v, want ErrInvalidBrowseCursor", err)
+			}
+		})
+	}
+
+	if _, _, _, err := DecodeBrowseCursor("not a cursor!", "fare"); !errors.Is(err, ErrInvalidBrowseCursor) {
+		t.Errorf("DecodeBrowseCursor() error = %v, want ErrInvalidBrowseCursor", err)
+	}
+}
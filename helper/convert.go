@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"shareway/util/jsonb"
 	"shareway/util/sanctum"
 
 	"github.com/google/uuid"
@@ -59,6 +60,21 @@ func ConvertToStringMap(data interface{}) (map[string]string, error) {
 	return result, nil
 }
 
+// ConvertToJSONB converts a struct to a JSONB column value using its JSON field names
+func ConvertToJSONB(data interface{}) (jsonb.JSONB, error) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling data: %v", err)
+	}
+
+	var result jsonb.JSONB
+	if err := json.Unmarshal(jsonBytes, &result); err != nil {
+		return nil, fmt.Errorf("error unmarshaling data: %v", err)
+	}
+
+	return result, nil
+}
+
 // Recommended method: Combines hash and range checking
 func UuidToUid(id uuid.UUID) uint32 {
 	// Agora requires uid to be between 1 and (2^32 - 1)
@@ -0,0 +1,106 @@
+package helper
+
+import (
+	"shareway/infra/db/migration"
+	"shareway/schemas"
+	"time"
+)
+
+// Types of route deviation
+const (
+	DeviationOffRoute = "off_route" // The driver is far from the planned route for long
+	DeviationLongStop = "long_stop" // The driver has not moved for long
+)
+
+// DeviationThresholds holds when a deviation from the planned route is alerted
+type DeviationThresholds struct {
+	Distance     float64       // Distance from the route in meters
+	Duration     time.Duration // How long the driver must stay farther than Distance
+	StopRadius   float64       // The driver is stopped while staying within this radius in meters
+	StopDuration time.Duration // How long the driver must stay stopped
+}
+
+// DeviationState is what is tracked of an ongoing ride between two location updates
+type DeviationState struct {
+	OffRouteSince   *time.Time    `json:"off_route_since,omitempty"`
+	OffRouteAlerted bool          `json:"off_route_alerted"`
+	MaxDistance     float64       `json:"max_distance"` // Largest distance from the route in meters since the driver left it
+	StoppedSince    *time.Time    `json:"stopped_since,omitempty"`
+	StopLocation    schemas.Point `json:"stop_location"`
+	StopAlerted     bool          `json:"stop_alerted"`
+}
+
+// DeviationChanges are the deviations raised or resolved by a location update
+type DeviationChanges struct {
+	Distance float64  // Current distance from the route in meters
+	Raised   []string // Deviations that just became sustained
+	Resolved []string // Alerted deviations that just ended
+}
+
+// TrackDeviation updates the deviation state with a new location of the driver and returns the deviations
+// to alert, a deviation is only raised once until it ends
+func TrackDeviation(state *DeviationState, route []schemas.Point, location schemas.Point, at time.Time, thresholds DeviationThresholds) DeviationChanges {
+	var changes DeviationChanges
+	if len(route) < 2 {
+		return changes
+	}
+
+	cumulative := make([]float64, len(route))
+	for i := 1; i < len(route); i++ {
+		cumulative[i] = cumulative[i-1] + haversineDistance(route[i-1], route[i])
+	}
+	distance, _ := projectOntoRoute(route, cumulative, location)
+	changes.Distance = distance * 1000
+
+	if changes.Distance > thresholds.Distance {
+		if state.OffRouteSince == nil {
+			state.OffRouteSince = &at
+			state.MaxDistance = 0
+		}
+		if changes.Distance > state.MaxDistance {
+			state.MaxDistance = changes.Distance
+		}
+		if !state.OffRouteAlerted && at.Sub(*state.OffRouteSince) >= thresholds.Duration {
+			state.OffRouteAlerted = true
+			changes.Raised = append(changes.Raised, DeviationOffRoute)
+		}
+	} else if state.OffRouteSince != nil {
+		if state.OffRouteAlerted {
+			changes.Resolved = append(changes.Resolved, DeviationOffRoute)
+		}
+		state.OffRouteSince = nil
+		state.OffRouteAlerted = false
+	}
+
+	if state.StoppedSince == nil || haversineDistance(state.StopLocation, location)*1000 > thresholds.StopRadius {
+		if state.StopAlerted {
+			changes.Resolved = append(changes.Resolved, DeviationLongStop)
+		}
+		state.StoppedSince = &at
+		state.StopLocation = location
+		state.StopAlerted = false
+	} else if !state.StopAlerted && at.Sub(*state.StoppedSince) >= thresholds.StopDuration {
+		state.StopAlerted = true
+		changes.Raised = append(changes.Raised, DeviationLongStop)
+	}
+
+	return changes
+}
+
+// ToDeviationEventDetail converts a deviation event to the representation sent to the admins
+func ToDeviationEventDetail(event migration.RideDeviationEvent) schemas.DeviationEventDetail {
+	return schemas.DeviationEventDetail{
+		ID:             event.ID,
+		RideID:         event.RideID,
+		Type:           event.Type,
+		StartedAt:      event.StartedAt,
+		EndedAt:        event.EndedAt,
+		Latitude:       event.Latitude,
+		Longitude:      event.Longitude,
+		Distance:       event.Distance,
+		MaxDistance:    event.MaxDistance,
+		AcknowledgedAt: event.AcknowledgedAt,
+		AcknowledgedBy: event.AcknowledgedBy,
+		Note:           event.Note,
+	}
+}
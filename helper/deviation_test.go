@@ -0,0 +1,109 @@
+package helper
+
+import (
+	"reflect"
+	"shareway/schemas"
+	"testing"
+	"time"
+)
+
+func TestTrackDeviation(t *testing.T) {
+	route := []schemas.Point{{Lat: 21.00, Lng: 105.80}, {Lat: 21.04, Lng: 105.80}}
+	thresholds := DeviationThresholds{
+		Distance:     300,
+		Duration:     2 * time.Minute,
+		StopRadius:   50,
+		StopDuration: 5 * time.Minute,
+	}
+	start := time.Date(2024, 11, 4, 7, 0, 0, 0, time.UTC)
+	offRoute := schemas.Point{Lat: 21.01, Lng: 105.81} // About 1 km east of the route
+
+	type update struct {
+		location     schemas.Point
+		after        time.Duration // Since the start of the ride
+		wantRaised   []string
+		wantResolved []string
+	}
+	tests := []struct {
+		name    string
+		updates []update
+	}{
+		{
+			name: "driving along the route",
+			updates: []update{
+				{schemas.Point{Lat: 21.00, Lng: 105.80}, 0, nil, nil},
+				{schemas.Point{Lat: 21.01, Lng: 105.80}, time.Minute, nil, nil},
+				{schemas.Point{Lat: 21.02, Lng: 105.80}, 2 * time.Minute, nil, nil},
+			},
+		},
+		{
+			name: "short detour",
+			updates: []update{
+				{schemas.Point{Lat: 21.00, Lng: 105.80}, 0, nil, nil},
+				{offRoute, time.Minute, nil, nil},
+				{schemas.Point{Lat: 21.02, Lng: 105.80}, 2 * time.Minute, nil, nil},
+			},
+		},
+		{
+			name: "sustained detour raised once then resolved",
+			updates: []update{
+				{schemas.Point{Lat: 21.00, Lng: 105.80}, 0, nil, nil},
+				{offRoute, time.Minute, nil, nil},
+				{schemas.Point{Lat: 21.012, Lng: 105.81}, 3 * time.Minute, []string{DeviationOffRoute}, nil},
+				{schemas.Point{Lat: 21.014, Lng: 105.81}, 4 * time.Minute, nil, nil},
+				{schemas.Point{Lat: 21.02, Lng: 105.80}, 5 * time.Minute, nil, []string{DeviationOffRoute}},
+			},
+		},
+		{
+			name: "long stop raised once then resolved",
+			updates: []update{
+				{schemas.Point{Lat: 21.01, Lng: 105.80}, 0, nil, nil},
+				{schemas.Point{Lat: 21.0001, Lng: 105.80}, time.Minute, nil, nil},
+				{schemas.Point{Lat: 21.00, Lng: 105.80}, time.Minute + 30*time.Second, nil, nil},
+				{schemas.Point{Lat: 21.0001, Lng: 105.80}, 7 * time.Minute, []string{DeviationLongStop}, nil},
+				{schemas.Point{Lat: 21.0001, Lng: 105.80}, 8 * time.Minute, nil, nil},
+				{schemas.Point{Lat: 21.02, Lng: 105.80}, 9 * time.Minute, nil, []string{DeviationLongStop}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state DeviationState
+			for i, u := range tt.updates {
+				changes := TrackDeviation(&state, route, u.location, start.Add(u.after), thresholds)
+				if !reflect.DeepEqual(changes.Raised, u.wantRaised) || !reflect.DeepEqual(changes.Resolved, u.wantResolved) {
+					t.Fatalf("update %d: TrackDeviation() raised %v and resolved %v, want %v and %v",
+						i, changes.Raised, changes.Resolved, u.wantRaised, u.wantResolved)
+				}
+			}
+		})
+	}
+}
+
+func TestTrackDeviationMaxDistance(t *testing.T) {
+	route := []schemas.Point{{Lat: 21.00, Lng: 105.80}, {Lat: 21.04, Lng: 105.80}}
+	thresholds := DeviationThresholds{Distance: 300, Duration: time.Minute, StopRadius: 50, StopDuration: time.Hour}
+	start := time.Date(2024, 11, 4, 7, 0, 0, 0, time.UTC)
+
+	var state DeviationState
+	TrackDeviation(&state, route, schemas.Point{Lat: 21.01, Lng: 105.81}, start, thresholds)
+	far := TrackDeviation(&state, route, schemas.Point{Lat: 21.02, Lng: 105.82}, start.Add(time.Minute), thresholds)
+	TrackDeviation(&state, route, schemas.Point{Lat: 21.03, Lng: 105.81}, start.Add(2*time.Minute), thresholds)
+
+	if far.Distance < 2000 {
+		t.Fatalf("TrackDeviation() distance = %v m, want about 2 km", far.Distance)
+	}
+	if state.MaxDistance != far.Distance {
+		t.Errorf("MaxDistance = %v m, want the farthest distance %v m", state.MaxDistance, far.Distance)
+	}
+}
+
+func TestTrackDeviationWithoutRoute(t *testing.T) {
+	var state DeviationState
+	point := schemas.Point{Lat: 21.00, Lng: 105.80}
+
+	changes := TrackDeviation(&state, []schemas.Point{point}, point, time.Now(), DeviationThresholds{})
+	if changes.Raised != nil || changes.Resolved != nil || state.StoppedSince != nil {
+		t.Errorf("TrackDeviation() = %+v with state %+v, want nothing tracked without a route", changes, state)
+	}
+}
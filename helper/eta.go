@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"math"
+	"shareway/schemas"
+)
+
+// RouteETA is the remaining distance and time of the driver to the meeting points of a ride
+type RouteETA struct {
+	OffRoute        float64 // Distance from the driver to the planned route in kilometers
+	PickupDistance  float64 // in kilometers, 0 once the driver has passed the pickup
+	PickupDuration  int     // in seconds
+	DropoffDistance float64 // in kilometers
+	DropoffDuration int     // in seconds
+	LegDistance     float64 // in kilometers, from the pickup to the dropoff along the route
+	LegDuration     int     // in seconds
+}
+
+// EstimateRouteETA projects the driver onto the planned route and estimates the remaining distance and
+// time to the pickup and the dropoff at the average speed of the route (routeDuration is in seconds).
+// A driver off the route is assumed to join it again where it is the nearest
+func EstimateRouteETA(route []schemas.Point, routeDuration int, driver, pickup, dropoff schemas.Point) RouteETA {
+	if len(route) < 2 {
+		return RouteETA{}
+	}
+
+	cumulative := make([]float64, len(route))
+	for i := 1; i < len(route); i++ {
+		cumulative[i] = cumulative[i-1] + haversineDistance(route[i-1], route[i])
+	}
+	routeLength := cumulative[len(cumulative)-1]
+
+	offRoute, driverAlong := projectOntoRoute(route, cumulative, driver)
+	_, pickupAlong := projectOntoRoute(route, cumulative, pickup)
+	_, dropoffAlong := projectOntoRoute(route, cumulative, dropoff)
+
+	eta := RouteETA{OffRoute: offRoute}
+	if pickupAlong > driverAlong {
+		eta.PickupDistance = offRoute + pickupAlong - driverAlong
+	}
+	eta.DropoffDistance = offRoute + math.Max(dropoffAlong-driverAlong, 0)
+	eta.LegDistance = math.Max(dropoffAlong-pickupAlong, 0)
+
+	if routeLength > 0 && routeDuration > 0 {
+		secondsPerKm := float64(routeDuration) / routeLength
+		eta.PickupDuration = int(math.Round(eta.PickupDistance * secondsPerKm))
+		eta.DropoffDuration = int(math.Round(eta.DropoffDistance * secondsPerKm))
+		eta.LegDuration = int(math.Round(eta.LegDistance * secondsPerKm))
+	}
+	return eta
+}
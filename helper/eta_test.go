@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"math"
+	"shareway/schemas"
+	"testing"
+)
+
+func TestEstimateRouteETA(t *testing.T) {
+	// A straight route north along a meridian, 0.01 degree of latitude is about 1.11 km
+	route := []schemas.Point{
+		{Lat: 21.00, Lng: 105.80},
+		{Lat: 21.01, Lng: 105.80},
+		{Lat: 21.02, Lng: 105.80},
+		{Lat: 21.03, Lng: 105.80},
+		{Lat: 21.04, Lng: 105.80},
+	}
+	const routeDuration = 600 // seconds for the whole route
+	routeLength := haversineDistance(route[0], route[len(route)-1])
+	secondsPerKm := routeDuration / routeLength
+	kmPerDegree := routeLength / 0.04
+
+	pickup := schemas.Point{Lat: 21.01, Lng: 105.80}
+	dropoff := schemas.Point{Lat: 21.03, Lng: 105.80}
+
+	tests := []struct {
+		name         string
+		driver       schemas.Point
+		wantOffRoute float64 // in kilometers
+		wantPickup   float64 // in kilometers
+		wantDropoff  float64 // in kilometers
+	}{
+		{"at the start", route[0], 0, 0.01 * kmPerDegree, 0.03 * kmPerDegree},
+		{"between the pickup and the dropoff", schemas.Point{Lat: 21.02, Lng: 105.80}, 0, 0, 0.01 * kmPerDegree},
+		{"past the dropoff", route[len(route)-1], 0, 0, 0},
+		// About 1 km east of the start, the driver is expected to join the route again at the start
+		{"off the route", schemas.Point{Lat: 21.00, Lng: 105.81}, 1.04, 1.04 + 0.01*kmPerDegree, 1.04 + 0.03*kmPerDegree},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eta := EstimateRouteETA(route, routeDuration, tt.driver, pickup, dropoff)
+
+			if math.Abs(eta.OffRoute-tt.wantOffRoute) > 0.01 {
+				t.Errorf("EstimateRouteETA() off route = %v km, want %v", eta.OffRoute, tt.wantOffRoute)
+			}
+			if math.Abs(eta.PickupDistance-tt.wantPickup) > 0.01 {
+				t.Errorf("EstimateRouteETA() pickup distance = %v km, want %v", eta.PickupDistance, tt.wantPickup)
+			}
+			if math.Abs(eta.DropoffDistance-tt.wantDropoff) > 0.01 {
+				t.Errorf("EstimateRouteETA() dropoff distance = %v km, want %v", eta.DropoffDistance, tt.wantDropoff)
+			}
+			if want := int(math.Round(eta.DropoffDistance * secondsPerKm)); eta.DropoffDuration != want {
+				t.Errorf("EstimateRouteETA() dropoff duration = %v s, want %v at the speed of the route", eta.DropoffDuration, want)
+			}
+
+			// The leg from the pickup to the dropoff does not depend on where the driver is
+			if math.Abs(eta.LegDistance-0.02*kmPerDegree) > 0.01 {
+				t.Errorf("EstimateRouteETA() leg distance = %v km, want %v", eta.LegDistance, 0.02*kmPerDegree)
+			}
+		})
+	}
+}
+
+func TestEstimateRouteETAWithoutRoute(t *testing.T) {
+	point := schemas.Point{Lat: 21.00, Lng: 105.80}
+
+	if eta := EstimateRouteETA([]schemas.Point{point}, 600, point, point, point); eta != (RouteETA{}) {
+		t.Errorf("EstimateRouteETA() = %+v, want an empty ETA for a route of one point", eta)
+	}
+}
+
+func TestEstimateRouteETAWithoutDuration(t *testing.T) {
+	route := []schemas.Point{{Lat: 21.00, Lng: 105.80}, {Lat: 21.04, Lng: 105.80}}
+
+	eta := EstimateRouteETA(route, 0, route[0], route[0], route[1])
+	if eta.DropoffDistance == 0 || eta.DropoffDuration != 0 {
+		t.Errorf("EstimateRouteETA() = %+v, want a distance and no duration", eta)
+	}
+}
@@ -0,0 +1,26 @@
+package helper
+
+import "strings"
+
+// Fuel types a vehicle can run on
+const (
+	FuelTypeRON95    = "ron95"
+	FuelTypeE5RON92  = "e5_ron92"
+	FuelTypeDiesel   = "diesel"
+	FuelTypeElectric = "electric" // Consumption in kWh per 100 kilometers, priced with the electricity tariff
+)
+
+// FuelTypeFromName returns the fuel type of a fuel price crawled by its Vietnamese name,
+// or an empty string for the grades no vehicle is priced with
+func FuelTypeFromName(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "e5") && strings.Contains(name, "92"):
+		return FuelTypeE5RON92
+	case strings.Contains(name, "95-iii"):
+		return FuelTypeRON95
+	case strings.Contains(name, "do 0,05s"), strings.Contains(name, "do 0.05s"):
+		return FuelTypeDiesel
+	}
+	return ""
+}
@@ -0,0 +1,92 @@
+package helper
+
+import (
+	"fmt"
+	"shareway/schemas"
+	"time"
+)
+
+// Reasons of a failed location check
+const (
+	GeofenceNoLocation      = "no_location"      // The driver has never reported a location
+	GeofenceStaleLocation   = "stale_location"   // The reported location is too old or its timestamp cannot be trusted
+	GeofenceImpossibleSpeed = "impossible_speed" // The driver would have moved faster than possible since the previous location
+	GeofenceOutside         = "outside_geofence" // The driver is too far from the meeting point
+)
+
+// Actions guarded by a geofence
+const (
+	GeofenceActionStart = "start"
+	GeofenceActionEnd   = "end"
+)
+
+const (
+	// Location updates may be timestamped slightly ahead of the server clock
+	maxLocationClockSkew = 30 * time.Second
+	// Distance under which two locations are considered the same because of the GPS accuracy, in kilometers
+	gpsAccuracy = 0.05
+)
+
+// GeofenceError is returned when the location of the driver cannot be trusted or is too far from the meeting point
+type GeofenceError struct {
+	Reason   string
+	Distance float64 // Distance to the meeting point in meters, when known
+	Radius   float64 // Allowed distance to the meeting point in meters
+}
+
+func (e *GeofenceError) Error() string {
+	switch e.Reason {
+	case GeofenceOutside:
+		return fmt.Sprintf("%s: driver is %.0fm from the meeting point, must be within %.0fm", e.Reason, e.Distance, e.Radius)
+	case GeofenceNoLocation:
+		return fmt.Sprintf("%s: driver has not reported a location yet", e.Reason)
+	case GeofenceStaleLocation:
+		return fmt.Sprintf("%s: driver location is outdated or has an invalid timestamp", e.Reason)
+	case GeofenceImpossibleSpeed:
+		return fmt.Sprintf("%s: driver location changed faster than possible", e.Reason)
+	default:
+		return e.Reason
+	}
+}
+
+// CheckGeofence checks that the last reported location of the driver is recent and within the radius
+// (in meters) of the meeting point
+func CheckGeofence(location schemas.Point, reportedAt *time.Time, target schemas.Point, radius float64, maxAge time.Duration, now time.Time) error {
+	if reportedAt == nil {
+		return &GeofenceError{Reason: GeofenceNoLocation, Radius: radius}
+	}
+	if now.Sub(*reportedAt) > maxAge {
+		return &GeofenceError{Reason: GeofenceStaleLocation, Radius: radius}
+	}
+
+	distance := haversineDistance(location, target) * 1000
+	if distance > radius {
+		return &GeofenceError{Reason: GeofenceOutside, Distance: distance, Radius: radius}
+	}
+	return nil
+}
+
+// CheckLocationUpdate detects spoofed location updates: a timestamp too old, in the future or before the
+// previous update, or a speed (in km/h) since the previous location that no vehicle can reach
+func CheckLocationUpdate(previous schemas.Point, previousAt *time.Time, current schemas.Point, recordedAt time.Time, maxAge time.Duration, maxSpeed float64, now time.Time) error {
+	if now.Sub(recordedAt) > maxAge || recordedAt.Sub(now) > maxLocationClockSkew {
+		return &GeofenceError{Reason: GeofenceStaleLocation}
+	}
+	if previousAt == nil {
+		return nil
+	}
+
+	elapsed := recordedAt.Sub(*previousAt)
+	if elapsed < 0 {
+		return &GeofenceError{Reason: GeofenceStaleLocation}
+	}
+
+	distance := haversineDistance(previous, current) - gpsAccuracy
+	if distance <= 0 {
+		return nil
+	}
+	if elapsed == 0 || distance/elapsed.Hours() > maxSpeed {
+		return &GeofenceError{Reason: GeofenceImpossibleSpeed}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package helper
+
+import (
+	"errors"
+	"shareway/schemas"
+	"testing"
+	"time"
+)
+
+func TestCheckGeofence(t *testing.T) {
+	now := time.Date(2024, 11, 4, 7, 0, 0, 0, time.UTC)
+	target := schemas.Point{Lat: 21.0285, Lng: 105.8542}
+	recent := now.Add(-30 * time.Second)
+	old := now.Add(-5 * time.Minute)
+
+	tests := []struct {
+		name       string
+		location   schemas.Point
+		reportedAt *time.Time
+		wantReason string // Empty when the check passes
+	}{
+		{"at the meeting point", target, &recent, ""},
+		{"about 110 m away", schemas.Point{Lat: 21.0295, Lng: 105.8542}, &recent, ""},
+		{"about 1 km away", schemas.Point{Lat: 21.0375, Lng: 105.8542}, &recent, GeofenceOutside},
+		{"never reported", target, nil, GeofenceNoLocation},
+		{"reported too long ago", target, &old, GeofenceStaleLocation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckGeofence(tt.location, tt.reportedAt, target, 200, 2*time.Minute, now)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("CheckGeofence() = %v, want nil", err)
+				}
+				return
+			}
+
+			var geofenceErr *GeofenceError
+			if !errors.As(err, &geofenceErr) || geofenceErr.Reason != tt.wantReason {
+				t.Fatalf("CheckGeofence() = %v, want reason %q", err, tt.wantReason)
+			}
+			if geofenceErr.Radius != 200 {
+				t.Errorf("CheckGeofence() radius = %v, want 200", geofenceErr.Radius)
+			}
+		})
+	}
+}
+
+func TestCheckLocationUpdate(t *testing.T) {
+	now := time.Date(2024, 11, 4, 7, 0, 0, 0, time.UTC)
+	previous := schemas.Point{Lat: 21.0285, Lng: 105.8542}
+	previousAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		current    schemas.Point
+		previousAt *time.Time
+		recordedAt time.Time
+		wantReason string // Empty when the update is trusted
+	}{
+		{"first location", previous, nil, now, ""},
+		{"about 1 km in a minute", schemas.Point{Lat: 21.0375, Lng: 105.8542}, &previousAt, now, ""},
+		{"GPS jitter at the same time", schemas.Point{Lat: 21.0286, Lng: 105.8542}, &now, now, ""},
+		{"about 10 km in a minute", schemas.Point{Lat: 21.1185, Lng: 105.8542}, &previousAt, now, GeofenceImpossibleSpeed},
+		{"before the previous update", previous, &previousAt, previousAt.Add(-time.Second), GeofenceStaleLocation},
+		{"recorded too long ago", previous, nil, now.Add(-10 * time.Minute), GeofenceStaleLocation},
+		{"recorded in the future", previous, nil, now.Add(time.Minute), GeofenceStaleLocation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckLocationUpdate(previous, tt.previousAt, tt.current, tt.recordedAt, 2*time.Minute, 200, now)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("CheckLocationUpdate() = %v, want nil", err)
+				}
+				return
+			}
+
+			var geofenceErr *GeofenceError
+			if !errors.As(err, &geofenceErr) || geofenceErr.Reason != tt.wantReason {
+				t.Errorf("CheckLocationUpdate() = %v, want reason %q", err, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"shareway/schemas"
+	"sort"
+)
+
+// ImpactSettings holds what the impact of the shared rides is computed with
+type ImpactSettings struct {
+	EmissionFactors     map[string]float64 // kg of CO2 per liter of each fuel type, per kWh for electric vehicles
+	DefaultFuelConsumed float64            // liters per 100 kilometers, for the vehicles without a known consumption
+}
+
+// ComputeImpact estimates what the shared rides saved. Every passenger of a shared ride would otherwise
+// have made the same trip alone in a similar vehicle, so each ride saves the fuel its vehicle used.
+// Only the distances and the fuel used are queried, the factors are applied here so a change of the factors
+// is taken into account right away
+func ComputeImpact(totals []schemas.ImpactFuelTotals, settings ImpactSettings) schemas.ImpactDetail {
+	impact := schemas.ImpactDetail{
+		ByFuelType: make([]schemas.ImpactFuelDetail, 0, len(totals)),
+	}
+
+	for _, total := range totals {
+		saved := total.FuelConsumed
+		if total.FuelType != FuelTypeElectric {
+			saved += total.UnknownDistance * settings.DefaultFuelConsumed / 100
+		}
+
+		factor := settings.EmissionFactors[total.FuelType]
+		detail := schemas.ImpactFuelDetail{
+			FuelType:       total.FuelType,
+			Rides:          total.Rides,
+			KmShared:       total.Distance,
+			Saved:          saved,
+			EmissionFactor: factor,
+			CO2Avoided:     saved * factor,
+		}
+		impact.ByFuelType = append(impact.ByFuelType, detail)
+
+		impact.Rides += detail.Rides
+		impact.KmShared += detail.KmShared
+		impact.CO2Avoided += detail.CO2Avoided
+		if total.FuelType == FuelTypeElectric {
+			impact.EnergySaved += saved
+		} else {
+			impact.FuelSaved += saved
+		}
+	}
+
+	sort.Slice(impact.ByFuelType, func(i, j int) bool {
+		return impact.ByFuelType[i].FuelType < impact.ByFuelType[j].FuelType
+	})
+	return impact
+}
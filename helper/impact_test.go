@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"math"
+	"shareway/schemas"
+	"testing"
+)
+
+func TestComputeImpact(t *testing.T) {
+	settings := ImpactSettings{
+		EmissionFactors: map[string]float64{
+			FuelTypeRON95:    2.3,
+			FuelTypeDiesel:   2.7,
+			FuelTypeElectric: 0.7,
+		},
+		DefaultFuelConsumed: 7,
+	}
+	totals := []schemas.ImpactFuelTotals{
+		// 100 km with a known consumption used 6 liters, 50 km more are counted at the default 7 l/100 km
+		{FuelType: FuelTypeRON95, Rides: 3, Distance: 150, FuelConsumed: 6, UnknownDistance: 50},
+		// Electric vehicles without a known consumption are not estimated with a fuel consumption
+		{FuelType: FuelTypeElectric, Rides: 2, Distance: 80, FuelConsumed: 12, UnknownDistance: 20},
+		{FuelType: FuelTypeDiesel, Rides: 1, Distance: 40, FuelConsumed: 3},
+	}
+
+	impact := ComputeImpact(totals, settings)
+
+	want := map[string]schemas.ImpactFuelDetail{
+		FuelTypeRON95:    {FuelType: FuelTypeRON95, Rides: 3, KmShared: 150, Saved: 9.5, EmissionFactor: 2.3, CO2Avoided: 9.5 * 2.3},
+		FuelTypeElectric: {FuelType: FuelTypeElectric, Rides: 2, KmShared: 80, Saved: 12, EmissionFactor: 0.7, CO2Avoided: 12 * 0.7},
+		FuelTypeDiesel:   {FuelType: FuelTypeDiesel, Rides: 1, KmShared: 40, Saved: 3, EmissionFactor: 2.7, CO2Avoided: 3 * 2.7},
+	}
+	if len(impact.ByFuelType) != len(want) {
+		t.Fatalf("ComputeImpact() by fuel type = %+v, want %d fuel types", impact.ByFuelType, len(want))
+	}
+	for i, detail := range impact.ByFuelType {
+		if i > 0 && impact.ByFuelType[i-1].FuelType > detail.FuelType {
+			t.Errorf("ComputeImpact() by fuel type is not sorted: %q before %q", impact.ByFuelType[i-1].FuelType, detail.FuelType)
+		}
+		w := want[detail.FuelType]
+		if detail.Rides != w.Rides || !nearlyEqual(detail.KmShared, w.KmShared) || !nearlyEqual(detail.Saved, w.Saved) ||
+			detail.EmissionFactor != w.EmissionFactor || !nearlyEqual(detail.CO2Avoided, w.CO2Avoided) {
+			t.Errorf("ComputeImpact() %s = %+v, want %+v", detail.FuelType, detail, w)
+		}
+	}
+
+	if impact.Rides != 6 || !nearlyEqual(impact.KmShared, 270) {
+		t.Errorf("ComputeImpact() = %d rides and %v km, want 6 rides and 270 km", impact.Rides, impact.KmShared)
+	}
+	if !nearlyEqual(impact.FuelSaved, 12.5) || !nearlyEqual(impact.EnergySaved, 12) {
+		t.Errorf("ComputeImpact() saved %v l and %v kWh, want 12.5 l and 12 kWh", impact.FuelSaved, impact.EnergySaved)
+	}
+	if wantCO2 := 9.5*2.3 + 12*0.7 + 3*2.7; !nearlyEqual(impact.CO2Avoided, wantCO2) {
+		t.Errorf("ComputeImpact() CO2 avoided = %v kg, want %v", impact.CO2Avoided, wantCO2)
+	}
+}
+
+func TestComputeImpactWithoutFactor(t *testing.T) {
+	totals := []schemas.ImpactFuelTotals{{FuelType: FuelTypeE5RON92, Rides: 1, Distance: 10, FuelConsumed: 0.5}}
+
+	impact := ComputeImpact(totals, ImpactSettings{EmissionFactors: map[string]float64{}})
+	if !nearlyEqual(impact.FuelSaved, 0.5) || impact.CO2Avoided != 0 {
+		t.Errorf("ComputeImpact() = %+v, want the fuel saved and no CO2 without an emission factor", impact)
+	}
+}
+
+func TestComputeImpactWithoutRides(t *testing.T) {
+	impact := ComputeImpact(nil, ImpactSettings{})
+	if impact.Rides != 0 || impact.ByFuelType == nil || len(impact.ByFuelType) != 0 {
+		t.Errorf("ComputeImpact() = %+v, want no rides and an empty list", impact)
+	}
+}
+
+func nearlyEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
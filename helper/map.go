@@ -18,8 +18,8 @@ const (
 	maxDistanceMatch    = 0.02   // About 2 km at equator
 	degreesToRad        = math.Pi / 180
 	maxDistanceSq       = maxDistanceMatch * maxDistanceMatch
-	nearbyDistance      = 0.0001
-	nearbyDistanceSq    = nearbyDistance * nearbyDistance
+	NearbyRadius        = 100.0            // Distance in meters within which two points are nearby
+	TimeOverlapBuffer   = 30 * time.Minute // Buffer around the offer time window to pick up and drop off the hitchhiker
 )
 
 func haversineDistance(p1, p2 schemas.Point) float64 {
@@ -284,8 +284,8 @@ func IsTimeOverlap(offer migration.RideOffer, request migration.RideRequest) boo
 	// Add a buffer of 30 minutes to the start and end time of the offer
 	// to account for the time it takes to pick up the hitchhiker and drop them off
 	// This buffer is added to the start and end time of the offer
-	offerStartTime := offer.StartTime.Add(-30 * time.Minute)
-	offerEndTime := offer.EndTime.Add(30 * time.Minute)
+	offerStartTime := offer.StartTime.Add(-TimeOverlapBuffer)
+	offerEndTime := offer.EndTime.Add(TimeOverlapBuffer)
 	return offerStartTime.Before(request.StartTime) && offerEndTime.After(request.EndTime)
 }
 
@@ -341,9 +341,19 @@ func IsTimeOverlap(offer migration.RideOffer, request migration.RideRequest) boo
 // 	return totalRadius <= maxDistance
 // }
 
-// IsNearby check the current location is nearby the target location or not with the given distance
-func IsNearby(current, target schemas.Point, distance float64) bool {
-	return squaredDistance(current, target) <= distance*distance
+// MatchBounds expands a route bounding box by the maximum distance IsMatchRoute allows between
+// a pickup/dropoff and the route, so a route whose bounding box does not overlap can never match
+func MatchBounds(minLat, maxLat, minLng, maxLng float64) (float64, float64, float64, float64) {
+	// squaredDistance shrinks longitude by cos(latitude), so the longitude margin grows toward the poles
+	maxAbsLat := math.Min(math.Max(math.Abs(minLat), math.Abs(maxLat))+maxDistanceMatch, 89)
+	lngMargin := maxDistanceMatch / math.Cos(maxAbsLat*degreesToRad)
+
+	return minLat - maxDistanceMatch, maxLat + maxDistanceMatch, minLng - lngMargin, maxLng + lngMargin
+}
+
+// IsNearby check the current location is within the given radius (in meters) of the target location
+func IsNearby(current, target schemas.Point, radius float64) bool {
+	return haversineDistance(current, target)*1000 <= radius
 }
 
 // Get the nearest point on the route to the given point on the start and end of the route
@@ -352,16 +362,50 @@ func GetNearestPointOnRoute(polyline []schemas.Point, point schemas.Point) schem
 		return schemas.Point{}
 	}
 
+	return polyline[nearestPointIndex(polyline, point)]
+}
+
+// FindMeetingPoints finds where on the driver's route the hitcher is picked up and dropped off.
+// The dropoff is searched after the pickup so the hitcher never travels backward on the route
+func FindMeetingPoints(polyline []schemas.Point, start, end schemas.Point) (pickup, dropoff schemas.Point) {
+	if len(polyline) == 0 {
+		return schemas.Point{}, schemas.Point{}
+	}
+
+	pickupIdx := nearestPointIndex(polyline, start)
+	dropoffIdx := pickupIdx + nearestPointIndex(polyline[pickupIdx:], end)
+
+	return polyline[pickupIdx], polyline[dropoffIdx]
+}
+
+// RouteProximity returns how far (in kilometers) the route passes from the origin and from the destination.
+// The destination is looked for after the point closest to the origin so the route must go the right way
+func RouteProximity(polyline []schemas.Point, origin, destination schemas.Point) (float64, float64) {
+	pickup, dropoff := FindMeetingPoints(polyline, origin, destination)
+	return haversineDistance(origin, pickup), haversineDistance(destination, dropoff)
+}
+
+// RadiusBounds returns the bounding box of the circle of the given radius (in kilometers) around the point
+func RadiusBounds(point schemas.Point, radius float64) (float64, float64, float64, float64) {
+	latMargin := radius / kmPerDegree
+	maxAbsLat := math.Min(math.Abs(point.Lat)+latMargin, 89)
+	lngMargin := latMargin / math.Cos(maxAbsLat*degreesToRad)
+
+	return point.Lat - latMargin, point.Lat + latMargin, point.Lng - lngMargin, point.Lng + lngMargin
+}
+
+// nearestPointIndex returns the index of the point of the polyline nearest to the given point
+func nearestPointIndex(polyline []schemas.Point, point schemas.Point) int {
 	minDistSq := math.MaxFloat64
-	var nearestPoint schemas.Point
+	nearestIdx := 0
 
-	for _, p := range polyline {
+	for i, p := range polyline {
 		distSq := squaredDistance(p, point)
 		if distSq < minDistSq {
 			minDistSq = distSq
-			nearestPoint = p
+			nearestIdx = i
 		}
 	}
 
-	return nearestPoint
+	return nearestIdx
 }
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"math"
+	"shareway/schemas"
+	"shareway/util/polyline"
+	"testing"
+
+	gopolyline "github.com/twpayne/go-polyline"
+)
+
+const syntheticRoutePoints = 30 // Number of points of the synthetic routes
+
+// encodeRoute encodes the straight route between two points as a polyline with its bounding box
+func encodeRoute(start, end schemas.Point) (polyline.Polyline, [4]float64) {
+	coords := make([][]float64, syntheticRoutePoints)
+	for i := range coords {
+		ratio := float64(i) / float64(syntheticRoutePoints-1)
+		coords[i] = []float64{start.Lat + (end.Lat-start.Lat)*ratio, start.Lng + (end.Lng-start.Lng)*ratio}
+	}
+
+	encoded := polyline.Polyline(gopolyline.EncodeCoords(coords))
+	minLat, maxLat, minLng, maxLng, _ := encoded.Bounds()
+	return encoded, [4]float64{minLat, maxLat, minLng, maxLng}
+}
+
+func TestMatchBounds(t *testing.T) {
+	minLat, maxLat, minLng, maxLng := MatchBounds(21, 21.1, 105.8, 105.9)
+
+	if math.Abs(minLat-(21-maxDistanceMatch)) > 1e-9 || math.Abs(maxLat-(21.1+maxDistanceMatch)) > 1e-9 {
+		t.Errorf("MatchBounds() latitude = [%v, %v], want the box expanded by %v", minLat, maxLat, maxDistanceMatch)
+	}
+	// The longitude margin grows with the latitude
+	if 105.8-minLng <= maxDistanceMatch || maxLng-105.9 <= maxDistanceMatch {
+		t.Errorf("MatchBounds() longitude = [%v, %v], want a margin over %v", minLng, maxLng, maxDistanceMatch)
+	}
+}
+
+func TestIsNearby(t *testing.T) {
+	target := schemas.Point{Lat: 21.0285, Lng: 105.8542}
+
+	tests := []struct {
+		name    string
+		current schemas.Point
+		want    bool
+	}{
+		{"same point", target, true},
+		{"about 55 m north", schemas.Point{Lat: 21.0290, Lng: 105.8542}, true},
+		{"about 110 m north", schemas.Point{Lat: 21.0295, Lng: 105.8542}, false},
+		{"about 1 km east", schemas.Point{Lat: 21.0285, Lng: 105.8638}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNearby(tt.current, target, NearbyRadius); got != tt.want {
+				t.Errorf("IsNearby() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package helper
+
+import (
+	"math"
+	"shareway/infra/db/migration"
+	"shareway/schemas"
+	"time"
+)
+
+const (
+	kmPerDegree      = 111.32                         // Approximate length of one degree of latitude in kilometers
+	maxMatchDistance = maxDistanceMatch * kmPerDegree // Pickup/dropoff farther than this from the route never match
+	maxDetourRatio   = 0.25                           // A detour adding a quarter of the offer distance scores 0
+	maxTimeGap       = 60 * time.Minute               // A start-time gap of one hour or more scores 0
+	maxRating        = 5.0                            // Ratings are given between 0 and 5
+	neutralRating    = maxRating / 2                  // Used when the counterpart has not been rated yet
+)
+
+// MatchWeights holds the weight of each factor when combining them into a match score
+type MatchWeights struct {
+	PickupDistance  float64
+	DropoffDistance float64
+	Detour          float64
+	TimeGap         float64
+	SharedRoute     float64
+	Rating          float64
+	Preferences     float64
+}
+
+// ScoreMatch scores how well a ride request fits a ride offer.
+// Every factor is normalized between 0 and 1 (1 is best) then combined with the given weights,
+// counterpartRating is the average rating of the user being suggested (0 if not rated yet)
+func ScoreMatch(offer migration.RideOffer, request migration.RideRequest, counterpartRating float64, weights MatchWeights) schemas.MatchScore {
+	offerRoute := DecodePolyline(string(offer.EncodedPolyline))
+	requestRoute := DecodePolyline(string(request.EncodedPolyline))
+	if len(offerRoute) < 2 || len(requestRoute) < 2 {
+		return schemas.MatchScore{}
+	}
+
+	// Cumulative distance along the offer route to locate the pickup and dropoff
+	cumulative := make([]float64, len(offerRoute))
+	for i := 1; i < len(offerRoute); i++ {
+		cumulative[i] = cumulative[i-1] + haversineDistance(offerRoute[i-1], offerRoute[i])
+	}
+	routeLength := cumulative[len(cumulative)-1]
+	if routeLength == 0 {
+		routeLength = offer.Distance
+	}
+
+	pickupDistance, pickupAlong := projectOntoRoute(offerRoute, cumulative, requestRoute[0])
+	dropoffDistance, dropoffAlong := projectOntoRoute(offerRoute, cumulative, requestRoute[len(requestRoute)-1])
+
+	// The driver leaves the route to reach the pickup and the dropoff then comes back
+	detour := 2 * (pickupDistance + dropoffDistance)
+
+	sharedFraction := 0.0
+	if routeLength > 0 {
+		sharedFraction = math.Max(dropoffAlong-pickupAlong, 0) / routeLength
+	}
+
+	// Estimate when the driver reaches the pickup point and compare it with the requested start time
+	estimatedPickupTime := offer.StartTime
+	if routeLength > 0 {
+		estimatedPickupTime = offer.StartTime.Add(time.Duration(float64(offer.Duration)*pickupAlong/routeLength) * time.Second)
+	}
+	timeGap := request.StartTime.Sub(estimatedPickupTime)
+	if timeGap < 0 {
+		timeGap = -timeGap
+	}
+
+	if counterpartRating <= 0 {
+		counterpartRating = neutralRating
+	}
+
+	maxDetour := maxDetourRatio * routeLength
+	score := schemas.MatchScore{
+		PickupDistance: schemas.MatchFactor{
+			Value:  pickupDistance,
+			Score:  1 - clamp01(pickupDistance/maxMatchDistance),
+			Weight: weights.PickupDistance,
+		},
+		DropoffDistance: schemas.MatchFactor{
+			Value:  dropoffDistance,
+			Score:  1 - clamp01(dropoffDistance/maxMatchDistance),
+			Weight: weights.DropoffDistance,
+		},
+		Detour: schemas.MatchFactor{
+			Value:  detour,
+			Weight: weights.Detour,
+		},
+		TimeGap: schemas.MatchFactor{
+			Value:  timeGap.Minutes(),
+			Score:  1 - clamp01(float64(timeGap)/float64(maxTimeGap)),
+			Weight: weights.TimeGap,
+		},
+		SharedRoute: schemas.MatchFactor{
+			Value:  sharedFraction,
+			Score:  clamp01(sharedFraction),
+			Weight: weights.SharedRoute,
+		},
+		Rating: schemas.MatchFactor{
+			Value:  counterpartRating,
+			Score:  clamp01(counterpartRating / maxRating),
+			Weight: weights.Rating,
+		},
+	}
+	agreement := PreferenceAgreement(offer.RidePreferences, request.RidePreferences)
+	score.Preferences = schemas.MatchFactor{
+		Value:  agreement,
+		Score:  agreement,
+		Weight: weights.Preferences,
+	}
+	if maxDetour > 0 {
+		score.Detour.Score = 1 - clamp01(detour/maxDetour)
+	}
+
+	factors := []schemas.MatchFactor{score.PickupDistance, score.DropoffDistance, score.Detour, score.TimeGap, score.SharedRoute, score.Rating, score.Preferences}
+	totalWeight := 0.0
+	for _, factor := range factors {
+		score.Score += factor.Score * factor.Weight
+		totalWeight += factor.Weight
+	}
+	if totalWeight > 0 {
+		score.Score /= totalWeight
+	}
+
+	return score
+}
+
+// projectOntoRoute returns the distance (km) from the point to the route
+// and the distance (km) along the route where the nearest point lies
+func projectOntoRoute(route []schemas.Point, cumulative []float64, p schemas.Point) (distance, along float64) {
+	minDistSq := math.MaxFloat64
+
+	for i := 0; i < len(route)-1; i++ {
+		v, w := route[i], route[i+1]
+		dx := w.Lng - v.Lng
+		dy := w.Lat - v.Lat
+		lengthSq := dx*dx + dy*dy
+
+		t := 0.0
+		if lengthSq > 0 {
+			t = clamp01(((p.Lng-v.Lng)*dx + (p.Lat-v.Lat)*dy) / lengthSq)
+		}
+		projection := schemas.Point{
+			Lat: v.Lat + t*dy,
+			Lng: v.Lng + t*dx,
+		}
+
+		distSq := squaredDistance(p, projection)
+		if distSq < minDistSq {
+			minDistSq = distSq
+			along = cumulative[i] + t*(cumulative[i+1]-cumulative[i])
+		}
+	}
+
+	return math.Sqrt(minDistSq) * kmPerDegree, along
+}
+
+func clamp01(x float64) float64 {
+	return math.Max(0, math.Min(1, x))
+}
@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"math"
+	"shareway/infra/db/migration"
+	"shareway/schemas"
+	"testing"
+	"time"
+)
+
+func TestScoreMatch(t *testing.T) {
+	start := time.Date(2024, 11, 4, 7, 0, 0, 0, time.UTC)
+	offerPolyline, _ := encodeRoute(schemas.Point{Lat: 21, Lng: 105.0}, schemas.Point{Lat: 21, Lng: 105.2})
+	offer := migration.RideOffer{
+		EncodedPolyline: offerPolyline,
+		StartTime:       start,
+		Duration:        1200,
+	}
+
+	rideRequest := func(from, to schemas.Point, startTime time.Time) migration.RideRequest {
+		encoded, _ := encodeRoute(from, to)
+		return migration.RideRequest{EncodedPolyline: encoded, StartTime: startTime}
+	}
+	fullRoute := rideRequest(schemas.Point{Lat: 21, Lng: 105.0}, schemas.Point{Lat: 21, Lng: 105.2}, start)
+
+	tests := []struct {
+		name    string
+		request migration.RideRequest
+		rating  float64
+		weights MatchWeights
+		want    float64
+	}{
+		{"pickup on the route", fullRoute, 0, MatchWeights{PickupDistance: 1}, 1},
+		{"pickup about 1.1 km from the route", rideRequest(schemas.Point{Lat: 21.01, Lng: 105.05}, schemas.Point{Lat: 21, Lng: 105.2}, start), 0, MatchWeights{PickupDistance: 1}, 0.5},
+		{"dropoff on the route", fullRoute, 0, MatchWeights{DropoffDistance: 1}, 1},
+		{"no detour", fullRoute, 0, MatchWeights{Detour: 1}, 1},
+		{"same start time", fullRoute, 0, MatchWeights{TimeGap: 1}, 1},
+		{"starts 30 minutes later", rideRequest(schemas.Point{Lat: 21, Lng: 105.0}, schemas.Point{Lat: 21, Lng: 105.2}, start.Add(30*time.Minute)), 0, MatchWeights{TimeGap: 1}, 0.5},
+		{"starts 2 hours earlier", rideRequest(schemas.Point{Lat: 21, Lng: 105.0}, schemas.Point{Lat: 21, Lng: 105.2}, start.Add(-2*time.Hour)), 0, MatchWeights{TimeGap: 1}, 0},
+		{"shares the whole route", fullRoute, 0, MatchWeights{SharedRoute: 1}, 1},
+		{"shares half of the route", rideRequest(schemas.Point{Lat: 21, Lng: 105.0}, schemas.Point{Lat: 21, Lng: 105.1}, start), 0, MatchWeights{SharedRoute: 1}, 0.5},
+		{"rated 4", fullRoute, 4, MatchWeights{Rating: 1}, 0.8},
+		{"not rated yet", fullRoute, 0, MatchWeights{Rating: 1}, 0.5},
+		{"no preferences", fullRoute, 0, MatchWeights{Preferences: 1}, 1},
+		{"weighted average", fullRoute, 4, MatchWeights{PickupDistance: 3, Rating: 1}, 0.95},
+		{"no weights", fullRoute, 4, MatchWeights{}, 0},
+		{"request without route", migration.RideRequest{StartTime: start}, 4, MatchWeights{Rating: 1}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoreMatch(offer, tt.request, tt.rating, tt.weights)
+			if math.Abs(got.Score-tt.want) > 0.01 {
+				t.Errorf("ScoreMatch() = %v, want %v", got.Score, tt.want)
+			}
+		})
+	}
+}
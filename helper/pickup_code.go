@@ -0,0 +1,83 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ways the driver can verify that the passenger is on board
+const (
+	PickupVerificationPIN      = "pin"
+	PickupVerificationQR       = "qr"
+	PickupVerificationTestMode = "test_mode" // No code was checked because the ride test mode is on
+)
+
+const (
+	pickupPINDigits = 6
+	pickupQRPrefix  = "shareway-pickup:v1"
+)
+
+// GeneratePickupPIN returns a random numeric PIN the hitcher shows to the driver
+func GeneratePickupPIN() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < pickupPINDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate pickup PIN: %w", err)
+	}
+	return fmt.Sprintf("%0*d", pickupPINDigits, n), nil
+}
+
+// HashPickupPIN returns the keyed hash of the PIN stored on the ride, the PIN itself is never stored
+func HashPickupPIN(secret string, rideID uuid.UUID, pin string) string {
+	return hex.EncodeToString(pickupMAC(secret, "pin", rideID.String(), pin))
+}
+
+// SignPickupQR returns the payload of the QR code the hitcher shows to the driver,
+// it is bound to the ride, to the current PIN and to its expiry
+func SignPickupQR(secret string, rideID uuid.UUID, pinHash string, expiresAt time.Time) string {
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	signature := pickupMAC(secret, "qr", rideID.String(), expires, pinHash)
+	return strings.Join([]string{pickupQRPrefix, rideID.String(), expires, base64.RawURLEncoding.EncodeToString(signature)}, ":")
+}
+
+// IsPickupQR checks if the submitted code is a QR payload rather than a PIN
+func IsPickupQR(code string) bool {
+	return strings.HasPrefix(code, pickupQRPrefix+":")
+}
+
+// VerifyPickupCode checks a PIN or a QR payload against the pickup code of the ride
+// and returns how the passenger was verified
+func VerifyPickupCode(secret string, rideID uuid.UUID, pinHash string, expiresAt time.Time, code string) (string, bool) {
+	if !IsPickupQR(code) {
+		expected := HashPickupPIN(secret, rideID, strings.TrimSpace(code))
+		return PickupVerificationPIN, hmac.Equal([]byte(expected), []byte(pinHash))
+	}
+
+	expected := SignPickupQR(secret, rideID, pinHash, expiresAt)
+	return PickupVerificationQR, hmac.Equal([]byte(expected), []byte(code))
+}
+
+// IsPickupCodeExpired checks if a pickup code, PIN or QR, can no longer be used at the given time
+func IsPickupCodeExpired(expiresAt, now time.Time) bool {
+	return now.After(expiresAt)
+}
+
+func pickupMAC(secret string, parts ...string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join(parts, "|")))
+	return mac.Sum(nil)
+}
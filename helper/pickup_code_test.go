@@ -0,0 +1,90 @@
+package helper
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestGeneratePickupPIN(t *testing.T) {
+	pin, err := GeneratePickupPIN()
+	if err != nil {
+		t.Fatalf("GeneratePickupPIN() error = %v", err)
+	}
+	if len(pin) != pickupPINDigits || strings.Trim(pin, "0123456789") != "" {
+		t.Errorf("GeneratePickupPIN() = %q, want %d digits", pin, pickupPINDigits)
+	}
+}
+
+func TestVerifyPickupCode(t *testing.T) {
+	const secret = "pickup-secret"
+	rideID := uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	otherRideID := uuid.MustParse("0f8fad5b-d9cb-469f-a165-70867728950e")
+	expiresAt := time.Date(2024, 11, 4, 7, 10, 0, 0, time.UTC)
+	previousExpiresAt := expiresAt.Add(-10 * time.Minute)
+
+	pinHash := HashPickupPIN(secret, rideID, "123456")
+	qr := SignPickupQR(secret, rideID, pinHash, expiresAt)
+	signature := qr[strings.LastIndex(qr, ":")+1:]
+	tampered := strings.TrimSuffix(qr, signature) + strings.Repeat("A", len(signature))
+
+	tests := []struct {
+		name       string
+		code       string
+		wantMethod string
+		wantOK     bool
+	}{
+		{"valid PIN", "123456", PickupVerificationPIN, true},
+		{"valid PIN with spaces", " 123456 ", PickupVerificationPIN, true},
+		{"wrong PIN", "654321", PickupVerificationPIN, false},
+		{"valid QR", qr, PickupVerificationQR, true},
+		// Issuing a new code moves the expiry, the QR of the replaced code no longer matches
+		{"QR of a replaced code", SignPickupQR(secret, rideID, pinHash, previousExpiresAt), PickupVerificationQR, false},
+		{"QR with a tampered signature", tampered, PickupVerificationQR, false},
+		{"QR with a tampered expiry", strings.Replace(qr, fmt.Sprintf(":%d:", expiresAt.Unix()), fmt.Sprintf(":%d:", expiresAt.Add(time.Hour).Unix()), 1), PickupVerificationQR, false},
+		{"QR of another ride", SignPickupQR(secret, otherRideID, pinHash, expiresAt), PickupVerificationQR, false},
+		{"QR signed with another secret", SignPickupQR("other-secret", rideID, pinHash, expiresAt), PickupVerificationQR, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, ok := VerifyPickupCode(secret, rideID, pinHash, expiresAt, tt.code)
+			if method != tt.wantMethod || ok != tt.wantOK {
+				t.Errorf("VerifyPickupCode() = (%q, %v), want (%q, %v)", method, ok, tt.wantMethod, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestExpiredPickupQR(t *testing.T) {
+	const secret = "pickup-secret"
+	rideID := uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	expiresAt := time.Date(2024, 11, 4, 7, 10, 0, 0, time.UTC)
+	pinHash := HashPickupPIN(secret, rideID, "123456")
+	qr := SignPickupQR(secret, rideID, pinHash, expiresAt)
+
+	// The signature stays valid after the expiry, the expiry is checked on its own
+	if _, ok := VerifyPickupCode(secret, rideID, pinHash, expiresAt, qr); !ok {
+		t.Fatal("VerifyPickupCode() rejected the QR, want it accepted")
+	}
+	if IsPickupCodeExpired(expiresAt, expiresAt.Add(-time.Second)) {
+		t.Error("IsPickupCodeExpired() = true before the expiry, want false")
+	}
+	if !IsPickupCodeExpired(expiresAt, expiresAt.Add(time.Second)) {
+		t.Error("IsPickupCodeExpired() = false after the expiry, want true")
+	}
+}
+
+func TestHashPickupPINIsBoundToTheRide(t *testing.T) {
+	rideID := uuid.MustParse("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	otherRideID := uuid.MustParse("0f8fad5b-d9cb-469f-a165-70867728950e")
+
+	if HashPickupPIN("secret", rideID, "123456") == HashPickupPIN("secret", otherRideID, "123456") {
+		t.Error("HashPickupPIN() is the same for two rides")
+	}
+	if HashPickupPIN("secret", rideID, "123456") == HashPickupPIN("other-secret", rideID, "123456") {
+		t.Error("HashPickupPIN() is the same for two secrets")
+	}
+}
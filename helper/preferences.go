@@ -0,0 +1,90 @@
+package helper
+
+import (
+	"shareway/infra/db/migration"
+	"shareway/schemas"
+)
+
+// Luggage sizes, from the smallest to the largest
+const (
+	LuggageSizeNone   = "none"
+	LuggageSizeSmall  = "small"
+	LuggageSizeMedium = "medium"
+	LuggageSizeLarge  = "large"
+)
+
+var luggageSizeRank = map[string]int{
+	LuggageSizeNone:   0,
+	LuggageSizeSmall:  1,
+	LuggageSizeMedium: 2,
+	LuggageSizeLarge:  3,
+}
+
+// IsPreferenceCompatible checks the hard preferences of a ride offer and a ride request,
+// the genders are the ones of the driver and of the hitcher
+func IsPreferenceCompatible(offer migration.RidePreferences, driverGender string, request migration.RidePreferences, hitcherGender string) bool {
+	if (offer.SameGender || request.SameGender) && driverGender != hitcherGender {
+		return false
+	}
+	if request.PetsAllowed && !offer.PetsAllowed {
+		return false
+	}
+	return luggageRank(request.LuggageSize) <= luggageRank(offer.LuggageSize)
+}
+
+// PreferenceAgreement returns the share (between 0 and 1) of the soft preferences asked by either
+// user that both users agree on, it is 1 when no soft preference is asked
+func PreferenceAgreement(offer, request migration.RidePreferences) float64 {
+	asked, agreed := 0, 0
+	for _, pair := range [][2]bool{
+		{offer.NoSmoking, request.NoSmoking},
+		{offer.QuietRide, request.QuietRide},
+	} {
+		if !pair[0] && !pair[1] {
+			continue
+		}
+		asked++
+		if pair[0] == pair[1] {
+			agreed++
+		}
+	}
+
+	if asked == 0 {
+		return 1
+	}
+	return float64(agreed) / float64(asked)
+}
+
+// ToRidePreferences converts the requested preferences, the luggage size defaults to small
+func ToRidePreferences(preferences schemas.RidePreferences) migration.RidePreferences {
+	if preferences.LuggageSize == "" {
+		preferences.LuggageSize = LuggageSizeSmall
+	}
+	return migration.RidePreferences{
+		SameGender:  preferences.SameGender,
+		PetsAllowed: preferences.PetsAllowed,
+		LuggageSize: preferences.LuggageSize,
+		NoSmoking:   preferences.NoSmoking,
+		QuietRide:   preferences.QuietRide,
+	}
+}
+
+// ToRidePreferencesDetail converts the stored preferences for the responses
+func ToRidePreferencesDetail(preferences migration.RidePreferences) schemas.RidePreferences {
+	return schemas.RidePreferences{
+		SameGender:  preferences.SameGender,
+		PetsAllowed: preferences.PetsAllowed,
+		LuggageSize: preferences.LuggageSize,
+		NoSmoking:   preferences.NoSmoking,
+		QuietRide:   preferences.QuietRide,
+	}
+}
+
+// luggageRank orders the luggage sizes, an unknown size counts as small
+func luggageRank(size string) int {
+	rank, ok := luggageSizeRank[size]
+	if !ok {
+		return luggageSizeRank[LuggageSizeSmall]
+	}
+	return rank
+}
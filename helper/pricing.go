@@ -0,0 +1,227 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+	"shareway/schemas"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Strategies a base fare can be computed with
+const (
+	FareStrategyFuelCost = "fuel_cost" // Share the fuel cost of the trip (default)
+	FareStrategyPerKm    = "per_km"    // Charge a rate per kilometer depending on the vehicle type
+)
+
+// Names of the strategies adjusting the base fare
+const (
+	fareStrategyTimeOfDay  = "time_of_day"
+	fareStrategyMinimum    = "minimum_fare"
+	fareStrategyCommission = "platform_commission"
+)
+
+// FareStrategy is one step of the fare computation, it updates the quote built by the previous steps
+type FareStrategy interface {
+	Name() string
+	Apply(quote *schemas.FareQuote)
+}
+
+// FuelCostStrategy prices a route at the cost of the fuel (or electricity) used to drive it
+type FuelCostStrategy struct{}
+
+func (FuelCostStrategy) Name() string {
+	return FareStrategyFuelCost
+}
+
+func (s FuelCostStrategy) Apply(quote *schemas.FareQuote) {
+	input := quote.Input
+	addFareComponent(quote, s.Name(), (input.FuelConsumed/100)*input.FuelPrice*input.Distance)
+}
+
+// PerKmRateStrategy prices a route at the rate of the vehicle type,
+// DefaultRate is used for vehicle types without a rate
+type PerKmRateStrategy struct {
+	DefaultRate float64
+}
+
+func (PerKmRateStrategy) Name() string {
+	return FareStrategyPerKm
+}
+
+func (s PerKmRateStrategy) Apply(quote *schemas.FareQuote) {
+	if quote.Input.PerKmRate <= 0 {
+		quote.Input.PerKmRate = s.DefaultRate
+	}
+	addFareComponent(quote, s.Name(), quote.Input.PerKmRate*quote.Input.Distance)
+}
+
+// HourRange is a range of hours of the day, From included and To excluded
+type HourRange struct {
+	From int
+	To   int
+}
+
+func (h HourRange) contains(hour int) bool {
+	return hour >= h.From && hour < h.To
+}
+
+// TimeOfDayStrategy multiplies the fare of the rides starting during peak hours
+type TimeOfDayStrategy struct {
+	PeakHours  []HourRange
+	Multiplier float64
+	Location   *time.Location // Time zone the peak hours are given in
+}
+
+func (TimeOfDayStrategy) Name() string {
+	return fareStrategyTimeOfDay
+}
+
+func (s TimeOfDayStrategy) Apply(quote *schemas.FareQuote) {
+	hour := quote.Input.StartTime.In(s.Location).Hour()
+	for _, peak := range s.PeakHours {
+		if peak.contains(hour) {
+			addFareComponent(quote, s.Name(), quote.Fare*(s.Multiplier-1))
+			return
+		}
+	}
+}
+
+// MinimumFareStrategy raises the fare of short rides to a minimum
+type MinimumFareStrategy struct {
+	MinimumFare float64
+}
+
+func (MinimumFareStrategy) Name() string {
+	return fareStrategyMinimum
+}
+
+func (s MinimumFareStrategy) Apply(quote *schemas.FareQuote) {
+	if quote.Fare < s.MinimumFare {
+		addFareComponent(quote, s.Name(), s.MinimumFare-quote.Fare)
+	}
+}
+
+// PlatformCommissionStrategy keeps a share of the fare for the platform,
+// the hitcher pays the same fare and the driver earns the rest
+type PlatformCommissionStrategy struct {
+	Rate float64 // between 0 and 1
+}
+
+func (PlatformCommissionStrategy) Name() string {
+	return fareStrategyCommission
+}
+
+func (s PlatformCommissionStrategy) Apply(quote *schemas.FareQuote) {
+	quote.Commission = quote.Fare * s.Rate
+}
+
+func addFareComponent(quote *schemas.FareQuote, strategy string, amount float64) {
+	quote.Breakdown = append(quote.Breakdown, schemas.FareComponent{
+		Strategy: strategy,
+		Amount:   amount,
+	})
+	quote.Fare += amount
+}
+
+// FareSettings holds the configuration of the fare pricing engine
+type FareSettings struct {
+	Strategy         string // Base strategy, fuel_cost or per_km
+	DefaultPerKmRate float64
+	PeakHours        []HourRange
+	PeakMultiplier   float64 // 1 disables the time-of-day multiplier
+	Location         *time.Location
+	MinimumFare      float64 // 0 disables the minimum fare
+	CommissionRate   float64 // 0 disables the platform commission
+}
+
+// FareEngine prices a route by applying its strategies in order, the first one computes the base fare
+type FareEngine struct {
+	Strategies []FareStrategy
+}
+
+// NewFareEngine builds the chain of strategies described by the settings
+func NewFareEngine(settings FareSettings) FareEngine {
+	var base FareStrategy = FuelCostStrategy{}
+	if settings.Strategy == FareStrategyPerKm {
+		base = PerKmRateStrategy{DefaultRate: settings.DefaultPerKmRate}
+	}
+
+	strategies := []FareStrategy{base}
+	if settings.PeakMultiplier > 0 && settings.PeakMultiplier != 1 && len(settings.PeakHours) > 0 {
+		location := settings.Location
+		if location == nil {
+			location = time.UTC
+		}
+		strategies = append(strategies, TimeOfDayStrategy{
+			PeakHours:  settings.PeakHours,
+			Multiplier: settings.PeakMultiplier,
+			Location:   location,
+		})
+	}
+	if settings.MinimumFare > 0 {
+		strategies = append(strategies, MinimumFareStrategy{MinimumFare: settings.MinimumFare})
+	}
+	if settings.CommissionRate > 0 {
+		strategies = append(strategies, PlatformCommissionStrategy{Rate: settings.CommissionRate})
+	}
+
+	return FareEngine{Strategies: strategies}
+}
+
+// Quote computes the fare of a route and explains every step of the computation
+func (e FareEngine) Quote(input schemas.FareInput) schemas.FareQuote {
+	quote := schemas.FareQuote{
+		Input:     input,
+		Breakdown: make([]schemas.FareComponent, 0, len(e.Strategies)),
+	}
+	if len(e.Strategies) > 0 {
+		quote.Strategy = e.Strategies[0].Name()
+	}
+
+	for _, strategy := range e.Strategies {
+		strategy.Apply(&quote)
+	}
+	quote.DriverEarning = quote.Fare - quote.Commission
+
+	return quote
+}
+
+// ParseHourRanges parses a comma separated list of hour ranges such as "7-9,17-19"
+func ParseHourRanges(input string) ([]HourRange, error) {
+	var ranges []HourRange
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		bounds := strings.SplitN(part, "-", 2)
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid hour range %q", part)
+		}
+		from, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hour range %q: %w", part, err)
+		}
+		to, err := strconv.Atoi(strings.TrimSpace(bounds[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hour range %q: %w", part, err)
+		}
+		if from < 0 || to > 24 || from >= to {
+			return nil, fmt.Errorf("invalid hour range %q", part)
+		}
+
+		ranges = append(ranges, HourRange{From: from, To: to})
+	}
+	return ranges, nil
+}
+
+// UTCOffsetLocation returns a fixed time zone shifted from UTC by the given number of hours
+func UTCOffsetLocation(hours float64) *time.Location {
+	if hours == 0 {
+		return time.UTC
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%+g", hours), int(math.Round(hours*3600)))
+}
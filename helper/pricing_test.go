@@ -0,0 +1,141 @@
+package helper
+
+import (
+	"math"
+	"reflect"
+	"shareway/schemas"
+	"testing"
+	"time"
+)
+
+func TestFareEngineQuote(t *testing.T) {
+	offPeak := time.Date(2024, 11, 4, 5, 0, 0, 0, time.UTC) // 12:00 at UTC+7
+	peak := time.Date(2024, 11, 4, 0, 30, 0, 0, time.UTC)   // 07:30 at UTC+7
+	peakHours := []HourRange{{From: 7, To: 9}, {From: 17, To: 19}}
+
+	tests := []struct {
+		name           string
+		settings       FareSettings
+		input          schemas.FareInput
+		wantStrategies []string
+		wantFare       float64
+		wantCommission float64
+	}{
+		{
+			name:           "fuel cost",
+			settings:       FareSettings{},
+			input:          schemas.FareInput{Distance: 100, FuelConsumed: 7, FuelPrice: 20000, StartTime: offPeak},
+			wantStrategies: []string{FareStrategyFuelCost},
+			wantFare:       140000,
+		},
+		{
+			name:           "per km with the default rate",
+			settings:       FareSettings{Strategy: FareStrategyPerKm, DefaultPerKmRate: 5000},
+			input:          schemas.FareInput{Distance: 10, StartTime: offPeak},
+			wantStrategies: []string{FareStrategyPerKm},
+			wantFare:       50000,
+		},
+		{
+			name:           "per km with the vehicle type rate",
+			settings:       FareSettings{Strategy: FareStrategyPerKm, DefaultPerKmRate: 5000},
+			input:          schemas.FareInput{Distance: 10, PerKmRate: 8000, StartTime: offPeak},
+			wantStrategies: []string{FareStrategyPerKm},
+			wantFare:       80000,
+		},
+		{
+			name:           "peak hour in the local time zone",
+			settings:       FareSettings{PeakHours: peakHours, PeakMultiplier: 1.5, Location: UTCOffsetLocation(7)},
+			input:          schemas.FareInput{Distance: 100, FuelConsumed: 7, FuelPrice: 20000, StartTime: peak},
+			wantStrategies: []string{FareStrategyFuelCost, fareStrategyTimeOfDay},
+			wantFare:       210000,
+		},
+		{
+			name:           "off peak hour",
+			settings:       FareSettings{PeakHours: peakHours, PeakMultiplier: 1.5, Location: UTCOffsetLocation(7)},
+			input:          schemas.FareInput{Distance: 100, FuelConsumed: 7, FuelPrice: 20000, StartTime: offPeak},
+			wantStrategies: []string{FareStrategyFuelCost},
+			wantFare:       140000,
+		},
+		{
+			name:           "minimum fare",
+			settings:       FareSettings{MinimumFare: 20000},
+			input:          schemas.FareInput{Distance: 1, FuelConsumed: 7, FuelPrice: 20000, StartTime: offPeak},
+			wantStrategies: []string{FareStrategyFuelCost, fareStrategyMinimum},
+			wantFare:       20000,
+		},
+		{
+			name:           "platform commission",
+			settings:       FareSettings{CommissionRate: 0.1},
+			input:          schemas.FareInput{Distance: 100, FuelConsumed: 7, FuelPrice: 20000, StartTime: offPeak},
+			wantStrategies: []string{FareStrategyFuelCost},
+			wantFare:       140000,
+			wantCommission: 14000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := NewFareEngine(tt.settings).Quote(tt.input)
+
+			var strategies []string
+			for _, component := range quote.Breakdown {
+				strategies = append(strategies, component.Strategy)
+			}
+			if !reflect.DeepEqual(strategies, tt.wantStrategies) {
+				t.Errorf("Quote() breakdown = %v, want %v", strategies, tt.wantStrategies)
+			}
+			if quote.Strategy != tt.wantStrategies[0] {
+				t.Errorf("Quote() strategy = %q, want %q", quote.Strategy, tt.wantStrategies[0])
+			}
+			if math.Abs(quote.Fare-tt.wantFare) > 1e-6 {
+				t.Errorf("Quote() fare = %v, want %v", quote.Fare, tt.wantFare)
+			}
+			if math.Abs(quote.Commission-tt.wantCommission) > 1e-6 {
+				t.Errorf("Quote() commission = %v, want %v", quote.Commission, tt.wantCommission)
+			}
+			if math.Abs(quote.DriverEarning-(tt.wantFare-tt.wantCommission)) > 1e-6 {
+				t.Errorf("Quote() driver earning = %v, want %v", quote.DriverEarning, tt.wantFare-tt.wantCommission)
+			}
+		})
+	}
+}
+
+func TestParseHourRanges(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    []HourRange
+		wantErr bool
+	}{
+		{input: "", want: nil},
+		{input: "7-9", want: []HourRange{{From: 7, To: 9}}},
+		{input: " 7-9 , 17-19 ,", want: []HourRange{{From: 7, To: 9}, {From: 17, To: 19}}},
+		{input: "0-24", want: []HourRange{{From: 0, To: 24}}},
+		{input: "9-7", wantErr: true},
+		{input: "7-25", wantErr: true},
+		{input: "7", wantErr: true},
+		{input: "a-9", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseHourRanges(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseHourRanges(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseHourRanges(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUTCOffsetLocation(t *testing.T) {
+	if got := UTCOffsetLocation(0); got != time.UTC {
+		t.Errorf("UTCOffsetLocation(0) = %v, want UTC", got)
+	}
+
+	for hours, wantOffset := range map[float64]int{7: 7 * 3600, -3.5: -12600, 5.75: 20700} {
+		_, offset := time.Date(2024, 11, 4, 0, 0, 0, 0, UTCOffsetLocation(hours)).Zone()
+		if offset != wantOffset {
+			t.Errorf("UTCOffsetLocation(%v) offset = %d, want %d", hours, offset, wantOffset)
+		}
+	}
+}
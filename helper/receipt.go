@@ -0,0 +1,487 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"net/url"
+	"shareway/infra/db/migration"
+	"shareway/schemas"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/google/uuid"
+)
+
+// receiptLabels holds the text of the receipts in every supported language, vi is the default
+var receiptLabels = map[string]map[string]string{
+	"vi": {
+		"title":          "Biên lai chuyến đi",
+		"receipt_number": "Số biên lai",
+		"issued_at":      "Ngày xuất",
+		"trip":           "Chuyến đi",
+		"ride_id":        "Mã chuyến đi",
+		"start_time":     "Bắt đầu",
+		"end_time":       "Kết thúc",
+		"from":           "Điểm đón",
+		"to":             "Điểm trả",
+		"distance":       "Quãng đường",
+		"duration":       "Thời gian",
+		"route":          "Lộ trình",
+		"people":         "Tài xế và phương tiện",
+		"driver":         "Tài xế",
+		"passenger":      "Hành khách",
+		"vehicle":        "Phương tiện",
+		"license_plate":  "Biển số",
+		"payment":        "Thanh toán",
+		"fare":           "Tổng cước",
+		"payment_method": "Phương thức thanh toán",
+		"payment_status": "Trạng thái",
+		"momo_trans_id":  "Mã giao dịch MoMo",
+		"amount_paid":    "Đã thanh toán",
+		"footer":         "Cảm ơn bạn đã đồng hành cùng ShareWay",
+		"fuel_cost":      "Chi phí nhiên liệu",
+		"per_km":         "Cước theo km",
+		"time_of_day":    "Phụ phí giờ cao điểm",
+		"minimum_fare":   "Bù cước tối thiểu",
+		"cash":           "Tiền mặt",
+		"momo":           "Ví MoMo",
+		"pending":        "Chờ thanh toán",
+		"completed":      "Đã thanh toán",
+		"failed":         "Thất bại",
+		"cancelled":      "Đã hủy",
+		"refunded":       "Đã hoàn tiền",
+		"hour":           "giờ",
+		"minute":         "phút",
+	},
+	"en": {
+		"title":          "Ride receipt",
+		"receipt_number": "Receipt number",
+		"issued_at":      "Issued on",
+		"trip":           "Trip",
+		"ride_id":        "Ride ID",
+		"start_time":     "Started",
+		"end_time":       "Ended",
+		"from":           "Pickup",
+		"to":             "Dropoff",
+		"distance":       "Distance",
+		"duration":       "Duration",
+		"route":          "Route",
+		"people":         "Driver and vehicle",
+		"driver":         "Driver",
+		"passenger":      "Passenger",
+		"vehicle":        "Vehicle",
+		"license_plate":  "License plate",
+		"payment":        "Payment",
+		"fare":           "Total fare",
+		"payment_method": "Payment method",
+		"payment_status": "Status",
+		"momo_trans_id":  "MoMo transaction ID",
+		"amount_paid":    "Amount paid",
+		"footer":         "Thank you for riding with ShareWay",
+		"fuel_cost":      "Fuel cost",
+		"per_km":         "Distance rate",
+		"time_of_day":    "Peak hour surcharge",
+		"minimum_fare":   "Minimum fare adjustment",
+		"cash":           "Cash",
+		"momo":           "MoMo wallet",
+		"pending":        "Pending",
+		"completed":      "Paid",
+		"failed":         "Failed",
+		"cancelled":      "Cancelled",
+		"refunded":       "Refunded",
+		"hour":           "h",
+		"minute":         "min",
+	},
+}
+
+// Size of the route map of the receipts, in millimeters for the PDF and in pixels (x4) for the HTML
+const (
+	receiptMapWidth  = 180
+	receiptMapHeight = 80
+	receiptMapMargin = 6
+)
+
+// RideReceiptURL returns the link to the PDF receipt of a ride
+func RideReceiptURL(baseURL string, rideID uuid.UUID) string {
+	return baseURL + "?rideID=" + url.QueryEscape(rideID.String())
+}
+
+// LatestTransaction returns the most recent transaction of a ride, nil if there is none
+func LatestTransaction(transactions []migration.Transaction) *migration.Transaction {
+	var latest *migration.Transaction
+	for i := range transactions {
+		if latest == nil || transactions[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &transactions[i]
+		}
+	}
+	return latest
+}
+
+// BuildRideReceipt gathers what is printed on the receipt of a ride from the stored ride, its offer, its request
+// and its transaction. The times are shown in the given time zone
+func BuildRideReceipt(ride migration.Ride, lang string, location *time.Location) (schemas.RideReceipt, error) {
+	if _, ok := receiptLabels[lang]; !ok {
+		lang = "vi"
+	}
+
+	var quote schemas.FareQuote
+	if len(ride.FareDetails) > 0 {
+		raw, err := json.Marshal(ride.FareDetails)
+		if err != nil {
+			return schemas.RideReceipt{}, fmt.Errorf("error marshaling fare details: %v", err)
+		}
+		if err := json.Unmarshal(raw, &quote); err != nil {
+			return schemas.RideReceipt{}, fmt.Errorf("error unmarshaling fare details: %v", err)
+		}
+	}
+
+	endTime := ride.EndTime.In(location)
+	receipt := schemas.RideReceipt{
+		ReceiptNumber:  fmt.Sprintf("SW-%s-%s", endTime.Format("20060102"), strings.ToUpper(ride.ID.String()[:8])),
+		Lang:           lang,
+		RideID:         ride.ID,
+		IssuedAt:       endTime,
+		StartTime:      ride.StartTime.In(location),
+		EndTime:        endTime,
+		StartAddress:   ride.StartAddress,
+		EndAddress:     ride.EndAddress,
+		PickupAddress:  ride.PickupAddress,
+		DropoffAddress: ride.DropoffAddress,
+		Distance:       ride.Distance,
+		Duration:       ride.Duration,
+		Route:          DecodePolyline(string(ride.EncodedPolyline)),
+		FareStrategy:   ride.FareStrategy,
+		FareBreakdown:  quote.Breakdown,
+		Fare:           ride.Fare,
+		MomoTransID:    ride.RideRequest.MomoTransID,
+		DriverName:     ride.RideOffer.User.FullName,
+		PassengerName:  ride.RideRequest.User.FullName,
+		VehicleName:    ride.Vehicle.Name,
+		LicensePlate:   ride.Vehicle.LicensePlate,
+	}
+	if transaction := LatestTransaction(ride.Transactions); transaction != nil {
+		receipt.PaymentMethod = transaction.PaymentMethod
+		receipt.PaymentStatus = transaction.Status
+		if transaction.Status == "completed" {
+			receipt.AmountPaid = transaction.Amount
+		}
+	}
+	return receipt, nil
+}
+
+// receiptRow is a label and its value on a receipt
+type receiptRow struct {
+	Label string
+	Value string
+}
+
+// receiptSection is a titled group of rows on a receipt
+type receiptSection struct {
+	Title string
+	Rows  []receiptRow
+}
+
+// receiptView is the receipt as it is printed, shared by the HTML and the PDF so both show the same
+type receiptView struct {
+	Lang       string
+	Title      string
+	Header     []receiptRow
+	Sections   []receiptSection
+	RouteTitle string
+	Route      [][2]float64 // Points of the route map, in the units of the map
+	Footer     string
+}
+
+func newReceiptView(receipt schemas.RideReceipt) receiptView {
+	labels := receiptLabels[receipt.Lang]
+	if labels == nil {
+		labels = receiptLabels["vi"]
+	}
+	label := func(key string) string {
+		if text, ok := labels[key]; ok {
+			return text
+		}
+		return key
+	}
+
+	from := receipt.PickupAddress
+	if from == "" {
+		from = receipt.StartAddress
+	}
+	to := receipt.DropoffAddress
+	if to == "" {
+		to = receipt.EndAddress
+	}
+
+	payment := receiptSection{Title: label("payment")}
+	for _, component := range receipt.FareBreakdown {
+		payment.Rows = append(payment.Rows, receiptRow{label(component.Strategy), formatReceiptMoney(component.Amount, receipt.Lang)})
+	}
+	payment.Rows = append(payment.Rows,
+		receiptRow{label("fare"), formatReceiptMoney(receipt.Fare, receipt.Lang)},
+		receiptRow{label("payment_method"), label(receipt.PaymentMethod)},
+		receiptRow{label("payment_status"), label(receipt.PaymentStatus)},
+	)
+	if receipt.PaymentMethod == "momo" && receipt.MomoTransID != 0 {
+		payment.Rows = append(payment.Rows, receiptRow{label("momo_trans_id"), fmt.Sprintf("%d", receipt.MomoTransID)})
+	}
+	payment.Rows = append(payment.Rows, receiptRow{label("amount_paid"), formatReceiptMoney(receipt.AmountPaid, receipt.Lang)})
+
+	return receiptView{
+		Lang:  receipt.Lang,
+		Title: label("title"),
+		Header: []receiptRow{
+			{label("receipt_number"), receipt.ReceiptNumber},
+			{label("issued_at"), formatReceiptTime(receipt.IssuedAt, receipt.Lang)},
+		},
+		Sections: []receiptSection{
+			{
+				Title: label("trip"),
+				Rows: []receiptRow{
+					{label("ride_id"), receipt.RideID.String()},
+					{label("start_time"), formatReceiptTime(receipt.StartTime, receipt.Lang)},
+					{label("end_time"), formatReceiptTime(receipt.EndTime, receipt.Lang)},
+					{label("from"), from},
+					{label("to"), to},
+					{label("distance"), fmt.Sprintf("%.1f km", receipt.Distance)},
+					{label("duration"), formatReceiptDuration(receipt.Duration, labels)},
+				},
+			},
+			{
+				Title: label("people"),
+				Rows: []receiptRow{
+					{label("driver"), receipt.DriverName},
+					{label("passenger"), receipt.PassengerName},
+					{label("vehicle"), receipt.VehicleName},
+					{label("license_plate"), receipt.LicensePlate},
+				},
+			},
+			payment,
+		},
+		RouteTitle: label("route"),
+		Route:      projectReceiptRoute(receipt.Route),
+		Footer:     label("footer"),
+	}
+}
+
+// formatReceiptMoney formats an amount in VND with the separators of the language
+func formatReceiptMoney(amount float64, lang string) string {
+	digits := fmt.Sprintf("%d", int64(math.Round(amount)))
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(digits, "-")
+
+	separator := "."
+	if lang == "en" {
+		separator = ","
+	}
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteString(separator)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+	if lang == "en" {
+		return sign + "VND " + grouped.String()
+	}
+	return sign + grouped.String() + " ₫"
+}
+
+func formatReceiptTime(t time.Time, lang string) string {
+	if lang == "en" {
+		return t.Format("Jan 2, 2006 15:04")
+	}
+	return t.Format("15:04 02/01/2006")
+}
+
+func formatReceiptDuration(seconds int, labels map[string]string) string {
+	minutes := int(math.Round(float64(seconds) / 60))
+	if minutes < 60 {
+		return fmt.Sprintf("%d %s", minutes, labels["minute"])
+	}
+	return fmt.Sprintf("%d %s %d %s", minutes/60, labels["hour"], minutes%60, labels["minute"])
+}
+
+// projectReceiptRoute fits the route in the map of the receipt, north up, keeping its proportions
+func projectReceiptRoute(route []schemas.Point) [][2]float64 {
+	if len(route) < 2 {
+		return nil
+	}
+
+	minLat, maxLat, minLng, maxLng := route[0].Lat, route[0].Lat, route[0].Lng, route[0].Lng
+	for _, point := range route {
+		minLat = math.Min(minLat, point.Lat)
+		maxLat = math.Max(maxLat, point.Lat)
+		minLng = math.Min(minLng, point.Lng)
+		maxLng = math.Max(maxLng, point.Lng)
+	}
+
+	// A degree of longitude shrinks away from the equator
+	lngScale := math.Cos((minLat + maxLat) / 2 * math.Pi / 180)
+	spanX := (maxLng - minLng) * lngScale
+	spanY := maxLat - minLat
+	width := float64(receiptMapWidth - 2*receiptMapMargin)
+	height := float64(receiptMapHeight - 2*receiptMapMargin)
+	scale := math.Min(width/math.Max(spanX, 1e-9), height/math.Max(spanY, 1e-9))
+	offsetX := receiptMapMargin + (width-spanX*scale)/2
+	offsetY := receiptMapMargin + (height-spanY*scale)/2
+
+	points := make([][2]float64, len(route))
+	for i, point := range route {
+		points[i] = [2]float64{
+			offsetX + (point.Lng-minLng)*lngScale*scale,
+			offsetY + (maxLat-point.Lat)*scale,
+		}
+	}
+	return points
+}
+
+var receiptHTMLTemplate = template.Must(template.New("receipt").Funcs(template.FuncMap{
+	"svgPoints": func(points [][2]float64) string {
+		var b strings.Builder
+		for i, point := range points {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			fmt.Fprintf(&b, "%.2f,%.2f", point[0]*4, point[1]*4)
+		}
+		return b.String()
+	},
+	"first": func(points [][2]float64) [2]float64 { return points[0] },
+	"last":  func(points [][2]float64) [2]float64 { return points[len(points)-1] },
+	"px":    func(value float64) string { return fmt.Sprintf("%.2f", value*4) },
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: "DejaVu Sans", Arial, sans-serif; color: #222; max-width: 760px; margin: 24px auto; padding: 0 16px; }
+h1 { font-size: 24px; margin-bottom: 4px; }
+h2 { font-size: 16px; border-bottom: 1px solid #ddd; padding-bottom: 4px; margin-top: 24px; }
+table { width: 100%; border-collapse: collapse; }
+td { padding: 4px 0; vertical-align: top; }
+td.label { color: #666; width: 40%; }
+svg { width: 100%; height: auto; background: #f4f6f8; border-radius: 4px; }
+footer { margin-top: 32px; color: #666; text-align: center; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>{{range .Header}}<tr><td class="label">{{.Label}}</td><td>{{.Value}}</td></tr>{{end}}</table>
+{{range .Sections}}<h2>{{.Title}}</h2>
+<table>{{range .Rows}}<tr><td class="label">{{.Label}}</td><td>{{.Value}}</td></tr>{{end}}</table>
+{{end}}{{if .Route}}<h2>{{.RouteTitle}}</h2>
+<svg viewBox="0 0 720 320" xmlns="http://www.w3.org/2000/svg">
+<polyline points="{{svgPoints .Route}}" fill="none" stroke="#1a73e8" stroke-width="4" stroke-linejoin="round" stroke-linecap="round"/>
+{{with first .Route}}<circle cx="{{px (index . 0)}}" cy="{{px (index . 1)}}" r="8" fill="#34a853"/>{{end}}
+{{with last .Route}}<circle cx="{{px (index . 0)}}" cy="{{px (index . 1)}}" r="8" fill="#ea4335"/>{{end}}
+</svg>
+{{end}}<footer>{{.Footer}}</footer>
+</body>
+</html>
+`))
+
+// RenderReceiptHTML renders a receipt as a standalone HTML page
+func RenderReceiptHTML(receipt schemas.RideReceipt) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := receiptHTMLTemplate.Execute(&buf, newReceiptView(receipt)); err != nil {
+		return nil, fmt.Errorf("failed to render receipt: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderReceiptPDF renders a receipt as an A4 PDF with the given TrueType font. The dates of the document are
+// the ones of the receipt so the same receipt always gives the same file
+func RenderReceiptPDF(receipt schemas.RideReceipt, font []byte) ([]byte, error) {
+	view := newReceiptView(receipt)
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetCatalogSort(true)
+	pdf.SetCreationDate(receipt.IssuedAt)
+	pdf.SetModificationDate(receipt.IssuedAt)
+	pdf.SetTitle(view.Title+" "+receipt.ReceiptNumber, true)
+	pdf.SetProducer("ShareWay", true)
+	pdf.AddUTF8FontFromBytes("receipt", "", font)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+
+	const labelWidth, valueWidth, lineHeight = 65.0, 115.0, 6.0
+	writeRows := func(rows []receiptRow) {
+		for _, row := range rows {
+			y := pdf.GetY()
+			pdf.SetTextColor(102, 102, 102)
+			pdf.MultiCell(labelWidth, lineHeight, row.Label, "", "L", false)
+			labelEnd := pdf.GetY()
+			pdf.SetXY(15+labelWidth, y)
+			pdf.SetTextColor(34, 34, 34)
+			pdf.MultiCell(valueWidth, lineHeight, row.Value, "", "L", false)
+			pdf.SetY(math.Max(labelEnd, pdf.GetY()))
+		}
+	}
+
+	pdf.SetFont("receipt", "", 18)
+	pdf.CellFormat(0, 10, view.Title, "", 1, "L", false, 0, "")
+	pdf.SetFont("receipt", "", 10)
+	writeRows(view.Header)
+
+	for _, section := range view.Sections {
+		pdf.Ln(4)
+		pdf.SetFont("receipt", "", 13)
+		pdf.SetTextColor(34, 34, 34)
+		pdf.CellFormat(0, 8, section.Title, "B", 1, "L", false, 0, "")
+		pdf.Ln(1)
+		pdf.SetFont("receipt", "", 10)
+		writeRows(section.Rows)
+	}
+
+	if len(view.Route) > 0 {
+		pdf.Ln(4)
+		pdf.SetFont("receipt", "", 13)
+		pdf.CellFormat(0, 8, view.RouteTitle, "B", 1, "L", false, 0, "")
+		pdf.Ln(2)
+		if pdf.GetY()+receiptMapHeight > 282 {
+			pdf.AddPage()
+		}
+
+		top := pdf.GetY()
+		left := 15.0
+		pdf.SetFillColor(244, 246, 248)
+		pdf.Rect(left, top, receiptMapWidth, receiptMapHeight, "F")
+		pdf.SetDrawColor(26, 115, 232)
+		pdf.SetLineWidth(0.8)
+		pdf.SetLineJoinStyle("round")
+		pdf.SetLineCapStyle("round")
+		for i := 1; i < len(view.Route); i++ {
+			pdf.Line(left+view.Route[i-1][0], top+view.Route[i-1][1], left+view.Route[i][0], top+view.Route[i][1])
+		}
+		start, end := view.Route[0], view.Route[len(view.Route)-1]
+		pdf.SetFillColor(52, 168, 83)
+		pdf.Circle(left+start[0], top+start[1], 1.8, "F")
+		pdf.SetFillColor(234, 67, 53)
+		pdf.Circle(left+end[0], top+end[1], 1.8, "F")
+		pdf.SetY(top + receiptMapHeight)
+	}
+
+	pdf.Ln(8)
+	pdf.SetFont("receipt", "", 10)
+	pdf.SetTextColor(102, 102, 102)
+	pdf.CellFormat(0, 6, view.Footer, "", 1, "C", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render receipt: %w", err)
+	}
+	return buf.Bytes(), nil
+}
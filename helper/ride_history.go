@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidRideHistoryCursor is returned when a cursor was not issued by EncodeRideHistoryCursor
+var ErrInvalidRideHistoryCursor = errors.New("invalid ride history cursor")
+
+// EncodeRideHistoryCursor builds the opaque cursor pointing after the given ride of the history,
+// the rides are ordered by start time then ID so the pair is unique
+func EncodeRideHistoryCursor(startTime time.Time, rideID uuid.UUID) string {
+	raw := startTime.UTC().Format(time.RFC3339Nano) + "|" + rideID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeRideHistoryCursor reads back the start time and the ID of the ride a cursor points after
+func DecodeRideHistoryCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidRideHistoryCursor
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, ErrInvalidRideHistoryCursor
+	}
+	startTime, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidRideHistoryCursor
+	}
+	rideID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidRideHistoryCursor
+	}
+	return startTime, rideID, nil
+}
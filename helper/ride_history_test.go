@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRideHistoryCursor(t *testing.T) {
+	rideID := uuid.MustParse("6f1c2b4e-8d3a-4c5e-9f7a-1b2c3d4e5f60")
+	startTime := time.Date(2024, 11, 4, 7, 30, 15, 123456789, UTCOffsetLocation(7))
+
+	cursor := EncodeRideHistoryCursor(startTime, rideID)
+	gotStartTime, gotRideID, err := DecodeRideHistoryCursor(cursor)
+	if err != nil {
+		t.Fatalf("DecodeRideHistoryCursor() error = %v", err)
+	}
+	if !gotStartTime.Equal(startTime) || gotRideID != rideID {
+		t.Errorf("DecodeRideHistoryCursor() = %v, %v, want %v, %v", gotStartTime, gotRideID, startTime, rideID)
+	}
+}
+
+func TestDecodeRideHistoryCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "not a cursor!"},
+		{"no separator", encode("2024-11-04T07:30:00Z")},
+		{"invalid time", encode("yesterday|6f1c2b4e-8d3a-4c5e-9f7a-1b2c3d4e5f60")},
+		{"invalid ride ID", encode("2024-11-04T07:30:00Z|42")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := DecodeRideHistoryCursor(tt.cursor); !errors.Is(err, ErrInvalidRideHistoryCursor) {
+				t.Errorf("DecodeRideHistoryCursor(%q) error = %v, want ErrInvalidRideHistoryCursor", tt.cursor, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+)
+
+// StatusEntity is the kind of record whose status follows the ride lifecycle
+type StatusEntity string
+
+const (
+	StatusEntityRide        StatusEntity = "ride"
+	StatusEntityRideOffer   StatusEntity = "ride_offer"
+	StatusEntityRideRequest StatusEntity = "ride_request"
+	StatusEntityTransaction StatusEntity = "transaction"
+)
+
+// ErrIllegalStatusTransition is matched (errors.Is) by every TransitionError
+var ErrIllegalStatusTransition = errors.New("illegal status transition")
+
+// TransitionError is returned when a status change is not allowed by the ride lifecycle
+type TransitionError struct {
+	Entity StatusEntity
+	From   string
+	To     string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s cannot go from %s to %s", e.Entity, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrIllegalStatusTransition
+}
+
+// statusTransitions lists, for each entity, the statuses reachable from every status.
+// Completed, cancelled, expired, no_show and refunded are final
+var statusTransitions = map[StatusEntity]map[string][]string{
+	StatusEntityRide: {
+		"scheduled": {"ongoing", "cancelled", "no_show"},
+		"ongoing":   {"completed", "cancelled"},
+	},
+	// A ride offer is shared by several passengers (see syncRideOfferStatus), so a matched
+	// ride offer reopens when a booking is cancelled before the trip starts
+	StatusEntityRideOffer: {
+		"created": {"matched", "ongoing", "cancelled", "expired"},
+		"matched": {"created", "ongoing", "cancelled"},
+		"ongoing": {"completed", "cancelled"},
+	},
+	StatusEntityRideRequest: {
+		"created": {"matched", "cancelled", "expired"},
+		"matched": {"ongoing", "cancelled", "expired"},
+		"ongoing": {"completed", "cancelled"},
+	},
+	StatusEntityTransaction: {
+		"pending":   {"completed", "failed", "cancelled"},
+		"failed":    {"pending", "cancelled"},
+		"completed": {"refunded"},
+	},
+}
+
+// CanTransitionStatus checks if the entity can go from one status to another
+func CanTransitionStatus(entity StatusEntity, from, to string) bool {
+	for _, next := range statusTransitions[entity][from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateStatusTransition returns a TransitionError if the entity cannot go from one status to another
+func ValidateStatusTransition(entity StatusEntity, from, to string) error {
+	if !CanTransitionStatus(entity, from, to) {
+		return &TransitionError{Entity: entity, From: from, To: to}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanTransitionStatus(t *testing.T) {
+	tests := []struct {
+		entity StatusEntity
+		from   string
+		to     string
+		want   bool
+	}{
+		{StatusEntityRide, "scheduled", "ongoing", true},
+		{StatusEntityRide, "scheduled", "no_show", true},
+		{StatusEntityRide, "ongoing", "completed", true},
+		{StatusEntityRide, "scheduled", "completed", false},
+		{StatusEntityRide, "completed", "cancelled", false},
+		{StatusEntityRide, "cancelled", "scheduled", false},
+		{StatusEntityRideOffer, "created", "matched", true},
+		{StatusEntityRideOffer, "matched", "created", true},
+		{StatusEntityRideOffer, "expired", "created", false},
+		{StatusEntityRideOffer, "ongoing", "matched", false},
+		{StatusEntityRideRequest, "matched", "expired", true},
+		{StatusEntityRideRequest, "matched", "created", false},
+		{StatusEntityRideRequest, "completed", "ongoing", false},
+		{StatusEntityTransaction, "failed", "pending", true},
+		{StatusEntityTransaction, "completed", "refunded", true},
+		{StatusEntityTransaction, "refunded", "completed", false},
+		{StatusEntityTransaction, "pending", "refunded", false},
+		{StatusEntity("unknown"), "created", "matched", false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.entity)+"/"+tt.from+"->"+tt.to, func(t *testing.T) {
+			if got := CanTransitionStatus(tt.entity, tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransitionStatus(%q, %q, %q) = %v, want %v", tt.entity, tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateStatusTransition(t *testing.T) {
+	if err := ValidateStatusTransition(StatusEntityRide, "scheduled", "ongoing"); err != nil {
+		t.Errorf("ValidateStatusTransition() = %v, want nil", err)
+	}
+
+	err := ValidateStatusTransition(StatusEntityRide, "completed", "ongoing")
+	if !errors.Is(err, ErrIllegalStatusTransition) {
+		t.Fatalf("ValidateStatusTransition() = %v, want ErrIllegalStatusTransition", err)
+	}
+	var transitionErr *TransitionError
+	if !errors.As(err, &transitionErr) || transitionErr.From != "completed" || transitionErr.To != "ongoing" {
+		t.Errorf("ValidateStatusTransition() = %#v, want a TransitionError from completed to ongoing", err)
+	}
+	if want := "ride cannot go from completed to ongoing"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"shareway/infra/db/migration"
+	"shareway/schemas"
+)
+
+// ToSOSEventDetail converts an SOS event to the representation sent to the admins
+func ToSOSEventDetail(event migration.SOSEvent) schemas.SOSEventDetail {
+	return schemas.SOSEventDetail{
+		ID:              event.ID,
+		Status:          event.Status,
+		UserID:          event.UserID,
+		UserFullName:    event.User.FullName,
+		UserPhoneNumber: event.User.PhoneNumber,
+		RideID:          event.RideID,
+		Location:        schemas.Point{Lat: event.Latitude, Lng: event.Longitude},
+		Message:         event.Message,
+		RideSnapshot:    event.RideSnapshot,
+		ContactsAlerted: event.ContactsAlerted,
+		CreatedAt:       event.CreatedAt,
+		AcknowledgedAt:  event.AcknowledgedAt,
+		AcknowledgedBy:  event.AcknowledgedBy,
+		ResolvedAt:      event.ResolvedAt,
+		ResolvedBy:      event.ResolvedBy,
+		ResolutionNote:  event.ResolutionNote,
+	}
+}
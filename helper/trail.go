@@ -0,0 +1,108 @@
+package helper
+
+import (
+	"encoding/xml"
+	"fmt"
+	"shareway/infra/db/migration"
+	"shareway/schemas"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define the GPX 1.1 document of a ride trail
+type gpxDocument struct {
+	XMLName xml.Name `xml:"gpx"`
+	Version string   `xml:"version,attr"`
+	Creator string   `xml:"creator,attr"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Track   gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Name    string     `xml:"name"`
+	Segment gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Latitude  float64 `xml:"lat,attr"`
+	Longitude float64 `xml:"lon,attr"`
+	Time      string  `xml:"time"`
+}
+
+// ToRideTrailGeoJSON converts the location samples of a ride, in the order they were recorded, to a GeoJSON line
+func ToRideTrailGeoJSON(rideID uuid.UUID, samples []migration.RideLocationSample) schemas.RideTrailFeatureCollection {
+	properties := schemas.RideTrailFeatureProperties{
+		RideID:          rideID,
+		Distance:        TrailDistance(samples),
+		CoordinateTimes: make([]time.Time, len(samples)),
+		Accuracies:      make([]*float64, len(samples)),
+		Speeds:          make([]*float64, len(samples)),
+		Headings:        make([]*float64, len(samples)),
+	}
+	coordinates := make([][2]float64, len(samples))
+	for i, sample := range samples {
+		coordinates[i] = [2]float64{sample.Longitude, sample.Latitude}
+		properties.CoordinateTimes[i] = sample.RecordedAt
+		properties.Accuracies[i] = sample.Accuracy
+		properties.Speeds[i] = sample.Speed
+		properties.Headings[i] = sample.Heading
+	}
+	if len(samples) > 0 {
+		properties.StartTime = &samples[0].RecordedAt
+		properties.EndTime = &samples[len(samples)-1].RecordedAt
+	}
+
+	return schemas.RideTrailFeatureCollection{
+		Type: "FeatureCollection",
+		Features: []schemas.RideTrailFeature{
+			{
+				Type: "Feature",
+				Geometry: schemas.RideTrailGeometry{
+					Type:        "LineString",
+					Coordinates: coordinates,
+				},
+				Properties: properties,
+			},
+		},
+	}
+}
+
+// ToRideTrailGPX converts the location samples of a ride, in the order they were recorded, to a GPX track
+func ToRideTrailGPX(rideID uuid.UUID, samples []migration.RideLocationSample) ([]byte, error) {
+	document := gpxDocument{
+		Version: "1.1",
+		Creator: "shareway",
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Track: gpxTrack{
+			Name:    fmt.Sprintf("Ride %s", rideID),
+			Segment: gpxSegment{Points: make([]gpxPoint, len(samples))},
+		},
+	}
+	for i, sample := range samples {
+		document.Track.Segment.Points[i] = gpxPoint{
+			Latitude:  sample.Latitude,
+			Longitude: sample.Longitude,
+			Time:      sample.RecordedAt.UTC().Format(time.RFC3339),
+		}
+	}
+
+	data, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode GPX: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// TrailDistance returns the distance in kilometers along the location samples
+func TrailDistance(samples []migration.RideLocationSample) float64 {
+	distance := 0.0
+	for i := 1; i < len(samples); i++ {
+		distance += HaversineDistance(samples[i-1].Latitude, samples[i-1].Longitude, samples[i].Latitude, samples[i].Longitude)
+	}
+	return distance
+}
@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+)
+
+const tripShareTokenBytes = 32
+
+// GenerateTripShareToken returns a random token for a public link to a ride, it cannot be guessed
+func GenerateTripShareToken() (string, error) {
+	b := make([]byte, tripShareTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate trip share token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashTripShareToken returns what is stored of a trip share token, so a leaked database does not leak the links
+func HashTripShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// TripShareURL returns the public link to a shared trip
+func TripShareURL(baseURL, token string) string {
+	return baseURL + "?token=" + url.QueryEscape(token)
+}
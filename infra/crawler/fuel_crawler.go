@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	"shareway/helper"
 	"shareway/infra/db/migration"
 	"strconv"
 	"strings"
@@ -39,7 +40,8 @@ func (fc *FuelCrawler) UpdateFuelPrices() error {
 	return fc.SaveFuelPrices(prices)
 }
 
-// FetchFuelPrices fetches the latest fuel prices from the website
+// FetchFuelPrices fetches the latest fuel prices of every fuel type from the website
+// the most recent table comes first on the page, older tables do not override its prices
 func (fc *FuelCrawler) FetchFuelPrices() ([]migration.FuelPrice, error) {
 	url := "https://vnexpress.net/chu-de/gia-xang-dau-3026"
 
@@ -55,6 +57,7 @@ func (fc *FuelCrawler) FetchFuelPrices() ([]migration.FuelPrice, error) {
 	}
 
 	var prices []migration.FuelPrice
+	seen := make(map[string]bool)
 
 	doc.Find("table").Each(func(i int, tableHTML *goquery.Selection) {
 		tableHTML.Find("tr").Each(func(rowIndex int, rowHTML *goquery.Selection) {
@@ -65,6 +68,10 @@ func (fc *FuelCrawler) FetchFuelPrices() ([]migration.FuelPrice, error) {
 
 			if len(rowData) >= 2 && rowData[0] != "Mặt hàng" {
 				fuelType := rowData[0]
+				if seen[fuelType] {
+					return
+				}
+
 				priceStr := strings.ReplaceAll(rowData[1], ".", "")
 				price, err := strconv.ParseFloat(priceStr, 64)
 				if err != nil {
@@ -72,8 +79,10 @@ func (fc *FuelCrawler) FetchFuelPrices() ([]migration.FuelPrice, error) {
 					return
 				}
 
+				seen[fuelType] = true
 				prices = append(prices, migration.FuelPrice{
 					FuelType: fuelType,
+					Code:     helper.FuelTypeFromName(fuelType),
 					Price:    price,
 				})
 			}
@@ -100,6 +109,7 @@ func (fc *FuelCrawler) SaveFuelPrices(prices []migration.FuelPrice) error {
 				}
 			} else {
 				existingPrice.Price = price.Price
+				existingPrice.Code = price.Code
 				if err := tx.Save(&existingPrice).Error; err != nil {
 					return err
 				}
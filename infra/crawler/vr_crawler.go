@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 	"regexp"
+	"shareway/helper"
 	"shareway/infra/db/migration"
 	"strconv"
 	"strings"
@@ -14,10 +15,11 @@ import (
 )
 
 var (
-	brandRegex           = regexp.MustCompile(`(?i)Nhãn hiệu\s*:([^:;]+)`)
-	commercialNameRegex  = regexp.MustCompile(`(?i)Tên thương mại:\s*([^;]+)`)
-	fuelConsumptionRegex = regexp.MustCompile(`(?i)Mức tiêu thụ nhiên liệu công khai\s*:?\s*([\d,\.]+)\s*(?:[lL]ít|[lL])?\s*/\s*100\s*km`)
-	modelCodeRegex       = regexp.MustCompile(`(?i)Mã [Kk]iểu [Ll]oại:?\s*([^;:]+)`)
+	brandRegex             = regexp.MustCompile(`(?i)Nhãn hiệu\s*:([^:;]+)`)
+	commercialNameRegex    = regexp.MustCompile(`(?i)Tên thương mại:\s*([^;]+)`)
+	fuelConsumptionRegex   = regexp.MustCompile(`(?i)Mức tiêu thụ nhiên liệu công khai\s*:?\s*([\d,\.]+)\s*(?:[lL]ít|[lL])?\s*/\s*100\s*km`)
+	modelCodeRegex         = regexp.MustCompile(`(?i)Mã [Kk]iểu [Ll]oại:?\s*([^;:]+)`)
+	energyConsumptionRegex = regexp.MustCompile(`(?i)([\d,\.]+)\s*Wh\s*/\s*km`)
 )
 
 type IVrCrawler interface {
@@ -93,6 +95,7 @@ func (c *VrCrawler) UpdateOrCreateVehicles(vehicles []migration.VehicleType) err
 					return err
 				}
 			} else if result.Error == nil {
+				existingVehicle.FuelType = vehicle.FuelType
 				existingVehicle.FuelConsumed = vehicle.FuelConsumed
 				existingVehicle.UpdatedAt = time.Now().UTC()
 				if err := tx.Save(&existingVehicle).Error; err != nil {
@@ -107,10 +110,9 @@ func (c *VrCrawler) UpdateOrCreateVehicles(vehicles []migration.VehicleType) err
 }
 
 func extractVehicleInfo(content string) *migration.VehicleType {
-	// Skip entries related to cars or electric vehicles
+	// Skip entries related to cars
 	if strings.Contains(strings.ToLower(content), "xe hơi") ||
-		strings.Contains(strings.ToLower(content), "ô tô") ||
-		strings.Contains(strings.ToLower(content), "wh/km") {
+		strings.Contains(strings.ToLower(content), "ô tô") {
 		return nil
 	}
 
@@ -158,11 +160,18 @@ func extractVehicleInfo(content string) *migration.VehicleType {
 	name = strings.ReplaceAll(name, ":", "")
 	name = strings.TrimSpace(name)
 
+	fuelType := helper.FuelTypeRON95
 	var fuelConsumption float64
 	if len(fuelConsumptionMatch) > 1 {
 		fuelConsumptionStr := strings.TrimSpace(fuelConsumptionMatch[1])
 		fuelConsumptionStr = strings.Replace(fuelConsumptionStr, ",", ".", -1) // Replace comma with dot
 		fuelConsumption, _ = strconv.ParseFloat(fuelConsumptionStr, 64)
+	} else if energyConsumptionMatch := energyConsumptionRegex.FindStringSubmatch(content); len(energyConsumptionMatch) > 1 {
+		// Electric vehicles publish their consumption in Wh/km, stored as kWh/100km
+		energyConsumptionStr := strings.Replace(strings.TrimSpace(energyConsumptionMatch[1]), ",", ".", -1)
+		energyConsumption, _ := strconv.ParseFloat(energyConsumptionStr, 64)
+		fuelType = helper.FuelTypeElectric
+		fuelConsumption = energyConsumption / 10
 	}
 
 	// If we couldn't extract either name or fuel consumption, return nil
@@ -172,6 +181,7 @@ func extractVehicleInfo(content string) *migration.VehicleType {
 
 	return &migration.VehicleType{
 		Name:         name,
+		FuelType:     fuelType,
 		FuelConsumed: fuelConsumption,
 	}
 }
@@ -53,6 +53,21 @@ func NewDatabaseInstance(cfg util.Config) *gorm.DB {
 		log.Fatal().Err(err).Msg("Failed to migrate database")
 	}
 
+	// Index the route bounding boxes used by the suggestion prefilter
+	if err := migration.CreateRouteBoxIndexes(db); err != nil {
+		log.Fatal().Err(err).Msg("Failed to create route box indexes")
+	}
+
+	// Fill the route bounding boxes used by the suggestion prefilter
+	if err := migration.BackfillRouteBounds(db); err != nil {
+		log.Fatal().Err(err).Msg("Failed to backfill route bounds")
+	}
+
+	// Cache the ratings of the users rated before the average was stored on the user
+	if err := migration.BackfillUserRatings(db); err != nil {
+		log.Fatal().Err(err).Msg("Failed to backfill user ratings")
+	}
+
 	// Seed admin user
 	if err := migration.SeedAdmin(db, cfg); err != nil {
 		log.Fatal().Err(err).Msg("Failed to seed admin user")
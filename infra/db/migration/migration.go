@@ -1,7 +1,9 @@
 package migration
 
 import (
+	"fmt"
 	"shareway/util"
+	"shareway/util/polyline"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -20,9 +22,23 @@ func Migrate(db *gorm.DB) error {
 		&Transaction{},
 		&Vehicle{},
 		&RideRequest{},
+		&RecurringRideOffer{},
+		&RecurringRideOfferSkipDate{},
 		&RideOffer{},
 		&Waypoint{},
 		&Ride{},
+		&RideStatusHistory{},
+		&RouteAlert{},
+		&RouteAlertNotification{},
+		&PickupCodeAttempt{},
+		&GeofenceOverride{},
+		&RideLocationSample{},
+		&RideDeviationEvent{},
+		&EmergencyContact{},
+		&SOSEvent{},
+		&TripShare{},
+		&UserBlock{},
+		&UserReport{},
 		&Rating{},
 		&Notification{},
 		&Chat{},
@@ -32,6 +48,72 @@ func Migrate(db *gorm.DB) error {
 	)
 }
 
+// CreateRouteBoxIndexes adds the route_box column to the ride offers and the ride requests with a GiST index.
+// The column is a box generated from the bounding box of the route, so it follows every insert and update,
+// and the suggestion prefilter looks up the routes overlapping a box through the index. It replaces the
+// btree index on the bounding box columns, which could only narrow the scan on the minimum latitude
+func CreateRouteBoxIndexes(db *gorm.DB) error {
+	for _, table := range []string{"ride_offers", "ride_requests"} {
+		statements := []string{
+			fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS route_box box GENERATED ALWAYS AS (box(point(min_longitude, min_latitude), point(max_longitude, max_latitude))) STORED", table),
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_route_box ON %s USING gist (route_box)", table, table),
+			fmt.Sprintf("DROP INDEX IF EXISTS idx_%s_bbox", table),
+		}
+		for _, statement := range statements {
+			if err := db.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// BackfillRouteBounds fills the route bounding box of ride offers and ride requests
+// created before the bounding box columns existed, so they can still be suggested
+func BackfillRouteBounds(db *gorm.DB) error {
+	for _, model := range []interface{}{&RideOffer{}, &RideRequest{}} {
+		var rows []struct {
+			ID              uuid.UUID
+			EncodedPolyline polyline.Polyline
+		}
+		err := db.Model(model).
+			Select("id, encoded_polyline").
+			Where("min_latitude = 0 AND max_latitude = 0 AND min_longitude = 0 AND max_longitude = 0").
+			Scan(&rows).Error
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			minLat, maxLat, minLng, maxLng, err := row.EncodedPolyline.Bounds()
+			if err != nil {
+				// Skip routes that cannot be decoded, they will never match anyway
+				continue
+			}
+			err = db.Model(model).Where("id = ?", row.ID).Updates(map[string]interface{}{
+				"min_latitude":  minLat,
+				"max_latitude":  maxLat,
+				"min_longitude": minLng,
+				"max_longitude": maxLng,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// BackfillUserRatings caches the average rating and the number of ratings on the users rated before
+// they were cached
+func BackfillUserRatings(db *gorm.DB) error {
+	return db.Exec(`UPDATE users SET average_rating = r.average, rating_count = r.count
+		FROM (SELECT ratee_id, AVG(rating) AS average, COUNT(*) AS count FROM ratings GROUP BY ratee_id) r
+		WHERE users.id = r.ratee_id AND users.rating_count = 0`).Error
+}
+
 // DropAllTables removes all tables from the database
 func DropAllTables(db *gorm.DB) error {
 	// Drop tables in reverse order of dependencies to avoid foreign key constraint issues
@@ -43,9 +125,23 @@ func DropAllTables(db *gorm.DB) error {
 		&Transaction{},
 		&Vehicle{},
 		&RideRequest{},
+		&RecurringRideOffer{},
+		&RecurringRideOfferSkipDate{},
 		&RideOffer{},
 		&Waypoint{},
 		&Ride{},
+		&RideStatusHistory{},
+		&RouteAlert{},
+		&RouteAlertNotification{},
+		&PickupCodeAttempt{},
+		&GeofenceOverride{},
+		&RideLocationSample{},
+		&RideDeviationEvent{},
+		&EmergencyContact{},
+		&SOSEvent{},
+		&TripShare{},
+		&UserBlock{},
+		&UserReport{},
 		&Rating{},
 		&Notification{},
 		&Chat{},
@@ -26,6 +26,9 @@ type User struct {
 	Role        string `gorm:"default:'user'"`
 	DeviceToken string // FCM token for push notification
 
+	// Default preferences copied to the ride offers and ride requests of the user
+	RidePreferences RidePreferences `gorm:"embedded;embeddedPrefix:pref_"`
+
 	// MoMo Wallet fields
 	MomoFirstRequestID uuid.UUID `gorm:"type:uuid"` // First request ID to link MoMo wallet (and use for get recurringToken so must store)
 	MoMoCallbackToken  string    `gorm:"type:text"` // Token to verify callback from MoMo and get recurring token for later use
@@ -38,6 +41,10 @@ type User struct {
 	// New field for storing money received in app
 	BalanceInApp float64 `gorm:"default:0"` // Store balance in cents/smallest currency unit
 
+	// Ratings received, kept up to date with the ratings so the payloads embedding the user do not compute them
+	AverageRating float64 `gorm:"default:0"`
+	RatingCount   int     `gorm:"default:0"`
+
 	Vehicles          []Vehicle          // One-to-many relationship with Vehicle
 	RatingsReceived   []Rating           `gorm:"foreignKey:RateeID"` // One-to-many relationship with Rating (received)
 	RatingsGiven      []Rating           `gorm:"foreignKey:RaterID"` // One-to-many relationship with Rating (given)
@@ -49,6 +56,17 @@ type User struct {
 	ReceivedChats     []Chat             `gorm:"foreignKey:ReceiverID"` // One-to-many relationship with Chat (received)
 }
 
+// RidePreferences represents what a user expects from a ride, it is embedded in the user (defaults),
+// the ride offer (what the driver accepts) and the ride request (what the hitcher needs).
+// Same gender, pets and luggage are hard preferences filtering the suggestions, no smoking and quiet ride only rank them
+type RidePreferences struct {
+	SameGender  bool   `gorm:"default:false"`   // Only ride with users of the same gender
+	PetsAllowed bool   `gorm:"default:false"`   // Driver accepts pets, or hitcher travels with a pet
+	LuggageSize string `gorm:"default:'small'"` // none, small, medium, large (largest accepted by the driver, or carried by the hitcher)
+	NoSmoking   bool   `gorm:"default:false"`   // Prefers a no-smoking ride
+	QuietRide   bool   `gorm:"default:false"`   // Prefers a quiet ride
+}
+
 // Admin represents an administrator in the system
 type Admin struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
@@ -115,7 +133,7 @@ type Transaction struct {
 	Receiver      User      `gorm:"foreignKey:ReceiverID"`
 	Amount        float64
 	PaymentMethod string    `gorm:"default:'cash'"`    // cash, momo
-	Status        string    `gorm:"default:'pending'"` // pending, completed, failed, refunded
+	Status        string    `gorm:"default:'pending'"` // pending, completed, failed, cancelled, refunded
 	RideID        uuid.UUID `gorm:"type:uuid"`
 	Ride          Ride      `gorm:"foreignKey:RideID"`
 }
@@ -131,8 +149,10 @@ type Vehicle struct {
 	VehicleTypeID uuid.UUID   `gorm:"type:uuid"`
 	VehicleType   VehicleType `gorm:"foreignKey:VehicleTypeID"`
 	Name          string
-	CaVet         string      `gorm:"uniqueIndex"` // Certificate of vehicle registration each vehicle has a unique number
-	FuelConsumed  float64     `gorm:"default:0"`   // liters per 100 kilometers
+	CaVet         string      `gorm:"uniqueIndex"`     // Certificate of vehicle registration each vehicle has a unique number
+	FuelType      string      `gorm:"default:'ron95'"` // ron95, e5_ron92, diesel, electric
+	FuelConsumed  float64     `gorm:"default:0"`       // liters (kWh for electric vehicles) per 100 kilometers
+	SeatCapacity  int         `gorm:"default:1"`       // Number of passenger seats the vehicle can carry
 	RideOffers    []RideOffer // One-to-many relationship with RideOffer
 }
 
@@ -151,16 +171,93 @@ type RideOffer struct {
 	EncodedPolyline        polyline.Polyline `gorm:"type:text"` // Store the overview_polyline here
 	DriverCurrentLatitude  float64
 	DriverCurrentLongitude float64
-	StartAddress           string  `gorm:"type:text"`
-	EndAddress             string  `gorm:"type:text"`
-	Distance               float64 // in kilometers
-	Duration               int     // in seconds
-	Status                 string  `gorm:"default:'created'"` // created, matched, ongoing, completed, cancelled
-	Rides                  []Ride  `gorm:"foreignKey:RideOfferID"`
-	StartTime              time.Time
+	DriverLocationAt       *time.Time // When the current location of the driver was recorded
+	StartAddress           string     `gorm:"type:text"`
+	EndAddress             string     `gorm:"type:text"`
+	EndPlaceID             string     // Goong place ID of the destination picked by the user, empty for the occurrences of a recurring ride offer
+	Distance               float64    // in kilometers
+	Duration               int        // in seconds
+	Status                 string     `gorm:"default:'created';index:idx_ride_offers_status_time,priority:1"` // created, matched, ongoing, completed, cancelled, expired
+	Rides                  []Ride     `gorm:"foreignKey:RideOfferID"`
+	StartTime              time.Time  `gorm:"index:idx_ride_offers_status_time,priority:2"`
 	EndTime                time.Time  // Time to end the ride (end time = start time + duration)
-	Fare                   float64    // Total price of the ride offer (to show to the hitchhiker)
-	Waypoints              []Waypoint `gorm:"foreignKey:RideOfferID"`
+	MinLatitude            float64    // Bounding box of the route, indexed through the generated route_box column (see CreateRouteBoxIndexes)
+	MaxLatitude            float64
+	MinLongitude           float64
+	MaxLongitude           float64
+	Fare                   float64         // Total price of the ride offer (to show to the hitchhiker)
+	FareStrategy           string          // Pricing strategy the fare was computed with
+	FareDetails            jsonb.JSONB     `gorm:"type:jsonb"` // Inputs and breakdown of the fare (schemas.FareQuote), kept for audit
+	TotalSeats             int             `gorm:"default:1"`  // Number of seats offered for this trip
+	AvailableSeats         int             `gorm:"default:1"`  // Seats not yet booked (decremented on each accepted request)
+	Waypoints              []Waypoint      `gorm:"foreignKey:RideOfferID"`
+	RecurringRideOfferID   *uuid.UUID      `gorm:"type:uuid;index"` // Set when the offer is an occurrence of a recurring ride offer
+	RidePreferences        RidePreferences `gorm:"embedded;embeddedPrefix:pref_"`
+}
+
+// RecurringRideOffer represents a template a driver uses to publish the same ride offer on a schedule
+// (concrete ride offers are materialised from it a few days ahead by a scheduled job)
+type RecurringRideOffer struct {
+	ID            uuid.UUID                    `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt     time.Time                    `gorm:"autoCreateTime"`
+	UpdatedAt     time.Time                    `gorm:"autoUpdateTime"`
+	UserID        uuid.UUID                    `gorm:"type:uuid;index"`
+	User          User                         `gorm:"foreignKey:UserID"`
+	VehicleID     uuid.UUID                    `gorm:"type:uuid"`
+	Vehicle       Vehicle                      `gorm:"foreignKey:VehicleID"`
+	Seats         int                          `gorm:"default:1"`
+	Route         string                       `gorm:"type:jsonb"` // Cached Goong directions response reused for every occurrence
+	StartAddress  string                       `gorm:"type:text"`
+	EndAddress    string                       `gorm:"type:text"`
+	DepartureTime string                       // Time of day of the departure (HH:MM in the time zone of APP_UTC_OFFSET)
+	Frequency     string                       `gorm:"default:'weekdays'"` // daily, weekdays, weekly
+	DaysOfWeek    string                       // Days of the week for the weekly frequency (comma separated, 0 = Sunday)
+	StartDate     time.Time                    // First day of the series
+	UntilDate     *time.Time                   // Last day of the series (nil = no end)
+	IsPaused      bool                         `gorm:"default:false"`
+	SkipDates     []RecurringRideOfferSkipDate `gorm:"foreignKey:RecurringRideOfferID"`
+	RideOffers    []RideOffer                  `gorm:"foreignKey:RecurringRideOfferID"`
+}
+
+// RouteAlert represents a route saved by a hitchhiker to be notified when a matching ride offer is published
+type RouteAlert struct {
+	ID                   uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt            time.Time `gorm:"autoCreateTime"`
+	UpdatedAt            time.Time `gorm:"autoUpdateTime"`
+	UserID               uuid.UUID `gorm:"type:uuid;index"`
+	User                 User      `gorm:"foreignKey:UserID"`
+	Name                 string    // Label chosen by the user, e.g. Home to work
+	OriginLatitude       float64   `gorm:"index:idx_route_alerts_origin,priority:1"`
+	OriginLongitude      float64   `gorm:"index:idx_route_alerts_origin,priority:2"`
+	OriginAddress        string    `gorm:"type:text"`
+	DestinationLatitude  float64
+	DestinationLongitude float64
+	DestinationAddress   string     `gorm:"type:text"`
+	DaysOfWeek           string     // Days of the week the alert applies to (comma separated, 0 = Sunday, empty = every day)
+	WindowStart          string     // Earliest departure time of the matching ride offers (HH:MM in the time zone of APP_UTC_OFFSET)
+	WindowEnd            string     // Latest departure time of the matching ride offers (HH:MM in the time zone of APP_UTC_OFFSET)
+	IsActive             bool       `gorm:"default:true"`
+	LastNotifiedAt       *time.Time // Last time the user was notified of a ride offer matching this alert
+}
+
+// RouteAlertNotification records that a user was notified of a ride offer matching one of their route alerts,
+// a ride offer is notified only once per alert
+type RouteAlertNotification struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime;index"`
+	RouteAlertID uuid.UUID  `gorm:"type:uuid;uniqueIndex:idx_route_alert_notification"`
+	RouteAlert   RouteAlert `gorm:"foreignKey:RouteAlertID"`
+	RideOfferID  uuid.UUID  `gorm:"type:uuid;uniqueIndex:idx_route_alert_notification"`
+	RideOffer    RideOffer  `gorm:"foreignKey:RideOfferID"`
+}
+
+// RecurringRideOfferSkipDate represents a day on which a recurring ride offer is not published
+type RecurringRideOfferSkipDate struct {
+	ID                   uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt            time.Time          `gorm:"autoCreateTime"`
+	RecurringRideOfferID uuid.UUID          `gorm:"type:uuid;uniqueIndex:idx_recurring_skip_date"`
+	RecurringRideOffer   RecurringRideOffer `gorm:"foreignKey:RecurringRideOfferID"`
+	Date                 time.Time          `gorm:"type:date;uniqueIndex:idx_recurring_skip_date"`
 }
 
 // Waypoint represents a waypoint of a ride offer (because a ride offer can have multiple waypoints max 5 points)
@@ -193,41 +290,221 @@ type RideRequest struct {
 	MomoTransID           int64             // MoMo transaction ID (if user paid with MoMo, then store the transaction ID here if later need to refund)
 	StartAddress          string            `gorm:"type:text"`
 	EndAddress            string            `gorm:"type:text"`
-	Status                string            `gorm:"default:'created'"` // created, matched, ongoing, completed, cancelled
+	EndPlaceID            string            // Goong place ID of the destination picked by the hitcher, empty for the ride requests created before it was stored
+	Status                string            `gorm:"default:'created';index:idx_ride_requests_status_time,priority:1"` // created, matched, ongoing, completed, cancelled, expired
 	Rides                 []Ride            `gorm:"foreignKey:RideRequestID"`
 	EncodedPolyline       polyline.Polyline `gorm:"type:text"`
 	Distance              float64           // in kilometers
 	Duration              int               // in seconds
-	StartTime             time.Time
-	EndTime               time.Time // Time to end the ride (end time = start time + duration)
+	StartTime             time.Time         `gorm:"index:idx_ride_requests_status_time,priority:2"`
+	EndTime               time.Time         // Time to end the ride (end time = start time + duration)
+	MinLatitude           float64           // Bounding box of the route, indexed through the generated route_box column (see CreateRouteBoxIndexes)
+	MaxLatitude           float64
+	MinLongitude          float64
+	MaxLongitude          float64
+	RidePreferences       RidePreferences `gorm:"embedded;embeddedPrefix:pref_"`
 }
 
 // Ride represents a matched ride between an offer and a request
 type Ride struct {
-	ID              uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	CreatedAt       time.Time   `gorm:"autoCreateTime"`
-	UpdatedAt       time.Time   `gorm:"autoUpdateTime"`
-	RideOfferID     uuid.UUID   `gorm:"type:uuid"`
-	RideOffer       RideOffer   `gorm:"foreignKey:RideOfferID"`
-	RideRequestID   uuid.UUID   `gorm:"type:uuid"`
-	RideRequest     RideRequest `gorm:"foreignKey:RideRequestID"`
-	Status          string      `gorm:"default:'scheduled'"` // scheduled, ongoing, completed, cancelled
-	StartTime       time.Time
-	EndTime         time.Time
-	Fare            float64
-	StartAddress    string            `gorm:"type:text"`
-	EndAddress      string            `gorm:"type:text"`
-	EncodedPolyline polyline.Polyline `gorm:"type:text"`
-	Distance        float64
-	Duration        int
-	StartLatitude   float64
-	StartLongitude  float64
-	EndLatitude     float64
-	EndLongitude    float64
-	VehicleID       uuid.UUID     `gorm:"type:uuid"`
-	Vehicle         Vehicle       `gorm:"foreignKey:VehicleID"`
-	Transactions    []Transaction `gorm:"foreignKey:RideID"`
-	Ratings         []Rating      `gorm:"foreignKey:RideID"`
+	ID                     uuid.UUID   `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt              time.Time   `gorm:"autoCreateTime"`
+	UpdatedAt              time.Time   `gorm:"autoUpdateTime"`
+	RideOfferID            uuid.UUID   `gorm:"type:uuid"`
+	RideOffer              RideOffer   `gorm:"foreignKey:RideOfferID"`
+	RideRequestID          uuid.UUID   `gorm:"type:uuid"`
+	RideRequest            RideRequest `gorm:"foreignKey:RideRequestID"`
+	Status                 string      `gorm:"default:'scheduled'"` // scheduled, ongoing, completed, cancelled, no_show
+	StartTime              time.Time
+	EndTime                time.Time
+	Fare                   float64
+	FareStrategy           string            // Copied from the ride offer when the ride request is accepted
+	FareDetails            jsonb.JSONB       `gorm:"type:jsonb"`
+	StartAddress           string            `gorm:"type:text"`
+	EndAddress             string            `gorm:"type:text"`
+	EncodedPolyline        polyline.Polyline `gorm:"type:text"`
+	Distance               float64
+	Duration               int
+	StartLatitude          float64
+	StartLongitude         float64
+	EndLatitude            float64
+	EndLongitude           float64
+	PickupLatitude         float64 // Meeting point on the driver's route agreed when the ride request was accepted
+	PickupLongitude        float64
+	PickupAddress          string  `gorm:"type:text"`
+	PickupWalkingDistance  float64 // Walking distance of the hitcher to the pickup point in kilometers
+	DropoffLatitude        float64
+	DropoffLongitude       float64
+	DropoffAddress         string    `gorm:"type:text"`
+	DropoffWalkingDistance float64   // Walking distance of the hitcher from the dropoff point in kilometers
+	VehicleID              uuid.UUID `gorm:"type:uuid"`
+	Vehicle                Vehicle   `gorm:"foreignKey:VehicleID"`
+	PickupCodeHash         string    // Keyed hash of the pickup PIN shown by the hitcher, cleared once the ride is started
+	PickupCodeExpiresAt    *time.Time
+	PickupCodeAttempts     int                  `gorm:"default:0"` // Wrong attempts since the pickup code was issued
+	PassengerVerifiedAt    *time.Time           // When the driver verified the hitcher on board
+	PassengerVerification  string               // pin, qr or test_mode
+	Transactions           []Transaction        `gorm:"foreignKey:RideID"`
+	Ratings                []Rating             `gorm:"foreignKey:RideID"`
+	DeviationEvents        []RideDeviationEvent `gorm:"foreignKey:RideID"`
+}
+
+// RideStatusHistory records every status transition of rides, ride offers, ride requests and transactions
+type RideStatusHistory struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	EntityType string    `gorm:"index:idx_ride_status_history_entity,priority:1"` // ride, ride_offer, ride_request, transaction
+	EntityID   uuid.UUID `gorm:"type:uuid;index:idx_ride_status_history_entity,priority:2"`
+	FromStatus string
+	ToStatus   string
+	ActorID    *uuid.UUID `gorm:"type:uuid"` // User who triggered the transition, nil when made by the system
+	Reason     string     `gorm:"type:text"`
+}
+
+func (RideStatusHistory) TableName() string {
+	return "ride_status_history"
+}
+
+// PickupCodeAttempt records the failed attempts of a driver to verify the hitcher with the pickup code
+type PickupCodeAttempt struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	RideID    uuid.UUID `gorm:"type:uuid;index"`
+	Ride      Ride      `gorm:"foreignKey:RideID"`
+	ActorID   uuid.UUID `gorm:"type:uuid"` // Driver who submitted the code
+	Method    string    // pin or qr
+	Reason    string    `gorm:"type:text"`
+}
+
+// GeofenceOverride lets the driver start or end a ride once without the location checks, granted by an admin
+type GeofenceOverride struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	RideID    uuid.UUID `gorm:"type:uuid;index"`
+	Ride      Ride      `gorm:"foreignKey:RideID"`
+	AdminID   uuid.UUID `gorm:"type:uuid"`
+	Admin     Admin     `gorm:"foreignKey:AdminID"`
+	Action    string    // start or end
+	Note      string    `gorm:"type:text"` // Why the location checks are skipped
+	UsedAt    *time.Time
+}
+
+// RideLocationSample is a position reported during a ride, the samples of a ride form its breadcrumb trail
+// and are only appended (the retention job downsamples and purges the old ones)
+type RideLocationSample struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	RideID     uuid.UUID `gorm:"type:uuid;index:idx_ride_location_samples_ride_time,priority:1"`
+	UserID     uuid.UUID `gorm:"type:uuid"` // User who reported the position
+	RecordedAt time.Time `gorm:"index:idx_ride_location_samples_ride_time,priority:2;index"`
+	Latitude   float64
+	Longitude  float64
+	Accuracy   *float64 // in meters, nil when not reported
+	Speed      *float64 // in meters per second, nil when not reported
+	Heading    *float64 // in degrees clockwise from the north, nil when not reported
+}
+
+// RideDeviationEvent records a driver leaving the planned route or stopping for long during an ongoing ride,
+// for the admins to review
+type RideDeviationEvent struct {
+	ID             uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+	RideID         uuid.UUID `gorm:"type:uuid;index"`
+	Ride           Ride      `gorm:"foreignKey:RideID"`
+	Type           string    // off_route or long_stop
+	StartedAt      time.Time
+	EndedAt        *time.Time // nil while the deviation lasts
+	Latitude       float64    // Location of the driver when the deviation was alerted
+	Longitude      float64
+	Distance       float64    // Distance from the route in meters when the deviation was alerted
+	MaxDistance    float64    // Largest distance from the route in meters during the deviation
+	AcknowledgedAt *time.Time `gorm:"index"`
+	AcknowledgedBy *uuid.UUID `gorm:"type:uuid"` // Admin who reviewed the event
+	Note           string     `gorm:"type:text"`
+}
+
+// EmergencyContact is a person the user wants alerted when raising an SOS, the phone number is verified by OTP
+// before any alert is sent to it
+type EmergencyContact struct {
+	ID           uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	UserID       uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_emergency_contacts_user_phone,priority:1"`
+	User         User      `gorm:"foreignKey:UserID"`
+	FullName     string
+	PhoneNumber  string `gorm:"uniqueIndex:idx_emergency_contacts_user_phone,priority:2"` // E.164 format
+	Relationship string // e.g. parent, partner, friend
+	VerifiedAt   *time.Time
+}
+
+// SOSEvent is an emergency raised by a user during a ride, handled by the admins from open to resolved
+type SOSEvent struct {
+	ID              uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+	UserID          uuid.UUID `gorm:"type:uuid;index"`
+	User            User      `gorm:"foreignKey:UserID"`
+	RideID          uuid.UUID `gorm:"type:uuid;index"`
+	Ride            Ride      `gorm:"foreignKey:RideID"`
+	Status          string    `gorm:"default:'open';index"` // open, acknowledged, resolved
+	Latitude        float64   // Location of the user when the SOS was raised
+	Longitude       float64
+	Message         string      `gorm:"type:text"`
+	RideSnapshot    jsonb.JSONB `gorm:"type:jsonb"` // State and last positions of the ride when the SOS was raised (schemas.SOSRideSnapshot)
+	ContactsAlerted int         // Emergency contacts the link to the live trip was sent to
+	AcknowledgedAt  *time.Time
+	AcknowledgedBy  *uuid.UUID `gorm:"type:uuid"`
+	ResolvedAt      *time.Time
+	ResolvedBy      *uuid.UUID `gorm:"type:uuid"`
+	ResolutionNote  string     `gorm:"type:text"`
+}
+
+// TripShare is a public link to follow a ride live without the app, only the hash of its token is stored
+type TripShare struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+	RideID     uuid.UUID `gorm:"type:uuid;index"`
+	Ride       Ride      `gorm:"foreignKey:RideID"`
+	UserID     uuid.UUID `gorm:"type:uuid;index"` // User who shared the ride
+	TokenHash  string    `gorm:"uniqueIndex"`
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	SOSEventID *uuid.UUID `gorm:"type:uuid;index"` // Set when the link was sent to the emergency contacts of an SOS
+}
+
+// UserBlock hides two users from each other, the block works both ways whoever created it
+type UserBlock struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	BlockerID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_user_block_pair"` // User who blocked
+	Blocker   User      `gorm:"foreignKey:BlockerID"`
+	BlockedID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_user_block_pair;index"`
+	Blocked   User      `gorm:"foreignKey:BlockedID"`
+}
+
+// UserReport is a case opened by a user against another user, reviewed by the admins
+type UserReport struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime"`
+	ReporterID  uuid.UUID  `gorm:"type:uuid;index"`
+	Reporter    User       `gorm:"foreignKey:ReporterID"`
+	ReportedID  uuid.UUID  `gorm:"type:uuid;index"`
+	Reported    User       `gorm:"foreignKey:ReportedID"`
+	Category    string     // harassment, inappropriate_behavior, unsafe_driving, fraud, no_show, other
+	Description string     `gorm:"type:text"`
+	RideID      *uuid.UUID `gorm:"type:uuid;index"` // Ride the report is about, if any
+	ChatID      *uuid.UUID `gorm:"type:uuid"`       // Chat message the report is about, if any
+	Chat        *Chat      `gorm:"foreignKey:ChatID"`
+	Status      string     `gorm:"default:'open';index"` // open, reviewing, resolved, dismissed
+	Action      string     // Taken by the admin when the report is closed: none, warned
+	ReviewedAt  *time.Time
+	ReviewedBy  *uuid.UUID `gorm:"type:uuid"`
+	ClosedAt    *time.Time
+	ClosedBy    *uuid.UUID `gorm:"type:uuid"`
+	AdminNote   string     `gorm:"type:text"`
 }
 
 // Rating represents a rating given by a user to another user
@@ -237,11 +514,12 @@ type Rating struct {
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 	Rating    float64   `gorm:"default:0;check:rating >= 0 AND rating <= 5"`
 	Comment   string
-	RaterID   uuid.UUID `gorm:"type:uuid"` // user who gave the rating
+	Tags      string    // Tag categories chosen by the rater (comma separated)
+	RaterID   uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_rating_ride_rater"` // user who gave the rating, once per ride
 	Rater     User      `gorm:"foreignKey:RaterID"`
-	RateeID   uuid.UUID `gorm:"type:uuid"` // user who received the rating
+	RateeID   uuid.UUID `gorm:"type:uuid;index"` // user who received the rating
 	Ratee     User      `gorm:"foreignKey:RateeID"`
-	RideID    uuid.UUID `gorm:"type:uuid"`
+	RideID    uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_rating_ride_rater"`
 	Ride      Ride      `gorm:"foreignKey:RideID"`
 }
 
@@ -294,11 +572,15 @@ type FavoriteLocation struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
-	UserID    uuid.UUID `gorm:"type:uuid"`
+	UserID    uuid.UUID `gorm:"type:uuid;index;uniqueIndex:idx_favorite_location_user_type,where:type <> 'custom'"`
 	User      User      `gorm:"foreignKey:UserID"`
+	Type      string    `gorm:"default:'custom';uniqueIndex:idx_favorite_location_user_type,where:type <> 'custom'"` // home, work, custom, a user has at most one home and one work
 	Name      string
+	PlaceID   string // Goong place ID of the location
+	Address   string `gorm:"type:text"`
 	Latitude  float64
 	Longitude float64
+	Position  int // Order of the location in the list of the user, starting at 0
 }
 
 // FuelPrice represents the price of fuel
@@ -306,7 +588,8 @@ type FuelPrice struct {
 	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
-	FuelType  string    `gorm:"uniqueIndex"`
+	FuelType  string    `gorm:"uniqueIndex"` // Name of the fuel as crawled, e.g. Xăng RON 95-III
+	Code      string    `gorm:"index"`       // Fuel type vehicles are priced with (ron95, e5_ron92, diesel), empty for other grades
 	Price     float64
 }
 
@@ -316,6 +599,8 @@ type VehicleType struct {
 	CreatedAt    time.Time `gorm:"autoCreateTime"`
 	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
 	Name         string    `gorm:"uniqueIndex"`
-	FuelConsumed float64   `gorm:"default:0"` // liters per 100 kilometers
+	FuelType     string    `gorm:"default:'ron95'"` // ron95, e5_ron92, diesel, electric
+	FuelConsumed float64   `gorm:"default:0"`       // liters (kWh for electric vehicles) per 100 kilometers
+	PerKmRate    float64   `gorm:"default:0"`       // Fare per kilometer with the per_km pricing strategy (0 uses the default rate)
 	Vehicles     []Vehicle // One-to-many relationship with Vehicle
 }
@@ -39,6 +39,7 @@ func (as *AsyncServer) StartAsynqServer(processor *TaskProcessor) {
 	mux := asynq.NewServeMux()
 	mux.HandleFunc(TypeWebsocketMessage, processor.HandleWebsocketMessageTask)
 	mux.HandleFunc(TypeFCMNofitication, processor.HandleFCMNotificationTask)
+	mux.HandleFunc(TypeRouteAlertMatch, processor.HandleRouteAlertMatchTask)
 
 	// Start the server in a goroutine
 	go func() {
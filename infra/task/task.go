@@ -2,6 +2,7 @@ package task
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"shareway/schemas"
 	"shareway/util"
@@ -12,6 +13,7 @@ import (
 const (
 	TypeWebsocketMessage = "websocket:message"
 	TypeFCMNofitication  = "notification:fcm"
+	TypeRouteAlertMatch  = "route-alert:match"
 )
 
 type AsyncClient struct {
@@ -64,3 +66,26 @@ func (ac *AsyncClient) EnqueueFCMNotification(notification schemas.Notification)
 	)
 	return err
 }
+
+// EnqueueRouteAlertMatch enqueues a task matching a new ride offer against the saved route alerts
+func (ac *AsyncClient) EnqueueRouteAlertMatch(payload schemas.RouteAlertMatchPayload) error {
+
+	// Marshal the task payload
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	// Create a new task
+	task := asynq.NewTask(TypeRouteAlertMatch, bytes)
+
+	// Enqueue the task, the task ID makes sure a ride offer waiting to be matched is not enqueued twice
+	_, err = ac.AsynqClient.Enqueue(task,
+		asynq.TaskID(fmt.Sprintf("%s:%s", TypeRouteAlertMatch, payload.RideOfferID)),
+		asynq.MaxRetry(3),
+	)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil
+	}
+	return err
+}
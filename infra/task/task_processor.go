@@ -9,20 +9,28 @@ import (
 	"shareway/schemas"
 	"shareway/util"
 
+	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
 )
 
+// RouteAlertMatcher notifies the users whose saved route alerts match a new ride offer
+type RouteAlertMatcher interface {
+	MatchRouteAlerts(ctx context.Context, rideOfferID uuid.UUID) error
+}
+
 type TaskProcessor struct {
-	hub       *ws.Hub
-	cfg       util.Config
-	fcmClient *fcm.FCMClient
+	hub               *ws.Hub
+	cfg               util.Config
+	fcmClient         *fcm.FCMClient
+	routeAlertMatcher RouteAlertMatcher
 }
 
-func NewTaskProcessor(hub *ws.Hub, cfg util.Config, fcmClient *fcm.FCMClient) *TaskProcessor {
+func NewTaskProcessor(hub *ws.Hub, cfg util.Config, fcmClient *fcm.FCMClient, routeAlertMatcher RouteAlertMatcher) *TaskProcessor {
 	return &TaskProcessor{
-		hub:       hub,
-		cfg:       cfg,
-		fcmClient: fcmClient,
+		hub:               hub,
+		cfg:               cfg,
+		fcmClient:         fcmClient,
+		routeAlertMatcher: routeAlertMatcher,
 	}
 }
 
@@ -54,4 +62,18 @@ func (tp *TaskProcessor) HandleFCMNotificationTask(ctx context.Context, t *asynq
 	return nil
 }
 
+// Handle route alert match task
+func (tp *TaskProcessor) HandleRouteAlertMatchTask(ctx context.Context, t *asynq.Task) error {
+	var payload schemas.RouteAlertMatchPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return err
+	}
+	err := tp.routeAlertMatcher.MatchRouteAlerts(ctx, payload.RideOfferID)
+	if err != nil {
+		return err
+	}
+	log.Printf("Matched route alerts for ride offer %s", payload.RideOfferID)
+	return nil
+}
+
 // RegisterTasks registers all tasks that this processor can handle.
@@ -65,16 +65,9 @@ func main() {
 		return
 	}
 
-	// Initialize the Asynq task processor
-	taskProcessor := task.NewTaskProcessor(hub, cfg, fcmClient)
-
 	// Initialize the Asynq Client
 	asynqClient := task.NewAsynqClient(cfg)
 
-	// Start the Asynq server
-	asynqServer := task.NewAsynqServer(cfg)
-	asynqServer.StartAsynqServer(taskProcessor)
-
 	// Create a scheduler
 	scheduler, err := gocron.NewScheduler()
 	if err != nil {
@@ -146,6 +139,57 @@ func main() {
 	serviceFactory := service.NewServiceFactory(database, cfg, maker, redisClient, hub, asynqClient, cloudinaryService, sanctumToken)
 	services := serviceFactory.CreateServices()
 
+	// Initialize the Asynq task processor, it needs the services to match the route alerts
+	taskProcessor := task.NewTaskProcessor(hub, cfg, fcmClient, services.RouteAlertService)
+
+	// Start the Asynq server
+	asynqServer := task.NewAsynqServer(cfg)
+	asynqServer.StartAsynqServer(taskProcessor)
+
+	// Expire stale ride offers and ride requests and mark rides not started in time as no-show
+	_, err = scheduler.NewJob(
+		gocron.CronJob(`*/5 * * * *`, false), // Run every 5 minutes
+		gocron.NewTask(
+			services.RideService.ExpireStaleRides,
+		),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not create cron job")
+	}
+
+	// Publish the upcoming occurrences of recurring ride offers every hour
+	_, err = scheduler.NewJob(
+		gocron.CronJob(`0 * * * *`, false), // Run every hour
+		gocron.NewTask(
+			services.RecurringRideService.MaterializeRecurringRideOffers,
+		),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not create cron job")
+	}
+
+	// Write the buffered location samples of the ride trails in batches
+	_, err = scheduler.NewJob(
+		gocron.DurationJob(time.Duration(cfg.TrailFlushInterval)*time.Second),
+		gocron.NewTask(
+			services.RideTrailService.FlushLocationSamples,
+		),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not create cron job")
+	}
+
+	// Downsample and purge the old ride trails every day
+	_, err = scheduler.NewJob(
+		gocron.CronJob(`0 3 * * *`, false), // Run every day at 3 AM
+		gocron.NewTask(
+			services.RideTrailService.ApplyTrailRetention,
+		),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Could not create cron job")
+	}
+
 	// Create new API server
 	server, err := router.NewAPIServer(
 		maker,
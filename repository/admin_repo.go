@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"errors"
+	"shareway/helper"
 	"shareway/infra/db/migration"
 	"shareway/schemas"
 
@@ -24,8 +26,13 @@ func NewAdminRepository(db *gorm.DB, redis *redis.Client) IAdminRepository {
 type IAdminRepository interface {
 	CheckAdminExists(req schemas.LoginAdminRequest) (migration.Admin, error)
 	GetAdminProfile(adminID uuid.UUID) (migration.Admin, error)
+	CreateGeofenceOverride(override migration.GeofenceOverride) (migration.GeofenceOverride, error)
 }
 
+var (
+	ErrGeofenceOverrideNotApplicable = errors.New("ride must be scheduled to override the start or ongoing to override the end")
+)
+
 // CheckAdminExists checks if the admin exists in the database
 func (r *AdminRepository) CheckAdminExists(req schemas.LoginAdminRequest) (migration.Admin, error) {
 	var admin migration.Admin
@@ -43,3 +50,24 @@ func (r *AdminRepository) GetAdminProfile(adminID uuid.UUID) (migration.Admin, e
 	}
 	return admin, nil
 }
+
+// CreateGeofenceOverride lets the driver start or end a ride once without the location checks
+func (r *AdminRepository) CreateGeofenceOverride(override migration.GeofenceOverride) (migration.GeofenceOverride, error) {
+	var ride migration.Ride
+	if err := r.db.Where("id = ?", override.RideID).First(&ride).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return override, ErrRideNotFound
+		}
+		return override, err
+	}
+
+	if (override.Action == helper.GeofenceActionStart && ride.Status != "scheduled") ||
+		(override.Action == helper.GeofenceActionEnd && ride.Status != "ongoing") {
+		return override, ErrGeofenceOverrideNotApplicable
+	}
+
+	if err := r.db.Create(&override).Error; err != nil {
+		return override, err
+	}
+	return override, nil
+}
@@ -27,6 +27,7 @@ type IAuthRepository interface {
 	RegisterDeviceToken(userID uuid.UUID, deviceToken string) error
 	DeleteUser(phoneNumber string) error
 	UpdateUserProfile(userID uuid.UUID, fullName string, email string, gender string) error
+	UpdateRidePreferences(userID uuid.UUID, preferences migration.RidePreferences) error
 	UpdateAvatar(userID uuid.UUID, avatarURL string) error
 }
 
@@ -479,6 +480,27 @@ func (r *AuthRepository) UpdateUserProfile(userID uuid.UUID, fullName, email, ge
 	return tx.Commit().Error
 }
 
+// UpdateRidePreferences updates the default ride preferences of the user with the given user ID
+func (r *AuthRepository) UpdateRidePreferences(userID uuid.UUID, preferences migration.RidePreferences) error {
+	// Update with a map so the false preferences are saved too
+	result := r.db.Model(&migration.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"pref_same_gender":  preferences.SameGender,
+			"pref_pets_allowed": preferences.PetsAllowed,
+			"pref_luggage_size": preferences.LuggageSize,
+			"pref_no_smoking":   preferences.NoSmoking,
+			"pref_quiet_ride":   preferences.QuietRide,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update ride preferences: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("user not found")
+	}
+	return nil
+}
+
 // UpdateAvatar updates the user avatar with the given user ID
 func (r *AuthRepository) UpdateAvatar(userID uuid.UUID, avatarURL string) error {
 	tx := r.db.Begin()
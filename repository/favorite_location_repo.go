@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"errors"
+	"shareway/infra/db/migration"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type IFavoriteLocationRepository interface {
+	CreateFavoriteLocation(favoriteLocation migration.FavoriteLocation) (migration.FavoriteLocation, error)
+	GetFavoriteLocationByID(favoriteLocationID, userID uuid.UUID) (migration.FavoriteLocation, error)
+	GetFavoriteLocationsByUserID(userID uuid.UUID) ([]migration.FavoriteLocation, error)
+	CountFavoriteLocations(userID uuid.UUID, locationType string) (int64, error)
+	UpdateFavoriteLocation(favoriteLocation migration.FavoriteLocation) error
+	ReorderFavoriteLocations(userID uuid.UUID, favoriteLocationIDs []uuid.UUID) error
+	DeleteFavoriteLocation(favoriteLocationID, userID uuid.UUID) error
+	SearchFavoriteLocations(userID uuid.UUID, input string, limit int) ([]migration.FavoriteLocation, error)
+	SearchRecentDestinations(userID uuid.UUID, input string, limit int) ([]RecentDestination, error)
+}
+
+type FavoriteLocationRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewFavoriteLocationRepository(db *gorm.DB, redis *redis.Client) IFavoriteLocationRepository {
+	return &FavoriteLocationRepository{db: db, redis: redis}
+}
+
+var (
+	ErrFavoriteLocationNotFound = errors.New("favorite location not found")
+	ErrFavoriteLocationOrder    = errors.New("the new order must list every favorite location of the user exactly once")
+)
+
+// RecentDestination is a destination of a ride offer or a ride request of the user
+type RecentDestination struct {
+	PlaceID   string
+	Address   string
+	Latitude  float64
+	Longitude float64
+}
+
+// CreateFavoriteLocation saves a new favorite location at the end of the list of the user
+func (r *FavoriteLocationRepository) CreateFavoriteLocation(favoriteLocation migration.FavoriteLocation) (migration.FavoriteLocation, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var position int
+		err := tx.Model(&migration.FavoriteLocation{}).
+			Select("COALESCE(MAX(position) + 1, 0)").
+			Where("user_id = ?", favoriteLocation.UserID).
+			Scan(&position).Error
+		if err != nil {
+			return err
+		}
+
+		favoriteLocation.Position = position
+		return tx.Create(&favoriteLocation).Error
+	})
+	if err != nil {
+		return migration.FavoriteLocation{}, err
+	}
+	return favoriteLocation, nil
+}
+
+// GetFavoriteLocationByID fetches a favorite location of the user
+func (r *FavoriteLocationRepository) GetFavoriteLocationByID(favoriteLocationID, userID uuid.UUID) (migration.FavoriteLocation, error) {
+	var favoriteLocation migration.FavoriteLocation
+	err := r.db.Where("id = ? AND user_id = ?", favoriteLocationID, userID).First(&favoriteLocation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return favoriteLocation, ErrFavoriteLocationNotFound
+		}
+		return favoriteLocation, err
+	}
+	return favoriteLocation, nil
+}
+
+// GetFavoriteLocationsByUserID returns the favorite locations of the user in the order the user chose
+func (r *FavoriteLocationRepository) GetFavoriteLocationsByUserID(userID uuid.UUID) ([]migration.FavoriteLocation, error) {
+	var favoriteLocations []migration.FavoriteLocation
+	err := r.db.Where("user_id = ?", userID).Order("position, created_at").Find(&favoriteLocations).Error
+	return favoriteLocations, err
+}
+
+// CountFavoriteLocations counts the favorite locations of the user, of the given type when set
+func (r *FavoriteLocationRepository) CountFavoriteLocations(userID uuid.UUID, locationType string) (int64, error) {
+	query := r.db.Model(&migration.FavoriteLocation{}).Where("user_id = ?", userID)
+	if locationType != "" {
+		query = query.Where("type = ?", locationType)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// UpdateFavoriteLocation saves the changes of a favorite location
+func (r *FavoriteLocationRepository) UpdateFavoriteLocation(favoriteLocation migration.FavoriteLocation) error {
+	return r.db.Save(&favoriteLocation).Error
+}
+
+// ReorderFavoriteLocations sets the position of every favorite location of the user to its index in the given list
+func (r *FavoriteLocationRepository) ReorderFavoriteLocations(userID uuid.UUID, favoriteLocationIDs []uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		err := tx.Model(&migration.FavoriteLocation{}).
+			Where("user_id = ?", userID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count != int64(len(favoriteLocationIDs)) {
+			return ErrFavoriteLocationOrder
+		}
+
+		for position, favoriteLocationID := range favoriteLocationIDs {
+			result := tx.Model(&migration.FavoriteLocation{}).
+				Where("id = ? AND user_id = ?", favoriteLocationID, userID).
+				Update("position", position)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrFavoriteLocationOrder
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteFavoriteLocation deletes a favorite location of the user
+func (r *FavoriteLocationRepository) DeleteFavoriteLocation(favoriteLocationID, userID uuid.UUID) error {
+	result := r.db.Where("id = ? AND user_id = ?", favoriteLocationID, userID).Delete(&migration.FavoriteLocation{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFavoriteLocationNotFound
+	}
+	return nil
+}
+
+// SearchFavoriteLocations returns the favorite locations of the user whose name or address contains the input
+func (r *FavoriteLocationRepository) SearchFavoriteLocations(userID uuid.UUID, input string, limit int) ([]migration.FavoriteLocation, error) {
+	pattern := likePattern(input)
+
+	var favoriteLocations []migration.FavoriteLocation
+	err := r.db.Where("user_id = ?", userID).
+		Where("name ILIKE ? OR address ILIKE ?", pattern, pattern).
+		Order("position, created_at").
+		Limit(limit).
+		Find(&favoriteLocations).Error
+	return favoriteLocations, err
+}
+
+// SearchRecentDestinations returns the latest distinct destinations of the ride offers and the ride requests
+// of the user whose address contains the input
+func (r *FavoriteLocationRepository) SearchRecentDestinations(userID uuid.UUID, input string, limit int) ([]RecentDestination, error) {
+	var destinations []RecentDestination
+	err := r.db.Raw(`
+		SELECT place_id, address, latitude, longitude FROM (
+			SELECT DISTINCT ON (end_place_id) end_place_id AS place_id, end_address AS address,
+				end_latitude AS latitude, end_longitude AS longitude, created_at
+			FROM (
+				SELECT end_place_id, end_address, end_latitude, end_longitude, created_at
+				FROM ride_offers WHERE user_id = @user AND end_place_id <> ''
+				UNION ALL
+				SELECT end_place_id, end_address, end_latitude, end_longitude, created_at
+				FROM ride_requests WHERE user_id = @user AND end_place_id <> ''
+			) AS destinations
+			WHERE end_address ILIKE @pattern
+			ORDER BY end_place_id, created_at DESC
+		) AS latest
+		ORDER BY created_at DESC
+		LIMIT @limit`,
+		map[string]interface{}{"user": userID, "pattern": likePattern(input), "limit": limit},
+	).Scan(&destinations).Error
+	return destinations, err
+}
+
+// likePattern matches the input anywhere in the column, the wildcards typed by the user are escaped
+func likePattern(input string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(strings.TrimSpace(input)) + "%"
+}
+
+// Make sure the FavoriteLocationRepository implements the IFavoriteLocationRepository interface
+var _ IFavoriteLocationRepository = (*FavoriteLocationRepository)(nil)
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"shareway/schemas"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type IImpactRepository interface {
+	GetUserImpactTotals(userID uuid.UUID) ([]schemas.ImpactFuelTotals, error)
+	GetPlatformImpactTotals(from, to *time.Time) ([]schemas.ImpactFuelTotals, error)
+	CountPlatformImpactUsers(from, to *time.Time) (int64, error)
+}
+
+type ImpactRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewImpactRepository(db *gorm.DB, redis *redis.Client) IImpactRepository {
+	return &ImpactRepository{db: db, redis: redis}
+}
+
+// impactTotalsSelect sums the shared distance and the fuel used of the completed rides per fuel type of their vehicle.
+// The distance shared is the distance of the passenger's ride request, as in the km shared of the ride history,
+// not the whole distance of the ride offer
+const impactTotalsSelect = `vehicles.fuel_type AS fuel_type,
+	COUNT(*) AS rides,
+	COALESCE(SUM(ride_requests.distance), 0) AS distance,
+	COALESCE(SUM(ride_requests.distance * vehicles.fuel_consumed / 100) FILTER (WHERE vehicles.fuel_consumed > 0), 0) AS fuel_consumed,
+	COALESCE(SUM(ride_requests.distance) FILTER (WHERE vehicles.fuel_consumed <= 0), 0) AS unknown_distance`
+
+// GetUserImpactTotals sums the completed rides of the user as driver or passenger per fuel type
+func (r *ImpactRepository) GetUserImpactTotals(userID uuid.UUID) ([]schemas.ImpactFuelTotals, error) {
+	var totals []schemas.ImpactFuelTotals
+	err := r.db.Table("rides").
+		Select(impactTotalsSelect).
+		Joins("JOIN vehicles ON vehicles.id = rides.vehicle_id").
+		Joins("JOIN ride_offers ON ride_offers.id = rides.ride_offer_id").
+		Joins("JOIN ride_requests ON ride_requests.id = rides.ride_request_id").
+		Where("rides.status = 'completed'").
+		Where("(ride_offers.user_id = ? OR ride_requests.user_id = ?)", userID, userID).
+		Group("vehicles.fuel_type").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// GetPlatformImpactTotals sums all the completed rides started between from (inclusive) and to (exclusive)
+// per fuel type, without bound when nil
+func (r *ImpactRepository) GetPlatformImpactTotals(from, to *time.Time) ([]schemas.ImpactFuelTotals, error) {
+	var totals []schemas.ImpactFuelTotals
+	err := r.completedRides(from, to).
+		Select(impactTotalsSelect).
+		Joins("JOIN vehicles ON vehicles.id = rides.vehicle_id").
+		Joins("JOIN ride_requests ON ride_requests.id = rides.ride_request_id").
+		Group("vehicles.fuel_type").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// CountPlatformImpactUsers counts the distinct drivers and passengers of the completed rides started between
+// from (inclusive) and to (exclusive)
+func (r *ImpactRepository) CountPlatformImpactUsers(from, to *time.Time) (int64, error) {
+	var count int64
+	err := r.db.Raw(`SELECT COUNT(DISTINCT user_id) FROM (
+			(?) UNION ALL (?)
+		) AS participants`,
+		r.completedRides(from, to).Select("ride_offers.user_id").Joins("JOIN ride_offers ON ride_offers.id = rides.ride_offer_id"),
+		r.completedRides(from, to).Select("ride_requests.user_id").Joins("JOIN ride_requests ON ride_requests.id = rides.ride_request_id"),
+	).Scan(&count).Error
+	return count, err
+}
+
+func (r *ImpactRepository) completedRides(from, to *time.Time) *gorm.DB {
+	query := r.db.Table("rides").Where("rides.status = 'completed'")
+	if from != nil {
+		query = query.Where("rides.start_time >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("rides.start_time < ?", *to)
+	}
+	return query
+}
+
+// Make sure the ImpactRepository implements the IImpactRepository interface
+var _ IImpactRepository = (*ImpactRepository)(nil)
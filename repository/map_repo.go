@@ -6,6 +6,7 @@ import (
 	"shareway/helper"
 	"shareway/infra/db/migration"
 	"shareway/schemas"
+	"shareway/util"
 	"shareway/util/polyline"
 	"sort"
 	"time"
@@ -16,31 +17,52 @@ import (
 )
 
 type IMapsRepository interface {
-	CreateGiveRide(route schemas.GoongDirectionsResponse, userID uuid.UUID, currentLocation schemas.Point, startTime time.Time, vehicleID uuid.UUID) (uuid.UUID, error)
-	CreateHitchRide(route schemas.GoongDirectionsResponse, userID uuid.UUID, currentLocation schemas.Point, startTime time.Time, weight int64) (uuid.UUID, error)
+	CreateGiveRide(route schemas.GoongDirectionsResponse, endPlaceID string, userID uuid.UUID, currentLocation schemas.Point, startTime time.Time, vehicleID uuid.UUID, seats int, preferences *migration.RidePreferences, recurringRideOfferID *uuid.UUID) (uuid.UUID, error)
+	CreateHitchRide(route schemas.GoongDirectionsResponse, endPlaceID string, userID uuid.UUID, currentLocation schemas.Point, startTime time.Time, weight int64, preferences *migration.RidePreferences) (uuid.UUID, error)
 	GetRideOfferDetails(rideOfferID uuid.UUID) (migration.RideOffer, error)
 	GetRideRequestDetails(rideRequestID uuid.UUID) (migration.RideRequest, error)
 	SuggestRideRequests(userID uuid.UUID, rideOfferID uuid.UUID) ([]migration.RideRequest, error)
 	SuggestRideOffers(userID uuid.UUID, rideRequestID uuid.UUID) ([]migration.RideOffer, error)
 	GetRideByID(rideID uuid.UUID) (migration.Ride, error)
 	GetAllWaypoints(rideOfferID uuid.UUID) ([]migration.Waypoint, error)
+	GetAverageRatings(userIDs []uuid.UUID) (map[uuid.UUID]float64, error)
+	QuoteFare(route schemas.GoongDirectionsResponse, userID uuid.UUID, vehicleID uuid.UUID, startTime time.Time) (schemas.FareQuote, error)
+	BrowseRideOffers(userID uuid.UUID, filter schemas.BrowseRideOffersFilter) ([]migration.RideOffer, map[uuid.UUID]schemas.RouteProximity, error)
 }
 
 type MapsRepository struct {
-	db *gorm.DB
+	db         *gorm.DB
+	cfg        util.Config
+	fareEngine helper.FareEngine // Pricing engine described by the configuration, built once
 }
 
-func NewMapsRepository(db *gorm.DB) IMapsRepository {
-	return &MapsRepository{db: db}
+func NewMapsRepository(db *gorm.DB, cfg util.Config) IMapsRepository {
+	return &MapsRepository{
+		db:         db,
+		cfg:        cfg,
+		fareEngine: newFareEngine(cfg),
+	}
 }
 
-func (r *MapsRepository) CreateGiveRide(route schemas.GoongDirectionsResponse, userID uuid.UUID, currentLocation schemas.Point, startTime time.Time, vehicleID uuid.UUID) (uuid.UUID, error) {
+var (
+	ErrSeatsExceedCapacity = errors.New("requested seats exceed the vehicle seat capacity")
+)
+
+// routeBoxOverlaps is the condition of the routes whose bounding box overlaps the box given by its
+// (longitude, latitude) corners, answered by the GiST index on route_box (see migration.CreateRouteBoxIndexes)
+const routeBoxOverlaps = "route_box && box(point(?, ?), point(?, ?))"
+
+// CreateGiveRide creates a ride offer, nil preferences use the defaults of the user profile.
+// recurringRideOfferID links an occurrence to its recurring ride offer in the same transaction, nil otherwise
+func (r *MapsRepository) CreateGiveRide(route schemas.GoongDirectionsResponse, endPlaceID string, userID uuid.UUID, currentLocation schemas.Point, startTime time.Time, vehicleID uuid.UUID, seats int, preferences *migration.RidePreferences, recurringRideOfferID *uuid.UUID) (uuid.UUID, error) {
 	log.Debug().
 		Interface("route", route).
+		Str("endPlaceID", endPlaceID).
 		Str("userID", userID.String()).
 		Interface("currentLocation", currentLocation).
 		Time("startTime", startTime).
 		Str("vehicleID", vehicleID.String()).
+		Int("seats", seats).
 		Msg("CreateGiveRide function called")
 
 	if len(route.Routes) == 0 || len(route.Routes[0].Legs) == 0 {
@@ -52,12 +74,7 @@ func (r *MapsRepository) CreateGiveRide(route schemas.GoongDirectionsResponse, u
 	firstLeg := firstRoute.Legs[0]
 	lastLeg := firstRoute.Legs[len(firstRoute.Legs)-1]
 
-	totalDistance, totalDuration := 0, 0
-	for _, leg := range firstRoute.Legs {
-		totalDistance += leg.Distance.Value
-		totalDuration += leg.Duration.Value
-	}
-	totalDistance /= 1000 // Convert to kilometers
+	totalDistance, totalDuration := routeTotals(route)
 
 	log.Debug().
 		Int("totalDistance", totalDistance).
@@ -77,10 +94,19 @@ func (r *MapsRepository) CreateGiveRide(route schemas.GoongDirectionsResponse, u
 		}
 		log.Debug().Interface("vehicle", vehicle).Msg("Fetched vehicle")
 
+		// Offer every seat of the vehicle unless the driver asked for fewer
+		if seats <= 0 {
+			seats = vehicle.SeatCapacity
+		}
+		if seats > vehicle.SeatCapacity {
+			log.Warn().Int("seats", seats).Int("seatCapacity", vehicle.SeatCapacity).Msg("Requested seats exceed vehicle capacity")
+			return ErrSeatsExceedCapacity
+		}
+
 		var existingRideOfferCount int64
 		err := tx.Model(&migration.RideOffer{}).
-			Where("user_id = ? AND ((start_time BETWEEN ? AND ?) OR (end_time BETWEEN ? AND ?) OR (start_time <= ? AND end_time >= ?))",
-				userID, startTime, endTime, startTime, endTime, startTime, endTime).
+			Where("user_id = ? AND status <> ? AND ((start_time BETWEEN ? AND ?) OR (end_time BETWEEN ? AND ?) OR (start_time <= ? AND end_time >= ?))",
+				userID, "cancelled", startTime, endTime, startTime, endTime, startTime, endTime).
 			Count(&existingRideOfferCount).Error
 		if err != nil {
 			log.Error().Err(err).Msg("Error checking for existing ride offers")
@@ -105,18 +131,29 @@ func (r *MapsRepository) CreateGiveRide(route schemas.GoongDirectionsResponse, u
 			return errors.New("ride request already exists for the user in that time frame")
 		}
 
-		var fuelPrice float64
-		if err := tx.Model(&migration.FuelPrice{}).
-			Select("price").
-			Where("fuel_type = ?", "Xăng RON 95-III").
-			First(&fuelPrice).Error; err != nil {
-			log.Error().Err(err).Msg("Failed to fetch fuel price")
-			return fmt.Errorf("failed to fetch fuel price: %w", err)
+		fareQuote, err := r.quoteFare(tx, schemas.FareInput{
+			Distance:     float64(totalDistance),
+			Duration:     totalDuration,
+			StartTime:    startTime,
+			VehicleType:  vehicle.VehicleType.Name,
+			FuelType:     vehicle.FuelType,
+			FuelConsumed: vehicle.FuelConsumed,
+			PerKmRate:    vehicle.VehicleType.PerKmRate,
+		})
+		if err != nil {
+			return err
 		}
-		log.Debug().Float64("fuelPrice", fuelPrice).Msg("Fetched fuel price")
+		log.Debug().Interface("fareQuote", fareQuote).Msg("Calculated fare")
 
-		fare := (vehicle.FuelConsumed / 100) * fuelPrice * float64(totalDistance)
-		log.Debug().Float64("fare", fare).Msg("Calculated fare")
+		ridePreferences, err := r.ridePreferences(tx, userID, preferences)
+		if err != nil {
+			return err
+		}
+
+		fareDetails, err := helper.ConvertToJSONB(fareQuote)
+		if err != nil {
+			return fmt.Errorf("failed to encode fare details: %w", err)
+		}
 
 		decodePolyline := helper.DecodePolyline(firstRoute.Overview_polyline.Points)
 		startLocation := schemas.Point{
@@ -134,6 +171,12 @@ func (r *MapsRepository) CreateGiveRide(route schemas.GoongDirectionsResponse, u
 			Interface("newEndLocation", newEndLocation).
 			Msg("Found closest points on route")
 
+		minLat, maxLat, minLng, maxLng, err := polyline.Polyline(firstRoute.Overview_polyline.Points).Bounds()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to compute route bounds")
+			return fmt.Errorf("failed to compute route bounds: %w", err)
+		}
+
 		rideOffer := migration.RideOffer{
 			UserID:                 userID,
 			StartLatitude:          newStartLocaton.Lat,
@@ -145,13 +188,24 @@ func (r *MapsRepository) CreateGiveRide(route schemas.GoongDirectionsResponse, u
 			DriverCurrentLongitude: currentLocation.Lng,
 			StartAddress:           firstLeg.Start_address,
 			EndAddress:             lastLeg.End_address,
+			EndPlaceID:             endPlaceID,
 			Distance:               float64(totalDistance),
 			Duration:               totalDuration,
 			Status:                 "created",
 			StartTime:              startTime,
 			EndTime:                endTime,
+			MinLatitude:            minLat,
+			MaxLatitude:            maxLat,
+			MinLongitude:           minLng,
+			MaxLongitude:           maxLng,
 			VehicleID:              vehicleID,
-			Fare:                   fare,
+			Fare:                   fareQuote.Fare,
+			FareStrategy:           fareQuote.Strategy,
+			FareDetails:            fareDetails,
+			TotalSeats:             seats,
+			AvailableSeats:         seats,
+			RidePreferences:        ridePreferences,
+			RecurringRideOfferID:   recurringRideOfferID,
 		}
 
 		if err := tx.Create(&rideOffer).Error; err != nil {
@@ -202,7 +256,8 @@ func (r *MapsRepository) CreateGiveRide(route schemas.GoongDirectionsResponse, u
 	return rideOfferID, nil
 }
 
-func (r *MapsRepository) CreateHitchRide(route schemas.GoongDirectionsResponse, userID uuid.UUID, currentLocation schemas.Point, startTime time.Time, weight int64) (uuid.UUID, error) {
+// CreateHitchRide creates a ride request, nil preferences use the defaults of the user profile
+func (r *MapsRepository) CreateHitchRide(route schemas.GoongDirectionsResponse, endPlaceID string, userID uuid.UUID, currentLocation schemas.Point, startTime time.Time, weight int64, preferences *migration.RidePreferences) (uuid.UUID, error) {
 	if len(route.Routes) == 0 || len(route.Routes[0].Legs) == 0 {
 		log.Error().Msg("Invalid route data: empty routes or legs")
 		return uuid.Nil, errors.New("invalid route data")
@@ -246,8 +301,8 @@ func (r *MapsRepository) CreateHitchRide(route schemas.GoongDirectionsResponse,
 
 		var existingRideOfferCount int64
 		err = tx.Model(&migration.RideOffer{}).
-			Where("user_id = ? AND ((start_time BETWEEN ? AND ?) OR (end_time BETWEEN ? AND ?) OR (start_time <= ? AND end_time >= ?))",
-				userID, startTime, endTime, startTime, endTime, startTime, endTime).
+			Where("user_id = ? AND status <> ? AND ((start_time BETWEEN ? AND ?) OR (end_time BETWEEN ? AND ?) OR (start_time <= ? AND end_time >= ?))",
+				userID, "cancelled", startTime, endTime, startTime, endTime, startTime, endTime).
 			Count(&existingRideOfferCount).Error
 		if err != nil {
 			log.Error().Err(err).Msg("Error checking for existing ride offers")
@@ -258,6 +313,11 @@ func (r *MapsRepository) CreateHitchRide(route schemas.GoongDirectionsResponse,
 			return errors.New("ride offer already exists for the user in that time frame")
 		}
 
+		ridePreferences, err := r.ridePreferences(tx, userID, preferences)
+		if err != nil {
+			return err
+		}
+
 		decodePolyline := helper.DecodePolyline(firstRoute.Overview_polyline.Points)
 		startLocation := schemas.Point{
 			Lat: firstLeg.Start_location.Lat,
@@ -274,6 +334,12 @@ func (r *MapsRepository) CreateHitchRide(route schemas.GoongDirectionsResponse,
 			Interface("newEndLocation", newEndLocation).
 			Msg("Found closest points on route")
 
+		minLat, maxLat, minLng, maxLng, err := polyline.Polyline(firstRoute.Overview_polyline.Points).Bounds()
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to compute route bounds")
+			return fmt.Errorf("failed to compute route bounds: %w", err)
+		}
+
 		rideRequest := migration.RideRequest{
 			UserID:                userID,
 			StartLatitude:         newStartLocaton.Lat,
@@ -284,13 +350,19 @@ func (r *MapsRepository) CreateHitchRide(route schemas.GoongDirectionsResponse,
 			RiderCurrentLongitude: currentLocation.Lng,
 			StartAddress:          firstLeg.Start_address,
 			EndAddress:            lastLeg.End_address,
+			EndPlaceID:            endPlaceID,
 			Status:                "created",
 			EncodedPolyline:       polyline.Polyline(firstRoute.Overview_polyline.Points),
 			Distance:              float64(totalDistance),
 			Duration:              totalDuration,
 			StartTime:             startTime,
 			EndTime:               endTime,
+			MinLatitude:           minLat,
+			MaxLatitude:           maxLat,
+			MinLongitude:          minLng,
+			MaxLongitude:          maxLng,
 			Weight:                weight,
+			RidePreferences:       ridePreferences,
 		}
 
 		if err := tx.Create(&rideRequest).Error; err != nil {
@@ -422,7 +494,7 @@ func (r *MapsRepository) CreateHitchRide(route schemas.GoongDirectionsResponse,
 
 func (r *MapsRepository) GetRideOfferDetails(rideOfferID uuid.UUID) (migration.RideOffer, error) {
 	rideOffer := migration.RideOffer{}
-	if err := r.db.Preload("Vehicle").First(&rideOffer, rideOfferID).Error; err != nil {
+	if err := r.db.Preload("Vehicle").Preload("User").First(&rideOffer, rideOfferID).Error; err != nil {
 		return migration.RideOffer{}, err
 	}
 	return rideOffer, nil
@@ -430,7 +502,7 @@ func (r *MapsRepository) GetRideOfferDetails(rideOfferID uuid.UUID) (migration.R
 
 func (r *MapsRepository) GetRideRequestDetails(rideRequestID uuid.UUID) (migration.RideRequest, error) {
 	rideRequest := migration.RideRequest{}
-	if err := r.db.First(&rideRequest, rideRequestID).Error; err != nil {
+	if err := r.db.Preload("User").First(&rideRequest, rideRequestID).Error; err != nil {
 		return migration.RideRequest{}, err
 	}
 	return rideRequest, nil
@@ -443,9 +515,17 @@ func (r *MapsRepository) SuggestRideRequests(userID uuid.UUID, rideOfferID uuid.
 		return nil, err
 	}
 
-	// Fetch the ride requests that have status "created"
+	// Fetch the ride requests that have status "created" and whose time window and route
+	// bounding box can overlap the ride offer, only these candidates are route matched
+	minLat, maxLat, minLng, maxLng := helper.MatchBounds(rideOffer.MinLatitude, rideOffer.MaxLatitude, rideOffer.MinLongitude, rideOffer.MaxLongitude)
 	var rideRequests []migration.RideRequest
-	if err := r.db.Where("status = ?", "created").Find(&rideRequests).Error; err != nil {
+	err = r.db.Preload("User").
+		Where("status = ? AND user_id <> ?", "created", userID).
+		Where("user_id NOT IN (?)", blockedUserIDs(r.db, userID)).
+		Where("start_time > ? AND end_time < ?", rideOffer.StartTime.Add(-helper.TimeOverlapBuffer), rideOffer.EndTime.Add(helper.TimeOverlapBuffer)).
+		Where(routeBoxOverlaps, minLng, minLat, maxLng, maxLat).
+		Find(&rideRequests).Error
+	if err != nil {
 		return nil, err
 	}
 
@@ -457,7 +537,8 @@ func (r *MapsRepository) SuggestRideRequests(userID uuid.UUID, rideOfferID uuid.
 		requestPolyline := helper.DecodePolyline(string(rideRequest.EncodedPolyline))
 
 		if rideRequest.UserID != userID && helper.IsMatchRoute(offerPolyline, requestPolyline) &&
-			helper.IsTimeOverlap(rideOffer, rideRequest) {
+			helper.IsTimeOverlap(rideOffer, rideRequest) &&
+			helper.IsPreferenceCompatible(rideOffer.RidePreferences, rideOffer.User.Gender, rideRequest.RidePreferences, rideRequest.User.Gender) {
 			filteredRideRequests = append(filteredRideRequests, rideRequest)
 		}
 	}
@@ -478,9 +559,17 @@ func (r *MapsRepository) SuggestRideOffers(userID uuid.UUID, rideRequestID uuid.
 		return nil, err
 	}
 
-	// Fetch the ride offers that have status "created"
+	// Fetch the ride offers that have status "created" and whose time window and route
+	// bounding box can overlap the ride request, only these candidates are route matched
+	minLat, maxLat, minLng, maxLng := helper.MatchBounds(rideRequest.MinLatitude, rideRequest.MaxLatitude, rideRequest.MinLongitude, rideRequest.MaxLongitude)
 	var rideOffers []migration.RideOffer
-	if err := r.db.Where("status = ?", "created").Find(&rideOffers).Error; err != nil {
+	err = r.db.Preload("User").
+		Where("status = ? AND user_id <> ?", "created", userID).
+		Where("user_id NOT IN (?)", blockedUserIDs(r.db, userID)).
+		Where("start_time < ? AND end_time > ?", rideRequest.StartTime.Add(helper.TimeOverlapBuffer), rideRequest.EndTime.Add(-helper.TimeOverlapBuffer)).
+		Where(routeBoxOverlaps, minLng, minLat, maxLng, maxLat).
+		Find(&rideOffers).Error
+	if err != nil {
 		return nil, err
 	}
 
@@ -492,7 +581,8 @@ func (r *MapsRepository) SuggestRideOffers(userID uuid.UUID, rideRequestID uuid.
 		offerPolyline := helper.DecodePolyline(string(rideOffer.EncodedPolyline))
 
 		if rideOffer.UserID != userID && helper.IsMatchRoute(offerPolyline, requestPolyline) &&
-			helper.IsTimeOverlap(rideOffer, rideRequest) {
+			helper.IsTimeOverlap(rideOffer, rideRequest) &&
+			helper.IsPreferenceCompatible(rideOffer.RidePreferences, rideOffer.User.Gender, rideRequest.RidePreferences, rideRequest.User.Gender) {
 			filteredRideOffers = append(filteredRideOffers, rideOffer)
 		}
 	}
@@ -500,6 +590,95 @@ func (r *MapsRepository) SuggestRideOffers(userID uuid.UUID, rideRequestID uuid.
 	return filteredRideOffers, nil
 }
 
+// BrowseRideOffers returns the open ride offers of other users leaving within the departure window
+// whose route passes close enough to the origin then to the destination, with how close it passes.
+// The ride offers are sorted and paged by the database, in batches since the route proximity is only checked here.
+// One ride offer more than the limit is returned to tell if there is a next page.
+// Nothing is written, so users can browse without creating a ride request
+func (r *MapsRepository) BrowseRideOffers(userID uuid.UUID, filter schemas.BrowseRideOffersFilter) ([]migration.RideOffer, map[uuid.UUID]schemas.RouteProximity, error) {
+	// A route passing within the radius of a point has a bounding box overlapping the box around the point
+	originMinLat, originMaxLat, originMinLng, originMaxLng := helper.RadiusBounds(filter.Origin, filter.OriginRadius)
+	destinationMinLat, destinationMaxLat, destinationMinLng, destinationMaxLng := helper.RadiusBounds(filter.Destination, filter.DestinationRadius)
+
+	query := r.db.Model(&migration.RideOffer{}).
+		Where("status = ? AND user_id <> ? AND available_seats >= ?", "created", userID, filter.Seats).
+		Where("user_id NOT IN (?)", blockedUserIDs(r.db, userID)).
+		Where("start_time BETWEEN ? AND ?", filter.DepartureFrom, filter.DepartureTo).
+		Where(routeBoxOverlaps, originMinLng, originMinLat, originMaxLng, originMaxLat).
+		Where(routeBoxOverlaps, destinationMinLng, destinationMinLat, destinationMaxLng, destinationMaxLat)
+
+	// Every sort ends with the start time then the ID so the position of a ride offer is unique
+	switch filter.SortBy {
+	case "fare":
+		query = query.Order("fare, start_time, id")
+	case "available_seats":
+		query = query.Order("available_seats DESC, start_time, id")
+	default:
+		query = query.Order("start_time, id")
+	}
+
+	batchSize := max(2*(filter.Limit+1), 20)
+	sortValue, startTime, rideOfferID := filter.CursorSortValue, filter.CursorStartTime, filter.CursorRideOfferID
+
+	filteredRideOffers := make([]migration.RideOffer, 0, filter.Limit+1)
+	proximities := make(map[uuid.UUID]schemas.RouteProximity, filter.Limit+1)
+	for len(filteredRideOffers) <= filter.Limit {
+		batch := query.Session(&gorm.Session{})
+		if startTime != nil {
+			switch filter.SortBy {
+			case "fare":
+				batch = batch.Where("(fare, start_time, id) > (?, ?, ?)", sortValue, *startTime, rideOfferID)
+			case "available_seats":
+				batch = batch.Where("(available_seats < ? OR (available_seats = ? AND (start_time, id) > (?, ?)))", sortValue, sortValue, *startTime, rideOfferID)
+			default:
+				batch = batch.Where("(start_time, id) > (?, ?)", *startTime, rideOfferID)
+			}
+		}
+
+		var rideOffers []migration.RideOffer
+		err := batch.
+			Preload("User").
+			Preload("Vehicle").
+			Preload("Waypoints", func(db *gorm.DB) *gorm.DB {
+				return db.Order("waypoint_order")
+			}).
+			Limit(batchSize).
+			Find(&rideOffers).Error
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, rideOffer := range rideOffers {
+			offerPolyline := helper.DecodePolyline(string(rideOffer.EncodedPolyline))
+			if len(offerPolyline) < 2 {
+				continue
+			}
+
+			pickupDistance, dropoffDistance := helper.RouteProximity(offerPolyline, filter.Origin, filter.Destination)
+			if pickupDistance > filter.OriginRadius || dropoffDistance > filter.DestinationRadius {
+				continue
+			}
+
+			filteredRideOffers = append(filteredRideOffers, rideOffer)
+			proximities[rideOffer.ID] = schemas.RouteProximity{
+				PickupDistance:  pickupDistance,
+				DropoffDistance: dropoffDistance,
+			}
+			if len(filteredRideOffers) > filter.Limit {
+				break
+			}
+		}
+
+		if len(rideOffers) < batchSize {
+			break
+		}
+		last := rideOffers[len(rideOffers)-1]
+		sortValue, startTime, rideOfferID = helper.BrowseSortValue(last, filter.SortBy), &last.StartTime, last.ID
+	}
+
+	return filteredRideOffers, proximities, nil
+}
+
 func (r *MapsRepository) GetRideByID(rideID uuid.UUID) (migration.Ride, error) {
 	ride := migration.Ride{}
 	if err := r.db.Preload("RideOffer").Preload("RideRequest").First(&ride, rideID).Error; err != nil {
@@ -519,5 +698,125 @@ func (r *MapsRepository) GetAllWaypoints(rideOfferID uuid.UUID) ([]migration.Way
 	return waypoints, nil
 }
 
+// GetAverageRatings returns the cached average rating received by each of the given users
+// users without any rating are not present in the returned map
+func (r *MapsRepository) GetAverageRatings(userIDs []uuid.UUID) (map[uuid.UUID]float64, error) {
+	averages := make(map[uuid.UUID]float64, len(userIDs))
+	if len(userIDs) == 0 {
+		return averages, nil
+	}
+
+	var rows []struct {
+		ID            uuid.UUID
+		AverageRating float64
+	}
+	err := r.db.Model(&migration.User{}).
+		Select("id, average_rating").
+		Where("id IN ? AND rating_count > 0", userIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		averages[row.ID] = row.AverageRating
+	}
+
+	return averages, nil
+}
+
+// QuoteFare prices the route without creating a ride offer,
+// without a vehicle the default fuel consumption and per kilometer rate are used
+func (r *MapsRepository) QuoteFare(route schemas.GoongDirectionsResponse, userID uuid.UUID, vehicleID uuid.UUID, startTime time.Time) (schemas.FareQuote, error) {
+	if len(route.Routes) == 0 || len(route.Routes[0].Legs) == 0 {
+		return schemas.FareQuote{}, errors.New("invalid route data")
+	}
+
+	totalDistance, totalDuration := routeTotals(route)
+	input := schemas.FareInput{
+		Distance:     float64(totalDistance),
+		Duration:     totalDuration,
+		StartTime:    startTime,
+		FuelType:     helper.FuelTypeRON95,
+		FuelConsumed: r.cfg.FareDefaultFuelConsumed,
+	}
+
+	if vehicleID != uuid.Nil {
+		var vehicle migration.Vehicle
+		if err := r.db.Preload("VehicleType").Where("id = ? AND user_id = ?", vehicleID, userID).First(&vehicle).Error; err != nil {
+			return schemas.FareQuote{}, err
+		}
+		input.VehicleType = vehicle.VehicleType.Name
+		input.FuelType = vehicle.FuelType
+		input.FuelConsumed = vehicle.FuelConsumed
+		input.PerKmRate = vehicle.VehicleType.PerKmRate
+	}
+
+	return r.quoteFare(r.db, input)
+}
+
+// quoteFare completes the input with the current price of the vehicle fuel and runs the pricing engine,
+// electric vehicles are priced with the electricity tariff
+func (r *MapsRepository) quoteFare(tx *gorm.DB, input schemas.FareInput) (schemas.FareQuote, error) {
+	if input.FuelType == "" {
+		input.FuelType = helper.FuelTypeRON95
+	}
+
+	if input.FuelType == helper.FuelTypeElectric {
+		input.FuelPrice = r.cfg.FareElectricityTariff
+	} else if err := tx.Model(&migration.FuelPrice{}).
+		Select("price").
+		Where("code = ?", input.FuelType).
+		Order("updated_at DESC").
+		First(&input.FuelPrice).Error; err != nil {
+		log.Error().Err(err).Str("fuelType", input.FuelType).Msg("Failed to fetch fuel price")
+		return schemas.FareQuote{}, fmt.Errorf("failed to fetch fuel price: %w", err)
+	}
+
+	return r.fareEngine.Quote(input), nil
+}
+
+// newFareEngine builds the pricing engine described by the configuration
+func newFareEngine(cfg util.Config) helper.FareEngine {
+	peakHours, err := helper.ParseHourRanges(cfg.FarePeakHours)
+	if err != nil {
+		log.Warn().Err(err).Str("peakHours", cfg.FarePeakHours).Msg("Ignoring invalid fare peak hours")
+		peakHours = nil
+	}
+
+	return helper.NewFareEngine(helper.FareSettings{
+		Strategy:         cfg.FareStrategy,
+		DefaultPerKmRate: cfg.FareDefaultPerKmRate,
+		PeakHours:        peakHours,
+		PeakMultiplier:   cfg.FarePeakMultiplier,
+		Location:         helper.UTCOffsetLocation(cfg.FareUTCOffset),
+		MinimumFare:      cfg.FareMinimum,
+		CommissionRate:   cfg.FareCommissionRate,
+	})
+}
+
+// routeTotals returns the distance (in whole kilometers) and the duration (in seconds) of the first route
+func routeTotals(route schemas.GoongDirectionsResponse) (int, int) {
+	totalDistance, totalDuration := 0, 0
+	for _, leg := range route.Routes[0].Legs {
+		totalDistance += leg.Distance.Value
+		totalDuration += leg.Duration.Value
+	}
+	return totalDistance / 1000, totalDuration // Convert to kilometers
+}
+
+// ridePreferences returns the given preferences, or the defaults of the user profile if none are given
+func (r *MapsRepository) ridePreferences(tx *gorm.DB, userID uuid.UUID, preferences *migration.RidePreferences) (migration.RidePreferences, error) {
+	if preferences != nil {
+		return *preferences, nil
+	}
+
+	var user migration.User
+	if err := tx.First(&user, userID).Error; err != nil {
+		return migration.RidePreferences{}, fmt.Errorf("failed to fetch the ride preferences of the user: %w", err)
+	}
+	return user.RidePreferences, nil
+}
+
 // Make sure to implement the IMapsRepository interface
 var _ IMapsRepository = (*MapsRepository)(nil)
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/schemas"
+	"shareway/util"
+	"shareway/util/polyline"
+
+	"github.com/google/uuid"
+	gopolyline "github.com/twpayne/go-polyline"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// The tests and benchmarks below run against the PostgreSQL database of TEST_DATABASE_URL (a DSN such as
+// "host=localhost user=postgres dbname=shareway_test sslmode=disable") and are skipped without it.
+// They seed their own rows and delete them when they are done
+const (
+	syntheticOfferCount  = 50000
+	syntheticRoutePoints = 30
+	syntheticMatchEvery  = 100 // One synthetic ride offer out of this many follows the ride request
+)
+
+// suggestionFixture holds the 50k synthetic ride offers and the ride request they are suggested for
+type suggestionFixture struct {
+	db            *gorm.DB
+	hitcherID     uuid.UUID
+	rideRequestID uuid.UUID
+	matches       int // Number of synthetic ride offers following the ride request
+}
+
+var fixture *suggestionFixture
+
+func TestMain(m *testing.M) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		os.Exit(m.Run())
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to connect to the test database: %v\n", err)
+		os.Exit(1)
+	}
+	if err := migration.Migrate(db); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to migrate the test database: %v\n", err)
+		os.Exit(1)
+	}
+	if err := migration.CreateRouteBoxIndexes(db); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create the route box indexes: %v\n", err)
+		os.Exit(1)
+	}
+
+	cleanup, err := seedSuggestionFixture(db)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to seed the test database: %v\n", err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	if err := cleanup(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to clean the test database: %v\n", err)
+	}
+	os.Exit(code)
+}
+
+// encodeRoute encodes the straight route between two points as a polyline with its bounding box
+func encodeRoute(start, end schemas.Point) (polyline.Polyline, [4]float64) {
+	coords := make([][]float64, syntheticRoutePoints)
+	for i := range coords {
+		ratio := float64(i) / float64(syntheticRoutePoints-1)
+		coords[i] = []float64{start.Lat + (end.Lat-start.Lat)*ratio, start.Lng + (end.Lng-start.Lng)*ratio}
+	}
+
+	encoded := polyline.Polyline(gopolyline.EncodeCoords(coords))
+	minLat, maxLat, minLng, maxLng, _ := encoded.Bounds()
+	return encoded, [4]float64{minLat, maxLat, minLng, maxLng}
+}
+
+// seedSuggestionFixture creates a driver with 50k open ride offers spread over the north of Vietnam and a week,
+// a few of them following the route of the ride request of a hitcher at the same time
+func seedSuggestionFixture(db *gorm.DB) (func() error, error) {
+	suffix := uuid.NewString()[:8]
+	base := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	random := rand.New(rand.NewSource(1))
+
+	vehicleType := migration.VehicleType{Name: "benchmark-" + suffix}
+	driver := migration.User{PhoneNumber: "bench-driver-" + suffix}
+	hitcher := migration.User{PhoneNumber: "bench-hitcher-" + suffix}
+	for _, record := range []interface{}{&vehicleType, &driver, &hitcher} {
+		if err := db.Create(record).Error; err != nil {
+			return nil, err
+		}
+	}
+	vehicle := migration.Vehicle{
+		UserID:        driver.ID,
+		VehicleTypeID: vehicleType.ID,
+		LicensePlate:  "bench-" + suffix,
+		CaVet:         "bench-" + suffix,
+		SeatCapacity:  4,
+	}
+	if err := db.Create(&vehicle).Error; err != nil {
+		return nil, err
+	}
+
+	cleanup := func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			for _, step := range []struct {
+				model     interface{}
+				condition string
+				value     interface{}
+			}{
+				{&migration.RideOffer{}, "user_id = ?", driver.ID},
+				{&migration.RideRequest{}, "user_id = ?", hitcher.ID},
+				{&migration.Vehicle{}, "id = ?", vehicle.ID},
+				{&migration.User{}, "id IN ?", []uuid.UUID{driver.ID, hitcher.ID}},
+				{&migration.VehicleType{}, "id = ?", vehicleType.ID},
+			} {
+				if err := tx.Where(step.condition, step.value).Delete(step.model).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	requestPolyline, requestBounds := encodeRoute(schemas.Point{Lat: 21.00, Lng: 105.80}, schemas.Point{Lat: 21.10, Lng: 105.90})
+	rideRequest := migration.RideRequest{
+		UserID:          hitcher.ID,
+		EncodedPolyline: requestPolyline,
+		Status:          "created",
+		StartTime:       base.Add(2 * time.Hour),
+		EndTime:         base.Add(2*time.Hour + 30*time.Minute),
+		MinLatitude:     requestBounds[0],
+		MaxLatitude:     requestBounds[1],
+		MinLongitude:    requestBounds[2],
+		MaxLongitude:    requestBounds[3],
+	}
+	if err := db.Create(&rideRequest).Error; err != nil {
+		return cleanup, err
+	}
+
+	rideOffers := make([]migration.RideOffer, syntheticOfferCount)
+	for i := range rideOffers {
+		start := schemas.Point{Lat: 19 + random.Float64()*4, Lng: 103 + random.Float64()*4}
+		end := schemas.Point{Lat: start.Lat + (random.Float64()-0.5)*0.6, Lng: start.Lng + (random.Float64()-0.5)*0.6}
+		startTime := base.Add(time.Duration(random.Int63n(int64(7 * 24 * time.Hour))))
+		if i%syntheticMatchEvery == 0 {
+			start, end = schemas.Point{Lat: 20.95, Lng: 105.75}, schemas.Point{Lat: 21.15, Lng: 105.95}
+			startTime = base.Add(time.Hour + 45*time.Minute)
+		}
+
+		encoded, bounds := encodeRoute(start, end)
+		rideOffers[i] = migration.RideOffer{
+			UserID:          driver.ID,
+			VehicleID:       vehicle.ID,
+			EncodedPolyline: encoded,
+			Status:          "created",
+			StartTime:       startTime,
+			EndTime:         startTime.Add(time.Hour + 15*time.Minute),
+			MinLatitude:     bounds[0],
+			MaxLatitude:     bounds[1],
+			MinLongitude:    bounds[2],
+			MaxLongitude:    bounds[3],
+			TotalSeats:      1,
+			AvailableSeats:  1,
+		}
+	}
+	if err := db.CreateInBatches(rideOffers, 1000).Error; err != nil {
+		return cleanup, err
+	}
+	if err := db.Exec("ANALYZE ride_offers").Error; err != nil {
+		return cleanup, err
+	}
+
+	fixture = &suggestionFixture{
+		db:            db,
+		hitcherID:     hitcher.ID,
+		rideRequestID: rideRequest.ID,
+		matches:       syntheticOfferCount / syntheticMatchEvery,
+	}
+	return cleanup, nil
+}
+
+func requireFixture(tb testing.TB) *suggestionFixture {
+	tb.Helper()
+	if fixture == nil {
+		tb.Skip("TEST_DATABASE_URL is not set")
+	}
+	return fixture
+}
+
+// suggestRideOffersFullScan suggests ride offers the way SuggestRideOffers did before the route box prefilter,
+// by route matching every open ride offer
+func suggestRideOffersFullScan(db *gorm.DB, userID uuid.UUID, rideRequest migration.RideRequest) ([]migration.RideOffer, error) {
+	var rideOffers []migration.RideOffer
+	err := db.Preload("User").
+		Where("status = ? AND user_id <> ?", "created", userID).
+		Find(&rideOffers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var filteredRideOffers []migration.RideOffer
+	requestPolyline := helper.DecodePolyline(string(rideRequest.EncodedPolyline))
+	for _, rideOffer := range rideOffers {
+		offerPolyline := helper.DecodePolyline(string(rideOffer.EncodedPolyline))
+		if helper.IsMatchRoute(offerPolyline, requestPolyline) && helper.IsTimeOverlap(rideOffer, rideRequest) &&
+			helper.IsPreferenceCompatible(rideOffer.RidePreferences, rideOffer.User.Gender, rideRequest.RidePreferences, rideRequest.User.Gender) {
+			filteredRideOffers = append(filteredRideOffers, rideOffer)
+		}
+	}
+	return filteredRideOffers, nil
+}
+
+func TestSuggestRideOffersKeepsEveryMatch(t *testing.T) {
+	f := requireFixture(t)
+	repo := NewMapsRepository(f.db, util.Config{})
+
+	rideOffers, err := repo.SuggestRideOffers(f.hitcherID, f.rideRequestID)
+	if err != nil {
+		t.Fatalf("SuggestRideOffers() error = %v", err)
+	}
+	if len(rideOffers) < f.matches {
+		t.Errorf("SuggestRideOffers() = %d ride offers, want at least %d", len(rideOffers), f.matches)
+	}
+
+	rideRequest, err := repo.GetRideRequestDetails(f.rideRequestID)
+	if err != nil {
+		t.Fatalf("GetRideRequestDetails() error = %v", err)
+	}
+	fullScan, err := suggestRideOffersFullScan(f.db, f.hitcherID, rideRequest)
+	if err != nil {
+		t.Fatalf("suggestRideOffersFullScan() error = %v", err)
+	}
+	if len(rideOffers) != len(fullScan) {
+		t.Errorf("SuggestRideOffers() = %d ride offers, the full scan finds %d", len(rideOffers), len(fullScan))
+	}
+}
+
+func TestSuggestRideOffersUsesRouteBoxIndex(t *testing.T) {
+	f := requireFixture(t)
+
+	var plan []string
+	err := f.db.Raw("EXPLAIN SELECT id FROM ride_offers WHERE status = ? AND "+routeBoxOverlaps, "created", 105.7, 20.9, 106.0, 21.2).
+		Scan(&plan).Error
+	if err != nil {
+		t.Fatalf("EXPLAIN error = %v", err)
+	}
+	for _, line := range plan {
+		if strings.Contains(line, "idx_ride_offers_route_box") {
+			return
+		}
+	}
+	t.Errorf("the prefilter does not use idx_ride_offers_route_box:\n%v", plan)
+}
+
+// BenchmarkSuggestRideOffers runs the real suggestion query, the GiST index on route_box and the time window
+// narrow the 50k open ride offers to the candidates that are route matched
+func BenchmarkSuggestRideOffers(b *testing.B) {
+	f := requireFixture(b)
+	repo := NewMapsRepository(f.db, util.Config{})
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.SuggestRideOffers(f.hitcherID, f.rideRequestID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSuggestRideOffersFullScan loads and route matches all the 50k open ride offers
+func BenchmarkSuggestRideOffersFullScan(b *testing.B) {
+	f := requireFixture(b)
+	rideRequest, err := NewMapsRepository(f.db, util.Config{}).GetRideRequestDetails(f.rideRequestID)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := suggestRideOffersFullScan(f.db, f.hitcherID, rideRequest); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
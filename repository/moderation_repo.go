@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"errors"
+	"shareway/infra/db/migration"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IModerationRepository interface {
+	BlockUser(blockerID, blockedID uuid.UUID) error
+	UnblockUser(blockerID, blockedID uuid.UUID) error
+	GetBlockedUsers(blockerID uuid.UUID) ([]migration.UserBlock, error)
+	IsBlocked(userID1, userID2 uuid.UUID) (bool, error)
+	UserExists(userID uuid.UUID) (bool, error)
+	IsRideShared(rideID, userID1, userID2 uuid.UUID) (bool, error)
+	IsChatShared(chatID, userID1, userID2 uuid.UUID) (bool, error)
+	CreateUserReport(report migration.UserReport) (migration.UserReport, error)
+	GetUserReportByID(reportID uuid.UUID) (migration.UserReport, error)
+	GetUserReports(status, category string, reportedID uuid.UUID, limit int) ([]migration.UserReport, error)
+	ReviewUserReport(reportID, adminID uuid.UUID) (migration.UserReport, error)
+	CloseUserReport(reportID, adminID uuid.UUID, status, action, note string) (migration.UserReport, error)
+}
+
+type ModerationRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewModerationRepository(db *gorm.DB, redis *redis.Client) IModerationRepository {
+	return &ModerationRepository{db: db, redis: redis}
+}
+
+var (
+	ErrUserBlocked           = errors.New("one of the users blocked the other")
+	ErrUserNotBlocked        = errors.New("user is not blocked")
+	ErrUserReportNotFound    = errors.New("user report not found")
+	ErrUserReportTransition  = errors.New("the user report cannot change from its current status")
+	ErrUserReportUnreachable = errors.New("the referenced ride or chat message was not shared with the reported user")
+)
+
+// blockedUserIDs is the subquery of the users hidden from the given user, the ones it blocked and the ones
+// who blocked it
+func blockedUserIDs(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Model(&migration.UserBlock{}).
+		Select("CASE WHEN blocker_id = ? THEN blocked_id ELSE blocker_id END", userID).
+		Where("blocker_id = ? OR blocked_id = ?", userID, userID)
+}
+
+// BlockUser blocks a user, blocking a user twice is a no-op
+func (r *ModerationRepository) BlockUser(blockerID, blockedID uuid.UUID) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "blocker_id"}, {Name: "blocked_id"}},
+		DoNothing: true,
+	}).Create(&migration.UserBlock{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}).Error
+}
+
+// UnblockUser lifts a block the user created, the blocks created by the other user stay
+func (r *ModerationRepository) UnblockUser(blockerID, blockedID uuid.UUID) error {
+	result := r.db.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&migration.UserBlock{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotBlocked
+	}
+	return nil
+}
+
+// GetBlockedUsers returns the users blocked by the user with the blocked users, newest first
+func (r *ModerationRepository) GetBlockedUsers(blockerID uuid.UUID) ([]migration.UserBlock, error) {
+	var blocks []migration.UserBlock
+	err := r.db.Preload("Blocked").
+		Where("blocker_id = ?", blockerID).
+		Order("created_at DESC").
+		Find(&blocks).Error
+	return blocks, err
+}
+
+// IsBlocked tells if one of the two users blocked the other
+func (r *ModerationRepository) IsBlocked(userID1, userID2 uuid.UUID) (bool, error) {
+	return isBlocked(r.db, userID1, userID2)
+}
+
+// isBlocked tells if one of the two users blocked the other, within the given transaction
+func isBlocked(db *gorm.DB, userID1, userID2 uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&migration.UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID1, userID2, userID2, userID1).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UserExists tells if there is a user with the given ID
+func (r *ModerationRepository) UserExists(userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&migration.User{}).Where("id = ?", userID).Count(&count).Error
+	return count > 0, err
+}
+
+// IsRideShared tells if the two users were the driver and the passenger of a ride
+func (r *ModerationRepository) IsRideShared(rideID, userID1, userID2 uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&migration.Ride{}).
+		Joins("JOIN ride_offers ON ride_offers.id = rides.ride_offer_id").
+		Joins("JOIN ride_requests ON ride_requests.id = rides.ride_request_id").
+		Where("rides.id = ?", rideID).
+		Where("(ride_offers.user_id = ? AND ride_requests.user_id = ?) OR (ride_offers.user_id = ? AND ride_requests.user_id = ?)", userID1, userID2, userID2, userID1).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// IsChatShared tells if a chat message was sent by one of the two users to the other
+func (r *ModerationRepository) IsChatShared(chatID, userID1, userID2 uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&migration.Chat{}).
+		Where("id = ?", chatID).
+		Where("(sender_id = ? AND receiver_id = ?) OR (sender_id = ? AND receiver_id = ?)", userID1, userID2, userID2, userID1).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CreateUserReport opens a case against a user
+func (r *ModerationRepository) CreateUserReport(report migration.UserReport) (migration.UserReport, error) {
+	if err := r.db.Create(&report).Error; err != nil {
+		return migration.UserReport{}, err
+	}
+	return report, nil
+}
+
+// GetUserReportByID fetches a report with the reporter, the reported user and the reported chat message
+func (r *ModerationRepository) GetUserReportByID(reportID uuid.UUID) (migration.UserReport, error) {
+	var report migration.UserReport
+	err := r.db.Preload("Reporter").
+		Preload("Reported").
+		Preload("Chat").
+		Where("id = ?", reportID).
+		First(&report).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return report, ErrUserReportNotFound
+		}
+		return report, err
+	}
+	return report, nil
+}
+
+// GetUserReports returns the latest reports for the admins, filtered by the given status, category
+// and reported user when set
+func (r *ModerationRepository) GetUserReports(status, category string, reportedID uuid.UUID, limit int) ([]migration.UserReport, error) {
+	query := r.db.Model(&migration.UserReport{}).Preload("Reporter").Preload("Reported").Preload("Chat")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+	if reportedID != uuid.Nil {
+		query = query.Where("reported_id = ?", reportedID)
+	}
+
+	var reports []migration.UserReport
+	err := query.Order("created_at DESC").Limit(limit).Find(&reports).Error
+	return reports, err
+}
+
+// ReviewUserReport records that an admin is looking into an open report
+func (r *ModerationRepository) ReviewUserReport(reportID, adminID uuid.UUID) (migration.UserReport, error) {
+	return r.transitionUserReport(reportID, []string{"open"}, map[string]interface{}{
+		"status":      "reviewing",
+		"reviewed_at": time.Now(),
+		"reviewed_by": adminID,
+	})
+}
+
+// CloseUserReport closes a report with the action taken, an open report is reviewed by the same admin
+// at the same time
+func (r *ModerationRepository) CloseUserReport(reportID, adminID uuid.UUID, status, action, note string) (migration.UserReport, error) {
+	now := time.Now()
+	return r.transitionUserReport(reportID, []string{"open", "reviewing"}, map[string]interface{}{
+		"status":      status,
+		"action":      action,
+		"reviewed_at": gorm.Expr("COALESCE(reviewed_at, ?)", now),
+		"reviewed_by": gorm.Expr("COALESCE(reviewed_by, ?)", adminID),
+		"closed_at":   now,
+		"closed_by":   adminID,
+		"admin_note":  note,
+	})
+}
+
+// transitionUserReport applies the updates to a report if its status is one of the given ones
+func (r *ModerationRepository) transitionUserReport(reportID uuid.UUID, from []string, updates map[string]interface{}) (migration.UserReport, error) {
+	result := r.db.Model(&migration.UserReport{}).
+		Where("id = ? AND status IN ?", reportID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return migration.UserReport{}, result.Error
+	}
+
+	report, err := r.GetUserReportByID(reportID)
+	if err != nil {
+		return report, err
+	}
+	if result.RowsAffected == 0 {
+		return report, ErrUserReportTransition
+	}
+	return report, nil
+}
+
+// Make sure the ModerationRepository implements the IModerationRepository interface
+var _ IModerationRepository = (*ModerationRepository)(nil)
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+	"shareway/infra/db/migration"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRatingRepository interface {
+	CreateRating(rating migration.Rating) (migration.Rating, migration.User, error)
+	GetRatingsReceived(rateeID uuid.UUID, page, limit int) ([]migration.Rating, int64, error)
+	GetRatedUser(userID uuid.UUID) (migration.User, error)
+}
+
+type RatingRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewRatingRepository(db *gorm.DB, redis *redis.Client) IRatingRepository {
+	return &RatingRepository{db: db, redis: redis}
+}
+
+var (
+	ErrAlreadyRated = errors.New("the ride has already been rated by the user")
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// CreateRating saves the rating of a ride given by a user, once per ride, and refreshes the average rating
+// cached on the rated user. It returns the rating with its rater and the rated user with the new average
+func (r *RatingRepository) CreateRating(rating migration.Rating) (migration.Rating, migration.User, error) {
+	var ratee migration.User
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the rated user so concurrent ratings do not overwrite the average with a stale one
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&ratee, "id = ?", rating.RateeID).Error; err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "rater_id"}, {Name: "ride_id"}},
+			DoNothing: true,
+		}).Create(&rating)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAlreadyRated
+		}
+
+		// Recompute from the ratings rather than incrementally so the cache heals itself
+		err := tx.Model(&migration.User{}).
+			Where("id = ?", rating.RateeID).
+			Updates(map[string]interface{}{
+				"average_rating": tx.Model(&migration.Rating{}).Select("COALESCE(AVG(rating), 0)").Where("ratee_id = ?", rating.RateeID),
+				"rating_count":   tx.Model(&migration.Rating{}).Select("COUNT(*)").Where("ratee_id = ?", rating.RateeID),
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.First(&rating.Rater, "id = ?", rating.RaterID).Error; err != nil {
+			return err
+		}
+		return tx.First(&ratee, "id = ?", rating.RateeID).Error
+	})
+	if err != nil {
+		return migration.Rating{}, migration.User{}, err
+	}
+	return rating, ratee, nil
+}
+
+// GetRatingsReceived returns a page of the ratings received by a user with their raters, newest first,
+// and the number of ratings over all pages
+func (r *RatingRepository) GetRatingsReceived(rateeID uuid.UUID, page, limit int) ([]migration.Rating, int64, error) {
+	var total int64
+	if err := r.db.Model(&migration.Rating{}).Where("ratee_id = ?", rateeID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var ratings []migration.Rating
+	err := r.db.Preload("Rater").
+		Where("ratee_id = ?", rateeID).
+		Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&ratings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return ratings, total, nil
+}
+
+// GetRatedUser returns a user with the average rating and the number of ratings cached on it
+func (r *RatingRepository) GetRatedUser(userID uuid.UUID) (migration.User, error) {
+	var user migration.User
+	err := r.db.Where("id = ?", userID).Take(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return user, ErrUserNotFound
+		}
+		return user, err
+	}
+	return user, nil
+}
+
+// Make sure the RatingRepository implements the IRatingRepository interface
+var _ IRatingRepository = (*RatingRepository)(nil)
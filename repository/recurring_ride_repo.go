@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"errors"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/util"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRecurringRideRepository interface {
+	CreateRecurringRideOffer(recurringRideOffer migration.RecurringRideOffer, skipDates []time.Time) (migration.RecurringRideOffer, error)
+	GetRecurringRideOfferByID(recurringRideOfferID, userID uuid.UUID) (migration.RecurringRideOffer, error)
+	GetRecurringRideOffersByUserID(userID uuid.UUID) ([]migration.RecurringRideOffer, error)
+	GetActiveRecurringRideOffers(day time.Time) ([]migration.RecurringRideOffer, error)
+	UpdateRecurringRideOffer(recurringRideOffer migration.RecurringRideOffer, skipDates []time.Time) error
+	SetRecurringRideOfferPaused(recurringRideOfferID, userID uuid.UUID, isPaused bool) error
+	AddSkipDate(recurringRideOfferID uuid.UUID, day time.Time) error
+	HasOccurrence(recurringRideOfferID uuid.UUID, day time.Time) (bool, error)
+	RemoveUnbookedOccurrences(recurringRideOfferID uuid.UUID, from time.Time, userID uuid.UUID) error
+	CancelOccurrence(recurringRideOfferID uuid.UUID, day time.Time, userID uuid.UUID) error
+}
+
+type RecurringRideRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+	cfg   util.Config
+}
+
+func NewRecurringRideRepository(db *gorm.DB, redis *redis.Client, cfg util.Config) IRecurringRideRepository {
+	return &RecurringRideRepository{db: db, redis: redis, cfg: cfg}
+}
+
+var (
+	ErrRecurringRideOfferNotFound = errors.New("recurring ride offer not found")
+	ErrOccurrenceAlreadyBooked    = errors.New("occurrence already has bookings, cancel the rides instead")
+)
+
+// CreateRecurringRideOffer creates a recurring ride offer with its skip dates
+// the vehicle must belong to the driver and have enough seats
+func (r *RecurringRideRepository) CreateRecurringRideOffer(recurringRideOffer migration.RecurringRideOffer, skipDates []time.Time) (migration.RecurringRideOffer, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkVehicleSeats(tx, &recurringRideOffer); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&recurringRideOffer).Error; err != nil {
+			return err
+		}
+
+		return replaceSkipDates(tx, recurringRideOffer.ID, skipDates)
+	})
+
+	if err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+
+	return recurringRideOffer, nil
+}
+
+// GetRecurringRideOfferByID fetches a recurring ride offer of the driver with its skip dates
+func (r *RecurringRideRepository) GetRecurringRideOfferByID(recurringRideOfferID, userID uuid.UUID) (migration.RecurringRideOffer, error) {
+	var recurringRideOffer migration.RecurringRideOffer
+	err := r.db.Preload("SkipDates").
+		Where("id = ? AND user_id = ?", recurringRideOfferID, userID).
+		First(&recurringRideOffer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return recurringRideOffer, ErrRecurringRideOfferNotFound
+		}
+		return recurringRideOffer, err
+	}
+
+	return recurringRideOffer, nil
+}
+
+// GetRecurringRideOffersByUserID fetches all recurring ride offers of the driver
+func (r *RecurringRideRepository) GetRecurringRideOffersByUserID(userID uuid.UUID) ([]migration.RecurringRideOffer, error) {
+	var recurringRideOffers []migration.RecurringRideOffer
+	err := r.db.Preload("SkipDates").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&recurringRideOffers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return recurringRideOffers, nil
+}
+
+// GetActiveRecurringRideOffers fetches the recurring ride offers that are not paused and not ended on the given day
+func (r *RecurringRideRepository) GetActiveRecurringRideOffers(day time.Time) ([]migration.RecurringRideOffer, error) {
+	var recurringRideOffers []migration.RecurringRideOffer
+	err := r.db.Preload("SkipDates").
+		Where("is_paused = ? AND (until_date IS NULL OR until_date >= ?)", false, day).
+		Find(&recurringRideOffers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return recurringRideOffers, nil
+}
+
+// UpdateRecurringRideOffer saves the recurring ride offer, skip dates are replaced when not nil
+func (r *RecurringRideRepository) UpdateRecurringRideOffer(recurringRideOffer migration.RecurringRideOffer, skipDates []time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkVehicleSeats(tx, &recurringRideOffer); err != nil {
+			return err
+		}
+
+		if err := tx.Omit("SkipDates", "RideOffers").Save(&recurringRideOffer).Error; err != nil {
+			return err
+		}
+
+		if skipDates == nil {
+			return nil
+		}
+
+		return replaceSkipDates(tx, recurringRideOffer.ID, skipDates)
+	})
+}
+
+// SetRecurringRideOfferPaused pauses or resumes a recurring ride offer of the driver
+func (r *RecurringRideRepository) SetRecurringRideOfferPaused(recurringRideOfferID, userID uuid.UUID, isPaused bool) error {
+	result := r.db.Model(&migration.RecurringRideOffer{}).
+		Where("id = ? AND user_id = ?", recurringRideOfferID, userID).
+		Update("is_paused", isPaused)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecurringRideOfferNotFound
+	}
+
+	return nil
+}
+
+// AddSkipDate stops the recurring ride offer from being published on the given day
+func (r *RecurringRideRepository) AddSkipDate(recurringRideOfferID uuid.UUID, day time.Time) error {
+	return r.db.
+		Where(migration.RecurringRideOfferSkipDate{RecurringRideOfferID: recurringRideOfferID, Date: day}).
+		FirstOrCreate(&migration.RecurringRideOfferSkipDate{}).Error
+}
+
+// HasOccurrence checks if a ride offer was already materialised for the given day
+func (r *RecurringRideRepository) HasOccurrence(recurringRideOfferID uuid.UUID, day time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&migration.RideOffer{}).
+		Where("recurring_ride_offer_id = ? AND start_time >= ? AND start_time < ?", recurringRideOfferID, day, day.AddDate(0, 0, 1)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// RemoveUnbookedOccurrences cancels the occurrences starting after the given time that nobody booked yet and
+// detaches them from the recurring ride offer, so they can be materialised again with its latest settings.
+// The ride offers are kept since route alerts may already link to them
+func (r *RecurringRideRepository) RemoveUnbookedOccurrences(recurringRideOfferID uuid.UUID, from time.Time, userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var rideOfferIDs []uuid.UUID
+		err := tx.Model(&migration.RideOffer{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("recurring_ride_offer_id = ? AND start_time > ? AND status = ?", recurringRideOfferID, from, "created").
+			Where("NOT EXISTS (SELECT 1 FROM rides WHERE rides.ride_offer_id = ride_offers.id)").
+			Pluck("id", &rideOfferIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(rideOfferIDs) == 0 {
+			return nil
+		}
+
+		for _, rideOfferID := range rideOfferIDs {
+			err := applyStatusTransition(tx, statusTransition{
+				entity:  helper.StatusEntityRideOffer,
+				id:      rideOfferID,
+				from:    "created",
+				to:      "cancelled",
+				actorID: &userID,
+				reason:  "recurring ride occurrence withdrawn",
+			}, r.cfg.RideTestMode)
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&migration.RideOffer{}).
+			Where("id IN ?", rideOfferIDs).
+			Update("recurring_ride_offer_id", nil).Error
+	})
+}
+
+// CancelOccurrence cancels the ride offer materialised on the given day if nobody booked it
+func (r *RecurringRideRepository) CancelOccurrence(recurringRideOfferID uuid.UUID, day time.Time, userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var rideOffer migration.RideOffer
+		err := tx.Select("id, status").
+			Where("recurring_ride_offer_id = ? AND start_time >= ? AND start_time < ? AND status <> ?", recurringRideOfferID, day, day.AddDate(0, 0, 1), "cancelled").
+			First(&rideOffer).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Not materialised yet, the skip date is enough
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var bookings int64
+		if err := tx.Model(&migration.Ride{}).Where("ride_offer_id = ?", rideOffer.ID).Count(&bookings).Error; err != nil {
+			return err
+		}
+		if bookings > 0 {
+			return ErrOccurrenceAlreadyBooked
+		}
+
+		return applyStatusTransition(tx, statusTransition{
+			entity:  helper.StatusEntityRideOffer,
+			id:      rideOffer.ID,
+			from:    rideOffer.Status,
+			to:      "cancelled",
+			actorID: &userID,
+			reason:  "recurring ride occurrence skipped",
+		}, r.cfg.RideTestMode)
+	})
+}
+
+// checkVehicleSeats makes sure the vehicle belongs to the driver and defaults the seats to the vehicle capacity
+func checkVehicleSeats(tx *gorm.DB, recurringRideOffer *migration.RecurringRideOffer) error {
+	var vehicle migration.Vehicle
+	err := tx.Select("id, seat_capacity").
+		Where("id = ? AND user_id = ?", recurringRideOffer.VehicleID, recurringRideOffer.UserID).
+		First(&vehicle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVehicleNotFound
+		}
+		return err
+	}
+
+	if recurringRideOffer.Seats <= 0 {
+		recurringRideOffer.Seats = vehicle.SeatCapacity
+	}
+	if recurringRideOffer.Seats > vehicle.SeatCapacity {
+		return ErrSeatsExceedCapacity
+	}
+
+	return nil
+}
+
+// replaceSkipDates replaces all skip dates of a recurring ride offer
+func replaceSkipDates(tx *gorm.DB, recurringRideOfferID uuid.UUID, skipDates []time.Time) error {
+	if err := tx.Where("recurring_ride_offer_id = ?", recurringRideOfferID).Delete(&migration.RecurringRideOfferSkipDate{}).Error; err != nil {
+		return err
+	}
+	if len(skipDates) == 0 {
+		return nil
+	}
+
+	rows := make([]migration.RecurringRideOfferSkipDate, len(skipDates))
+	for i, day := range skipDates {
+		rows[i] = migration.RecurringRideOfferSkipDate{
+			RecurringRideOfferID: recurringRideOfferID,
+			Date:                 day,
+		}
+	}
+
+	return tx.Create(&rows).Error
+}
+
+// Make sure the RecurringRideRepository implements the IRecurringRideRepository interface
+var _ IRecurringRideRepository = (*RecurringRideRepository)(nil)
@@ -9,16 +9,25 @@ import (
 
 // RepositoryContainer holds all the repositories
 type RepositoryContainer struct {
-	AuthRepository         IAuthRepository
-	MapsRepository         IMapsRepository
-	OTPRepository          IOTPRepository
-	VehicleRepository      IVehicleRepository
-	RideRepository         IRideRepository
-	NotificationRepository INotificationRepository
-	ChatRepository         IChatRepository
-	AdminRepository        IAdminRepository
-	PaymentRepository      IPaymentRepository
-	IPNRepository          IIPNRepository
+	AuthRepository             IAuthRepository
+	MapsRepository             IMapsRepository
+	OTPRepository              IOTPRepository
+	VehicleRepository          IVehicleRepository
+	RideRepository             IRideRepository
+	NotificationRepository     INotificationRepository
+	ChatRepository             IChatRepository
+	AdminRepository            IAdminRepository
+	PaymentRepository          IPaymentRepository
+	IPNRepository              IIPNRepository
+	RecurringRideRepository    IRecurringRideRepository
+	RouteAlertRepository       IRouteAlertRepository
+	RideTrailRepository        IRideTrailRepository
+	SOSRepository              ISOSRepository
+	TripShareRepository        ITripShareRepository
+	ImpactRepository           IImpactRepository
+	RatingRepository           IRatingRepository
+	ModerationRepository       IModerationRepository
+	FavoriteLocationRepository IFavoriteLocationRepository
 	// Add other repositories here as needed
 }
 
@@ -41,16 +50,25 @@ func NewRepositoryFactory(db *gorm.DB, redisClient *redis.Client, cfg util.Confi
 // CreateRepositories initializes and returns all repositories
 func (f *RepositoryFactory) CreateRepositories() *RepositoryContainer {
 	return &RepositoryContainer{
-		AuthRepository:         f.createAuthRepository(),
-		MapsRepository:         f.createMapsRepository(),
-		OTPRepository:          f.createOTPRepository(),
-		VehicleRepository:      f.createVehicleRepository(),
-		RideRepository:         f.createRideRepository(),
-		NotificationRepository: f.createNotificationRepository(),
-		ChatRepository:         f.createChatRepository(),
-		AdminRepository:        f.createAdminRepository(),
-		PaymentRepository:      f.createPaymentRepository(),
-		IPNRepository:          f.createIPNRepository(),
+		AuthRepository:             f.createAuthRepository(),
+		MapsRepository:             f.createMapsRepository(),
+		OTPRepository:              f.createOTPRepository(),
+		VehicleRepository:          f.createVehicleRepository(),
+		RideRepository:             f.createRideRepository(),
+		NotificationRepository:     f.createNotificationRepository(),
+		ChatRepository:             f.createChatRepository(),
+		AdminRepository:            f.createAdminRepository(),
+		PaymentRepository:          f.createPaymentRepository(),
+		IPNRepository:              f.createIPNRepository(),
+		RecurringRideRepository:    f.createRecurringRideRepository(),
+		RouteAlertRepository:       f.createRouteAlertRepository(),
+		RideTrailRepository:        f.createRideTrailRepository(),
+		SOSRepository:              f.createSOSRepository(),
+		TripShareRepository:        f.createTripShareRepository(),
+		ImpactRepository:           f.createImpactRepository(),
+		RatingRepository:           f.createRatingRepository(),
+		ModerationRepository:       f.createModerationRepository(),
+		FavoriteLocationRepository: f.createFavoriteLocationRepository(),
 		// Initialize other repositories here
 	}
 }
@@ -62,7 +80,7 @@ func (f *RepositoryFactory) createAuthRepository() IAuthRepository {
 
 // createMapsRepository initializes and returns the Maps repository
 func (f *RepositoryFactory) createMapsRepository() IMapsRepository {
-	return NewMapsRepository(f.db)
+	return NewMapsRepository(f.db, f.cfg)
 }
 
 // createOTPRepository initializes and returns the OTP repository
@@ -77,7 +95,7 @@ func (f *RepositoryFactory) createVehicleRepository() IVehicleRepository {
 
 // createRideRepository initializes and returns the Ride repository
 func (f *RepositoryFactory) createRideRepository() IRideRepository {
-	return NewRideRepository(f.db, f.redisClient)
+	return NewRideRepository(f.db, f.redisClient, f.cfg)
 }
 
 // createNotificationRepository initializes and returns the Notification repository
@@ -105,4 +123,49 @@ func (f *RepositoryFactory) createIPNRepository() IIPNRepository {
 	return NewIPNRepository(f.db, f.redisClient)
 }
 
+// createRecurringRideRepository initializes and returns the RecurringRide repository
+func (f *RepositoryFactory) createRecurringRideRepository() IRecurringRideRepository {
+	return NewRecurringRideRepository(f.db, f.redisClient, f.cfg)
+}
+
+// createRouteAlertRepository initializes and returns the RouteAlert repository
+func (f *RepositoryFactory) createRouteAlertRepository() IRouteAlertRepository {
+	return NewRouteAlertRepository(f.db, f.redisClient)
+}
+
+// createRideTrailRepository initializes and returns the RideTrail repository
+func (f *RepositoryFactory) createRideTrailRepository() IRideTrailRepository {
+	return NewRideTrailRepository(f.db, f.redisClient)
+}
+
+// createSOSRepository initializes and returns the SOS repository
+func (f *RepositoryFactory) createSOSRepository() ISOSRepository {
+	return NewSOSRepository(f.db, f.redisClient)
+}
+
+// createTripShareRepository initializes and returns the TripShare repository
+func (f *RepositoryFactory) createTripShareRepository() ITripShareRepository {
+	return NewTripShareRepository(f.db, f.redisClient)
+}
+
+// createImpactRepository initializes and returns the Impact repository
+func (f *RepositoryFactory) createImpactRepository() IImpactRepository {
+	return NewImpactRepository(f.db, f.redisClient)
+}
+
+// createRatingRepository initializes and returns the Rating repository
+func (f *RepositoryFactory) createRatingRepository() IRatingRepository {
+	return NewRatingRepository(f.db, f.redisClient)
+}
+
+// createModerationRepository initializes and returns the Moderation repository
+func (f *RepositoryFactory) createModerationRepository() IModerationRepository {
+	return NewModerationRepository(f.db, f.redisClient)
+}
+
+// createFavoriteLocationRepository initializes and returns the FavoriteLocation repository
+func (f *RepositoryFactory) createFavoriteLocationRepository() IFavoriteLocationRepository {
+	return NewFavoriteLocationRepository(f.db, f.redisClient)
+}
+
 // Add methods for creating other repositories as needed
@@ -2,13 +2,16 @@ package repository
 
 import (
 	"errors"
+	"fmt"
 	"shareway/helper"
 	"shareway/infra/db/migration"
 	"shareway/schemas"
+	"shareway/util"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
 )
 
@@ -19,27 +22,52 @@ type IRideRepository interface {
 	GetRideOfferByID(rideOfferID uuid.UUID) (migration.RideOffer, error)
 	GetRideRequestByID(rideRequestID uuid.UUID) (migration.RideRequest, error)
 	GetTransactionByRideID(rideID uuid.UUID) (migration.Transaction, error)
-	AcceptRideRequest(rideOfferID, rideRequestID, vehicleID uuid.UUID) (migration.Ride, error)
+	AcceptRideRequest(rideOfferID, rideRequestID, vehicleID, actorID uuid.UUID, suggestMeetingPoints MeetingPointsFunc) (migration.Ride, error)
 	CreateRideTransaction(rideID uuid.UUID, Fare float64, paymentMethod string, payerID uuid.UUID, receiverID uuid.UUID) (migration.Transaction, error)
-	StartRide(req schemas.StartRideRequest, userID uuid.UUID) (migration.Ride, error)
+	GetRideByID(rideID uuid.UUID) (migration.Ride, error)
+	IssuePickupCode(rideID uuid.UUID, pinHash string, expiresAt time.Time) error
+	ReservePickupAttempt(rideID uuid.UUID, maxAttempts int) (bool, error)
+	ReleasePickupAttempt(rideID uuid.UUID) error
+	RecordFailedPickupAttempt(rideID, actorID uuid.UUID, method, reason string) error
+	StartRide(req schemas.StartRideRequest, userID uuid.UUID, verification string) (migration.Ride, error)
 	EndRide(req schemas.EndRideRequest, userID uuid.UUID) (migration.Ride, error)
 	UpdateRideLocation(req schemas.UpdateRideLocationRequest, userID uuid.UUID) (migration.Ride, error)
+	UpdateRiderLocation(req schemas.UpdateRiderLocationRequest, userID uuid.UUID) (migration.Ride, error)
 	CancelRide(req schemas.CancelRideRequest, userID uuid.UUID) (migration.Ride, error)
 	GetAllPendingRide(userID uuid.UUID) ([]migration.RideOffer, []migration.RideRequest, error)
+	ExpireStaleRides(expireBefore, noShowBefore time.Time) ([]migration.Ride, []migration.RideOffer, []migration.RideRequest, error)
+	GetRideHistory(userID uuid.UUID, filter schemas.RideHistoryFilter) ([]migration.Ride, error)
+	GetRideHistorySummary(userID uuid.UUID, from, to time.Time) ([]schemas.RideHistoryMonth, error)
+	GetRideForReceipt(rideID uuid.UUID) (migration.Ride, error)
+	GetRideCompletedAt(ride migration.Ride) (time.Time, error)
 }
 
 type RideRepository struct {
 	db    *gorm.DB
 	redis *redis.Client
+	cfg   util.Config
 }
 
-func NewRideRepository(db *gorm.DB, redis *redis.Client) IRideRepository {
-	return &RideRepository{db: db, redis: redis}
+func NewRideRepository(db *gorm.DB, redis *redis.Client, cfg util.Config) IRideRepository {
+	return &RideRepository{db: db, redis: redis, cfg: cfg}
 }
 
+// MeetingPointsFunc suggests where the hitcher of the ride request is picked up and dropped off on the
+// route of the ride offer
+type MeetingPointsFunc func(rideOffer migration.RideOffer, rideRequest migration.RideRequest) (schemas.MeetingPoints, error)
+
 var (
+	ErrRideNotFound        = errors.New("ride not found")
 	ErrRideOfferNotFound   = errors.New("ride offer not found")
 	ErrRideRequestNotFound = errors.New("ride request not found")
+	ErrNoSeatsAvailable    = errors.New("no seats available on this ride offer")
+	ErrRideAlreadyBooked   = errors.New("ride request is already booked on this ride offer")
+	ErrRideOfferNotOpen    = errors.New("ride offer is not open for booking")
+	ErrRideNotActive       = errors.New("ride is not scheduled or ongoing")
+	ErrStatusChanged       = errors.New("status was changed by another request, please try again")
+	ErrNotRideDriver       = errors.New("only the driver of the ride can do this")
+	ErrNotRideParticipant  = errors.New("only the driver and the passenger of the ride can do this")
+	ErrNotRidePassenger    = errors.New("only the passenger of the ride can do this")
 )
 
 // CreateNewChatRoom creates a new chat room between two users
@@ -160,25 +188,24 @@ func (r *RideRepository) GetRideRequestByID(rideRequestID uuid.UUID) (migration.
 	return rideRequest, nil
 }
 
-// AcceptGiveRideRequest accepts a give ride request
-func (r *RideRepository) AcceptRideRequest(rideOfferID, rideRequestID, vehicleID uuid.UUID) (migration.Ride, error) {
+// AcceptGiveRideRequest accepts a give ride request, actorID is the user who accepted it
+func (r *RideRepository) AcceptRideRequest(rideOfferID, rideRequestID, vehicleID, actorID uuid.UUID, suggestMeetingPoints MeetingPointsFunc) (migration.Ride, error) {
 	var ride migration.Ride
 
 	err := r.db.Transaction(func(tx *gorm.DB) error {
 		// Get the ride offer by ID with only necessary fields
 		var rideOffer migration.RideOffer
-		err := tx.Select("user_id, start_time, end_time, status, fare, start_address, end_address, encoded_polyline, distance, duration, start_latitude, start_longitude, end_latitude, end_longitude").
+		err := tx.Select("user_id, start_time, end_time, status, fare, fare_strategy, fare_details, start_address, end_address, encoded_polyline, distance, duration, start_latitude, start_longitude, end_latitude, end_longitude").
 			Where("id = ?", rideOfferID).
 			First(&rideOffer).Error
 		if err != nil {
 			return err
 		}
 
-		// TODO: COMMENTED OUT FOR NOW FOR BETTER TESTING
-		// // Check if the ride offer is already matched
-		// if rideOffer.Status == "matched" {
-		// 	return errors.New("ride offer is already matched")
-		// }
+		// Only ride offers still looking for passengers can take new bookings
+		if rideOffer.Status != "created" && !r.cfg.RideTestMode {
+			return ErrRideOfferNotOpen
+		}
 
 		// Get the ride request by ID with only necessary fields
 		var rideRequest migration.RideRequest
@@ -189,30 +216,86 @@ func (r *RideRepository) AcceptRideRequest(rideOfferID, rideRequestID, vehicleID
 			return err
 		}
 
-		// TODO: COMMENTED OUT FOR NOW FOR BETTER TESTING
-		// // Check if the ride request is already matched
-		// if rideRequest.Status == "matched" {
-		// 	return errors.New("ride request is already matched")
-		// }
+		// The users who blocked each other cannot ride together, whoever sent the request
+		blocked, err := isBlocked(tx, rideOffer.UserID, rideRequest.UserID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return ErrUserBlocked
+		}
+
+		// A ride request can only hold one active booking on the same ride offer
+		var activeBookings int64
+		err = tx.Model(&migration.Ride{}).
+			Where("ride_offer_id = ? AND ride_request_id = ? AND status IN ?", rideOfferID, rideRequestID, []string{"scheduled", "ongoing"}).
+			Count(&activeBookings).Error
+		if err != nil {
+			return err
+		}
+		if activeBookings > 0 {
+			return ErrRideAlreadyBooked
+		}
+
+		// Reserve a seat on the ride offer, the conditional update prevents overbooking
+		// when several requests are accepted at the same time
+		result := tx.Model(&migration.RideOffer{}).
+			Where("id = ? AND available_seats > 0", rideOfferID).
+			Update("available_seats", gorm.Expr("available_seats - 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNoSeatsAvailable
+		}
+
+		// The ride request is matched with this ride offer (fails if it is already matched elsewhere)
+		err = r.transitionStatus(tx, statusTransition{
+			entity:  helper.StatusEntityRideRequest,
+			id:      rideRequestID,
+			from:    rideRequest.Status,
+			to:      "matched",
+			actorID: &actorID,
+			reason:  "ride request accepted",
+		})
+		if err != nil {
+			return err
+		}
+
+		// The meeting points are stored with the ride so an accepted ride always has them
+		meetingPoints, err := suggestMeetingPoints(rideOffer, rideRequest)
+		if err != nil {
+			return err
+		}
 
 		// Create a new ride
 		ride = migration.Ride{
-			RideOfferID:     rideOfferID,
-			RideRequestID:   rideRequestID,
-			Status:          "scheduled",
-			StartTime:       rideOffer.StartTime,
-			EndTime:         rideOffer.EndTime,
-			Fare:            rideOffer.Fare,
-			StartAddress:    rideOffer.StartAddress,
-			EndAddress:      rideOffer.EndAddress,
-			EncodedPolyline: rideOffer.EncodedPolyline,
-			Distance:        rideOffer.Distance,
-			Duration:        rideOffer.Duration,
-			StartLatitude:   rideOffer.StartLatitude,
-			StartLongitude:  rideOffer.StartLongitude,
-			EndLatitude:     rideOffer.EndLatitude,
-			EndLongitude:    rideOffer.EndLongitude,
-			VehicleID:       vehicleID,
+			RideOfferID:            rideOfferID,
+			RideRequestID:          rideRequestID,
+			Status:                 "scheduled",
+			StartTime:              rideOffer.StartTime,
+			EndTime:                rideOffer.EndTime,
+			Fare:                   rideOffer.Fare,
+			FareStrategy:           rideOffer.FareStrategy,
+			FareDetails:            rideOffer.FareDetails,
+			StartAddress:           rideOffer.StartAddress,
+			EndAddress:             rideOffer.EndAddress,
+			EncodedPolyline:        rideOffer.EncodedPolyline,
+			Distance:               rideOffer.Distance,
+			Duration:               rideOffer.Duration,
+			StartLatitude:          rideOffer.StartLatitude,
+			StartLongitude:         rideOffer.StartLongitude,
+			EndLatitude:            rideOffer.EndLatitude,
+			EndLongitude:           rideOffer.EndLongitude,
+			VehicleID:              vehicleID,
+			PickupLatitude:         meetingPoints.Pickup.Latitude,
+			PickupLongitude:        meetingPoints.Pickup.Longitude,
+			PickupAddress:          meetingPoints.Pickup.Address,
+			PickupWalkingDistance:  meetingPoints.Pickup.WalkingDistance,
+			DropoffLatitude:        meetingPoints.Dropoff.Latitude,
+			DropoffLongitude:       meetingPoints.Dropoff.Longitude,
+			DropoffAddress:         meetingPoints.Dropoff.Address,
+			DropoffWalkingDistance: meetingPoints.Dropoff.WalkingDistance,
 		}
 
 		// Create the ride
@@ -220,17 +303,10 @@ func (r *RideRepository) AcceptRideRequest(rideOfferID, rideRequestID, vehicleID
 			return err
 		}
 
-		// TODO: COMMENTED OUT FOR NOW FOR BETTER TESTING
-
-		// // Update ride offer status
-		// if err := tx.Model(&migration.RideOffer{}).Where("id = ?", rideOfferID).Update("status", "matched").Error; err != nil {
-		// 	return err
-		// }
-
-		// // Update ride request status
-		// if err := tx.Model(&migration.RideRequest{}).Where("id = ?", rideRequestID).Update("status", "matched").Error; err != nil {
-		// 	return err
-		// }
+		// The ride offer stays open for other hitchers until all seats are booked
+		if err := r.syncRideOfferStatus(tx, rideOfferID, &actorID); err != nil {
+			return err
+		}
 
 		// Before creating the chat room, verify both users exist
 		var userCount int64
@@ -291,8 +367,70 @@ func (r *RideRepository) CreateRideTransaction(rideID uuid.UUID, Fare float64, p
 	return transaction, nil
 }
 
-// StartRide starts a ride
-func (r *RideRepository) StartRide(req schemas.StartRideRequest, userID uuid.UUID) (migration.Ride, error) {
+// GetRideByID fetches a ride with its ride offer and ride request by its ID
+func (r *RideRepository) GetRideByID(rideID uuid.UUID) (migration.Ride, error) {
+	var ride migration.Ride
+	err := r.db.Model(&migration.Ride{}).
+		Preload("RideOffer").
+		Preload("RideRequest.User").
+		Where("id = ?", rideID).
+		Take(&ride).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ride, ErrRideNotFound
+		}
+		return ride, err
+	}
+
+	return ride, nil
+}
+
+// IssuePickupCode replaces the pickup code of a ride and resets its wrong attempts
+func (r *RideRepository) IssuePickupCode(rideID uuid.UUID, pinHash string, expiresAt time.Time) error {
+	return r.db.Model(&migration.Ride{}).
+		Where("id = ?", rideID).
+		Updates(map[string]interface{}{
+			"pickup_code_hash":       pinHash,
+			"pickup_code_expires_at": expiresAt,
+			"pickup_code_attempts":   0,
+		}).Error
+}
+
+// ReservePickupAttempt counts a pickup code attempt against the ride before the code is checked, it returns
+// false without counting it when the ride already used all its attempts. The check and the increment are a
+// single statement so parallel attempts cannot go past the limit
+func (r *RideRepository) ReservePickupAttempt(rideID uuid.UUID, maxAttempts int) (bool, error) {
+	result := r.db.Model(&migration.Ride{}).
+		Where("id = ? AND pickup_code_attempts < ?", rideID, maxAttempts).
+		Update("pickup_code_attempts", gorm.Expr("pickup_code_attempts + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleasePickupAttempt gives back the attempt reserved for a pickup code that turned out to be right,
+// so only the wrong codes count against the ride
+func (r *RideRepository) ReleasePickupAttempt(rideID uuid.UUID) error {
+	return r.db.Model(&migration.Ride{}).
+		Where("id = ? AND pickup_code_attempts > 0", rideID).
+		Update("pickup_code_attempts", gorm.Expr("pickup_code_attempts - 1")).Error
+}
+
+// RecordFailedPickupAttempt logs a wrong pickup code, the attempt is already counted by ReservePickupAttempt
+func (r *RideRepository) RecordFailedPickupAttempt(rideID, actorID uuid.UUID, method, reason string) error {
+	return r.db.Create(&migration.PickupCodeAttempt{
+		RideID:  rideID,
+		ActorID: actorID,
+		Method:  method,
+		Reason:  reason,
+	}).Error
+}
+
+// StartRide starts a ride, verification is how the driver verified the hitcher on board
+func (r *RideRepository) StartRide(req schemas.StartRideRequest, userID uuid.UUID, verification string) (migration.Ride, error) {
 	var ride migration.Ride
 
 	err := r.db.Transaction(func(tx *gorm.DB) error {
@@ -322,41 +460,62 @@ func (r *RideRepository) StartRide(req schemas.StartRideRequest, userID uuid.UUI
 			return err
 		}
 
-		// TODO: COMMENTED OUT FOR NOW FOR BETTER TESTING
-		// // Check if the ride is already started
-		// if ride.Status == "ongoing" {
-		// 	return errors.New("ride is already started")
-		// }
-
-		// // Check if the ride is already ended
-		// if ride.Status == "completed" {
-		// 	return errors.New("ride is already ended")
-		// }
-
-		// // Check if the ride is already cancelled
-		// if ride.Status == "cancelled" {
-		// 	return errors.New("ride is already cancelled")
-		// }
-
-		// Check if the current location of the driver and hitcher is near less than 100 meters
-		if !helper.IsNearby(schemas.Point{Lat: rideOffer.DriverCurrentLatitude, Lng: rideOffer.DriverCurrentLongitude}, schemas.Point{Lat: rideRequest.RiderCurrentLatitude, Lng: rideRequest.RiderCurrentLongitude}, 0.0001) {
-			return errors.New("driver and rider are not nearby") // Make sure cannot fake the location
+		// Check the last reported location of the driver against the agreed pickup point
+		pickup := schemas.Point{Lat: ride.PickupLatitude, Lng: ride.PickupLongitude}
+		if pickup.Lat == 0 && pickup.Lng == 0 { // Rides accepted before the meeting points were stored
+			pickup = schemas.Point{Lat: rideRequest.StartLatitude, Lng: rideRequest.StartLongitude}
+		}
+		reason, err := r.checkGeofence(tx, ride.ID, rideOffer, pickup, r.cfg.GeofencePickupRadius, helper.GeofenceActionStart, "passenger picked up")
+		if err != nil {
+			return err
 		}
 
 		// TODO: In the future must check start time and end time of the ride to prevent early start or late start
 
-		// Update the ride offer status to ongoing
-		if err := tx.Model(&migration.RideOffer{}).Where("id = ?", ride.RideOfferID).Update("status", "ongoing").Error; err != nil {
+		// Update the ride status to started (only this passenger is picked up)
+		err = r.transitionStatus(tx, statusTransition{
+			entity:  helper.StatusEntityRide,
+			id:      ride.ID,
+			from:    ride.Status,
+			to:      "ongoing",
+			actorID: &userID,
+			reason:  reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The pickup code cannot be used again once the passenger is on board
+		verifiedAt := time.Now()
+		err = tx.Model(&migration.Ride{}).
+			Where("id = ?", ride.ID).
+			Updates(map[string]interface{}{
+				"passenger_verified_at":  verifiedAt,
+				"passenger_verification": verification,
+				"pickup_code_hash":       "",
+				"pickup_code_expires_at": nil,
+			}).Error
+		if err != nil {
 			return err
 		}
+		ride.PassengerVerifiedAt = &verifiedAt
+		ride.PassengerVerification = verification
 
 		// Update the ride request status to ongoing
-		if err := tx.Model(&migration.RideRequest{}).Where("id = ?", ride.RideRequestID).Update("status", "ongoing").Error; err != nil {
+		err = r.transitionStatus(tx, statusTransition{
+			entity:  helper.StatusEntityRideRequest,
+			id:      rideRequest.ID,
+			from:    rideRequest.Status,
+			to:      "ongoing",
+			actorID: &userID,
+			reason:  "passenger picked up",
+		})
+		if err != nil {
 			return err
 		}
 
-		// Update the ride status to started
-		if err := tx.Model(&migration.Ride{}).Where("id = ?", req.RideID).Update("status", "ongoing").Error; err != nil {
+		// The driver's trip becomes ongoing with the first pickup
+		if err := r.syncRideOfferStatus(tx, ride.RideOfferID, &userID); err != nil {
 			return err
 		}
 
@@ -407,6 +566,11 @@ func (r *RideRepository) EndRide(req schemas.EndRideRequest, userID uuid.UUID) (
 			return err
 		}
 
+		// Only the driver ends the ride, like the driver starts it
+		if rideOffer.UserID != userID {
+			return ErrNotRideDriver
+		}
+
 		// Get the ride request by ID
 		var rideRequest migration.RideRequest
 		err = tx.Model(&migration.RideRequest{}).
@@ -416,40 +580,49 @@ func (r *RideRepository) EndRide(req schemas.EndRideRequest, userID uuid.UUID) (
 			return err
 		}
 
-		// TODO: COMMENTED OUT FOR NOW FOR BETTER TESTING
-		// // Check if the ride is already ended
-		// if ride.Status == "completed" {
-		// 	return errors.New("ride is already ended")
-		// }
-
-		// // Check if the ride is already cancelled
-		// if ride.Status == "cancelled" {
-		// 	return errors.New("ride is already cancelled")
-		// }
-
-		// TODO: COMMENTED OUT FOR NOW FOR BETTER TESTING
-		// Check if the current location of the driver and the hitcher end location is near less than 100 meters
-		// if !helper.IsNearby(schemas.Point{Lat: rideOffer.DriverCurrentLatitude, Lng: rideOffer.DriverCurrentLongitude}, schemas.Point{Lat: rideRequest.EndLatitude, Lng: rideRequest.EndLongitude}, 0.0001) {
-		// 	return errors.New("driver not nearby the end location") // Make sure cannot fake the location
-		// }
+		// Check the last reported location of the driver against the agreed dropoff point
+		dropoff := schemas.Point{Lat: ride.DropoffLatitude, Lng: ride.DropoffLongitude}
+		if dropoff.Lat == 0 && dropoff.Lng == 0 { // Rides accepted before the meeting points were stored
+			dropoff = schemas.Point{Lat: rideRequest.EndLatitude, Lng: rideRequest.EndLongitude}
+		}
+		reason, err := r.checkGeofence(tx, ride.ID, rideOffer, dropoff, r.cfg.GeofenceDropoffRadius, helper.GeofenceActionEnd, "passenger dropped off")
+		if err != nil {
+			return err
+		}
 
-		// Update the ride offer status to ended
-		if err := tx.Model(&migration.RideOffer{}).Where("id = ?", ride.RideOfferID).Update("status", "completed").Error; err != nil {
+		// Update the ride status to ended
+		err = r.transitionStatus(tx, statusTransition{
+			entity:  helper.StatusEntityRide,
+			id:      ride.ID,
+			from:    ride.Status,
+			to:      "completed",
+			actorID: &userID,
+			reason:  reason,
+		})
+		if err != nil {
 			return err
 		}
 
 		// Update the ride request status to ended
-		if err := tx.Model(&migration.RideRequest{}).Where("id = ?", ride.RideRequestID).Update("status", "completed").Error; err != nil {
+		err = r.transitionStatus(tx, statusTransition{
+			entity:  helper.StatusEntityRideRequest,
+			id:      rideRequest.ID,
+			from:    rideRequest.Status,
+			to:      "completed",
+			actorID: &userID,
+			reason:  "passenger dropped off",
+		})
+		if err != nil {
 			return err
 		}
 
 		// Update the transaction status to completed
-		if err := tx.Model(&migration.Transaction{}).Where("ride_id = ?", req.RideID).Update("status", "completed").Error; err != nil {
+		if err := r.transitionRideTransactions(tx, ride.ID, "completed", &userID, "ride completed"); err != nil {
 			return err
 		}
 
-		// Update the ride status to ended
-		if err := tx.Model(&migration.Ride{}).Where("id = ?", req.RideID).Update("status", "completed").Error; err != nil {
+		// The driver's trip is only completed once every passenger is dropped off
+		if err := r.syncRideOfferStatus(tx, ride.RideOfferID, &userID); err != nil {
 			return err
 		}
 
@@ -484,25 +657,36 @@ func (r *RideRepository) UpdateRideLocation(req schemas.UpdateRideLocationReques
 			return err
 		}
 
-		// Get the ride request by ID
-		var rideRequest migration.RideRequest
-		err = tx.Model(&migration.RideRequest{}).
-			Where("id = ?", ride.RideRequestID).
-			First(&rideRequest).Error
-		if err != nil {
-			return err
+		// Only the driver reports the location, the geofences, the trail and the deviations trust it
+		if rideOffer.UserID != userID {
+			return ErrNotRideDriver
 		}
 
-		// TODO: COMMENTED OUT FOR NOW FOR BETTER TESTING
-		// // Check if the ride is already ended
-		// if ride.Status == "completed" {
-		// 	return errors.New("ride is already ended")
-		// }
+		// Locations are only tracked while the ride is active
+		if ride.Status != "scheduled" && ride.Status != "ongoing" && !r.cfg.RideTestMode {
+			return ErrRideNotActive
+		}
 
-		// // Check if the ride is already cancelled
-		// if ride.Status == "cancelled" {
-		// 	return errors.New("ride is already cancelled")
-		// }
+		// Reject the locations that are outdated or too far from the previous one to be real
+		recordedAt := time.Now()
+		if req.RecordedAt != nil {
+			recordedAt = *req.RecordedAt
+		}
+		if !r.cfg.RideTestMode {
+			err = helper.CheckLocationUpdate(
+				schemas.Point{Lat: rideOffer.DriverCurrentLatitude, Lng: rideOffer.DriverCurrentLongitude},
+				rideOffer.DriverLocationAt,
+				req.CurrentLocation,
+				recordedAt,
+				time.Duration(r.cfg.LocationMaxAge)*time.Second,
+				r.cfg.LocationMaxSpeed,
+				time.Now(),
+			)
+			if err != nil {
+				log.Warn().Str("rideID", ride.ID.String()).Str("driverID", userID.String()).Err(err).Msg("Rejected driver location update")
+				return err
+			}
+		}
 
 		// Update the driver's current location
 		if err := tx.Model(&migration.RideOffer{}).Where("id = ?", ride.RideOfferID).Update("driver_current_latitude", req.CurrentLocation.Lat).Error; err != nil {
@@ -511,18 +695,58 @@ func (r *RideRepository) UpdateRideLocation(req schemas.UpdateRideLocationReques
 		if err := tx.Model(&migration.RideOffer{}).Where("id = ?", ride.RideOfferID).Update("driver_current_longitude", req.CurrentLocation.Lng).Error; err != nil {
 			return err
 		}
+		if err := tx.Model(&migration.RideOffer{}).Where("id = ?", ride.RideOfferID).Update("driver_location_at", recordedAt).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return migration.Ride{}, err
+	}
 
-		// Update the hitcher's current location
-		if err := tx.Model(&migration.RideRequest{}).Where("id = ?", ride.RideRequestID).Update("rider_current_latitude", req.CurrentLocation.Lat).Error; err != nil {
+	return ride, nil
+}
+
+// UpdateRiderLocation updates the location the passenger shares with the driver during a ride.
+// It is only shown to the driver, the geofences, the trail and the deviations use the location of the driver
+func (r *RideRepository) UpdateRiderLocation(req schemas.UpdateRiderLocationRequest, userID uuid.UUID) (migration.Ride, error) {
+	var ride migration.Ride
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Get the ride by ID
+		err := tx.Model(&migration.Ride{}).
+			Where("id = ?", req.RideID).
+			First(&ride).Error
+		if err != nil {
 			return err
 		}
-		if err := tx.Model(&migration.RideRequest{}).Where("id = ?", ride.RideRequestID).Update("rider_current_longitude", req.CurrentLocation.Lng).Error; err != nil {
+
+		// Get the ride request by ID
+		var rideRequest migration.RideRequest
+		err = tx.Model(&migration.RideRequest{}).
+			Where("id = ?", ride.RideRequestID).
+			First(&rideRequest).Error
+		if err != nil {
 			return err
 		}
 
-		return nil
-	})
+		if rideRequest.UserID != userID {
+			return ErrNotRidePassenger
+		}
+
+		// Locations are only shared while the ride is active
+		if ride.Status != "scheduled" && ride.Status != "ongoing" && !r.cfg.RideTestMode {
+			return ErrRideNotActive
+		}
 
+		return tx.Model(&migration.RideRequest{}).
+			Where("id = ?", ride.RideRequestID).
+			Updates(map[string]interface{}{
+				"rider_current_latitude":  req.CurrentLocation.Lat,
+				"rider_current_longitude": req.CurrentLocation.Lng,
+			}).Error
+	})
 	if err != nil {
 		return migration.Ride{}, err
 	}
@@ -560,34 +784,58 @@ func (r *RideRepository) CancelRide(req schemas.CancelRideRequest, userID uuid.U
 			return err
 		}
 
-		// TODO: COMMENTED OUT FOR NOW FOR BETTER TESTING
-		// // Check if the ride is already ended
-		// if ride.Status == "completed" {
-		// 	return errors.New("ride is already ended")
-		// }
+		// Only the driver and the passenger of the ride can cancel it
+		if userID != rideOffer.UserID && userID != rideRequest.UserID {
+			return ErrNotRideParticipant
+		}
 
-		// // Check if the ride is already cancelled
-		// if ride.Status == "cancelled" {
-		// 	return errors.New("ride is already cancelled")
-		// }
+		reason := req.Reason
+		if reason == "" {
+			reason = "ride cancelled"
+		}
 
-		// Update the ride offer status to cancelled
-		if err := tx.Model(&migration.RideOffer{}).Where("id = ?", ride.RideOfferID).Update("status", "cancelled").Error; err != nil {
+		// Update the ride status to cancelled (fails if the ride is already completed or cancelled)
+		err = r.transitionStatus(tx, statusTransition{
+			entity:  helper.StatusEntityRide,
+			id:      ride.ID,
+			from:    ride.Status,
+			to:      "cancelled",
+			actorID: &userID,
+			reason:  reason,
+		})
+		if err != nil {
 			return err
 		}
 
+		// Give the seat back to the ride offer if the booking was still active
+		if ride.Status == "scheduled" || ride.Status == "ongoing" {
+			if err := tx.Model(&migration.RideOffer{}).
+				Where("id = ? AND available_seats < total_seats", ride.RideOfferID).
+				Update("available_seats", gorm.Expr("available_seats + 1")).Error; err != nil {
+				return err
+			}
+		}
+
 		// Update the ride request status to cancelled
-		if err := tx.Model(&migration.RideRequest{}).Where("id = ?", ride.RideRequestID).Update("status", "cancelled").Error; err != nil {
+		err = r.transitionStatus(tx, statusTransition{
+			entity:  helper.StatusEntityRideRequest,
+			id:      rideRequest.ID,
+			from:    rideRequest.Status,
+			to:      "cancelled",
+			actorID: &userID,
+			reason:  reason,
+		})
+		if err != nil {
 			return err
 		}
 
-		// Update the ride status to cancelled
-		if err := tx.Model(&migration.Ride{}).Where("id = ?", req.RideID).Update("status", "cancelled").Error; err != nil {
+		// Update the transaction status to cancelled
+		if err := r.transitionRideTransactions(tx, ride.ID, "cancelled", &userID, reason); err != nil {
 			return err
 		}
 
-		// Update the transaction status to cancelled
-		if err := tx.Model(&migration.Transaction{}).Where("ride_id = ?", req.RideID).Update("status", "cancelled").Error; err != nil {
+		// Only this booking is cancelled, the driver's trip continues with the other passengers
+		if err := r.syncRideOfferStatus(tx, ride.RideOfferID, &userID); err != nil {
 			return err
 		}
 
@@ -627,5 +875,487 @@ func (r *RideRepository) GetAllPendingRide(userID uuid.UUID) ([]migration.RideOf
 	return rideOffers, rideRequests, nil
 }
 
+// ExpireStaleRides marks the scheduled rides that were not started before noShowBefore as no-show,
+// then expires the ride offers and ride requests nobody matched that should have started before expireBefore.
+// It returns the changed rows (with their users) so both parties can be notified
+func (r *RideRepository) ExpireStaleRides(expireBefore, noShowBefore time.Time) ([]migration.Ride, []migration.RideOffer, []migration.RideRequest, error) {
+	var staleRides []migration.Ride
+	err := r.db.Select("id").
+		Where("status = ? AND start_time < ?", "scheduled", noShowBefore).
+		Find(&staleRides).Error
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Every row is handled in its own transaction so one failure does not block the others
+	var noShowRideIDs []uuid.UUID
+	for _, staleRide := range staleRides {
+		if err := r.markRideNoShow(staleRide.ID); err != nil {
+			log.Warn().Err(err).Str("rideID", staleRide.ID.String()).Msg("Failed to mark ride as no-show")
+			continue
+		}
+		noShowRideIDs = append(noShowRideIDs, staleRide.ID)
+	}
+
+	var staleRideOffers []migration.RideOffer
+	err = r.db.Select("id").
+		Where("status = ? AND start_time < ?", "created", expireBefore).
+		Where("NOT EXISTS (SELECT 1 FROM rides WHERE rides.ride_offer_id = ride_offers.id AND rides.status IN ?)", []string{"scheduled", "ongoing"}).
+		Find(&staleRideOffers).Error
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var expiredRideOfferIDs []uuid.UUID
+	for _, staleRideOffer := range staleRideOffers {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			return r.transitionStatus(tx, statusTransition{
+				entity: helper.StatusEntityRideOffer,
+				id:     staleRideOffer.ID,
+				from:   "created",
+				to:     "expired",
+				reason: "nobody booked the ride offer before its start time",
+			})
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("rideOfferID", staleRideOffer.ID.String()).Msg("Failed to expire ride offer")
+			continue
+		}
+		expiredRideOfferIDs = append(expiredRideOfferIDs, staleRideOffer.ID)
+	}
+
+	var staleRideRequests []migration.RideRequest
+	err = r.db.Select("id").
+		Where("status = ? AND start_time < ?", "created", expireBefore).
+		Find(&staleRideRequests).Error
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var expiredRideRequestIDs []uuid.UUID
+	for _, staleRideRequest := range staleRideRequests {
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			return r.transitionStatus(tx, statusTransition{
+				entity: helper.StatusEntityRideRequest,
+				id:     staleRideRequest.ID,
+				from:   "created",
+				to:     "expired",
+				reason: "no driver matched the ride request before its start time",
+			})
+		})
+		if err != nil {
+			log.Warn().Err(err).Str("rideRequestID", staleRideRequest.ID.String()).Msg("Failed to expire ride request")
+			continue
+		}
+		expiredRideRequestIDs = append(expiredRideRequestIDs, staleRideRequest.ID)
+	}
+
+	var noShowRides []migration.Ride
+	if len(noShowRideIDs) > 0 {
+		err = r.db.Preload("RideOffer.User").
+			Preload("RideRequest.User").
+			Where("id IN ?", noShowRideIDs).
+			Find(&noShowRides).Error
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var expiredRideOffers []migration.RideOffer
+	if len(expiredRideOfferIDs) > 0 {
+		err = r.db.Preload("User").
+			Where("id IN ?", expiredRideOfferIDs).
+			Find(&expiredRideOffers).Error
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var expiredRideRequests []migration.RideRequest
+	if len(expiredRideRequestIDs) > 0 {
+		err = r.db.Preload("User").
+			Where("id IN ?", expiredRideRequestIDs).
+			Find(&expiredRideRequests).Error
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return noShowRides, expiredRideOffers, expiredRideRequests, nil
+}
+
+// markRideNoShow ends a scheduled ride that was never started, the seat goes back to the ride offer
+func (r *RideRepository) markRideNoShow(rideID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var ride migration.Ride
+		err := tx.Select("id, status, ride_offer_id, ride_request_id").
+			Where("id = ?", rideID).
+			First(&ride).Error
+		if err != nil {
+			return err
+		}
+
+		var rideRequest migration.RideRequest
+		err = tx.Select("id, status").
+			Where("id = ?", ride.RideRequestID).
+			First(&rideRequest).Error
+		if err != nil {
+			return err
+		}
+
+		reason := "ride was not started in time"
+		err = r.transitionStatus(tx, statusTransition{
+			entity: helper.StatusEntityRide,
+			id:     ride.ID,
+			from:   ride.Status,
+			to:     "no_show",
+			reason: reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Model(&migration.RideOffer{}).
+			Where("id = ? AND available_seats < total_seats", ride.RideOfferID).
+			Update("available_seats", gorm.Expr("available_seats + 1")).Error; err != nil {
+			return err
+		}
+
+		err = r.transitionStatus(tx, statusTransition{
+			entity: helper.StatusEntityRideRequest,
+			id:     rideRequest.ID,
+			from:   rideRequest.Status,
+			to:     "expired",
+			reason: reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := r.transitionRideTransactions(tx, ride.ID, "cancelled", nil, reason); err != nil {
+			return err
+		}
+
+		return r.syncRideOfferStatus(tx, ride.RideOfferID, nil)
+	})
+}
+
+// syncRideOfferStatus derives the status of a ride offer from the bookings (rides) made on it.
+// A ride offer is shared by several passengers, so its status only moves forward when all of
+// them allow it: it stays created while seats are left, is matched once full, is ongoing while
+// any passenger is on board and is completed once no booking is waiting to be picked up or dropped off.
+func (r *RideRepository) syncRideOfferStatus(tx *gorm.DB, rideOfferID uuid.UUID, actorID *uuid.UUID) error {
+	var rideOffer migration.RideOffer
+	err := tx.Select("id, status, available_seats").
+		Where("id = ?", rideOfferID).
+		First(&rideOffer).Error
+	if err != nil {
+		return err
+	}
+
+	var bookings []struct {
+		Status string
+		Count  int64
+	}
+	err = tx.Model(&migration.Ride{}).
+		Select("status, COUNT(*) AS count").
+		Where("ride_offer_id = ?", rideOfferID).
+		Group("status").
+		Scan(&bookings).Error
+	if err != nil {
+		return err
+	}
+
+	bookingCount := make(map[string]int64, len(bookings))
+	for _, booking := range bookings {
+		bookingCount[booking.Status] = booking.Count
+	}
+
+	status := rideOffer.Status
+	switch {
+	case bookingCount["ongoing"] > 0:
+		status = "ongoing"
+	case bookingCount["scheduled"] > 0:
+		// Passengers are still waiting for pickup, an ongoing trip stays ongoing
+		if rideOffer.Status != "ongoing" {
+			status = "created"
+			if rideOffer.AvailableSeats <= 0 {
+				status = "matched"
+			}
+		}
+	case bookingCount["completed"] > 0:
+		status = "completed"
+	case rideOffer.Status == "ongoing":
+		// Every passenger cancelled after the trip started
+		status = "cancelled"
+	default:
+		// Every booking was cancelled (or no-show) before the trip started, reopen the ride offer
+		status = "created"
+	}
+
+	if status == rideOffer.Status {
+		return nil
+	}
+
+	return r.transitionStatus(tx, statusTransition{
+		entity:  helper.StatusEntityRideOffer,
+		id:      rideOfferID,
+		from:    rideOffer.Status,
+		to:      status,
+		actorID: actorID,
+		reason:  "derived from the bookings of the ride offer",
+	})
+}
+
+// checkGeofence checks that the last reported location of the driver is recent and near a meeting point of
+// the ride. When it is not, an unused admin override of the action is consumed instead and mentioned in the
+// returned reason of the status transition, so the override stays in the audit trail
+func (r *RideRepository) checkGeofence(tx *gorm.DB, rideID uuid.UUID, rideOffer migration.RideOffer, target schemas.Point, radius float64, action, reason string) (string, error) {
+	if r.cfg.RideTestMode {
+		return reason, nil
+	}
+
+	geofenceErr := helper.CheckGeofence(
+		schemas.Point{Lat: rideOffer.DriverCurrentLatitude, Lng: rideOffer.DriverCurrentLongitude},
+		rideOffer.DriverLocationAt,
+		target,
+		radius,
+		time.Duration(r.cfg.LocationMaxAge)*time.Second,
+		time.Now(),
+	)
+	if geofenceErr == nil {
+		return reason, nil
+	}
+
+	var override migration.GeofenceOverride
+	result := tx.Model(&migration.GeofenceOverride{}).
+		Where("ride_id = ? AND action = ? AND used_at IS NULL", rideID, action).
+		Order("created_at").
+		Limit(1).
+		Find(&override)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		log.Warn().Str("rideID", rideID.String()).Str("action", action).Err(geofenceErr).Msg("Geofence check failed")
+		return "", geofenceErr
+	}
+
+	result = tx.Model(&migration.GeofenceOverride{}).
+		Where("id = ? AND used_at IS NULL", override.ID).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return "", ErrStatusChanged
+	}
+
+	log.Info().Str("rideID", rideID.String()).Str("adminID", override.AdminID.String()).Str("action", action).Msg("Geofence check overridden by admin")
+	return fmt.Sprintf("%s (geofence overridden by admin %s: %s)", reason, override.AdminID, override.Note), nil
+}
+
+// GetRideHistory fetches the rides of the user as driver or passenger, newest first, with the offer and the request
+// and their users, the vehicle and the transactions. One ride more than the limit is fetched to tell if there is a next page
+func (r *RideRepository) GetRideHistory(userID uuid.UUID, filter schemas.RideHistoryFilter) ([]migration.Ride, error) {
+	query := r.db.Model(&migration.Ride{}).
+		Joins("JOIN ride_offers ON ride_offers.id = rides.ride_offer_id").
+		Joins("JOIN ride_requests ON ride_requests.id = rides.ride_request_id")
+
+	switch filter.Role {
+	case "driver":
+		query = query.Where("ride_offers.user_id = ?", userID)
+	case "passenger":
+		query = query.Where("ride_requests.user_id = ?", userID)
+	default:
+		query = query.Where("(ride_offers.user_id = ? OR ride_requests.user_id = ?)", userID, userID)
+	}
+	if filter.Status != "" {
+		query = query.Where("rides.status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("rides.start_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("rides.start_time < ?", *filter.To)
+	}
+	if filter.CursorStartTime != nil {
+		query = query.Where("(rides.start_time, rides.id) < (?, ?)", *filter.CursorStartTime, filter.CursorRideID)
+	}
+
+	var rides []migration.Ride
+	err := query.Preload("RideOffer.User").
+		Preload("RideRequest.User").
+		Preload("Vehicle").
+		Preload("Transactions").
+		Order("rides.start_time DESC, rides.id DESC").
+		Limit(filter.Limit + 1).
+		Find(&rides).Error
+	return rides, err
+}
+
+// GetRideHistorySummary totals the completed rides of the user started between from (inclusive) and to (exclusive)
+// per month of the local time zone, newest first
+func (r *RideRepository) GetRideHistorySummary(userID uuid.UUID, from, to time.Time) ([]schemas.RideHistoryMonth, error) {
+	offset := int(r.cfg.AppUTCOffset * 3600)
+
+	var months []schemas.RideHistoryMonth
+	err := r.db.Raw(`
+		SELECT to_char(date_trunc('month', (rides.start_time AT TIME ZONE 'UTC') + make_interval(secs => ?)), 'YYYY-MM') AS month,
+			COUNT(*) AS trips,
+			COUNT(*) FILTER (WHERE ride_offers.user_id = ?) AS trips_as_driver,
+			COUNT(*) FILTER (WHERE ride_requests.user_id = ?) AS trips_as_passenger,
+			COALESCE(SUM(ride_requests.distance), 0) AS km_shared,
+			COALESCE(SUM(payments.earned), 0) AS earned,
+			COALESCE(SUM(payments.spent), 0) AS spent
+		FROM rides
+		JOIN ride_offers ON ride_offers.id = rides.ride_offer_id
+		JOIN ride_requests ON ride_requests.id = rides.ride_request_id
+		LEFT JOIN (
+			SELECT ride_id,
+				SUM(amount) FILTER (WHERE receiver_id = ?) AS earned,
+				SUM(amount) FILTER (WHERE payer_id = ?) AS spent
+			FROM transactions
+			WHERE status = 'completed'
+			GROUP BY ride_id
+		) payments ON payments.ride_id = rides.id
+		WHERE rides.status = 'completed'
+			AND (ride_offers.user_id = ? OR ride_requests.user_id = ?)
+			AND rides.start_time >= ? AND rides.start_time < ?
+		GROUP BY 1
+		ORDER BY 1 DESC`,
+		offset, userID, userID, userID, userID, userID, userID, from, to,
+	).Scan(&months).Error
+	return months, err
+}
+
+// GetRideForReceipt fetches a ride with everything printed on its receipt: the offer and the request with their users,
+// the vehicle and the transactions
+func (r *RideRepository) GetRideForReceipt(rideID uuid.UUID) (migration.Ride, error) {
+	var ride migration.Ride
+	err := r.db.Model(&migration.Ride{}).
+		Preload("RideOffer.User").
+		Preload("RideRequest.User").
+		Preload("Vehicle").
+		Preload("Transactions").
+		Where("id = ?", rideID).
+		Take(&ride).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ride, ErrRideNotFound
+		}
+		return ride, err
+	}
+
+	return ride, nil
+}
+
+// GetRideCompletedAt returns when a completed ride was actually ended from its status history,
+// the rides completed before the history existed fall back to their planned end time
+func (r *RideRepository) GetRideCompletedAt(ride migration.Ride) (time.Time, error) {
+	var history migration.RideStatusHistory
+	err := r.db.Where("entity_type = ? AND entity_id = ? AND to_status = ?", helper.StatusEntityRide, ride.ID, "completed").
+		Order("created_at DESC").
+		Take(&history).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ride.EndTime, nil
+		}
+		return time.Time{}, err
+	}
+
+	return history.CreatedAt, nil
+}
+
+// statusTransition is a status change checked against the ride lifecycle (see helper.ValidateStatusTransition)
+type statusTransition struct {
+	entity  helper.StatusEntity
+	id      uuid.UUID
+	from    string
+	to      string
+	actorID *uuid.UUID // nil when the system makes the transition
+	reason  string
+}
+
+func (r *RideRepository) transitionStatus(tx *gorm.DB, t statusTransition) error {
+	return applyStatusTransition(tx, t, r.cfg.RideTestMode)
+}
+
+// transitionRideTransactions moves the transactions of a ride to the given status,
+// transactions that cannot (a completed payment when the ride is cancelled) are left untouched
+func (r *RideRepository) transitionRideTransactions(tx *gorm.DB, rideID uuid.UUID, status string, actorID *uuid.UUID, reason string) error {
+	var transactions []migration.Transaction
+	if err := tx.Select("id, status").Where("ride_id = ?", rideID).Find(&transactions).Error; err != nil {
+		return err
+	}
+
+	for _, transaction := range transactions {
+		if !helper.CanTransitionStatus(helper.StatusEntityTransaction, transaction.Status, status) {
+			continue
+		}
+
+		err := r.transitionStatus(tx, statusTransition{
+			entity:  helper.StatusEntityTransaction,
+			id:      transaction.ID,
+			from:    transaction.Status,
+			to:      status,
+			actorID: actorID,
+			reason:  reason,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyStatusTransition updates the status if the ride lifecycle allows it and records the change in the ride status history.
+// In test mode illegal transitions are only logged so the same ride can be replayed
+func applyStatusTransition(tx *gorm.DB, t statusTransition, testMode bool) error {
+	if err := helper.ValidateStatusTransition(t.entity, t.from, t.to); err != nil {
+		if !testMode {
+			return err
+		}
+		log.Warn().Err(err).Str("id", t.id.String()).Msg("Illegal status transition allowed in test mode")
+	}
+
+	var model interface{}
+	switch t.entity {
+	case helper.StatusEntityRide:
+		model = &migration.Ride{}
+	case helper.StatusEntityRideOffer:
+		model = &migration.RideOffer{}
+	case helper.StatusEntityRideRequest:
+		model = &migration.RideRequest{}
+	case helper.StatusEntityTransaction:
+		model = &migration.Transaction{}
+	default:
+		return fmt.Errorf("unknown status entity %s", t.entity)
+	}
+
+	// The current status is part of the condition so two concurrent transitions cannot both succeed
+	result := tx.Model(model).
+		Where("id = ? AND status = ?", t.id, t.from).
+		Update("status", t.to)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStatusChanged
+	}
+
+	return tx.Create(&migration.RideStatusHistory{
+		EntityType: string(t.entity),
+		EntityID:   t.id,
+		FromStatus: t.from,
+		ToStatus:   t.to,
+		ActorID:    t.actorID,
+		Reason:     t.reason,
+	}).Error
+}
+
 // Make sure the RideRepository implements the IRideRepository interface
 var _ IRideRepository = (*RideRepository)(nil)
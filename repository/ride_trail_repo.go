@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	// Redis list buffering the location samples until they are written to the database
+	rideTrailBufferKey = "ride_trail:buffer"
+	// How long the deviation tracking of a ride is kept without location updates
+	deviationStateTTL = 24 * time.Hour
+)
+
+// Redis channel the deviation events are published on for the admin dashboards
+const deviationEventsChannel = "ride_deviation:events"
+
+type IRideTrailRepository interface {
+	BufferLocationSample(ctx context.Context, sample migration.RideLocationSample) error
+	FlushLocationSamples(ctx context.Context, batchSize int) (int, error)
+	GetBufferedLocationSamples(ctx context.Context, rideID uuid.UUID) ([]migration.RideLocationSample, error)
+	GetLocationSamples(rideID uuid.UUID) ([]migration.RideLocationSample, error)
+	GetLatestLocationSamples(rideID uuid.UUID, limit int) ([]migration.RideLocationSample, error)
+	DownsampleLocationSamples(before time.Time, interval time.Duration) (int64, error)
+	PurgeLocationSamples(before time.Time) (int64, error)
+	GetDeviationState(ctx context.Context, rideID uuid.UUID) (helper.DeviationState, error)
+	SaveDeviationState(ctx context.Context, rideID uuid.UUID, state helper.DeviationState) error
+	CreateDeviationEvent(event migration.RideDeviationEvent) (migration.RideDeviationEvent, error)
+	ResolveDeviationEvent(rideID uuid.UUID, deviationType string, endedAt time.Time, maxDistance float64) error
+	GetDeviationEvents(rideID *uuid.UUID, unacknowledgedOnly bool, limit int) ([]migration.RideDeviationEvent, error)
+	AcknowledgeDeviationEvent(eventID, adminID uuid.UUID, note string) (migration.RideDeviationEvent, error)
+	PublishDeviationEvent(ctx context.Context, message []byte) error
+	SubscribeDeviationEvents(ctx context.Context) *redis.PubSub
+}
+
+type RideTrailRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewRideTrailRepository(db *gorm.DB, redis *redis.Client) IRideTrailRepository {
+	return &RideTrailRepository{db: db, redis: redis}
+}
+
+var (
+	ErrDeviationEventNotFound = errors.New("deviation event not found")
+)
+
+// BufferLocationSample queues a location sample, it is written to the database by FlushLocationSamples
+func (r *RideTrailRepository) BufferLocationSample(ctx context.Context, sample migration.RideLocationSample) error {
+	data, err := json.Marshal(sample)
+	if err != nil {
+		return err
+	}
+	return r.redis.RPush(ctx, rideTrailBufferKey, data).Err()
+}
+
+// FlushLocationSamples writes the buffered location samples to the database in batches and returns how many were written.
+// A batch that cannot be written is put back at the end of the buffer in its order for the next flush
+func (r *RideTrailRepository) FlushLocationSamples(ctx context.Context, batchSize int) (int, error) {
+	flushed := 0
+	for {
+		items, err := r.redis.LPopCount(ctx, rideTrailBufferKey, batchSize).Result()
+		if err == redis.Nil || len(items) == 0 {
+			return flushed, nil
+		}
+		if err != nil {
+			return flushed, err
+		}
+
+		samples := make([]migration.RideLocationSample, 0, len(items))
+		for _, item := range items {
+			var sample migration.RideLocationSample
+			if err := json.Unmarshal([]byte(item), &sample); err != nil {
+				continue // A malformed sample would block the buffer forever
+			}
+			samples = append(samples, sample)
+		}
+
+		if len(samples) == 0 {
+			continue
+		}
+		if err := r.db.CreateInBatches(samples, batchSize).Error; err != nil {
+			values := make([]interface{}, len(items))
+			for i, item := range items {
+				values[i] = item
+			}
+			if pushErr := r.redis.RPush(ctx, rideTrailBufferKey, values...).Err(); pushErr != nil {
+				return flushed, pushErr
+			}
+			return flushed, err
+		}
+		flushed += len(samples)
+
+		if len(items) < batchSize {
+			return flushed, nil
+		}
+	}
+}
+
+// GetBufferedLocationSamples returns the location samples of a ride still waiting in the buffer, without removing them
+func (r *RideTrailRepository) GetBufferedLocationSamples(ctx context.Context, rideID uuid.UUID) ([]migration.RideLocationSample, error) {
+	items, err := r.redis.LRange(ctx, rideTrailBufferKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []migration.RideLocationSample
+	for _, item := range items {
+		var sample migration.RideLocationSample
+		if err := json.Unmarshal([]byte(item), &sample); err != nil {
+			continue
+		}
+		if sample.RideID == rideID {
+			samples = append(samples, sample)
+		}
+	}
+	return samples, nil
+}
+
+// GetLocationSamples returns the location samples of a ride in the order they were recorded
+func (r *RideTrailRepository) GetLocationSamples(rideID uuid.UUID) ([]migration.RideLocationSample, error) {
+	var samples []migration.RideLocationSample
+	err := r.db.Model(&migration.RideLocationSample{}).
+		Where("ride_id = ?", rideID).
+		Order("recorded_at").
+		Find(&samples).Error
+	return samples, err
+}
+
+// GetLatestLocationSamples returns the last location samples of a ride in the order they were recorded
+func (r *RideTrailRepository) GetLatestLocationSamples(rideID uuid.UUID, limit int) ([]migration.RideLocationSample, error) {
+	var samples []migration.RideLocationSample
+	err := r.db.Model(&migration.RideLocationSample{}).
+		Where("ride_id = ?", rideID).
+		Order("recorded_at DESC").
+		Limit(limit).
+		Find(&samples).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(samples)-1; i < j; i, j = i+1, j-1 {
+		samples[i], samples[j] = samples[j], samples[i]
+	}
+	return samples, nil
+}
+
+// DownsampleLocationSamples keeps only the first location sample of each interval of each ride for
+// the samples recorded before the given time, and returns how many were deleted
+func (r *RideTrailRepository) DownsampleLocationSamples(before time.Time, interval time.Duration) (int64, error) {
+	result := r.db.Exec(`
+		DELETE FROM ride_location_samples AS s
+		USING (
+			SELECT id, ROW_NUMBER() OVER (
+				PARTITION BY ride_id, FLOOR(EXTRACT(EPOCH FROM recorded_at) / ?)
+				ORDER BY recorded_at
+			) AS rank
+			FROM ride_location_samples
+			WHERE recorded_at < ?
+		) AS d
+		WHERE s.id = d.id AND d.rank > 1`,
+		interval.Seconds(), before,
+	)
+	return result.RowsAffected, result.Error
+}
+
+// PurgeLocationSamples deletes the location samples recorded before the given time and returns how many were deleted
+func (r *RideTrailRepository) PurgeLocationSamples(before time.Time) (int64, error) {
+	result := r.db.Where("recorded_at < ?", before).Delete(&migration.RideLocationSample{})
+	return result.RowsAffected, result.Error
+}
+
+// GetDeviationState returns the deviation tracking of a ride, empty before its first location update
+func (r *RideTrailRepository) GetDeviationState(ctx context.Context, rideID uuid.UUID) (helper.DeviationState, error) {
+	var state helper.DeviationState
+	data, err := r.redis.Get(ctx, deviationStateKey(rideID)).Bytes()
+	if err == redis.Nil {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+// SaveDeviationState stores the deviation tracking of a ride until its next location update
+func (r *RideTrailRepository) SaveDeviationState(ctx context.Context, rideID uuid.UUID, state helper.DeviationState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return r.redis.Set(ctx, deviationStateKey(rideID), data, deviationStateTTL).Err()
+}
+
+// CreateDeviationEvent records a deviation alerted during a ride
+func (r *RideTrailRepository) CreateDeviationEvent(event migration.RideDeviationEvent) (migration.RideDeviationEvent, error) {
+	if err := r.db.Create(&event).Error; err != nil {
+		return migration.RideDeviationEvent{}, err
+	}
+	return event, nil
+}
+
+// ResolveDeviationEvent records the end of the ongoing deviation of the given type of a ride
+func (r *RideTrailRepository) ResolveDeviationEvent(rideID uuid.UUID, deviationType string, endedAt time.Time, maxDistance float64) error {
+	return r.db.Model(&migration.RideDeviationEvent{}).
+		Where("ride_id = ? AND type = ? AND ended_at IS NULL", rideID, deviationType).
+		Updates(map[string]interface{}{
+			"ended_at":     endedAt,
+			"max_distance": gorm.Expr("GREATEST(max_distance, ?)", maxDistance),
+		}).Error
+}
+
+// GetDeviationEvents returns the latest deviation events, of a ride when rideID is set
+func (r *RideTrailRepository) GetDeviationEvents(rideID *uuid.UUID, unacknowledgedOnly bool, limit int) ([]migration.RideDeviationEvent, error) {
+	query := r.db.Model(&migration.RideDeviationEvent{})
+	if rideID != nil {
+		query = query.Where("ride_id = ?", *rideID)
+	}
+	if unacknowledgedOnly {
+		query = query.Where("acknowledged_at IS NULL")
+	}
+
+	var events []migration.RideDeviationEvent
+	err := query.Order("created_at DESC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// AcknowledgeDeviationEvent marks a deviation event as reviewed by an admin
+func (r *RideTrailRepository) AcknowledgeDeviationEvent(eventID, adminID uuid.UUID, note string) (migration.RideDeviationEvent, error) {
+	var event migration.RideDeviationEvent
+	if err := r.db.Where("id = ?", eventID).First(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return event, ErrDeviationEventNotFound
+		}
+		return event, err
+	}
+
+	now := time.Now()
+	event.AcknowledgedAt = &now
+	event.AcknowledgedBy = &adminID
+	event.Note = note
+	err := r.db.Model(&event).Updates(map[string]interface{}{
+		"acknowledged_at": event.AcknowledgedAt,
+		"acknowledged_by": event.AcknowledgedBy,
+		"note":            event.Note,
+	}).Error
+	return event, err
+}
+
+func deviationStateKey(rideID uuid.UUID) string {
+	return fmt.Sprintf("ride_trail:deviation:%s", rideID)
+}
+
+// PublishDeviationEvent sends a deviation event to the admin dashboards listening with SubscribeDeviationEvents
+func (r *RideTrailRepository) PublishDeviationEvent(ctx context.Context, message []byte) error {
+	return r.redis.Publish(ctx, deviationEventsChannel, message).Err()
+}
+
+// SubscribeDeviationEvents listens to the deviation events published by every instance of the server
+func (r *RideTrailRepository) SubscribeDeviationEvents(ctx context.Context) *redis.PubSub {
+	return r.redis.Subscribe(ctx, deviationEventsChannel)
+}
+
+// Make sure the RideTrailRepository implements the IRideTrailRepository interface
+var _ IRideTrailRepository = (*RideTrailRepository)(nil)
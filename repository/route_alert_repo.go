@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"errors"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRouteAlertRepository interface {
+	CreateRouteAlert(routeAlert migration.RouteAlert) (migration.RouteAlert, error)
+	GetRouteAlertByID(routeAlertID, userID uuid.UUID) (migration.RouteAlert, error)
+	GetRouteAlertsByUserID(userID uuid.UUID) ([]migration.RouteAlert, error)
+	UpdateRouteAlert(routeAlert migration.RouteAlert) error
+	DeleteRouteAlert(routeAlertID, userID uuid.UUID) error
+	GetRouteAlertCandidates(rideOffer migration.RideOffer) ([]migration.RouteAlert, error)
+	CountNotificationsSince(userID uuid.UUID, since time.Time) (int64, error)
+	RecordNotification(routeAlertIDs []uuid.UUID, rideOfferID uuid.UUID) (bool, error)
+}
+
+type RouteAlertRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewRouteAlertRepository(db *gorm.DB, redis *redis.Client) IRouteAlertRepository {
+	return &RouteAlertRepository{db: db, redis: redis}
+}
+
+var (
+	ErrRouteAlertNotFound = errors.New("route alert not found")
+)
+
+// CreateRouteAlert saves a new route alert
+func (r *RouteAlertRepository) CreateRouteAlert(routeAlert migration.RouteAlert) (migration.RouteAlert, error) {
+	if err := r.db.Create(&routeAlert).Error; err != nil {
+		return migration.RouteAlert{}, err
+	}
+	return routeAlert, nil
+}
+
+// GetRouteAlertByID fetches a route alert of the user
+func (r *RouteAlertRepository) GetRouteAlertByID(routeAlertID, userID uuid.UUID) (migration.RouteAlert, error) {
+	var routeAlert migration.RouteAlert
+	err := r.db.Where("id = ? AND user_id = ?", routeAlertID, userID).First(&routeAlert).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return routeAlert, ErrRouteAlertNotFound
+		}
+		return routeAlert, err
+	}
+	return routeAlert, nil
+}
+
+// GetRouteAlertsByUserID returns all route alerts of the user, newest first
+func (r *RouteAlertRepository) GetRouteAlertsByUserID(userID uuid.UUID) ([]migration.RouteAlert, error) {
+	var routeAlerts []migration.RouteAlert
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&routeAlerts).Error
+	return routeAlerts, err
+}
+
+// UpdateRouteAlert saves the changes of a route alert
+func (r *RouteAlertRepository) UpdateRouteAlert(routeAlert migration.RouteAlert) error {
+	return r.db.Save(&routeAlert).Error
+}
+
+// DeleteRouteAlert deletes a route alert of the user with its notification records
+func (r *RouteAlertRepository) DeleteRouteAlert(routeAlertID, userID uuid.UUID) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", routeAlertID, userID).Delete(&migration.RouteAlert{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRouteAlertNotFound
+		}
+
+		return tx.Where("route_alert_id = ?", routeAlertID).Delete(&migration.RouteAlertNotification{}).Error
+	})
+}
+
+// GetRouteAlertCandidates returns the active route alerts of other users whose origin and destination
+// lie within the matching distance of the ride offer route bounding box, only these can match the route.
+// The users who blocked the driver or were blocked by the driver are never alerted
+func (r *RouteAlertRepository) GetRouteAlertCandidates(rideOffer migration.RideOffer) ([]migration.RouteAlert, error) {
+	minLat, maxLat, minLng, maxLng := helper.MatchBounds(rideOffer.MinLatitude, rideOffer.MaxLatitude, rideOffer.MinLongitude, rideOffer.MaxLongitude)
+
+	var routeAlerts []migration.RouteAlert
+	err := r.db.Preload("User").
+		Where("is_active = ? AND user_id <> ?", true, rideOffer.UserID).
+		Where("user_id NOT IN (?)", blockedUserIDs(r.db, rideOffer.UserID)).
+		Where("origin_latitude BETWEEN ? AND ? AND origin_longitude BETWEEN ? AND ?", minLat, maxLat, minLng, maxLng).
+		Where("destination_latitude BETWEEN ? AND ? AND destination_longitude BETWEEN ? AND ?", minLat, maxLat, minLng, maxLng).
+		Find(&routeAlerts).Error
+	return routeAlerts, err
+}
+
+// CountNotificationsSince counts the route alert notifications sent to the user since the given time
+func (r *RouteAlertRepository) CountNotificationsSince(userID uuid.UUID, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&migration.RouteAlertNotification{}).
+		Joins("JOIN route_alerts ON route_alerts.id = route_alert_notifications.route_alert_id").
+		Where("route_alerts.user_id = ? AND route_alert_notifications.created_at >= ?", userID, since).
+		Distinct("route_alert_notifications.ride_offer_id").
+		Count(&count).Error
+	return count, err
+}
+
+// RecordNotification records that the ride offer was notified for the given alerts of a user,
+// it returns false when the ride offer was already notified for one of them so it is not sent twice
+func (r *RouteAlertRepository) RecordNotification(routeAlertIDs []uuid.UUID, rideOfferID uuid.UUID) (bool, error) {
+	recorded := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		notifications := make([]migration.RouteAlertNotification, len(routeAlertIDs))
+		for i, routeAlertID := range routeAlertIDs {
+			notifications[i] = migration.RouteAlertNotification{
+				RouteAlertID: routeAlertID,
+				RideOfferID:  rideOfferID,
+			}
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&notifications)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected < int64(len(routeAlertIDs)) {
+			// Another evaluation of the same ride offer already notified the user
+			return nil
+		}
+		recorded = true
+
+		return tx.Model(&migration.RouteAlert{}).
+			Where("id IN ?", routeAlertIDs).
+			Update("last_notified_at", time.Now().UTC()).Error
+	})
+	return recorded, err
+}
+
+// Make sure RouteAlertRepository implements IRouteAlertRepository
+var _ IRouteAlertRepository = (*RouteAlertRepository)(nil)
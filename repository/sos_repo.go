@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"shareway/infra/db/migration"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Redis channel the SOS events are published on for the admin dashboards
+const sosEventsChannel = "sos:events"
+
+type ISOSRepository interface {
+	CreateEmergencyContact(contact migration.EmergencyContact) (migration.EmergencyContact, error)
+	GetEmergencyContactByID(contactID, userID uuid.UUID) (migration.EmergencyContact, error)
+	GetEmergencyContactsByUserID(userID uuid.UUID) ([]migration.EmergencyContact, error)
+	GetVerifiedEmergencyContacts(userID uuid.UUID) ([]migration.EmergencyContact, error)
+	CountEmergencyContacts(userID uuid.UUID) (int64, error)
+	MarkEmergencyContactVerified(contactID, userID uuid.UUID, verifiedAt time.Time) error
+	DeleteEmergencyContact(contactID, userID uuid.UUID) error
+	CreateSOSEvent(event migration.SOSEvent) (migration.SOSEvent, error)
+	SetSOSEventContactsAlerted(eventID uuid.UUID, contactsAlerted int) error
+	GetSOSEventByID(eventID uuid.UUID) (migration.SOSEvent, error)
+	GetSOSEvents(status string, limit int) ([]migration.SOSEvent, error)
+	AcknowledgeSOSEvent(eventID, adminID uuid.UUID) (migration.SOSEvent, error)
+	ResolveSOSEvent(eventID, adminID uuid.UUID, note string) (migration.SOSEvent, error)
+	PublishSOSEvent(ctx context.Context, message []byte) error
+	SubscribeSOSEvents(ctx context.Context) *redis.PubSub
+}
+
+type SOSRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewSOSRepository(db *gorm.DB, redis *redis.Client) ISOSRepository {
+	return &SOSRepository{db: db, redis: redis}
+}
+
+var (
+	ErrEmergencyContactNotFound = errors.New("emergency contact not found")
+	ErrSOSEventNotFound         = errors.New("SOS event not found")
+	ErrSOSEventTransition       = errors.New("SOS event cannot move to this status")
+)
+
+// CreateEmergencyContact saves a new emergency contact, not verified yet
+func (r *SOSRepository) CreateEmergencyContact(contact migration.EmergencyContact) (migration.EmergencyContact, error) {
+	if err := r.db.Create(&contact).Error; err != nil {
+		return migration.EmergencyContact{}, err
+	}
+	return contact, nil
+}
+
+// GetEmergencyContactByID fetches an emergency contact of the user
+func (r *SOSRepository) GetEmergencyContactByID(contactID, userID uuid.UUID) (migration.EmergencyContact, error) {
+	var contact migration.EmergencyContact
+	err := r.db.Where("id = ? AND user_id = ?", contactID, userID).First(&contact).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return contact, ErrEmergencyContactNotFound
+		}
+		return contact, err
+	}
+	return contact, nil
+}
+
+// GetEmergencyContactsByUserID returns all emergency contacts of the user, oldest first
+func (r *SOSRepository) GetEmergencyContactsByUserID(userID uuid.UUID) ([]migration.EmergencyContact, error) {
+	var contacts []migration.EmergencyContact
+	err := r.db.Where("user_id = ?", userID).Order("created_at").Find(&contacts).Error
+	return contacts, err
+}
+
+// GetVerifiedEmergencyContacts returns the emergency contacts of the user that can be alerted, with the user
+// to name in the alert
+func (r *SOSRepository) GetVerifiedEmergencyContacts(userID uuid.UUID) ([]migration.EmergencyContact, error) {
+	var contacts []migration.EmergencyContact
+	err := r.db.Preload("User").
+		Where("user_id = ? AND verified_at IS NOT NULL", userID).
+		Order("created_at").
+		Find(&contacts).Error
+	return contacts, err
+}
+
+// CountEmergencyContacts returns how many emergency contacts the user has
+func (r *SOSRepository) CountEmergencyContacts(userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&migration.EmergencyContact{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// MarkEmergencyContactVerified records that the phone number of an emergency contact was verified by OTP
+func (r *SOSRepository) MarkEmergencyContactVerified(contactID, userID uuid.UUID, verifiedAt time.Time) error {
+	result := r.db.Model(&migration.EmergencyContact{}).
+		Where("id = ? AND user_id = ?", contactID, userID).
+		Update("verified_at", verifiedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrEmergencyContactNotFound
+	}
+	return nil
+}
+
+// DeleteEmergencyContact deletes an emergency contact of the user
+func (r *SOSRepository) DeleteEmergencyContact(contactID, userID uuid.UUID) error {
+	result := r.db.Where("id = ? AND user_id = ?", contactID, userID).Delete(&migration.EmergencyContact{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrEmergencyContactNotFound
+	}
+	return nil
+}
+
+// CreateSOSEvent saves a new open SOS event
+func (r *SOSRepository) CreateSOSEvent(event migration.SOSEvent) (migration.SOSEvent, error) {
+	event.Status = "open"
+	if err := r.db.Create(&event).Error; err != nil {
+		return migration.SOSEvent{}, err
+	}
+	return event, nil
+}
+
+// SetSOSEventContactsAlerted records how many emergency contacts were alerted of an SOS event
+func (r *SOSRepository) SetSOSEventContactsAlerted(eventID uuid.UUID, contactsAlerted int) error {
+	return r.db.Model(&migration.SOSEvent{}).
+		Where("id = ?", eventID).
+		Update("contacts_alerted", contactsAlerted).Error
+}
+
+// GetSOSEventByID fetches an SOS event with the user who raised it
+func (r *SOSRepository) GetSOSEventByID(eventID uuid.UUID) (migration.SOSEvent, error) {
+	var event migration.SOSEvent
+	err := r.db.Preload("User").Where("id = ?", eventID).First(&event).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return event, ErrSOSEventNotFound
+		}
+		return event, err
+	}
+	return event, nil
+}
+
+// GetSOSEvents returns the latest SOS events, with the given status when set
+func (r *SOSRepository) GetSOSEvents(status string, limit int) ([]migration.SOSEvent, error) {
+	query := r.db.Model(&migration.SOSEvent{}).Preload("User")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var events []migration.SOSEvent
+	err := query.Order("created_at DESC").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+// AcknowledgeSOSEvent records that an admin is handling an open SOS event
+func (r *SOSRepository) AcknowledgeSOSEvent(eventID, adminID uuid.UUID) (migration.SOSEvent, error) {
+	return r.transitionSOSEvent(eventID, []string{"open"}, map[string]interface{}{
+		"status":          "acknowledged",
+		"acknowledged_at": time.Now(),
+		"acknowledged_by": adminID,
+	})
+}
+
+// ResolveSOSEvent closes an SOS event, an open event is acknowledged by the same admin at the same time
+func (r *SOSRepository) ResolveSOSEvent(eventID, adminID uuid.UUID, note string) (migration.SOSEvent, error) {
+	now := time.Now()
+	return r.transitionSOSEvent(eventID, []string{"open", "acknowledged"}, map[string]interface{}{
+		"status":          "resolved",
+		"acknowledged_at": gorm.Expr("COALESCE(acknowledged_at, ?)", now),
+		"acknowledged_by": gorm.Expr("COALESCE(acknowledged_by, ?)", adminID),
+		"resolved_at":     now,
+		"resolved_by":     adminID,
+		"resolution_note": note,
+	})
+}
+
+// transitionSOSEvent applies the updates to an SOS event if its status is one of the given ones
+func (r *SOSRepository) transitionSOSEvent(eventID uuid.UUID, from []string, updates map[string]interface{}) (migration.SOSEvent, error) {
+	result := r.db.Model(&migration.SOSEvent{}).
+		Where("id = ? AND status IN ?", eventID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return migration.SOSEvent{}, result.Error
+	}
+
+	event, err := r.GetSOSEventByID(eventID)
+	if err != nil {
+		return event, err
+	}
+	if result.RowsAffected == 0 {
+		return event, ErrSOSEventTransition
+	}
+	return event, nil
+}
+
+// PublishSOSEvent sends an SOS event to the admin dashboards listening with SubscribeSOSEvents
+func (r *SOSRepository) PublishSOSEvent(ctx context.Context, message []byte) error {
+	return r.redis.Publish(ctx, sosEventsChannel, message).Err()
+}
+
+// SubscribeSOSEvents listens to the SOS events published by every instance of the server
+func (r *SOSRepository) SubscribeSOSEvents(ctx context.Context) *redis.PubSub {
+	return r.redis.Subscribe(ctx, sosEventsChannel)
+}
+
+// Make sure the SOSRepository implements the ISOSRepository interface
+var _ ISOSRepository = (*SOSRepository)(nil)
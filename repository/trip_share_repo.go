@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"errors"
+	"shareway/infra/db/migration"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type ITripShareRepository interface {
+	CreateTripShare(tripShare migration.TripShare) (migration.TripShare, error)
+	GetTripShareByTokenHash(tokenHash string) (migration.TripShare, error)
+	GetTripSharesByRideID(rideID, userID uuid.UUID) ([]migration.TripShare, error)
+	RevokeTripShare(tripShareID, userID uuid.UUID) error
+}
+
+type TripShareRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewTripShareRepository(db *gorm.DB, redis *redis.Client) ITripShareRepository {
+	return &TripShareRepository{db: db, redis: redis}
+}
+
+var (
+	ErrTripShareNotFound = errors.New("trip share not found")
+)
+
+// CreateTripShare saves a new public link to a ride
+func (r *TripShareRepository) CreateTripShare(tripShare migration.TripShare) (migration.TripShare, error) {
+	if err := r.db.Create(&tripShare).Error; err != nil {
+		return migration.TripShare{}, err
+	}
+	return tripShare, nil
+}
+
+// GetTripShareByTokenHash fetches a public link with its ride, the offer with the vehicle and the request
+func (r *TripShareRepository) GetTripShareByTokenHash(tokenHash string) (migration.TripShare, error) {
+	var tripShare migration.TripShare
+	err := r.db.Preload("Ride.RideOffer.Vehicle").
+		Preload("Ride.RideRequest").
+		Where("token_hash = ?", tokenHash).
+		First(&tripShare).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tripShare, ErrTripShareNotFound
+		}
+		return tripShare, err
+	}
+	return tripShare, nil
+}
+
+// GetTripSharesByRideID returns the public links the user created for a ride, newest first
+func (r *TripShareRepository) GetTripSharesByRideID(rideID, userID uuid.UUID) ([]migration.TripShare, error) {
+	var tripShares []migration.TripShare
+	err := r.db.Where("ride_id = ? AND user_id = ?", rideID, userID).
+		Order("created_at DESC").
+		Find(&tripShares).Error
+	return tripShares, err
+}
+
+// RevokeTripShare stops a public link of the user from working
+func (r *TripShareRepository) RevokeTripShare(tripShareID, userID uuid.UUID) error {
+	result := r.db.Model(&migration.TripShare{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", tripShareID, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTripShareNotFound
+	}
+	return nil
+}
+
+// Make sure the TripShareRepository implements the ITripShareRepository interface
+var _ ITripShareRepository = (*TripShareRepository)(nil)
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"shareway/helper"
 	"shareway/infra/db/migration"
 	"shareway/schemas"
 	"strings"
@@ -14,7 +15,7 @@ import (
 
 type IVehicleRepository interface {
 	GetVehicles(ctx context.Context, limit int, page int, input string) ([]schemas.Vehicle, error)
-	RegisterVehicle(userID uuid.UUID, vehicleID uuid.UUID, licensePlate string, caVet string) error
+	RegisterVehicle(userID uuid.UUID, vehicleID uuid.UUID, licensePlate string, caVet string, seatCapacity int, fuelType string) error
 	LicensePlateExists(licensePlate string) (bool, error)
 	CaVetExists(caVet string) (bool, error)
 	GetVehicleFromID(vehicleID uuid.UUID) (schemas.VehicleDetail, error)
@@ -39,7 +40,7 @@ func (r *VehicleRepository) GetVehicles(ctx context.Context, limit int, page int
 	var vehicles []migration.VehicleType
 	input = strings.ToLower(input)
 	query := r.db.Model(&migration.VehicleType{}).
-		Select("id", "name", "fuel_consumed").
+		Select("id", "name", "fuel_type", "fuel_consumed").
 		Limit(limit).
 		Offset(page * limit)
 
@@ -57,6 +58,7 @@ func (r *VehicleRepository) GetVehicles(ctx context.Context, limit int, page int
 		schemaVehicles[i] = schemas.Vehicle{
 			VehicleID:    vehicle.ID,
 			Name:         vehicle.Name,
+			FuelType:     vehicle.FuelType,
 			FuelConsumed: vehicle.FuelConsumed,
 		}
 	}
@@ -65,20 +67,32 @@ func (r *VehicleRepository) GetVehicles(ctx context.Context, limit int, page int
 }
 
 // RegisterVehicle registers a vehicle for a user
-func (r *VehicleRepository) RegisterVehicle(userID, vehicleID uuid.UUID, licensePlate, caVet string) error {
+// fuelType overrides the fuel of the vehicle type (empty keeps it), an electric vehicle always stays electric
+func (r *VehicleRepository) RegisterVehicle(userID, vehicleID uuid.UUID, licensePlate, caVet string, seatCapacity int, fuelType string) error {
+	// A vehicle always carries at least one passenger
+	if seatCapacity <= 0 {
+		seatCapacity = 1
+	}
+
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		var vehicle migration.VehicleType
-		if err := tx.Select("name", "fuel_consumed").First(&vehicle, vehicleID).Error; err != nil {
+		if err := tx.Select("name", "fuel_type", "fuel_consumed").First(&vehicle, vehicleID).Error; err != nil {
 			return err
 		}
 
+		if fuelType == "" || vehicle.FuelType == helper.FuelTypeElectric {
+			fuelType = vehicle.FuelType
+		}
+
 		vehicleRegistration := migration.Vehicle{
 			UserID:        userID,
 			VehicleTypeID: vehicleID,
 			LicensePlate:  licensePlate,
 			Name:          vehicle.Name,
+			FuelType:      fuelType,
 			FuelConsumed:  vehicle.FuelConsumed,
 			CaVet:         caVet,
+			SeatCapacity:  seatCapacity,
 		}
 
 		return tx.Create(&vehicleRegistration).Error
@@ -112,7 +126,7 @@ func (r *VehicleRepository) CaVetExists(caVet string) (bool, error) {
 // GetVehicleFromID retrieves a vehicle from the database using the vehicle ID
 func (r *VehicleRepository) GetVehicleFromID(vehicleID uuid.UUID) (schemas.VehicleDetail, error) {
 	var vehicle migration.Vehicle
-	err := r.db.Select("id", "name", "fuel_consumed", "license_plate").
+	err := r.db.Select("id", "name", "fuel_type", "fuel_consumed", "license_plate", "seat_capacity").
 		First(&vehicle, vehicleID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -124,15 +138,17 @@ func (r *VehicleRepository) GetVehicleFromID(vehicleID uuid.UUID) (schemas.Vehic
 	return schemas.VehicleDetail{
 		VehicleID:    vehicle.ID,
 		Name:         vehicle.Name,
+		FuelType:     vehicle.FuelType,
 		FuelConsumed: vehicle.FuelConsumed,
 		LicensePlate: vehicle.LicensePlate,
+		SeatCapacity: vehicle.SeatCapacity,
 	}, nil
 }
 
 // GetAllVehiclesFromUserID retrieves all vehicles for a user using the user ID
 func (r *VehicleRepository) GetAllVehiclesFromUserID(userID uuid.UUID) ([]schemas.VehicleDetail, error) {
 	var vehicles []migration.Vehicle
-	err := r.db.Select("id", "name", "fuel_consumed", "license_plate").
+	err := r.db.Select("id", "name", "fuel_type", "fuel_consumed", "license_plate", "seat_capacity").
 		Where("user_id = ?", userID).
 		Find(&vehicles).Error
 	if err != nil {
@@ -144,8 +160,10 @@ func (r *VehicleRepository) GetAllVehiclesFromUserID(userID uuid.UUID) ([]schema
 		schemaVehicles[i] = schemas.VehicleDetail{
 			VehicleID:    vehicle.ID,
 			Name:         vehicle.Name,
+			FuelType:     vehicle.FuelType,
 			FuelConsumed: vehicle.FuelConsumed,
 			LicensePlate: vehicle.LicensePlate,
+			SeatCapacity: vehicle.SeatCapacity,
 		}
 	}
 	return schemaVehicles, nil
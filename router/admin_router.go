@@ -13,4 +13,37 @@ func SetupAdminRouter(group *gin.RouterGroup, server *APIServer) {
 		server.Service.AdminService,
 	)
 	group.GET("/get-profile", adminController.GetAdminProfile)
+	group.POST("/ride/geofence-override", adminController.CreateGeofenceOverride)
+
+	rideTrailController := controller.NewRideTrailController(
+		server.Validate,
+		server.Service.RideTrailService,
+	)
+	group.GET("/ride/trail", rideTrailController.AdminGetRideTrail)
+	group.GET("/ride/deviation-events", rideTrailController.AdminGetDeviationEvents)
+	group.GET("/ride/deviation-events/stream", rideTrailController.AdminStreamDeviationEvents)
+	group.POST("/ride/deviation-events/acknowledge", rideTrailController.AdminAcknowledgeDeviationEvent)
+
+	sosController := controller.NewSOSController(
+		server.Validate,
+		server.Service.SOSService,
+	)
+	group.GET("/sos/get-all", sosController.AdminGetSOSEvents)
+	group.GET("/sos/stream", sosController.AdminStreamSOSEvents)
+	group.POST("/sos/acknowledge", sosController.AdminAcknowledgeSOSEvent)
+	group.POST("/sos/resolve", sosController.AdminResolveSOSEvent)
+
+	impactController := controller.NewImpactController(
+		server.Validate,
+		server.Service.ImpactService,
+	)
+	group.GET("/impact", impactController.AdminGetPlatformImpact)
+
+	moderationController := controller.NewModerationController(
+		server.Validate,
+		server.Service.ModerationService,
+	)
+	group.GET("/report/get-all", moderationController.AdminGetUserReports)
+	group.POST("/report/review", moderationController.AdminReviewUserReport)
+	group.POST("/report/close", moderationController.AdminCloseUserReport)
 }
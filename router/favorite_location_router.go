@@ -0,0 +1,19 @@
+package router
+
+import (
+	"shareway/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupFavoriteLocationRouter(group *gin.RouterGroup, server *APIServer) {
+	favoriteLocationController := controller.NewFavoriteLocationController(
+		server.Validate,
+		server.Service.FavoriteLocationService,
+	)
+	group.POST("/create", favoriteLocationController.CreateFavoriteLocation)
+	group.GET("/get-all", favoriteLocationController.GetFavoriteLocations)
+	group.POST("/update", favoriteLocationController.UpdateFavoriteLocation)
+	group.POST("/reorder", favoriteLocationController.ReorderFavoriteLocations)
+	group.POST("/delete", favoriteLocationController.DeleteFavoriteLocation)
+}
@@ -22,6 +22,9 @@ func SetupMapRouter(group *gin.RouterGroup, server *APIServer) {
 	// CreateHitchRide request
 	group.POST("/hitch-ride", mapController.CreateHitchRide)
 
+	// GetFareQuote request
+	group.POST("/fare-quote", mapController.GetFareQuote)
+
 	// GetGeoCode request
 	group.POST("/geocode", mapController.GetGeoCode)
 
@@ -31,4 +34,7 @@ func SetupMapRouter(group *gin.RouterGroup, server *APIServer) {
 	// SuggestRideOffers request
 	group.POST("/suggest-give-rides", mapController.SuggestGiveRides)
 
+	// BrowseRideOffers request
+	group.POST("/browse-give-rides", mapController.BrowseGiveRides)
+
 }
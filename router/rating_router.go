@@ -0,0 +1,16 @@
+package router
+
+import (
+	"shareway/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRatingRouter(group *gin.RouterGroup, server *APIServer) {
+	ratingController := controller.NewRatingController(
+		server.Validate,
+		server.Service.RatingService,
+	)
+	group.POST("/create", ratingController.CreateRating)
+	group.GET("/received", ratingController.GetRatingsReceived)
+}
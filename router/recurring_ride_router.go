@@ -0,0 +1,19 @@
+package router
+
+import (
+	"shareway/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRecurringRideRouter(group *gin.RouterGroup, server *APIServer) {
+	recurringRideController := controller.NewRecurringRideController(
+		server.Validate,
+		server.Service.RecurringRideService,
+	)
+	group.POST("/create", recurringRideController.CreateRecurringRideOffer)
+	group.GET("/get-all", recurringRideController.GetRecurringRideOffers)
+	group.POST("/update", recurringRideController.UpdateRecurringRideOffer)
+	group.POST("/pause", recurringRideController.PauseRecurringRideOffer)
+	group.POST("/skip-occurrence", recurringRideController.SkipOccurrence)
+}
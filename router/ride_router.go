@@ -14,6 +14,7 @@ func SetupRideRouter(group *gin.RouterGroup, server *APIServer) {
 		server.Service.MapService,
 		server.Service.UserService,
 		server.Service.VehicleService,
+		server.Service.ModerationService,
 		server.AsyncClient,
 	)
 	group.POST("/give-ride-request", rideController.SendGiveRideRequest)
@@ -22,9 +23,34 @@ func SetupRideRouter(group *gin.RouterGroup, server *APIServer) {
 	group.POST("/accept-hitch-ride-request", rideController.AcceptHitchRideRequest)
 	group.POST("/cancel-give-ride-request", rideController.CancelGiveRideRequest)
 	group.POST("/cancel-hitch-ride-request", rideController.CancelHitchRideRequest)
+	group.POST("/pickup-code", rideController.IssuePickupCode)
 	group.POST("/start-ride", rideController.StartRide)
 	group.POST("/end-ride", rideController.EndRide)
 	group.POST("/update-ride-location", rideController.UpdateRideLocation)
+	group.POST("/update-rider-location", rideController.UpdateRiderLocation)
 	group.POST("/cancel-ride", rideController.CancelRide)
 	group.GET("/get-all-pending-ride", rideController.GetAllPendingRide)
+	group.GET("/history", rideController.GetRideHistory)
+	group.GET("/history-summary", rideController.GetRideHistorySummary)
+
+	rideTrailController := controller.NewRideTrailController(
+		server.Validate,
+		server.Service.RideTrailService,
+	)
+	group.GET("/trail", rideTrailController.GetRideTrail)
+
+	tripShareController := controller.NewTripShareController(
+		server.Validate,
+		server.Service.TripShareService,
+		server.Cfg,
+	)
+	group.POST("/share-trip", tripShareController.ShareTrip)
+	group.GET("/trip-shares", tripShareController.GetTripShares)
+	group.POST("/revoke-trip-share", tripShareController.RevokeTripShare)
+
+	receiptController := controller.NewReceiptController(
+		server.Validate,
+		server.Service.ReceiptService,
+	)
+	group.GET("/receipt", receiptController.GetRideReceipt)
 }
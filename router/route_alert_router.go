@@ -0,0 +1,18 @@
+package router
+
+import (
+	"shareway/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRouteAlertRouter(group *gin.RouterGroup, server *APIServer) {
+	routeAlertController := controller.NewRouteAlertController(
+		server.Validate,
+		server.Service.RouteAlertService,
+	)
+	group.POST("/create", routeAlertController.CreateRouteAlert)
+	group.GET("/get-all", routeAlertController.GetRouteAlerts)
+	group.POST("/update", routeAlertController.UpdateRouteAlert)
+	group.POST("/delete", routeAlertController.DeleteRouteAlert)
+}
@@ -101,6 +101,18 @@ func (server *APIServer) SetupRouter() {
 	server.router.GET("/ws", server.HandleWebSocket)
 	// Ride routes for ride matching and engagement
 	SetupRideRouter(server.router.Group("/ride", middleware.AuthMiddleware(server.Maker)), server)
+	// Recurring ride routes for commute ride offers
+	SetupRecurringRideRouter(server.router.Group("/recurring-ride", middleware.AuthMiddleware(server.Maker)), server)
+	// Route alert routes to be notified of new matching ride offers
+	SetupRouteAlertRouter(server.router.Group("/route-alert", middleware.AuthMiddleware(server.Maker)), server)
+	// Favorite location routes for the places the user saved, used in the autocomplete and the place lists
+	SetupFavoriteLocationRouter(server.router.Group("/favorite-location", middleware.AuthMiddleware(server.Maker)), server)
+	// SOS routes for the emergency contacts and the emergencies during a ride
+	SetupSOSRouter(server.router.Group("/sos", middleware.AuthMiddleware(server.Maker)), server)
+	// Rating routes to rate the counterpart of a completed ride and read the reviews
+	SetupRatingRouter(server.router.Group("/rating", middleware.AuthMiddleware(server.Maker)), server)
+	// Trip share routes to follow a ride from a public link, without an account
+	SetupTripShareRouter(server.router.Group("/trip-share"), server)
 	// Notification routes for sending notifications
 	SetupNotificationRouter(server.router.Group("/notification", middleware.AuthMiddleware(server.Maker)), server)
 	// Chat routes for sending messages
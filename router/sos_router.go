@@ -0,0 +1,20 @@
+package router
+
+import (
+	"shareway/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSOSRouter(group *gin.RouterGroup, server *APIServer) {
+	sosController := controller.NewSOSController(
+		server.Validate,
+		server.Service.SOSService,
+	)
+	group.POST("/emergency-contact/create", sosController.CreateEmergencyContact)
+	group.POST("/emergency-contact/send-otp", sosController.SendEmergencyContactOTP)
+	group.POST("/emergency-contact/verify", sosController.VerifyEmergencyContact)
+	group.GET("/emergency-contact/get-all", sosController.GetEmergencyContacts)
+	group.POST("/emergency-contact/delete", sosController.DeleteEmergencyContact)
+	group.POST("/trigger", sosController.TriggerSOS)
+}
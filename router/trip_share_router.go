@@ -0,0 +1,17 @@
+package router
+
+import (
+	"shareway/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupTripShareRouter(group *gin.RouterGroup, server *APIServer) {
+	tripShareController := controller.NewTripShareController(
+		server.Validate,
+		server.Service.TripShareService,
+		server.Cfg,
+	)
+	group.GET("/live", tripShareController.GetSharedTrip)
+	group.GET("/stream", tripShareController.StreamSharedTrip)
+}
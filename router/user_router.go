@@ -10,6 +10,7 @@ import (
 func SetupUserRouter(group *gin.RouterGroup, server *APIServer) {
 	userController := controller.NewUserController(
 		server.Service.UserService,
+		server.Service.ImpactService,
 		server.Validate,
 	)
 	// GetUserProfile Request
@@ -18,6 +19,17 @@ func SetupUserRouter(group *gin.RouterGroup, server *APIServer) {
 	group.POST("/register-device-token", userController.RegisterDeviceToken)
 	// UpdateUserProfile Request
 	group.POST("/update-profile", userController.UpdateUserProfile)
+	// UpdateRidePreferences Request
+	group.POST("/update-ride-preferences", userController.UpdateRidePreferences)
 	// UpdateAvatar Request
 	group.POST("/update-avatar", userController.UpdateAvatar)
+
+	moderationController := controller.NewModerationController(
+		server.Validate,
+		server.Service.ModerationService,
+	)
+	group.POST("/block", moderationController.BlockUser)
+	group.POST("/unblock", moderationController.UnblockUser)
+	group.GET("/blocked-users", moderationController.GetBlockedUsers)
+	group.POST("/report", moderationController.ReportUser)
 }
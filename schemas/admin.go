@@ -0,0 +1,59 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define CreateGeofenceOverrideRequest struct
+type CreateGeofenceOverrideRequest struct {
+	RideID uuid.UUID `json:"ride_id" binding:"required,uuid" validate:"required,uuid"`
+	Action string    `json:"action" binding:"required" validate:"required,oneof=start end"` // Which location check is skipped
+	Note   string    `json:"note" binding:"required" validate:"required,max=1000"`          // Why the location check is skipped, kept for the audit
+}
+
+// Define GeofenceOverrideDetail struct
+type GeofenceOverrideDetail struct {
+	ID        uuid.UUID  `json:"geofence_override_id"`
+	RideID    uuid.UUID  `json:"ride_id"`
+	AdminID   uuid.UUID  `json:"admin_id"`
+	Action    string     `json:"action"`
+	Note      string     `json:"note"`
+	CreatedAt time.Time  `json:"created_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+}
+
+// Define GetDeviationEventsRequest struct
+type GetDeviationEventsRequest struct {
+	RideID         string `form:"rideID" validate:"omitempty,uuid"` // All the rides when empty
+	Unacknowledged bool   `form:"unacknowledged"`                   // Only the events not reviewed yet
+	Limit          int    `form:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+// Define AcknowledgeDeviationEventRequest struct
+type AcknowledgeDeviationEventRequest struct {
+	EventID uuid.UUID `json:"event_id" binding:"required,uuid" validate:"required,uuid"`
+	Note    string    `json:"note" validate:"max=1000"`
+}
+
+// Define DeviationEventDetail struct
+type DeviationEventDetail struct {
+	ID             uuid.UUID  `json:"deviation_event_id"`
+	RideID         uuid.UUID  `json:"ride_id"`
+	Type           string     `json:"type"`
+	StartedAt      time.Time  `json:"started_at"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+	Latitude       float64    `json:"latitude"`
+	Longitude      float64    `json:"longitude"`
+	Distance       float64    `json:"distance"`     // in meters
+	MaxDistance    float64    `json:"max_distance"` // in meters
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy *uuid.UUID `json:"acknowledged_by,omitempty"`
+	Note           string     `json:"note"`
+}
+
+// Define GetDeviationEventsResponse struct
+type GetDeviationEventsResponse struct {
+	Events []DeviationEventDetail `json:"events"`
+}
@@ -0,0 +1,37 @@
+package schemas
+
+import (
+	"github.com/google/uuid"
+)
+
+// Define CreateFavoriteLocationRequest struct
+type CreateFavoriteLocationRequest struct {
+	Type    string `json:"type" binding:"required" validate:"required,oneof=home work custom"` // A user has at most one home and one work
+	Name    string `json:"name" binding:"required" validate:"required,max=100"`                // Name given by the user, e.g. "Gym"
+	PlaceID string `json:"place_id" binding:"required" validate:"required"`                    // Place ID from goong api
+	Address string `json:"address" binding:"required" validate:"required,max=500"`             // Address shown to the user, usually the description of the prediction
+}
+
+// Define UpdateFavoriteLocationRequest struct
+// Only the provided fields are updated, the place and the address are changed together
+type UpdateFavoriteLocationRequest struct {
+	FavoriteLocationID uuid.UUID `json:"favorite_location_id" binding:"required,uuid" validate:"required,uuid"`
+	Name               *string   `json:"name,omitempty" validate:"omitempty,min=1,max=100"`                    // New name of the location
+	PlaceID            *string   `json:"place_id,omitempty" validate:"required_with=Address"`                  // New place ID from goong api
+	Address            *string   `json:"address,omitempty" validate:"required_with=PlaceID,omitempty,max=500"` // New address of the location
+}
+
+// Define ReorderFavoriteLocationsRequest struct
+type ReorderFavoriteLocationsRequest struct {
+	FavoriteLocationIDs []uuid.UUID `json:"favorite_location_ids" binding:"required" validate:"required,unique"` // All the favorite locations of the user in the new order
+}
+
+// Define DeleteFavoriteLocationRequest struct
+type DeleteFavoriteLocationRequest struct {
+	FavoriteLocationID uuid.UUID `json:"favorite_location_id" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define GetFavoriteLocationsResponse struct
+type GetFavoriteLocationsResponse struct {
+	FavoriteLocations []FavoriteLocationResponse `json:"favorite_locations"`
+}
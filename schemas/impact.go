@@ -0,0 +1,44 @@
+package schemas
+
+// Define ImpactFuelTotals struct, the completed rides driven with vehicles of one fuel type
+type ImpactFuelTotals struct {
+	FuelType        string
+	Rides           int
+	Distance        float64 // in kilometers, shared with the passengers
+	FuelConsumed    float64 // liters (kWh for electric vehicles) the vehicles with a known consumption used
+	UnknownDistance float64 // in kilometers, shared in the vehicles without a known consumption
+}
+
+// Define ImpactDetail struct
+type ImpactDetail struct {
+	Rides       int                `json:"rides"`
+	KmShared    float64            `json:"km_shared"`
+	FuelSaved   float64            `json:"fuel_saved"`   // in liters
+	EnergySaved float64            `json:"energy_saved"` // in kWh, by the electric vehicles
+	CO2Avoided  float64            `json:"co2_avoided"`  // in kilograms
+	ByFuelType  []ImpactFuelDetail `json:"by_fuel_type"`
+}
+
+// Define ImpactFuelDetail struct
+type ImpactFuelDetail struct {
+	FuelType       string  `json:"fuel_type"`
+	Rides          int     `json:"rides"`
+	KmShared       float64 `json:"km_shared"`
+	Saved          float64 `json:"saved"`           // in liters, in kWh for electric vehicles
+	EmissionFactor float64 `json:"emission_factor"` // kg of CO2 per liter, per kWh for electric vehicles
+	CO2Avoided     float64 `json:"co2_avoided"`     // in kilograms
+}
+
+// Define GetPlatformImpactRequest struct
+type GetPlatformImpactRequest struct {
+	From string `form:"from" validate:"omitempty,datetime=2006-01-02"` // First day of the rides, inclusive
+	To   string `form:"to" validate:"omitempty,datetime=2006-01-02"`   // Last day of the rides, inclusive
+}
+
+// Define GetPlatformImpactResponse struct
+type GetPlatformImpactResponse struct {
+	From   string       `json:"from,omitempty"`
+	To     string       `json:"to,omitempty"`
+	Users  int          `json:"users"` // Drivers and passengers of the rides
+	Impact ImpactDetail `json:"impact"`
+}
@@ -36,7 +36,9 @@ type Prediction struct {
 	DisplayType          string               `json:"display_type"`
 	Score                float64              `json:"score"`
 	PlusCode             PlusCode             `json:"plus_code"`
-	Distance             float64              `json:"distance,omitempty"` // Distance from the location (in kilometers) for which the autocomplete is performed
+	Distance             float64              `json:"distance,omitempty"`             // Distance from the location (in kilometers) for which the autocomplete is performed
+	Source               string               `json:"source,omitempty"`               // favorite, recent or goong
+	FavoriteLocationID   *uuid.UUID           `json:"favorite_location_id,omitempty"` // Set for the favorite locations of the user
 }
 
 // Define MatchedSubstring struct
@@ -69,12 +71,24 @@ type Point struct {
 	Lng float64 `json:"lng"` // Longitude
 }
 
+// Define RidePreferences struct
+// same_gender, pets_allowed and luggage_size filter the suggestions, no_smoking and quiet_ride only rank them
+type RidePreferences struct {
+	SameGender  bool   `json:"same_gender"`                                                     // Only ride with users of the same gender
+	PetsAllowed bool   `json:"pets_allowed"`                                                    // Driver accepts pets, or hitcher travels with a pet
+	LuggageSize string `json:"luggage_size" validate:"omitempty,oneof=none small medium large"` // Largest luggage accepted by the driver, or carried by the hitcher (default: small)
+	NoSmoking   bool   `json:"no_smoking"`                                                      // Prefers a no-smoking ride
+	QuietRide   bool   `json:"quiet_ride"`                                                      // Prefers a quiet ride
+}
+
 // Define GiveRideRequest struct
 type GiveRideRequest struct {
 	// Points []Point `json:"points" binding:"required"` // List of points for the route
-	PlaceList []string  `json:"place_list" binding:"required"`                               // List of places for the route (place_id) from goong api
-	StartTime string    `json:"start_time,omitempty"`                                        // Start time of the ride (if not provided, the ride is immediate)
-	VehicleID uuid.UUID `json:"vehicle_id" binding:"required,uuid" validate:"required,uuid"` // Vehicle ID for the ride that user has registered
+	PlaceList   []string         `json:"place_list" binding:"required"`                               // List of places for the route (place_id) from goong api, or favorite:<favorite_location_id>
+	StartTime   string           `json:"start_time,omitempty"`                                        // Start time of the ride (if not provided, the ride is immediate)
+	VehicleID   uuid.UUID        `json:"vehicle_id" binding:"required,uuid" validate:"required,uuid"` // Vehicle ID for the ride that user has registered
+	Seats       int              `json:"seats,omitempty" validate:"omitempty,min=1"`                  // Number of seats offered (defaults to the vehicle seat capacity)
+	Preferences *RidePreferences `json:"preferences,omitempty"`                                       // Preferences for this ride (defaults to the preferences of the user profile)
 }
 
 // Define
@@ -141,23 +155,26 @@ type GoongDirectionsResponse struct {
 
 // Define GiveRideResponse struct
 type GiveRideResponse struct {
-	Route       GoongDirectionsResponse `json:"route"`
-	RideOfferID uuid.UUID               `json:"ride_offer_id"`
-	Distance    float64                 `json:"distance"`
-	Duration    int                     `json:"duration"`
-	StartTime   time.Time               `json:"start_time"`
-	EndTime     time.Time               `json:"end_time"`
-	Fare        float64                 `json:"fare"`
-	Vehicle     VehicleDetail           `json:"vehicle"`
-	Waypoints   []Waypoint              `json:"waypoints"`
+	Route          GoongDirectionsResponse `json:"route"`
+	RideOfferID    uuid.UUID               `json:"ride_offer_id"`
+	Distance       float64                 `json:"distance"`
+	Duration       int                     `json:"duration"`
+	StartTime      time.Time               `json:"start_time"`
+	EndTime        time.Time               `json:"end_time"`
+	Fare           float64                 `json:"fare"`
+	TotalSeats     int                     `json:"total_seats"`
+	AvailableSeats int                     `json:"available_seats"`
+	Vehicle        VehicleDetail           `json:"vehicle"`
+	Waypoints      []Waypoint              `json:"waypoints"`
 }
 
 // Define HitchRideRequest struct
 type HitchRideRequest struct {
 	// Points []Point `json:"points" binding:"required"` // List of points for the route
-	PlaceList []string `json:"place_list" binding:"required"` // List of places for the route (place_id) from goong api
-	StartTime string   `json:"start_time,omitempty"`          // Start time of the ride (if not provided, the ride is immediate)
-	Weight    int64    `json:"weight" binding:"required"`     // Weight of the rider to consider
+	PlaceList   []string         `json:"place_list" binding:"required"` // List of places for the route (place_id) from goong api, or favorite:<favorite_location_id>
+	StartTime   string           `json:"start_time,omitempty"`          // Start time of the ride (if not provided, the ride is immediate)
+	Weight      int64            `json:"weight" binding:"required"`     // Weight of the rider to consider
+	Preferences *RidePreferences `json:"preferences,omitempty"`         // Preferences for this ride (defaults to the preferences of the user profile)
 }
 
 // Define HitchRideResponse struct
@@ -266,33 +283,37 @@ type SuggestRideRequestResponse struct {
 
 // Define UserInfo struct
 type UserInfo struct {
-	ID           uuid.UUID `json:"user_id"`
-	PhoneNumber  string    `json:"phone_number"`
-	FullName     string    `json:"full_name"`
-	AvatarURL    string    `json:"avatar_url"`
-	Gender       string    `json:"gender"`
-	IsMomoLinked bool      `json:"is_momo_linked"`
+	ID            uuid.UUID `json:"user_id"`
+	PhoneNumber   string    `json:"phone_number"`
+	FullName      string    `json:"full_name"`
+	AvatarURL     string    `json:"avatar_url"`
+	Gender        string    `json:"gender"`
+	IsMomoLinked  bool      `json:"is_momo_linked"`
+	AverageRating float64   `json:"average_rating"`
+	RatingCount   int       `json:"rating_count"`
 }
 
 // Define RideRequestDetail struct
 type RideRequestDetail struct {
-	ID                    uuid.UUID `json:"ride_request_id"`
-	User                  UserInfo  `json:"user"`
-	StartLatitude         float64   `json:"start_latitude"`
-	StartLongitude        float64   `json:"start_longitude"`
-	EndLatitude           float64   `json:"end_latitude"`
-	EndLongitude          float64   `json:"end_longitude"`
-	RiderCurrentLatitude  float64   `json:"rider_current_latitude"`
-	RiderCurrentLongitude float64   `json:"rider_current_longitude"`
-	StartAddress          string    `json:"start_address"`
-	EndAddress            string    `json:"end_address"`
-	Status                string    `json:"status"`
-	EncodedPolyline       string    `json:"encoded_polyline"`
-	Distance              float64   `json:"distance"`
-	Duration              int       `json:"duration"`
-	StartTime             time.Time `json:"start_time"`
-	EndTime               time.Time `json:"end_time"`
-	Weight                int64     `json:"weight"`
+	ID                    uuid.UUID       `json:"ride_request_id"`
+	User                  UserInfo        `json:"user"`
+	StartLatitude         float64         `json:"start_latitude"`
+	StartLongitude        float64         `json:"start_longitude"`
+	EndLatitude           float64         `json:"end_latitude"`
+	EndLongitude          float64         `json:"end_longitude"`
+	RiderCurrentLatitude  float64         `json:"rider_current_latitude"`
+	RiderCurrentLongitude float64         `json:"rider_current_longitude"`
+	StartAddress          string          `json:"start_address"`
+	EndAddress            string          `json:"end_address"`
+	Status                string          `json:"status"`
+	EncodedPolyline       string          `json:"encoded_polyline"`
+	Distance              float64         `json:"distance"`
+	Duration              int             `json:"duration"`
+	StartTime             time.Time       `json:"start_time"`
+	EndTime               time.Time       `json:"end_time"`
+	Weight                int64           `json:"weight"`
+	Preferences           RidePreferences `json:"preferences"`
+	MatchScore            *MatchScore     `json:"match_score,omitempty"` // Only set on suggestions
 }
 
 // Define SuggestRideOfferRequest struct
@@ -316,23 +337,124 @@ type Waypoint struct {
 
 // Define RideOfferDetail struct
 type RideOfferDetail struct {
-	ID                     uuid.UUID     `json:"ride_offer_id"`
-	User                   UserInfo      `json:"user"`
-	Vehicle                VehicleDetail `json:"vehicle"`
-	StartLatitude          float64       `json:"start_latitude"`
-	StartLongitude         float64       `json:"start_longitude"`
-	EndLatitude            float64       `json:"end_latitude"`
-	EndLongitude           float64       `json:"end_longitude"`
-	StartAddress           string        `json:"start_address"`
-	EndAddress             string        `json:"end_address"`
-	EncodedPolyline        string        `json:"encoded_polyline"`
-	Distance               float64       `json:"distance"`
-	Duration               int           `json:"duration"`
-	DriverCurrentLatitude  float64       `json:"driver_current_latitude"`
-	DriverCurrentLongitude float64       `json:"driver_current_longitude"`
-	StartTime              time.Time     `json:"start_time"`
-	EndTime                time.Time     `json:"end_time"`
-	Status                 string        `json:"status"`
-	Fare                   float64       `json:"fare"`
-	Waypoints              []Waypoint    `json:"waypoints"`
+	ID                     uuid.UUID       `json:"ride_offer_id"`
+	User                   UserInfo        `json:"user"`
+	Vehicle                VehicleDetail   `json:"vehicle"`
+	StartLatitude          float64         `json:"start_latitude"`
+	StartLongitude         float64         `json:"start_longitude"`
+	EndLatitude            float64         `json:"end_latitude"`
+	EndLongitude           float64         `json:"end_longitude"`
+	StartAddress           string          `json:"start_address"`
+	EndAddress             string          `json:"end_address"`
+	EncodedPolyline        string          `json:"encoded_polyline"`
+	Distance               float64         `json:"distance"`
+	Duration               int             `json:"duration"`
+	DriverCurrentLatitude  float64         `json:"driver_current_latitude"`
+	DriverCurrentLongitude float64         `json:"driver_current_longitude"`
+	StartTime              time.Time       `json:"start_time"`
+	EndTime                time.Time       `json:"end_time"`
+	Status                 string          `json:"status"`
+	Fare                   float64         `json:"fare"`
+	TotalSeats             int             `json:"total_seats"`
+	AvailableSeats         int             `json:"available_seats"`
+	Waypoints              []Waypoint      `json:"waypoints"`
+	Preferences            RidePreferences `json:"preferences"`
+	MatchScore             *MatchScore     `json:"match_score,omitempty"` // Only set on suggestions
+	Proximity              *RouteProximity `json:"proximity,omitempty"`   // Only set when browsing
+}
+
+// Define MatchFactor struct
+type MatchFactor struct {
+	Value  float64 `json:"value"`  // Measured value of the factor
+	Score  float64 `json:"score"`  // Normalized score between 0 and 1 (1 is best)
+	Weight float64 `json:"weight"` // Weight of the factor in the final score
+}
+
+// Define MatchScore struct to explain why a ride was suggested
+type MatchScore struct {
+	Score           float64     `json:"score"`            // Weighted score between 0 and 1
+	PickupDistance  MatchFactor `json:"pickup_distance"`  // Distance (km) from the pickup to the offer route
+	DropoffDistance MatchFactor `json:"dropoff_distance"` // Distance (km) from the dropoff to the offer route
+	Detour          MatchFactor `json:"detour"`           // Extra distance (km) the driver travels
+	TimeGap         MatchFactor `json:"time_gap"`         // Gap (minutes) between the requested start and the estimated pickup
+	SharedRoute     MatchFactor `json:"shared_route"`     // Fraction of the offer route shared with the hitcher
+	Rating          MatchFactor `json:"rating"`           // Average rating of the suggested user
+	Preferences     MatchFactor `json:"preferences"`      // Share of the soft preferences (no smoking, quiet ride) both users agree on
+}
+
+// Define FareQuoteRequest struct
+type FareQuoteRequest struct {
+	PlaceList []string  `json:"place_list" binding:"required"` // List of places for the route (place_id) from goong api, or favorite:<favorite_location_id>
+	StartTime string    `json:"start_time,omitempty"`          // Start time of the ride (if not provided, the ride is immediate)
+	VehicleID uuid.UUID `json:"vehicle_id,omitempty"`          // Vehicle of the driver (if not provided, the default fuel consumption is used)
+}
+
+// Define FareInput struct holding everything a fare is computed from
+type FareInput struct {
+	Distance     float64   `json:"distance"` // in kilometers
+	Duration     int       `json:"duration"` // in seconds
+	StartTime    time.Time `json:"start_time"`
+	VehicleType  string    `json:"vehicle_type"`
+	FuelType     string    `json:"fuel_type"`     // ron95, e5_ron92, diesel or electric
+	FuelConsumed float64   `json:"fuel_consumed"` // liters (kWh for electric vehicles) per 100 kilometers
+	FuelPrice    float64   `json:"fuel_price"`    // per liter (per kWh for electric vehicles)
+	PerKmRate    float64   `json:"per_km_rate"`   // Rate of the vehicle type (0 if the default rate applies)
+}
+
+// Define FareComponent struct, one step of the fare computation
+type FareComponent struct {
+	Strategy string  `json:"strategy"` // Strategy that applied the step
+	Amount   float64 `json:"amount"`   // Amount the step added to the fare
+}
+
+// Define FareQuote struct to explain how a fare was computed
+type FareQuote struct {
+	Strategy      string          `json:"strategy"` // Strategy that computed the base fare
+	Input         FareInput       `json:"input"`
+	Breakdown     []FareComponent `json:"breakdown"`
+	Fare          float64         `json:"fare"`           // Price paid by the hitcher
+	Commission    float64         `json:"commission"`     // Part of the fare kept by the platform
+	DriverEarning float64         `json:"driver_earning"` // Part of the fare paid out to the driver
+}
+
+// Define BrowseRideOffersRequest struct to search the open ride offers without creating a ride request
+type BrowseRideOffersRequest struct {
+	Origin            Point   `json:"origin"`
+	Destination       Point   `json:"destination"`
+	OriginRadius      float64 `json:"origin_radius,omitempty" validate:"omitempty,gt=0,max=20"`                     // Maximum distance (km) between the origin and the route (default: 2 km)
+	DestinationRadius float64 `json:"destination_radius,omitempty" validate:"omitempty,gt=0,max=20"`                // Maximum distance (km) between the destination and the route (default: 2 km)
+	DepartureFrom     string  `json:"departure_from,omitempty"`                                                     // Earliest start time of the ride offers (default: now)
+	DepartureTo       string  `json:"departure_to,omitempty"`                                                       // Latest start time of the ride offers (default: 24 hours after departure_from)
+	Seats             int     `json:"seats,omitempty" validate:"omitempty,min=1"`                                   // Minimum number of available seats (default: 1)
+	SortBy            string  `json:"sort_by,omitempty" validate:"omitempty,oneof=start_time fare available_seats"` // Sort of the ride offers (default: start_time)
+	Cursor            string  `json:"cursor,omitempty"`                                                             // next_cursor of the previous page, for the same sort
+	Limit             int     `json:"limit,omitempty" validate:"omitempty,min=1,max=50"`                            // Number of ride offers per page (default: 10)
+}
+
+// Define BrowseRideOffersFilter struct, the BrowseRideOffersRequest resolved by the map service
+type BrowseRideOffersFilter struct {
+	Origin            Point
+	Destination       Point
+	OriginRadius      float64 // km
+	DestinationRadius float64 // km
+	DepartureFrom     time.Time
+	DepartureTo       time.Time
+	Seats             int
+	SortBy            string
+	CursorSortValue   float64    // Fare or available seats of the ride offer the cursor points after
+	CursorStartTime   *time.Time // Only the ride offers after this one in the sort are returned
+	CursorRideOfferID uuid.UUID
+	Limit             int
+}
+
+// Define BrowseRideOffersResponse struct
+type BrowseRideOffersResponse struct {
+	RideOffers []RideOfferDetail `json:"ride_offers"`
+	NextCursor string            `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// Define RouteProximity struct
+type RouteProximity struct {
+	PickupDistance  float64 `json:"pickup_distance"`  // Distance (km) from the origin to the closest point of the route
+	DropoffDistance float64 `json:"dropoff_distance"` // Distance (km) from the destination to the closest point of the route after the pickup
 }
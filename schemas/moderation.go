@@ -0,0 +1,97 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define BlockUserRequest struct, to block or unblock a user
+type BlockUserRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define BlockedUserDetail struct
+type BlockedUserDetail struct {
+	User      UserInfo  `json:"user"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+// Define GetBlockedUsersResponse struct
+type GetBlockedUsersResponse struct {
+	BlockedUsers []BlockedUserDetail `json:"blocked_users"`
+}
+
+// Define ReportUserRequest struct, the ride or the chat message the report is about are optional
+type ReportUserRequest struct {
+	UserID      uuid.UUID  `json:"user_id" binding:"required,uuid" validate:"required,uuid"`
+	Category    string     `json:"category" binding:"required" validate:"required,oneof=harassment inappropriate_behavior unsafe_driving fraud no_show other"`
+	Description string     `json:"description" binding:"required" validate:"required,max=2000"`
+	RideID      *uuid.UUID `json:"ride_id,omitempty"` // Ride shared with the reported user
+	ChatID      *uuid.UUID `json:"chat_id,omitempty"` // Message exchanged with the reported user
+}
+
+// Define UserReportDetail struct
+type UserReportDetail struct {
+	ID          uuid.UUID  `json:"report_id"`
+	Status      string     `json:"status"`
+	Category    string     `json:"category"`
+	Description string     `json:"description"`
+	ReporterID  uuid.UUID  `json:"reporter_id"`
+	ReportedID  uuid.UUID  `json:"reported_id"`
+	RideID      *uuid.UUID `json:"ride_id,omitempty"`
+	ChatID      *uuid.UUID `json:"chat_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Define GetUserReportsRequest struct
+type GetUserReportsRequest struct {
+	Status     string    `form:"status" validate:"omitempty,oneof=open reviewing resolved dismissed"` // All the statuses when empty
+	Category   string    `form:"category" validate:"omitempty,oneof=harassment inappropriate_behavior unsafe_driving fraud no_show other"`
+	ReportedID uuid.UUID `form:"reportedID"` // Reports against this user only when set
+	Limit      int       `form:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+// Define UserReportRequest struct, to start reviewing a report
+type UserReportRequest struct {
+	ReportID uuid.UUID `json:"report_id" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define CloseUserReportRequest struct, a dismissed report needs no action, a warned user is notified.
+// There is no action restricting the reported user, the accounts cannot be restricted yet
+type CloseUserReportRequest struct {
+	ReportID uuid.UUID `json:"report_id" binding:"required,uuid" validate:"required,uuid"`
+	Action   string    `json:"action" binding:"required" validate:"required,oneof=dismiss warn"` // dismiss or warn
+	Note     string    `json:"note" binding:"required" validate:"required,max=1000"`             // How the report was handled, kept for the audit
+}
+
+// Define AdminUserReportDetail struct
+type AdminUserReportDetail struct {
+	ID          uuid.UUID  `json:"report_id"`
+	Status      string     `json:"status"`
+	Category    string     `json:"category"`
+	Description string     `json:"description"`
+	Reporter    UserInfo   `json:"reporter"`
+	Reported    UserInfo   `json:"reported"`
+	RideID      *uuid.UUID `json:"ride_id,omitempty"`
+	ChatID      *uuid.UUID `json:"chat_id,omitempty"`
+	ChatMessage string     `json:"chat_message,omitempty"` // Content of the reported chat message
+	Action      string     `json:"action,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	ReviewedBy  *uuid.UUID `json:"reviewed_by,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	ClosedBy    *uuid.UUID `json:"closed_by,omitempty"`
+	AdminNote   string     `json:"admin_note,omitempty"`
+}
+
+// Define GetUserReportsResponse struct
+type GetUserReportsResponse struct {
+	Reports []AdminUserReportDetail `json:"reports"`
+}
+
+// Define UserWarningNotification struct, sent to a user warned after a report
+type UserWarningNotification struct {
+	ReportID uuid.UUID `json:"report_id"`
+	Category string    `json:"category"`
+}
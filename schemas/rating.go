@@ -0,0 +1,51 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define CreateRatingRequest struct, the rated user is the counterpart of the caller in the ride
+type CreateRatingRequest struct {
+	RideID  uuid.UUID `json:"ride_id" binding:"required" validate:"required"`
+	Rating  int       `json:"rating" binding:"required" validate:"required,min=1,max=5"`
+	Comment string    `json:"comment,omitempty" validate:"omitempty,max=1000"`
+	Tags    []string  `json:"tags,omitempty" validate:"omitempty,max=5,unique,dive,oneof=punctual friendly safe_driving clean_vehicle good_communication late rude unsafe_driving dirty_vehicle"`
+}
+
+// Define RatingDetail struct
+type RatingDetail struct {
+	ID        uuid.UUID `json:"rating_id"`
+	RideID    uuid.UUID `json:"ride_id"`
+	Rating    float64   `json:"rating"`
+	Comment   string    `json:"comment"`
+	Tags      []string  `json:"tags"`
+	Rater     UserInfo  `json:"rater"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Define CreateRatingResponse struct
+type CreateRatingResponse struct {
+	Rating        RatingDetail `json:"rating"`
+	RateeID       uuid.UUID    `json:"ratee_id"`
+	AverageRating float64      `json:"average_rating"` // Average of the rated user after this rating
+	RatingCount   int          `json:"rating_count"`
+}
+
+// Define GetRatingsReceivedRequest struct
+type GetRatingsReceivedRequest struct {
+	UserID uuid.UUID `form:"userID"`                                  // The caller by default
+	Page   int       `form:"page" validate:"omitempty,min=1"`         // Page number starting at 1 (default: 1)
+	Limit  int       `form:"limit" validate:"omitempty,min=1,max=50"` // Number of ratings per page (default: 10)
+}
+
+// Define GetRatingsReceivedResponse struct
+type GetRatingsReceivedResponse struct {
+	Ratings       []RatingDetail `json:"ratings"`
+	AverageRating float64        `json:"average_rating"`
+	RatingCount   int            `json:"rating_count"`
+	Page          int            `json:"page"`
+	Limit         int            `json:"limit"`
+	Total         int            `json:"total"` // Number of ratings over all pages
+}
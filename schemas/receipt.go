@@ -0,0 +1,49 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define GetRideReceiptRequest struct
+type GetRideReceiptRequest struct {
+	RideID uuid.UUID `form:"rideID" binding:"required,uuid" validate:"required,uuid"`
+	Format string    `form:"format" validate:"omitempty,oneof=pdf html json"` // pdf by default
+	Lang   string    `form:"lang" validate:"omitempty,oneof=vi en"`           // vi by default
+}
+
+// Define RideReceipt struct, everything printed on the receipt of a completed ride.
+// It is only built from the stored ride, so the same receipt is rendered every time
+type RideReceipt struct {
+	ReceiptNumber  string          `json:"receipt_number"`
+	Lang           string          `json:"lang"`
+	RideID         uuid.UUID       `json:"ride_id"`
+	IssuedAt       time.Time       `json:"issued_at"` // End of the ride
+	StartTime      time.Time       `json:"start_time"`
+	EndTime        time.Time       `json:"end_time"`
+	StartAddress   string          `json:"start_address"`
+	EndAddress     string          `json:"end_address"`
+	PickupAddress  string          `json:"pickup_address"`
+	DropoffAddress string          `json:"dropoff_address"`
+	Distance       float64         `json:"distance"` // in kilometers
+	Duration       int             `json:"duration"` // in seconds
+	Route          []Point         `json:"route"`    // Decoded polyline of the ride
+	FareStrategy   string          `json:"fare_strategy"`
+	FareBreakdown  []FareComponent `json:"fare_breakdown"`
+	Fare           float64         `json:"fare"`
+	AmountPaid     float64         `json:"amount_paid"`
+	PaymentMethod  string          `json:"payment_method"` // cash or momo
+	PaymentStatus  string          `json:"payment_status"`
+	MomoTransID    int64           `json:"momo_trans_id,omitempty"`
+	DriverName     string          `json:"driver_name"`
+	PassengerName  string          `json:"passenger_name"`
+	VehicleName    string          `json:"vehicle_name"`
+	LicensePlate   string          `json:"license_plate"`
+}
+
+// Define RideReceiptNotification struct, sent to the driver and the passenger when a ride is completed
+type RideReceiptNotification struct {
+	RideID     uuid.UUID `json:"ride_id"`
+	ReceiptURL string    `json:"receipt_url"` // PDF receipt, the HTML one is at the same URL with format=html
+}
@@ -0,0 +1,66 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define CreateRecurringRideOfferRequest struct
+type CreateRecurringRideOfferRequest struct {
+	PlaceList  []string  `json:"place_list" binding:"required"`                                       // List of places for the route (place_id) from goong api
+	StartTime  string    `json:"start_time" binding:"required" validate:"required"`                   // First departure of the series (2006-01-02T15:04:05.999999)
+	VehicleID  uuid.UUID `json:"vehicle_id" binding:"required,uuid" validate:"required,uuid"`         // Vehicle ID for the ride that user has registered
+	Seats      int       `json:"seats,omitempty" validate:"omitempty,min=1"`                          // Number of seats offered (defaults to the vehicle seat capacity)
+	Frequency  string    `json:"frequency" binding:"required" validate:"oneof=daily weekdays weekly"` // daily, weekdays (Monday to Friday) or weekly (on days_of_week)
+	DaysOfWeek []int     `json:"days_of_week,omitempty" validate:"omitempty,dive,min=0,max=6"`        // Days of the week for the weekly frequency (0 = Sunday)
+	UntilDate  string    `json:"until_date,omitempty"`                                                // Last day of the series (2006-01-02), empty means no end
+	SkipDates  []string  `json:"skip_dates,omitempty"`                                                // Days on which the ride is not offered (2006-01-02)
+}
+
+// Define UpdateRecurringRideOfferRequest struct
+// Only the provided fields are updated, future occurrences without bookings are republished
+type UpdateRecurringRideOfferRequest struct {
+	RecurringRideOfferID uuid.UUID  `json:"recurring_ride_offer_id" binding:"required,uuid" validate:"required,uuid"`
+	PlaceList            []string   `json:"place_list,omitempty"`                                                 // New route (place_id) from goong api
+	DepartureTime        string     `json:"departure_time,omitempty" validate:"omitempty,datetime=15:04"`         // New time of day of the departure (HH:MM in local time)
+	VehicleID            *uuid.UUID `json:"vehicle_id,omitempty"`                                                 // New vehicle for the ride
+	Seats                int        `json:"seats,omitempty" validate:"omitempty,min=1"`                           // New number of seats offered
+	Frequency            string     `json:"frequency,omitempty" validate:"omitempty,oneof=daily weekdays weekly"` // New frequency
+	DaysOfWeek           []int      `json:"days_of_week,omitempty" validate:"omitempty,dive,min=0,max=6"`         // New days of the week for the weekly frequency
+	UntilDate            *string    `json:"until_date,omitempty"`                                                 // New last day of the series, empty string removes the end
+	SkipDates            []string   `json:"skip_dates,omitempty"`                                                 // Replaces the days on which the ride is not offered
+}
+
+// Define PauseRecurringRideOfferRequest struct
+type PauseRecurringRideOfferRequest struct {
+	RecurringRideOfferID uuid.UUID `json:"recurring_ride_offer_id" binding:"required,uuid" validate:"required,uuid"`
+	IsPaused             bool      `json:"is_paused"` // true to pause the series, false to resume it
+}
+
+// Define SkipRecurringRideOccurrenceRequest struct
+type SkipRecurringRideOccurrenceRequest struct {
+	RecurringRideOfferID uuid.UUID `json:"recurring_ride_offer_id" binding:"required,uuid" validate:"required,uuid"`
+	Date                 string    `json:"date" binding:"required" validate:"required,datetime=2006-01-02"` // Day of the occurrence to cancel
+}
+
+// Define RecurringRideOfferDetail struct
+type RecurringRideOfferDetail struct {
+	ID            uuid.UUID  `json:"recurring_ride_offer_id"`
+	VehicleID     uuid.UUID  `json:"vehicle_id"`
+	Seats         int        `json:"seats"`
+	StartAddress  string     `json:"start_address"`
+	EndAddress    string     `json:"end_address"`
+	DepartureTime string     `json:"departure_time"`
+	Frequency     string     `json:"frequency"`
+	DaysOfWeek    []int      `json:"days_of_week"`
+	StartDate     time.Time  `json:"start_date"`
+	UntilDate     *time.Time `json:"until_date,omitempty"`
+	SkipDates     []string   `json:"skip_dates"`
+	IsPaused      bool       `json:"is_paused"`
+}
+
+// Define GetRecurringRideOffersResponse struct
+type GetRecurringRideOffersResponse struct {
+	RecurringRideOffers []RecurringRideOfferDetail `json:"recurring_ride_offers"`
+}
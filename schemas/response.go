@@ -7,18 +7,21 @@ import (
 )
 
 type UserResponse struct {
-	ID           uuid.UUID `json:"id" binding:"required"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	PhoneNumber  string    `json:"phone_number"`
-	Email        string    `json:"email,omitempty"`
-	AvatarURL    string    `json:"avatar_url"`
-	FullName     string    `json:"full_name"`
-	IsVerified   bool      `json:"is_verified"`
-	IsActivated  bool      `json:"is_activated"`
-	IsMomoLinked bool      `json:"is_momo_linked"`
-	Role         string    `json:"role"`
-	Gender       string    `json:"gender"`
+	ID              uuid.UUID       `json:"id" binding:"required"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	PhoneNumber     string          `json:"phone_number"`
+	Email           string          `json:"email,omitempty"`
+	AvatarURL       string          `json:"avatar_url"`
+	FullName        string          `json:"full_name"`
+	IsVerified      bool            `json:"is_verified"`
+	IsActivated     bool            `json:"is_activated"`
+	IsMomoLinked    bool            `json:"is_momo_linked"`
+	Role            string          `json:"role"`
+	Gender          string          `json:"gender"`
+	AverageRating   float64         `json:"average_rating"`
+	RatingCount     int             `json:"rating_count"`
+	RidePreferences RidePreferences `json:"ride_preferences"` // Defaults for the new ride offers and ride requests
 }
 
 type AdminResponse struct {
@@ -119,9 +122,13 @@ type ChatResponse struct {
 type FavoriteLocationResponse struct {
 	ID        uuid.UUID `json:"id"`
 	CreatedAt time.Time `json:"created_at"`
+	Type      string    `json:"type"`
 	Name      string    `json:"name"`
+	PlaceID   string    `json:"place_id"`
+	Address   string    `json:"address"`
 	Latitude  float64   `json:"latitude"`
 	Longitude float64   `json:"longitude"`
+	Position  int       `json:"position"`
 }
 
 type FuelPriceResponse struct {
@@ -8,6 +8,21 @@ import (
 
 // There is some duplicate schema but it is for easier for maintain and clean code
 
+// Define MeetingPoint schema
+// A point on the driver's route where the hitcher is picked up or dropped off
+type MeetingPoint struct {
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	Address         string  `json:"address"`
+	WalkingDistance float64 `json:"walking_distance"` // Walking distance of the hitcher (in kilometers)
+}
+
+// Define MeetingPoints schema
+type MeetingPoints struct {
+	Pickup  MeetingPoint `json:"pickup"`
+	Dropoff MeetingPoint `json:"dropoff"`
+}
+
 // Define SendGiveRideRequestRequest schema
 type SendGiveRideRequestRequest struct {
 	// The ID of the ride offer (current user is the driver)
@@ -22,27 +37,30 @@ type SendGiveRideRequestRequest struct {
 // Define SendGiveRideRequestResponse schema
 type SendGiveRideRequestResponse struct {
 	// This will act as data send through websocket to the receiver to able preview the request before accepting or rejecting
-	ID                     uuid.UUID     `json:"ride_offer_id"`
-	User                   UserInfo      `json:"user"`
-	Vehicle                VehicleDetail `json:"vehicle"`
-	StartLatitude          float64       `json:"start_latitude"`
-	StartLongitude         float64       `json:"start_longitude"`
-	EndLatitude            float64       `json:"end_latitude"`
-	EndLongitude           float64       `json:"end_longitude"`
-	StartAddress           string        `json:"start_address"`
-	EndAddress             string        `json:"end_address"`
-	EncodedPolyline        string        `json:"encoded_polyline"`
-	Distance               float64       `json:"distance"`
-	Duration               int           `json:"duration"`
-	DriverCurrentLatitude  float64       `json:"driver_current_latitude"`
-	DriverCurrentLongitude float64       `json:"driver_current_longitude"`
-	StartTime              time.Time     `json:"start_time"`
-	EndTime                time.Time     `json:"end_time"`
-	Status                 string        `json:"status"`
-	Fare                   float64       `json:"fare"`
-	ReceiverID             uuid.UUID     `json:"receiver_id"`
-	RideRequestID          uuid.UUID     `json:"ride_request_id"`
-	Waypoints              []Waypoint    `json:"waypoints"`
+	ID                     uuid.UUID       `json:"ride_offer_id"`
+	User                   UserInfo        `json:"user"`
+	Vehicle                VehicleDetail   `json:"vehicle"`
+	StartLatitude          float64         `json:"start_latitude"`
+	StartLongitude         float64         `json:"start_longitude"`
+	EndLatitude            float64         `json:"end_latitude"`
+	EndLongitude           float64         `json:"end_longitude"`
+	StartAddress           string          `json:"start_address"`
+	EndAddress             string          `json:"end_address"`
+	EncodedPolyline        string          `json:"encoded_polyline"`
+	Distance               float64         `json:"distance"`
+	Duration               int             `json:"duration"`
+	DriverCurrentLatitude  float64         `json:"driver_current_latitude"`
+	DriverCurrentLongitude float64         `json:"driver_current_longitude"`
+	StartTime              time.Time       `json:"start_time"`
+	EndTime                time.Time       `json:"end_time"`
+	Status                 string          `json:"status"`
+	Fare                   float64         `json:"fare"`
+	AvailableSeats         int             `json:"available_seats"`
+	ReceiverID             uuid.UUID       `json:"receiver_id"`
+	RideRequestID          uuid.UUID       `json:"ride_request_id"`
+	Waypoints              []Waypoint      `json:"waypoints"`
+	MeetingPoints          MeetingPoints   `json:"meeting_points"` // Suggested pickup and dropoff points on the route
+	Preferences            RidePreferences `json:"preferences"`    // Preferences of the ride offer
 }
 
 // Define SendHitchRideRequestRequest schema
@@ -59,25 +77,27 @@ type SendHitchRideRequestRequest struct {
 // Define SendHitchRideRequestResponse schema
 type SendHitchRideRequestResponse struct {
 	// This will act as data send through websocket to the receiver to able preview the request before accepting or rejecting
-	ID                    uuid.UUID     `json:"ride_request_id"`
-	User                  UserInfo      `json:"user"`
-	Vehicle               VehicleDetail `json:"vehicle"`
-	StartLatitude         float64       `json:"start_latitude"`
-	StartLongitude        float64       `json:"start_longitude"`
-	EndLatitude           float64       `json:"end_latitude"`
-	EndLongitude          float64       `json:"end_longitude"`
-	RiderCurrentLatitude  float64       `json:"rider_current_latitude"`
-	RiderCurrentLongitude float64       `json:"rider_current_longitude"`
-	StartAddress          string        `json:"start_address"`
-	EndAddress            string        `json:"end_address"`
-	Status                string        `json:"status"`
-	EncodedPolyline       string        `json:"encoded_polyline"`
-	Distance              float64       `json:"distance"`
-	Duration              int           `json:"duration"`
-	StartTime             time.Time     `json:"start_time"`
-	EndTime               time.Time     `json:"end_time"`
-	ReceiverID            uuid.UUID     `json:"receiver_id"`
-	RideOfferID           uuid.UUID     `json:"ride_offer_id"`
+	ID                    uuid.UUID       `json:"ride_request_id"`
+	User                  UserInfo        `json:"user"`
+	Vehicle               VehicleDetail   `json:"vehicle"`
+	StartLatitude         float64         `json:"start_latitude"`
+	StartLongitude        float64         `json:"start_longitude"`
+	EndLatitude           float64         `json:"end_latitude"`
+	EndLongitude          float64         `json:"end_longitude"`
+	RiderCurrentLatitude  float64         `json:"rider_current_latitude"`
+	RiderCurrentLongitude float64         `json:"rider_current_longitude"`
+	StartAddress          string          `json:"start_address"`
+	EndAddress            string          `json:"end_address"`
+	Status                string          `json:"status"`
+	EncodedPolyline       string          `json:"encoded_polyline"`
+	Distance              float64         `json:"distance"`
+	Duration              int             `json:"duration"`
+	StartTime             time.Time       `json:"start_time"`
+	EndTime               time.Time       `json:"end_time"`
+	ReceiverID            uuid.UUID       `json:"receiver_id"`
+	RideOfferID           uuid.UUID       `json:"ride_offer_id"`
+	MeetingPoints         MeetingPoints   `json:"meeting_points"` // Suggested pickup and dropoff points on the route
+	Preferences           RidePreferences `json:"preferences"`    // Preferences of the ride request
 }
 
 // Define AcceptRideGiveRequestRequest schema
@@ -128,6 +148,7 @@ type AcceptGiveRideRequestResponse struct {
 	UserInfo               UserInfo          `json:"user"`
 	ReceiverID             uuid.UUID         `json:"receiver_id"`
 	Waypoints              []Waypoint        `json:"waypoints"`
+	MeetingPoints          MeetingPoints     `json:"meeting_points"` // Agreed pickup and dropoff points on the route
 }
 
 // Define AcceptHitchRideRequestRequest schema
@@ -171,6 +192,7 @@ type AcceptHitchRideRequestResponse struct {
 	RiderCurrentLatitude   float64           `json:"rider_current_latitude"`
 	RiderCurrentLongitude  float64           `json:"rider_current_longitude"`
 	Waypoints              []Waypoint        `json:"waypoints"`
+	MeetingPoints          MeetingPoints     `json:"meeting_points"` // Agreed pickup and dropoff points on the route
 }
 
 type CancelGiveRideRequestRequest struct {
@@ -215,6 +237,22 @@ type StartRideRequest struct {
 	// Current user location
 	CurrentLocation Point     `json:"currentLocation" binding:"required" validate:"required"`
 	VehicleID       uuid.UUID `json:"vehicleID,omitempty" binding:"omitempty,uuid" validate:"omitempty,uuid"`
+	// PIN or QR payload shown by the hitcher, optional in ride test mode
+	PickupCode string `json:"pickupCode,omitempty" validate:"omitempty,max=256"`
+}
+
+// Define IssuePickupCodeRequest schema
+type IssuePickupCodeRequest struct {
+	// Ride ID of the ride to issue a pickup code for
+	RideID uuid.UUID `json:"rideID" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define IssuePickupCodeResponse schema
+type IssuePickupCodeResponse struct {
+	RideID    uuid.UUID `json:"ride_id"`
+	PIN       string    `json:"pin"`        // Shown to the driver to type in
+	QRPayload string    `json:"qr_payload"` // Shown to the driver as a QR code to scan
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 // Define StartRideResponse schema
@@ -244,6 +282,8 @@ type StartRideResponse struct {
 	RiderCurrentLatitude   float64           `json:"rider_current_latitude"`
 	RiderCurrentLongitude  float64           `json:"rider_current_longitude"`
 	Waypoints              []Waypoint        `json:"waypoints"`
+	PassengerVerifiedAt    *time.Time        `json:"passenger_verified_at,omitempty"`
+	PassengerVerification  string            `json:"passenger_verification"` // pin, qr or test_mode
 }
 
 // Define EndRideRequest schema
@@ -291,6 +331,30 @@ type UpdateRideLocationRequest struct {
 	// Current driver location
 	CurrentLocation Point     `json:"currentLocation" binding:"required" validate:"required"`
 	VehicleID       uuid.UUID `json:"vehicleID,omitempty" binding:"omitempty,uuid" validate:"omitempty,uuid"`
+	// When the location was recorded by the device, the time of the request when empty
+	RecordedAt *time.Time `json:"recordedAt,omitempty"`
+	// Accuracy of the location in meters, as reported by the device
+	Accuracy *float64 `json:"accuracy,omitempty" validate:"omitempty,min=0"`
+	// Speed in meters per second, as reported by the device
+	Speed *float64 `json:"speed,omitempty" validate:"omitempty,min=0"`
+	// Heading in degrees clockwise from the north, as reported by the device
+	Heading *float64 `json:"heading,omitempty" validate:"omitempty,min=0,max=360"`
+}
+
+// Define UpdateRiderLocationRequest schema
+type UpdateRiderLocationRequest struct {
+	// Ride ID of the ride to update the location of the passenger
+	RideID uuid.UUID `json:"rideID" binding:"required,uuid" validate:"required,uuid"`
+	// Current passenger location
+	CurrentLocation Point `json:"currentLocation" binding:"required" validate:"required"`
+}
+
+// Define UpdateRiderLocationResponse schema
+type UpdateRiderLocationResponse struct {
+	ID                    uuid.UUID `json:"ride_id"`
+	Status                string    `json:"status"`
+	RiderCurrentLatitude  float64   `json:"rider_current_latitude"`
+	RiderCurrentLongitude float64   `json:"rider_current_longitude"`
 }
 
 // Define UpdateRideLocationResponse schema
@@ -328,6 +392,8 @@ type CancelRideRequest struct {
 	// The receiver id (the hitcher) who received the cancel request
 	ReceiverID uuid.UUID `json:"receiverID" binding:"required,uuid" validate:"required,uuid"`
 	VehicleID  uuid.UUID `json:"vehicleID,omitempty" binding:"omitempty,uuid" validate:"omitempty,uuid"`
+	// Why the ride is cancelled, kept in the ride status history
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=500"`
 }
 
 type CancelRideResponse struct {
@@ -352,3 +418,165 @@ type GetAllPendingRideResponse struct {
 	// The pending ride offer of the user
 	PendingRideOffer []RideOfferDetail `json:"pending_ride_offer"`
 }
+
+// Define RideNoShowResponse schema
+// Sent to the driver and the hitcher when a scheduled ride was not started in time
+type RideNoShowResponse struct {
+	RideID        uuid.UUID `json:"ride_id"`
+	RideOfferID   uuid.UUID `json:"ride_offer_id"`
+	RideRequestID uuid.UUID `json:"ride_request_id"`
+	StartTime     time.Time `json:"start_time"`
+}
+
+// Define RideOfferExpiredResponse schema
+// Sent to the driver when nobody booked the ride offer before its start time
+type RideOfferExpiredResponse struct {
+	RideOfferID  uuid.UUID `json:"ride_offer_id"`
+	StartAddress string    `json:"start_address"`
+	EndAddress   string    `json:"end_address"`
+	StartTime    time.Time `json:"start_time"`
+}
+
+// Define RideRequestExpiredResponse schema
+// Sent to the hitcher when no driver matched the ride request before its start time
+type RideRequestExpiredResponse struct {
+	RideRequestID uuid.UUID `json:"ride_request_id"`
+	StartAddress  string    `json:"start_address"`
+	EndAddress    string    `json:"end_address"`
+	StartTime     time.Time `json:"start_time"`
+}
+
+// Define GetRideTrailRequest schema
+type GetRideTrailRequest struct {
+	// Ride ID of the ride to export the trail of
+	RideID string `form:"rideID" binding:"required,uuid" validate:"required,uuid"`
+	// Format of the export, geojson (default) or gpx
+	Format string `form:"format" validate:"omitempty,oneof=geojson gpx"`
+}
+
+// Define RideTrailFeatureCollection schema, the GeoJSON export of the trail of a ride
+type RideTrailFeatureCollection struct {
+	Type     string             `json:"type"` // Always FeatureCollection
+	Features []RideTrailFeature `json:"features"`
+}
+
+// Define RideTrailFeature schema
+type RideTrailFeature struct {
+	Type       string                     `json:"type"` // Always Feature
+	Geometry   RideTrailGeometry          `json:"geometry"`
+	Properties RideTrailFeatureProperties `json:"properties"`
+}
+
+// Define RideTrailGeometry schema
+type RideTrailGeometry struct {
+	Type        string       `json:"type"`        // Always LineString
+	Coordinates [][2]float64 `json:"coordinates"` // [longitude, latitude] of each sample
+}
+
+// Define RideTrailFeatureProperties schema, the arrays are in the order of the coordinates
+type RideTrailFeatureProperties struct {
+	RideID          uuid.UUID   `json:"ride_id"`
+	StartTime       *time.Time  `json:"start_time,omitempty"`
+	EndTime         *time.Time  `json:"end_time,omitempty"`
+	Distance        float64     `json:"distance"` // Driven distance in kilometers
+	CoordinateTimes []time.Time `json:"coordinate_times"`
+	Accuracies      []*float64  `json:"accuracies"` // in meters
+	Speeds          []*float64  `json:"speeds"`     // in meters per second
+	Headings        []*float64  `json:"headings"`   // in degrees
+}
+
+// Define RideETAUpdate schema, sent to the hitcher with the location updates of the driver
+type RideETAUpdate struct {
+	RideID          uuid.UUID  `json:"ride_id"`
+	Status          string     `json:"status"`
+	DriverLocation  Point      `json:"driver_location"`
+	OffRoute        bool       `json:"off_route"`                 // The driver has left the planned route
+	Source          string     `json:"source"`                    // route when estimated along the planned route, provider when asked to Goong
+	PickupDistance  *float64   `json:"pickup_distance,omitempty"` // in kilometers, only before the pickup
+	PickupDuration  *int       `json:"pickup_duration,omitempty"` // in seconds, only before the pickup
+	PickupETA       *time.Time `json:"pickup_eta,omitempty"`
+	DropoffDistance float64    `json:"dropoff_distance"` // in kilometers
+	DropoffDuration int        `json:"dropoff_duration"` // in seconds
+	DropoffETA      time.Time  `json:"dropoff_eta"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Define RideDeviationAlert schema, sent to the hitcher when the driver leaves the route or stops for long
+type RideDeviationAlert struct {
+	RideID         uuid.UUID `json:"ride_id"`
+	Type           string    `json:"type"` // off_route or long_stop
+	DriverLocation Point     `json:"driver_location"`
+	Distance       float64   `json:"distance"` // Distance from the planned route in meters
+	StartedAt      time.Time `json:"started_at"`
+}
+
+// Define GetRideHistoryRequest schema
+type GetRideHistoryRequest struct {
+	Role   string `form:"role" validate:"omitempty,oneof=driver passenger"`                                // Both roles when empty
+	Status string `form:"status" validate:"omitempty,oneof=scheduled ongoing completed cancelled no_show"` // All the statuses when empty
+	From   string `form:"from" validate:"omitempty,datetime=2006-01-02"`                                   // First day of the start time, inclusive
+	To     string `form:"to" validate:"omitempty,datetime=2006-01-02"`                                     // Last day of the start time, inclusive
+	Cursor string `form:"cursor"`                                                                          // next_cursor of the previous page
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// Define RideHistoryFilter schema, the GetRideHistoryRequest resolved by the ride service
+type RideHistoryFilter struct {
+	Role            string
+	Status          string
+	From            *time.Time
+	To              *time.Time // exclusive
+	CursorStartTime *time.Time // Only the rides after this one in the history are returned
+	CursorRideID    uuid.UUID
+	Limit           int
+}
+
+// Define RideHistoryItem schema
+type RideHistoryItem struct {
+	RideID         uuid.UUID          `json:"ride_id"`
+	Role           string             `json:"role"` // driver or passenger
+	Status         string             `json:"status"`
+	StartTime      time.Time          `json:"start_time"`
+	EndTime        time.Time          `json:"end_time"`
+	StartAddress   string             `json:"start_address"`
+	EndAddress     string             `json:"end_address"`
+	PickupAddress  string             `json:"pickup_address"`
+	DropoffAddress string             `json:"dropoff_address"`
+	Distance       float64            `json:"distance"` // Distance shared with the passenger in kilometers
+	Duration       int                `json:"duration"` // in seconds
+	Fare           float64            `json:"fare"`
+	Counterpart    UserInfo           `json:"counterpart"` // The passenger for the driver, the driver for the passenger
+	Vehicle        VehicleDetail      `json:"vehicle"`
+	Transaction    *TransactionDetail `json:"transaction,omitempty"`
+}
+
+// Define GetRideHistoryResponse schema
+type GetRideHistoryResponse struct {
+	Rides      []RideHistoryItem `json:"rides"`
+	NextCursor string            `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// Define GetRideHistorySummaryRequest schema
+type GetRideHistorySummaryRequest struct {
+	From string `form:"from" validate:"omitempty,datetime=2006-01"` // First month, 11 months before the last one when empty
+	To   string `form:"to" validate:"omitempty,datetime=2006-01"`   // Last month, the current one when empty
+}
+
+// Define RideHistoryMonth schema, totals of the completed rides started in a month
+type RideHistoryMonth struct {
+	Month            string  `json:"month"` // YYYY-MM
+	Trips            int     `json:"trips"`
+	TripsAsDriver    int     `json:"trips_as_driver"`
+	TripsAsPassenger int     `json:"trips_as_passenger"`
+	KmShared         float64 `json:"km_shared"` // Kilometers travelled with the other party
+	Earned           float64 `json:"earned"`    // Completed payments received as the driver
+	Spent            float64 `json:"spent"`     // Completed payments made as the passenger
+}
+
+// Define GetRideHistorySummaryResponse schema
+type GetRideHistorySummaryResponse struct {
+	From   string             `json:"from"`
+	To     string             `json:"to"`
+	Months []RideHistoryMonth `json:"months"` // Newest first, only the months with rides
+	Total  RideHistoryMonth   `json:"total"`  // Month is empty
+}
@@ -0,0 +1,76 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define CreateRouteAlertRequest struct
+type CreateRouteAlertRequest struct {
+	Name               string `json:"name,omitempty" validate:"omitempty,max=100"`                  // Name given by the user, e.g. "Home to work"
+	Origin             Point  `json:"origin"`                                                       // Where the user wants to be picked up
+	OriginAddress      string `json:"origin_address,omitempty"`                                     // Address of the origin, shown in the notification
+	Destination        Point  `json:"destination"`                                                  // Where the user wants to be dropped off
+	DestinationAddress string `json:"destination_address,omitempty"`                                // Address of the destination, shown in the notification
+	DaysOfWeek         []int  `json:"days_of_week,omitempty" validate:"omitempty,dive,min=0,max=6"` // Days of the week of the departure (0 = Sunday), empty means every day
+	WindowStart        string `json:"window_start" binding:"required" validate:"datetime=15:04"`    // Earliest departure time of the day (HH:MM in local time)
+	WindowEnd          string `json:"window_end" binding:"required" validate:"datetime=15:04"`      // Latest departure time of the day (HH:MM in local time), before window_start for a window past midnight
+}
+
+// Define UpdateRouteAlertRequest struct
+// Only the provided fields are updated
+type UpdateRouteAlertRequest struct {
+	RouteAlertID       uuid.UUID `json:"route_alert_id" binding:"required,uuid" validate:"required,uuid"`
+	Name               *string   `json:"name,omitempty" validate:"omitempty,max=100"`                  // New name of the alert
+	Origin             *Point    `json:"origin,omitempty"`                                             // New origin
+	OriginAddress      *string   `json:"origin_address,omitempty"`                                     // New address of the origin
+	Destination        *Point    `json:"destination,omitempty"`                                        // New destination
+	DestinationAddress *string   `json:"destination_address,omitempty"`                                // New address of the destination
+	DaysOfWeek         []int     `json:"days_of_week,omitempty" validate:"omitempty,dive,min=0,max=6"` // New days of the week of the departure
+	WindowStart        string    `json:"window_start,omitempty" validate:"omitempty,datetime=15:04"`   // New earliest departure time of the day
+	WindowEnd          string    `json:"window_end,omitempty" validate:"omitempty,datetime=15:04"`     // New latest departure time of the day
+	IsActive           *bool     `json:"is_active,omitempty"`                                          // false to stop the notifications without deleting the alert
+}
+
+// Define DeleteRouteAlertRequest struct
+type DeleteRouteAlertRequest struct {
+	RouteAlertID uuid.UUID `json:"route_alert_id" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define RouteAlertDetail struct
+type RouteAlertDetail struct {
+	ID                 uuid.UUID  `json:"route_alert_id"`
+	Name               string     `json:"name"`
+	Origin             Point      `json:"origin"`
+	OriginAddress      string     `json:"origin_address"`
+	Destination        Point      `json:"destination"`
+	DestinationAddress string     `json:"destination_address"`
+	DaysOfWeek         []int      `json:"days_of_week"`
+	WindowStart        string     `json:"window_start"`
+	WindowEnd          string     `json:"window_end"`
+	IsActive           bool       `json:"is_active"`
+	LastNotifiedAt     *time.Time `json:"last_notified_at,omitempty"`
+}
+
+// Define GetRouteAlertsResponse struct
+type GetRouteAlertsResponse struct {
+	RouteAlerts []RouteAlertDetail `json:"route_alerts"`
+}
+
+// Define RouteAlertMatchPayload struct, the payload of the task matching a new ride offer against the route alerts
+type RouteAlertMatchPayload struct {
+	RideOfferID uuid.UUID `json:"ride_offer_id"`
+}
+
+// Define RouteAlertMatchResponse struct, sent to the users whose route alert matches a new ride offer
+type RouteAlertMatchResponse struct {
+	RouteAlertIDs  []uuid.UUID `json:"route_alert_ids"`
+	RideOfferID    uuid.UUID   `json:"ride_offer_id"`
+	DeepLink       string      `json:"deep_link"`
+	StartAddress   string      `json:"start_address"`
+	EndAddress     string      `json:"end_address"`
+	StartTime      time.Time   `json:"start_time"`
+	Fare           float64     `json:"fare"`
+	AvailableSeats int         `json:"available_seats"`
+}
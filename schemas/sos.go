@@ -0,0 +1,122 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define CreateEmergencyContactRequest struct
+type CreateEmergencyContactRequest struct {
+	FullName     string `json:"full_name" binding:"required" validate:"required,max=100"`
+	PhoneNumber  string `json:"phone_number" binding:"required,e164" validate:"required,e164"` // An OTP is sent to this number to verify it
+	Relationship string `json:"relationship,omitempty" validate:"omitempty,max=50"`            // e.g. parent, partner, friend
+}
+
+// Define EmergencyContactRequest struct, to resend the OTP or to delete an emergency contact
+type EmergencyContactRequest struct {
+	EmergencyContactID uuid.UUID `json:"emergency_contact_id" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define VerifyEmergencyContactRequest struct
+type VerifyEmergencyContactRequest struct {
+	EmergencyContactID uuid.UUID `json:"emergency_contact_id" binding:"required,uuid" validate:"required,uuid"`
+	OTP                string    `json:"otp" binding:"required,numeric,min=6,max=6" validate:"required,numeric,min=6,max=6"`
+}
+
+// Define EmergencyContactDetail struct
+type EmergencyContactDetail struct {
+	ID           uuid.UUID  `json:"emergency_contact_id"`
+	FullName     string     `json:"full_name"`
+	PhoneNumber  string     `json:"phone_number"`
+	Relationship string     `json:"relationship"`
+	IsVerified   bool       `json:"is_verified"` // Only the verified contacts are alerted
+	VerifiedAt   *time.Time `json:"verified_at,omitempty"`
+}
+
+// Define GetEmergencyContactsResponse struct
+type GetEmergencyContactsResponse struct {
+	EmergencyContacts []EmergencyContactDetail `json:"emergency_contacts"`
+}
+
+// Define TriggerSOSRequest struct
+type TriggerSOSRequest struct {
+	RideID          uuid.UUID `json:"ride_id" binding:"required,uuid" validate:"required,uuid"`
+	CurrentLocation Point     `json:"current_location"`                                // Location of the user when raising the SOS
+	Message         string    `json:"message,omitempty" validate:"omitempty,max=1000"` // What happens, if the user can tell
+}
+
+// Define TriggerSOSResponse struct
+type TriggerSOSResponse struct {
+	SOSEventID      uuid.UUID `json:"sos_event_id"`
+	Status          string    `json:"status"`
+	ContactsAlerted int       `json:"contacts_alerted"`
+	TripShareURL    string    `json:"trip_share_url"` // Public link to the live trip sent to the emergency contacts
+	TripShareExpiry time.Time `json:"trip_share_expiry"`
+}
+
+// Define SOSRideSnapshot struct, the state of the ride frozen when the SOS was raised
+type SOSRideSnapshot struct {
+	RideID         uuid.UUID     `json:"ride_id"`
+	Status         string        `json:"status"`
+	RideOfferID    uuid.UUID     `json:"ride_offer_id"`
+	RideRequestID  uuid.UUID     `json:"ride_request_id"`
+	DriverID       uuid.UUID     `json:"driver_id"`
+	HitcherID      uuid.UUID     `json:"hitcher_id"`
+	VehicleID      uuid.UUID     `json:"vehicle_id"`
+	StartAddress   string        `json:"start_address"`
+	EndAddress     string        `json:"end_address"`
+	StartTime      time.Time     `json:"start_time"`
+	DriverLocation Point         `json:"driver_location"`
+	LocationAt     *time.Time    `json:"location_at,omitempty"` // When the driver location was reported
+	LastPositions  []SOSPosition `json:"last_positions"`
+	TakenAt        time.Time     `json:"taken_at"`
+}
+
+// Define SOSPosition struct
+type SOSPosition struct {
+	Point
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// Define GetSOSEventsRequest struct
+type GetSOSEventsRequest struct {
+	Status string `form:"status" validate:"omitempty,oneof=open acknowledged resolved"` // All the statuses when empty
+	Limit  int    `form:"limit" validate:"omitempty,min=1,max=200"`
+}
+
+// Define SOSEventRequest struct, to acknowledge an SOS event
+type SOSEventRequest struct {
+	SOSEventID uuid.UUID `json:"sos_event_id" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define ResolveSOSEventRequest struct
+type ResolveSOSEventRequest struct {
+	SOSEventID uuid.UUID `json:"sos_event_id" binding:"required,uuid" validate:"required,uuid"`
+	Note       string    `json:"note" binding:"required" validate:"required,max=1000"` // How the emergency was handled, kept for the audit
+}
+
+// Define SOSEventDetail struct, also published to the admin dashboards on every change
+type SOSEventDetail struct {
+	ID              uuid.UUID              `json:"sos_event_id"`
+	Status          string                 `json:"status"`
+	UserID          uuid.UUID              `json:"user_id"`
+	UserFullName    string                 `json:"user_full_name"`
+	UserPhoneNumber string                 `json:"user_phone_number"`
+	RideID          uuid.UUID              `json:"ride_id"`
+	Location        Point                  `json:"location"`
+	Message         string                 `json:"message"`
+	RideSnapshot    map[string]interface{} `json:"ride_snapshot" swaggertype:"object"`
+	ContactsAlerted int                    `json:"contacts_alerted"`
+	CreatedAt       time.Time              `json:"created_at"`
+	AcknowledgedAt  *time.Time             `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy  *uuid.UUID             `json:"acknowledged_by,omitempty"`
+	ResolvedAt      *time.Time             `json:"resolved_at,omitempty"`
+	ResolvedBy      *uuid.UUID             `json:"resolved_by,omitempty"`
+	ResolutionNote  string                 `json:"resolution_note,omitempty"`
+}
+
+// Define GetSOSEventsResponse struct
+type GetSOSEventsResponse struct {
+	Events []SOSEventDetail `json:"events"`
+}
@@ -0,0 +1,72 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Define ShareTripRequest struct
+type ShareTripRequest struct {
+	RideID    uuid.UUID `json:"ride_id" binding:"required,uuid" validate:"required,uuid"`
+	ExpiresIn int       `json:"expires_in,omitempty" validate:"omitempty,min=1"` // in minutes, TRIP_SHARE_TTL by default and at most TRIP_SHARE_MAX_TTL
+}
+
+// Define ShareTripResponse struct
+type ShareTripResponse struct {
+	TripShareID  uuid.UUID `json:"trip_share_id"`
+	TripShareURL string    `json:"trip_share_url"` // Public link, the token cannot be retrieved again
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Define RevokeTripShareRequest struct
+type RevokeTripShareRequest struct {
+	TripShareID uuid.UUID `json:"trip_share_id" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define GetTripSharesRequest struct
+type GetTripSharesRequest struct {
+	RideID string `form:"rideID" binding:"required,uuid" validate:"required,uuid"`
+}
+
+// Define TripShareDetail struct
+type TripShareDetail struct {
+	ID        uuid.UUID  `json:"trip_share_id"`
+	RideID    uuid.UUID  `json:"ride_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	IsSOS     bool       `json:"is_sos"` // Sent to the emergency contacts when an SOS was raised
+}
+
+// Define GetTripSharesResponse struct
+type GetTripSharesResponse struct {
+	TripShares []TripShareDetail `json:"trip_shares"`
+}
+
+// Define GetSharedTripRequest struct
+type GetSharedTripRequest struct {
+	Token string `form:"token" binding:"required" validate:"required"` // Token of the public link
+}
+
+// Define SharedTripResponse struct, what anyone with the link can see of a ride
+type SharedTripResponse struct {
+	RideID         uuid.UUID  `json:"ride_id"`
+	Status         string     `json:"status"`
+	StartAddress   string     `json:"start_address"`
+	EndAddress     string     `json:"end_address"`
+	StartTime      time.Time  `json:"start_time"`
+	DriverLocation Point      `json:"driver_location"`
+	LocationAt     *time.Time `json:"location_at,omitempty"` // When the driver location was reported
+	ExpiresAt      time.Time  `json:"expires_at"`            // When the link stops working
+	// Planned route of the driver
+	EncodedPolyline string         `json:"encoded_polyline"`
+	LicensePlate    string         `json:"license_plate"`
+	VehicleName     string         `json:"vehicle_name"`
+	ETA             *RideETAUpdate `json:"eta,omitempty"` // Remaining distance and time to the pickup and the dropoff
+}
+
+// Define SharedTripEnd struct, the last event of the stream of a shared trip
+type SharedTripEnd struct {
+	Reason string `json:"reason"` // ended when the ride is over, unavailable when the link expired or was revoked
+}
@@ -3,7 +3,8 @@ package schemas
 import "mime/multipart"
 
 type GetUserProfileResponse struct {
-	User UserResponse `json:"user" binding:"required"`
+	User   UserResponse  `json:"user" binding:"required"`
+	Impact *ImpactDetail `json:"impact,omitempty"` // What the completed rides of the user saved
 }
 
 // Define RegisterDeviceTokenRequest struct
@@ -22,6 +23,11 @@ type UpdateUserProfileResponse struct {
 	User UserResponse `json:"user" binding:"required"`
 }
 
+// Define UpdateRidePreferencesResponse struct
+type UpdateRidePreferencesResponse struct {
+	User UserResponse `json:"user" binding:"required"`
+}
+
 // Define UpdateAvatarRequest struct
 type UpdateAvatarRequest struct {
 	AvatarImage *multipart.FileHeader `form:"avatar_image" binding:"required" validate:"required"`
@@ -20,7 +20,8 @@ type GetVehiclesResponse struct {
 type Vehicle struct {
 	VehicleID    uuid.UUID `json:"vehicle_id" binding:"required"`
 	Name         string    `json:"name"`
-	FuelConsumed float64   `json:"fuel_consumed"`
+	FuelType     string    `json:"fuel_type"`     // ron95, e5_ron92, diesel or electric
+	FuelConsumed float64   `json:"fuel_consumed"` // liters (kWh for electric vehicles) per 100 kilometers
 }
 
 // Define the RegisterVehicleRequest schema
@@ -29,14 +30,18 @@ type RegisterVehicleRequest struct {
 	UserID       uuid.UUID `json:"user_id" binding:"required,uuid" validate:"required,uuid"`
 	LicensePlate string    `json:"license_plate" binding:"required" validate:"required"`
 	CaVet        string    `json:"ca_vet" binding:"required" validate:"required"`
+	SeatCapacity int       `json:"seat_capacity,omitempty" validate:"omitempty,min=1,max=8"`             // Number of passenger seats (defaults to 1)
+	FuelType     string    `json:"fuel_type,omitempty" validate:"omitempty,oneof=ron95 e5_ron92 diesel"` // Fuel the vehicle is filled with (defaults to the fuel of the vehicle type, ignored for electric vehicles)
 }
 
 // Define the VehicleDetail schema
 type VehicleDetail struct {
 	VehicleID    uuid.UUID `json:"vehicle_id" binding:"required"`
 	Name         string    `json:"name"`
+	FuelType     string    `json:"fuel_type"`
 	FuelConsumed float64   `json:"fuel_consumed"`
 	LicensePlate string    `json:"license_plate"`
+	SeatCapacity int       `json:"seat_capacity"`
 }
 
 // Define the GetVehicleRequest schema
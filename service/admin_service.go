@@ -36,6 +36,7 @@ type IAdminService interface {
 	VerifyPassword(password, hashedPassword string) bool
 	CreateToken(admin migration.Admin) (string, error)
 	GetAdminProfile(adminID uuid.UUID) (migration.Admin, error)
+	CreateGeofenceOverride(req schemas.CreateGeofenceOverrideRequest, adminID uuid.UUID) (migration.GeofenceOverride, error)
 }
 
 // CheckAdminExists checks if an admin exists with the given email and password
@@ -57,3 +58,13 @@ func (s *AdminService) CreateToken(admin migration.Admin) (string, error) {
 func (s *AdminService) GetAdminProfile(adminID uuid.UUID) (migration.Admin, error) {
 	return s.repo.GetAdminProfile(adminID)
 }
+
+// CreateGeofenceOverride lets the driver start or end a ride once without the location checks, the note is kept for the audit
+func (s *AdminService) CreateGeofenceOverride(req schemas.CreateGeofenceOverrideRequest, adminID uuid.UUID) (migration.GeofenceOverride, error) {
+	return s.repo.CreateGeofenceOverride(migration.GeofenceOverride{
+		RideID:  req.RideID,
+		AdminID: adminID,
+		Action:  req.Action,
+		Note:    req.Note,
+	})
+}
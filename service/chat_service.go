@@ -13,18 +13,20 @@ import (
 )
 
 type ChatService struct {
-	repo       repository.IChatRepository
-	hub        *ws.Hub
-	cfg        util.Config
-	cloudinary *bucket.CloudinaryService
+	repo              repository.IChatRepository
+	moderationService IModerationService
+	hub               *ws.Hub
+	cfg               util.Config
+	cloudinary        *bucket.CloudinaryService
 }
 
-func NewChatService(repo repository.IChatRepository, hub *ws.Hub, cfg util.Config, cloudinary *bucket.CloudinaryService) IChatService {
+func NewChatService(repo repository.IChatRepository, moderationService IModerationService, hub *ws.Hub, cfg util.Config, cloudinary *bucket.CloudinaryService) IChatService {
 	return &ChatService{
-		repo:       repo,
-		hub:        hub,
-		cfg:        cfg,
-		cloudinary: cloudinary,
+		repo:              repo,
+		moderationService: moderationService,
+		hub:               hub,
+		cfg:               cfg,
+		cloudinary:        cloudinary,
 	}
 }
 
@@ -39,11 +41,22 @@ type IChatService interface {
 
 // SendMessage sends a message to a chat room
 func (s *ChatService) SendMessage(req schemas.SendMessageRequest, userID uuid.UUID) (migration.Chat, error) {
+	if err := s.moderationService.CheckNotBlocked(userID, req.ReceiverID); err != nil {
+		return migration.Chat{}, err
+	}
 	return s.repo.SendMessage(req, userID)
 }
 
 // UploadImage uploads an image to a chat room
 func (s *ChatService) UploadImage(ctx context.Context, req schemas.SendImageRequest, userID uuid.UUID) (migration.Chat, error) {
+	receiverID, err := uuid.Parse(req.ReceiverID)
+	if err != nil {
+		return migration.Chat{}, err
+	}
+	if err := s.moderationService.CheckNotBlocked(userID, receiverID); err != nil {
+		return migration.Chat{}, err
+	}
+
 	// First, upload the image to Cloudinary
 	imageURL, err := s.cloudinary.UploadChatImage(ctx, req.Image)
 	if err != nil {
@@ -76,6 +89,13 @@ func (s *ChatService) UpdateCallStatus(req schemas.UpdateCallStatusRequest, user
 
 // InitiateCall initiates a call in a chat room
 func (s *ChatService) InitiateCall(req schemas.InitiateCallRequest, userID uuid.UUID) (migration.Chat, error) {
+	receiverID, err := uuid.Parse(req.ReceiverID)
+	if err != nil {
+		return migration.Chat{}, err
+	}
+	if err := s.moderationService.CheckNotBlocked(userID, receiverID); err != nil {
+		return migration.Chat{}, err
+	}
 	return s.repo.InitiateCall(req, userID)
 }
 
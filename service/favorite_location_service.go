@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shareway/infra/db/migration"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	maxFavoriteLocations = 20 // Maximum number of favorite locations of a user
+)
+
+type IFavoriteLocationService interface {
+	CreateFavoriteLocation(ctx context.Context, input schemas.CreateFavoriteLocationRequest, userID uuid.UUID) (migration.FavoriteLocation, error)
+	GetFavoriteLocations(userID uuid.UUID) ([]migration.FavoriteLocation, error)
+	UpdateFavoriteLocation(ctx context.Context, input schemas.UpdateFavoriteLocationRequest, userID uuid.UUID) (migration.FavoriteLocation, error)
+	ReorderFavoriteLocations(favoriteLocationIDs []uuid.UUID, userID uuid.UUID) ([]migration.FavoriteLocation, error)
+	DeleteFavoriteLocation(favoriteLocationID, userID uuid.UUID) error
+}
+
+type FavoriteLocationService struct {
+	repo       repository.IFavoriteLocationRepository
+	mapService IMapService
+	cfg        util.Config
+}
+
+func NewFavoriteLocationService(repo repository.IFavoriteLocationRepository, mapService IMapService, cfg util.Config) IFavoriteLocationService {
+	return &FavoriteLocationService{
+		repo:       repo,
+		mapService: mapService,
+		cfg:        cfg,
+	}
+}
+
+var (
+	ErrFavoriteLocationTypeTaken = errors.New("the user already saved a favorite location of this type")
+	ErrFavoriteLocationLimit     = errors.New("the user reached the maximum number of favorite locations")
+)
+
+// CreateFavoriteLocation saves a favorite location of the user at the end of the list, the coordinates are
+// resolved from the place ID once so the location can be used without calling Goong again
+func (s *FavoriteLocationService) CreateFavoriteLocation(ctx context.Context, input schemas.CreateFavoriteLocationRequest, userID uuid.UUID) (migration.FavoriteLocation, error) {
+	count, err := s.repo.CountFavoriteLocations(userID, "")
+	if err != nil {
+		return migration.FavoriteLocation{}, err
+	}
+	if count >= maxFavoriteLocations {
+		return migration.FavoriteLocation{}, ErrFavoriteLocationLimit
+	}
+
+	if input.Type != "custom" {
+		count, err := s.repo.CountFavoriteLocations(userID, input.Type)
+		if err != nil {
+			return migration.FavoriteLocation{}, err
+		}
+		if count > 0 {
+			return migration.FavoriteLocation{}, ErrFavoriteLocationTypeTaken
+		}
+	}
+
+	point, err := s.mapService.GetLocationFromPlaceID(ctx, input.PlaceID)
+	if err != nil {
+		return migration.FavoriteLocation{}, err
+	}
+
+	favoriteLocation, err := s.repo.CreateFavoriteLocation(migration.FavoriteLocation{
+		UserID:    userID,
+		Type:      input.Type,
+		Name:      strings.TrimSpace(input.Name),
+		PlaceID:   input.PlaceID,
+		Address:   strings.TrimSpace(input.Address),
+		Latitude:  point.Lat,
+		Longitude: point.Lng,
+	})
+	if err != nil {
+		return favoriteLocation, err
+	}
+
+	log.Info().Str("favoriteLocationID", favoriteLocation.ID.String()).Str("type", favoriteLocation.Type).Msg("Favorite location created")
+	return favoriteLocation, nil
+}
+
+// GetFavoriteLocations returns the favorite locations of the user in the order the user chose
+func (s *FavoriteLocationService) GetFavoriteLocations(userID uuid.UUID) ([]migration.FavoriteLocation, error) {
+	return s.repo.GetFavoriteLocationsByUserID(userID)
+}
+
+// UpdateFavoriteLocation renames a favorite location of the user or moves it to another place
+func (s *FavoriteLocationService) UpdateFavoriteLocation(ctx context.Context, input schemas.UpdateFavoriteLocationRequest, userID uuid.UUID) (migration.FavoriteLocation, error) {
+	favoriteLocation, err := s.repo.GetFavoriteLocationByID(input.FavoriteLocationID, userID)
+	if err != nil {
+		return migration.FavoriteLocation{}, err
+	}
+
+	if input.Name != nil {
+		favoriteLocation.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.PlaceID != nil && *input.PlaceID != favoriteLocation.PlaceID {
+		point, err := s.mapService.GetLocationFromPlaceID(ctx, *input.PlaceID)
+		if err != nil {
+			return migration.FavoriteLocation{}, err
+		}
+		favoriteLocation.PlaceID = *input.PlaceID
+		favoriteLocation.Latitude = point.Lat
+		favoriteLocation.Longitude = point.Lng
+	}
+	if input.Address != nil {
+		favoriteLocation.Address = strings.TrimSpace(*input.Address)
+	}
+
+	if err := s.repo.UpdateFavoriteLocation(favoriteLocation); err != nil {
+		return migration.FavoriteLocation{}, err
+	}
+	return favoriteLocation, nil
+}
+
+// ReorderFavoriteLocations saves the new order of the favorite locations of the user and returns them in that order
+func (s *FavoriteLocationService) ReorderFavoriteLocations(favoriteLocationIDs []uuid.UUID, userID uuid.UUID) ([]migration.FavoriteLocation, error) {
+	if err := s.repo.ReorderFavoriteLocations(userID, favoriteLocationIDs); err != nil {
+		return nil, err
+	}
+	return s.repo.GetFavoriteLocationsByUserID(userID)
+}
+
+// DeleteFavoriteLocation deletes a favorite location of the user, the positions of the others keep their order
+func (s *FavoriteLocationService) DeleteFavoriteLocation(favoriteLocationID, userID uuid.UUID) error {
+	return s.repo.DeleteFavoriteLocation(favoriteLocationID, userID)
+}
+
+// Make sure the FavoriteLocationService implements the IFavoriteLocationService interface
+var _ IFavoriteLocationService = (*FavoriteLocationService)(nil)
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"shareway/helper"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IImpactService interface {
+	GetUserImpact(userID uuid.UUID) (schemas.ImpactDetail, error)
+	GetPlatformImpact(req schemas.GetPlatformImpactRequest) (schemas.GetPlatformImpactResponse, error)
+}
+
+type ImpactService struct {
+	repo repository.IImpactRepository
+	cfg  util.Config
+}
+
+func NewImpactService(repo repository.IImpactRepository, cfg util.Config) IImpactService {
+	return &ImpactService{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+// GetUserImpact estimates the fuel and the CO2 the completed rides of the user saved
+func (s *ImpactService) GetUserImpact(userID uuid.UUID) (schemas.ImpactDetail, error) {
+	totals, err := s.repo.GetUserImpactTotals(userID)
+	if err != nil {
+		return schemas.ImpactDetail{}, err
+	}
+	return helper.ComputeImpact(totals, s.impactSettings()), nil
+}
+
+// GetPlatformImpact estimates the fuel and the CO2 all the completed rides saved, the dates of the request
+// are days of the local time zone
+func (s *ImpactService) GetPlatformImpact(req schemas.GetPlatformImpactRequest) (schemas.GetPlatformImpactResponse, error) {
+	location := helper.UTCOffsetLocation(s.cfg.AppUTCOffset)
+
+	var from, to *time.Time
+	if req.From != "" {
+		day, err := time.ParseInLocation("2006-01-02", req.From, location)
+		if err != nil {
+			return schemas.GetPlatformImpactResponse{}, err
+		}
+		from = &day
+	}
+	if req.To != "" {
+		day, err := time.ParseInLocation("2006-01-02", req.To, location)
+		if err != nil {
+			return schemas.GetPlatformImpactResponse{}, err
+		}
+		// The last day is included
+		day = day.AddDate(0, 0, 1)
+		to = &day
+	}
+
+	totals, err := s.repo.GetPlatformImpactTotals(from, to)
+	if err != nil {
+		return schemas.GetPlatformImpactResponse{}, err
+	}
+	users, err := s.repo.CountPlatformImpactUsers(from, to)
+	if err != nil {
+		return schemas.GetPlatformImpactResponse{}, err
+	}
+
+	return schemas.GetPlatformImpactResponse{
+		From:   req.From,
+		To:     req.To,
+		Users:  int(users),
+		Impact: helper.ComputeImpact(totals, s.impactSettings()),
+	}, nil
+}
+
+func (s *ImpactService) impactSettings() helper.ImpactSettings {
+	return helper.ImpactSettings{
+		EmissionFactors: map[string]float64{
+			helper.FuelTypeRON95:    s.cfg.EmissionFactorRON95,
+			helper.FuelTypeE5RON92:  s.cfg.EmissionFactorE5RON92,
+			helper.FuelTypeDiesel:   s.cfg.EmissionFactorDiesel,
+			helper.FuelTypeElectric: s.cfg.EmissionFactorElectricity,
+		},
+		DefaultFuelConsumed: s.cfg.FareDefaultFuelConsumed,
+	}
+}
+
+// Make sure the ImpactService implements the IImpactService interface
+var _ IImpactService = (*ImpactService)(nil)
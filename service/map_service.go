@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -10,9 +11,11 @@ import (
 	"net/url"
 	"shareway/helper"
 	"shareway/infra/db/migration"
+	"shareway/infra/task"
 	"shareway/repository"
 	"shareway/schemas"
 	"shareway/util"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -23,10 +26,24 @@ import (
 
 const (
 	MaxRetry = 5 // Maximum number of retries for fetching data from Goong API
+
+	defaultBrowseRadius = 2.0                // Default distance (km) between the searched points and the route
+	defaultBrowseWindow = 24 * time.Hour     // Default departure window when browsing ride offers
+	maxBrowseWindow     = 7 * 24 * time.Hour // Longest departure window a search can cover
+
+	favoritePlacePrefix      = "favorite:" // Prefix of the place list entries referring to a favorite location
+	defaultAutoCompleteLimit = 4           // Number of predictions when the request sets no limit
+	maxAutoCompleteFavorites = 3           // Maximum number of favorite locations put ahead of the Goong predictions
+	maxAutoCompleteRecents   = 3           // Maximum number of recent destinations put ahead of the Goong predictions
+)
+
+var (
+	ErrInvalidDepartureWindow = errors.New("departure window must end after it starts and cover at most 7 days")
 )
 
 type IMapService interface {
-	GetAutoComplete(ctx context.Context, input string, limit int, location string, radius int, moreCompound bool, currentLocation string) (schemas.GoongAutoCompleteResponse, error)
+	GetAutoComplete(ctx context.Context, userID uuid.UUID, input string, limit int, location string, radius int, moreCompound bool, currentLocation string) (schemas.GoongAutoCompleteResponse, error)
+	GetRoute(ctx context.Context, placeList []string) (schemas.GoongDirectionsResponse, error)
 	CreateGiveRide(ctx context.Context, input schemas.GiveRideRequest, userID uuid.UUID) (schemas.GoongDirectionsResponse, uuid.UUID, error)
 	CreateHitchRide(ctx context.Context, input schemas.HitchRideRequest, userID uuid.UUID) (schemas.GoongDirectionsResponse, uuid.UUID, error)
 	GetGeoCode(ctx context.Context, point schemas.Point, currentLocation schemas.Point) (schemas.GeoCodeLocationResponse, error)
@@ -34,22 +51,30 @@ type IMapService interface {
 	GetRideOfferDetails(ctx context.Context, rideOfferID uuid.UUID) (migration.RideOffer, error)
 	GetRideRequestDetails(ctx context.Context, rideRequestID uuid.UUID) (migration.RideRequest, error)
 	GetDistanceFromCurrentLocation(ctx context.Context, currentLocation schemas.Point, destinationPoint []schemas.Point) (schemas.GoongDistanceMatrixResponse, error)
-	SuggestRideRequests(ctx context.Context, userID uuid.UUID, rideOfferID uuid.UUID) ([]migration.RideRequest, error)
-	SuggestRideOffers(ctx context.Context, userID uuid.UUID, rideRequestID uuid.UUID) ([]migration.RideOffer, error)
+	SuggestRideRequests(ctx context.Context, userID uuid.UUID, rideOfferID uuid.UUID) ([]migration.RideRequest, map[uuid.UUID]schemas.MatchScore, error)
+	SuggestRideOffers(ctx context.Context, userID uuid.UUID, rideRequestID uuid.UUID) ([]migration.RideOffer, map[uuid.UUID]schemas.MatchScore, error)
 	GetAllWaypoints(rideOfferID uuid.UUID) ([]migration.Waypoint, error)
+	EstimateRideETA(ctx context.Context, ride migration.Ride, rideOffer migration.RideOffer, rideRequest migration.RideRequest, driverLocation schemas.Point) (schemas.RideETAUpdate, error)
+	SuggestMeetingPoints(ctx context.Context, rideOffer migration.RideOffer, rideRequest migration.RideRequest) (schemas.MeetingPoints, error)
+	GetFareQuote(ctx context.Context, input schemas.FareQuoteRequest, userID uuid.UUID) (schemas.FareQuote, error)
+	BrowseRideOffers(ctx context.Context, input schemas.BrowseRideOffersRequest, userID uuid.UUID) ([]migration.RideOffer, map[uuid.UUID]schemas.RouteProximity, string, error)
 }
 
 type MapService struct {
-	repo        repository.IMapsRepository
-	cfg         util.Config
-	redisClient *redis.Client
+	repo         repository.IMapsRepository
+	favoriteRepo repository.IFavoriteLocationRepository
+	cfg          util.Config
+	redisClient  *redis.Client
+	asynqClient  *task.AsyncClient
 }
 
-func NewMapService(repo repository.IMapsRepository, cfg util.Config, redisClient *redis.Client) IMapService {
+func NewMapService(repo repository.IMapsRepository, favoriteRepo repository.IFavoriteLocationRepository, cfg util.Config, redisClient *redis.Client, asynqClient *task.AsyncClient) IMapService {
 	return &MapService{
-		repo:        repo,
-		cfg:         cfg,
-		redisClient: redisClient,
+		repo:         repo,
+		favoriteRepo: favoriteRepo,
+		cfg:          cfg,
+		redisClient:  redisClient,
+		asynqClient:  asynqClient,
 	}
 }
 
@@ -108,8 +133,74 @@ func (s *MapService) GetLocationFromPlaceID(ctx context.Context, placeID string)
 	return schemas.Point{}, fmt.Errorf("max retries reached, unable to get location data")
 }
 
-// GetAutoComplete returns the auto-complete results for the given input
-func (s *MapService) GetAutoComplete(ctx context.Context, input string, limit int, location string, radius int, moreCompound bool, currentLocation string) (schemas.GoongAutoCompleteResponse, error) {
+// GetAutoComplete returns the auto-complete results for the given input, the matching favorite locations
+// and recent destinations of the user come first. They are still returned when Goong fails
+func (s *MapService) GetAutoComplete(ctx context.Context, userID uuid.UUID, input string, limit int, location string, radius int, moreCompound bool, currentLocation string) (schemas.GoongAutoCompleteResponse, error) {
+	if limit <= 0 {
+		limit = defaultAutoCompleteLimit
+	}
+
+	// The locations of the user are already resolved, only the Goong predictions need a place detail call
+	predictions, points := s.userPredictions(userID, input)
+
+	response, err := s.fetchAutoComplete(input, limit, location, radius, moreCompound)
+	if err != nil {
+		if len(predictions) == 0 {
+			return schemas.GoongAutoCompleteResponse{}, err
+		}
+		log.Printf("Failed to get autocomplete from Goong, only the locations of the user are returned: %v", err)
+	}
+
+	seen := make(map[string]bool, len(predictions))
+	for _, prediction := range predictions {
+		seen[prediction.PlaceID] = true
+	}
+	for _, prediction := range response.Predictions {
+		if seen[prediction.PlaceID] {
+			continue
+		}
+		prediction.Source = "goong"
+		predictions = append(predictions, prediction)
+	}
+	if len(predictions) > limit {
+		predictions = predictions[:limit]
+	}
+	if len(points) > len(predictions) {
+		points = points[:len(predictions)]
+	}
+	response.Predictions = predictions
+
+	if currentLocation != "" {
+		currentLocationPoint := helper.ConvertStringToLocation(currentLocation)
+
+		destinationPoints := make([]schemas.Point, len(response.Predictions))
+		copy(destinationPoints, points)
+		for i, prediction := range response.Predictions[len(points):] {
+			point, err := s.GetLocationFromPlaceID(ctx, prediction.PlaceID)
+			if err != nil {
+				log.Printf("Failed to get location for place ID %s: %v", prediction.PlaceID, err)
+				continue
+			}
+			destinationPoints[len(points)+i] = point
+		}
+
+		distanceMatrix, err := s.GetDistanceFromCurrentLocation(ctx, currentLocationPoint, destinationPoints)
+		if err != nil {
+			log.Printf("Failed to get distance matrix: %v", err)
+		} else {
+			for i := range response.Predictions {
+				if i < len(distanceMatrix.Rows[0].Elements) {
+					response.Predictions[i].Distance = float64(distanceMatrix.Rows[0].Elements[i].Distance.Value) / 1000 // Convert to km
+				}
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// fetchAutoComplete returns the Goong predictions for the given input
+func (s *MapService) fetchAutoComplete(input string, limit int, location string, radius int, moreCompound bool) (schemas.GoongAutoCompleteResponse, error) {
 	// Build the request URL
 	baseURL, err := url.Parse(fmt.Sprintf("%s/place/autocomplete", s.cfg.GoongApiURL))
 	if err != nil {
@@ -121,11 +212,7 @@ func (s *MapService) GetAutoComplete(ctx context.Context, input string, limit in
 		"input":   {input},
 	}
 
-	if limit > 0 {
-		params.Set("limit", strconv.Itoa(limit))
-	} else {
-		params.Set("limit", strconv.Itoa(4))
-	}
+	params.Set("limit", strconv.Itoa(limit))
 	if location != "" {
 		params.Set("location", location)
 	}
@@ -175,48 +262,98 @@ func (s *MapService) GetAutoComplete(ctx context.Context, input string, limit in
 		break
 	}
 
-	if currentLocation != "" {
-		currentLocationPoint := helper.ConvertStringToLocation(currentLocation)
+	return response, nil
+}
 
-		destinationPoints := make([]schemas.Point, len(response.Predictions))
-		for i, prediction := range response.Predictions {
-			point, err := s.GetLocationFromPlaceID(ctx, prediction.PlaceID)
-			if err != nil {
-				log.Printf("Failed to get location for place ID %s: %v", prediction.PlaceID, err)
-				continue
-			}
-			destinationPoints[i] = point
+// userPredictions returns the favorite locations and the recent destinations of the user matching the input
+// as predictions, with their coordinates in the same order
+func (s *MapService) userPredictions(userID uuid.UUID, input string) ([]schemas.Prediction, []schemas.Point) {
+	var predictions []schemas.Prediction
+	var points []schemas.Point
+	seen := make(map[string]bool)
+
+	favoriteLocations, err := s.favoriteRepo.SearchFavoriteLocations(userID, input, maxAutoCompleteFavorites)
+	if err != nil {
+		log.Printf("Failed to search favorite locations of user %s: %v", userID, err)
+	}
+	for _, favoriteLocation := range favoriteLocations {
+		favoriteLocationID := favoriteLocation.ID
+		seen[favoriteLocation.PlaceID] = true
+		predictions = append(predictions, schemas.Prediction{
+			Description: favoriteLocation.Address,
+			PlaceID:     favoriteLocation.PlaceID,
+			StructuredFormatting: schemas.StructuredFormatting{
+				MainText:      favoriteLocation.Name,
+				SecondaryText: favoriteLocation.Address,
+			},
+			Source:             "favorite",
+			FavoriteLocationID: &favoriteLocationID,
+		})
+		points = append(points, schemas.Point{Lat: favoriteLocation.Latitude, Lng: favoriteLocation.Longitude})
+	}
+
+	destinations, err := s.favoriteRepo.SearchRecentDestinations(userID, input, maxAutoCompleteRecents)
+	if err != nil {
+		log.Printf("Failed to search recent destinations of user %s: %v", userID, err)
+	}
+	for _, destination := range destinations {
+		if seen[destination.PlaceID] {
+			continue
 		}
+		predictions = append(predictions, schemas.Prediction{
+			Description: destination.Address,
+			PlaceID:     destination.PlaceID,
+			StructuredFormatting: schemas.StructuredFormatting{
+				MainText: destination.Address,
+			},
+			Source: "recent",
+		})
+		points = append(points, schemas.Point{Lat: destination.Latitude, Lng: destination.Longitude})
+	}
 
-		distanceMatrix, err := s.GetDistanceFromCurrentLocation(ctx, currentLocationPoint, destinationPoints)
+	return predictions, points
+}
+
+// resolvePlaceList replaces the favorite locations of the user in the place list by their Goong place ID
+func (s *MapService) resolvePlaceList(userID uuid.UUID, placeList []string) ([]string, error) {
+	resolved := make([]string, len(placeList))
+	for i, place := range placeList {
+		if !strings.HasPrefix(place, favoritePlacePrefix) {
+			resolved[i] = place
+			continue
+		}
+
+		favoriteLocationID, err := uuid.Parse(strings.TrimPrefix(place, favoritePlacePrefix))
 		if err != nil {
-			log.Printf("Failed to get distance matrix: %v", err)
-		} else {
-			for i := range response.Predictions {
-				if i < len(distanceMatrix.Rows[0].Elements) {
-					response.Predictions[i].Distance = float64(distanceMatrix.Rows[0].Elements[i].Distance.Value) / 1000 // Convert to km
-				}
-			}
+			return nil, repository.ErrFavoriteLocationNotFound
+		}
+		favoriteLocation, err := s.favoriteRepo.GetFavoriteLocationByID(favoriteLocationID, userID)
+		if err != nil {
+			return nil, err
 		}
+		resolved[i] = favoriteLocation.PlaceID
 	}
-
-	return response, nil
+	return resolved, nil
 }
 
-// CreateGiveRide creates a ride offer based on the given input
-func (s *MapService) CreateGiveRide(ctx context.Context, input schemas.GiveRideRequest, userID uuid.UUID) (schemas.GoongDirectionsResponse, uuid.UUID, error) {
-	points := make([]schemas.Point, len(input.PlaceList))
-	for i, placeID := range input.PlaceList {
+// GetRoute resolves the given place IDs and returns the Goong route going through them in order
+func (s *MapService) GetRoute(ctx context.Context, placeList []string) (schemas.GoongDirectionsResponse, error) {
+	if len(placeList) < 2 {
+		return schemas.GoongDirectionsResponse{}, fmt.Errorf("at least two places are required to create a route")
+	}
+
+	points := make([]schemas.Point, len(placeList))
+	for i, placeID := range placeList {
 		point, err := s.GetLocationFromPlaceID(ctx, placeID)
 		if err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to get location for place ID %s: %w", placeID, err)
+			return schemas.GoongDirectionsResponse{}, fmt.Errorf("failed to get location for place ID %s: %w", placeID, err)
 		}
 		points[i] = point
 	}
 
 	baseURL, err := url.Parse(fmt.Sprintf("%s/direction", s.cfg.GoongApiURL))
 	if err != nil {
-		return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("invalid base URL: %w", err)
+		return schemas.GoongDirectionsResponse{}, fmt.Errorf("invalid base URL: %w", err)
 	}
 
 	params := url.Values{
@@ -241,7 +378,7 @@ func (s *MapService) CreateGiveRide(ctx context.Context, input schemas.GiveRideR
 	for i := 0; i < maxRetries; i++ {
 		resp, err := http.Get(url)
 		if err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to fetch from Goong API: %w", err)
+			return schemas.GoongDirectionsResponse{}, fmt.Errorf("failed to fetch from Goong API: %w", err)
 		}
 		defer resp.Body.Close()
 
@@ -252,134 +389,109 @@ func (s *MapService) CreateGiveRide(ctx context.Context, input schemas.GiveRideR
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+			return schemas.GoongDirectionsResponse{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 		}
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to read response body: %w", err)
+			return schemas.GoongDirectionsResponse{}, fmt.Errorf("failed to read response body: %w", err)
 		}
 
 		if err := json.Unmarshal(body, &response); err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to unmarshal response: %w", err)
+			return schemas.GoongDirectionsResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 
 		// If we've reached here, we've successfully got and parsed the response
 		break
 	}
 
-	currentLocation := schemas.Point{
-		Lat: points[0].Lat,
-		Lng: points[0].Lng,
-	}
+	return response, nil
+}
 
-	// Check start_time from input and set the ride request status accordingly
-	// If start_time is not provided, the ride is immediate
-	var startTime time.Time
-	if input.StartTime != "" {
-		// Parse the start time to UTC time
-		startTime, err = time.Parse("2006-01-02T15:04:05.999999", input.StartTime)
-		if err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to parse start time: %w", err)
-		}
-		startTime = startTime.UTC()
-	} else {
-		startTime = time.Now().UTC()
+// ParseStartTime parses the start time sent by the client as UTC time
+// If start_time is not provided, the ride is immediate
+func ParseStartTime(input string) (time.Time, error) {
+	if input == "" {
+		return time.Now().UTC(), nil
 	}
 
-	rideOfferID, err := s.repo.CreateGiveRide(response, userID, currentLocation, startTime, input.VehicleID)
+	startTime, err := time.Parse("2006-01-02T15:04:05.999999", input)
 	if err != nil {
-		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
+		return time.Time{}, fmt.Errorf("failed to parse start time: %w", err)
 	}
 
-	return response, rideOfferID, nil
+	return startTime.UTC(), nil
 }
 
-// CreateHitchRide creates a hitch ride request based on the given input
-func (s *MapService) CreateHitchRide(ctx context.Context, input schemas.HitchRideRequest, userID uuid.UUID) (schemas.GoongDirectionsResponse, uuid.UUID, error) {
-	points := make([]schemas.Point, len(input.PlaceList))
-	for i, placeID := range input.PlaceList {
-		point, err := s.GetLocationFromPlaceID(ctx, placeID)
-		if err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to get location for place ID %s: %w", placeID, err)
-		}
-		points[i] = point
+// CreateGiveRide creates a ride offer based on the given input
+func (s *MapService) CreateGiveRide(ctx context.Context, input schemas.GiveRideRequest, userID uuid.UUID) (schemas.GoongDirectionsResponse, uuid.UUID, error) {
+	placeList, err := s.resolvePlaceList(userID, input.PlaceList)
+	if err != nil {
+		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
 	}
 
-	baseURL, err := url.Parse(fmt.Sprintf("%s/direction", s.cfg.GoongApiURL))
+	response, err := s.GetRoute(ctx, placeList)
 	if err != nil {
-		return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("invalid base URL: %w", err)
+		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
 	}
 
-	params := url.Values{
-		"api_key": {s.cfg.GoongAPIKey},
-		"origin":  {fmt.Sprintf("%f,%f", points[0].Lat, points[0].Lng)},
-		"vehicle": {"hd"},
+	startTime, err := ParseStartTime(input.StartTime)
+	if err != nil {
+		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
 	}
 
-	destinations := make([]string, len(points)-1)
-	for i := 1; i < len(points); i++ {
-		destinations[i-1] = fmt.Sprintf("%f,%f", points[i].Lat, points[i].Lng)
+	rideOfferID, err := s.repo.CreateGiveRide(response, placeList[len(placeList)-1], userID, routeOrigin(response), startTime, input.VehicleID, input.Seats, ridePreferences(input.Preferences), nil)
+	if err != nil {
+		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
 	}
-	params.Set("destination", strings.Join(destinations, ";"))
-
-	baseURL.RawQuery = params.Encode()
-	url := baseURL.String()
 
-	var response schemas.GoongDirectionsResponse
-	maxRetries := MaxRetry
-	retryDelay := time.Second
+	// Notify the users whose route alerts match the new ride offer in the background
+	err = s.asynqClient.EnqueueRouteAlertMatch(schemas.RouteAlertMatchPayload{RideOfferID: rideOfferID})
+	if err != nil {
+		log.Printf("Failed to enqueue route alert match for ride offer %s: %v", rideOfferID, err)
+	}
 
-	for i := 0; i < maxRetries; i++ {
-		resp, err := http.Get(url)
-		if err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to fetch from Goong API: %w", err)
-		}
-		defer resp.Body.Close()
+	return response, rideOfferID, nil
+}
 
-		if resp.StatusCode == http.StatusTooManyRequests {
-			time.Sleep(retryDelay)
-			retryDelay *= 2 // Exponential backoff
-			continue
-		}
+// GetFareQuote prices the route of the given input before any ride offer or request is created
+func (s *MapService) GetFareQuote(ctx context.Context, input schemas.FareQuoteRequest, userID uuid.UUID) (schemas.FareQuote, error) {
+	placeList, err := s.resolvePlaceList(userID, input.PlaceList)
+	if err != nil {
+		return schemas.FareQuote{}, err
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-		}
+	response, err := s.GetRoute(ctx, placeList)
+	if err != nil {
+		return schemas.FareQuote{}, err
+	}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to read response body: %w", err)
-		}
+	startTime, err := ParseStartTime(input.StartTime)
+	if err != nil {
+		return schemas.FareQuote{}, err
+	}
 
-		if err := json.Unmarshal(body, &response); err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to unmarshal response: %w", err)
-		}
+	return s.repo.QuoteFare(response, userID, input.VehicleID, startTime)
+}
 
-		// If we've reached here, we've successfully got and parsed the response
-		break
+// CreateHitchRide creates a hitch ride request based on the given input
+func (s *MapService) CreateHitchRide(ctx context.Context, input schemas.HitchRideRequest, userID uuid.UUID) (schemas.GoongDirectionsResponse, uuid.UUID, error) {
+	placeList, err := s.resolvePlaceList(userID, input.PlaceList)
+	if err != nil {
+		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
 	}
 
-	currentLocation := schemas.Point{
-		Lat: points[0].Lat,
-		Lng: points[0].Lng,
+	response, err := s.GetRoute(ctx, placeList)
+	if err != nil {
+		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
 	}
 
-	// Check start_time from input and set the ride request status accordingly
-	// If start_time is not provided, the ride is immediate
-	var startTime time.Time
-	if input.StartTime != "" {
-		// Parse the start time to UTC time
-		startTime, err = time.Parse("2006-01-02T15:04:05.999999", input.StartTime)
-		if err != nil {
-			return schemas.GoongDirectionsResponse{}, uuid.Nil, fmt.Errorf("failed to parse start time: %w", err)
-		}
-		startTime = startTime.UTC()
-	} else {
-		startTime = time.Now().UTC()
+	startTime, err := ParseStartTime(input.StartTime)
+	if err != nil {
+		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
 	}
 
-	rideRequestID, err := s.repo.CreateHitchRide(response, userID, currentLocation, startTime, input.Weight)
+	rideRequestID, err := s.repo.CreateHitchRide(response, placeList[len(placeList)-1], userID, routeOrigin(response), startTime, input.Weight, ridePreferences(input.Preferences))
 	if err != nil {
 		return schemas.GoongDirectionsResponse{}, uuid.Nil, err
 	}
@@ -387,6 +499,19 @@ func (s *MapService) CreateHitchRide(ctx context.Context, input schemas.HitchRid
 	return response, rideRequestID, nil
 }
 
+// routeOrigin returns the starting point of the route, used as the current location of the user
+func routeOrigin(route schemas.GoongDirectionsResponse) schemas.Point {
+	if len(route.Routes) == 0 || len(route.Routes[0].Legs) == 0 {
+		return schemas.Point{}
+	}
+
+	start := route.Routes[0].Legs[0].Start_location
+	return schemas.Point{
+		Lat: start.Lat,
+		Lng: start.Lng,
+	}
+}
+
 // GetGeoCode returns the geocode information for the given point
 func (s *MapService) GetGeoCode(ctx context.Context, point schemas.Point, currentLocation schemas.Point) (schemas.GeoCodeLocationResponse, error) {
 	baseURL, err := url.Parse(fmt.Sprintf("%s/geocode", s.cfg.GoongApiURL))
@@ -469,6 +594,45 @@ func (s *MapService) GetGeoCode(ctx context.Context, point schemas.Point, curren
 	return optimizedResults, nil
 }
 
+// SuggestMeetingPoints suggests where on the driver's route the hitcher is picked up and dropped off,
+// each point comes with the walking distance of the hitcher and its address
+func (s *MapService) SuggestMeetingPoints(ctx context.Context, rideOffer migration.RideOffer, rideRequest migration.RideRequest) (schemas.MeetingPoints, error) {
+	route := helper.DecodePolyline(string(rideOffer.EncodedPolyline))
+	if len(route) == 0 {
+		return schemas.MeetingPoints{}, fmt.Errorf("ride offer has no route")
+	}
+
+	start := schemas.Point{Lat: rideRequest.StartLatitude, Lng: rideRequest.StartLongitude}
+	end := schemas.Point{Lat: rideRequest.EndLatitude, Lng: rideRequest.EndLongitude}
+	pickup, dropoff := helper.FindMeetingPoints(route, start, end)
+
+	return schemas.MeetingPoints{
+		Pickup:  s.meetingPoint(ctx, pickup, start),
+		Dropoff: s.meetingPoint(ctx, dropoff, end),
+	}, nil
+}
+
+// meetingPoint reverse geocodes a meeting point, the address stays empty if Goong cannot resolve it
+// so the ride can still be matched
+func (s *MapService) meetingPoint(ctx context.Context, point schemas.Point, hitcherLocation schemas.Point) schemas.MeetingPoint {
+	meetingPoint := schemas.MeetingPoint{
+		Latitude:        point.Lat,
+		Longitude:       point.Lng,
+		WalkingDistance: helper.HaversineDistance(hitcherLocation.Lat, hitcherLocation.Lng, point.Lat, point.Lng),
+	}
+
+	geoCode, err := s.GetGeoCode(ctx, point, hitcherLocation)
+	if err != nil {
+		log.Printf("Failed to get address of meeting point: %v", err)
+		return meetingPoint
+	}
+	if len(geoCode.Results) > 0 {
+		meetingPoint.Address = geoCode.Results[0].FormattedAddress
+	}
+
+	return meetingPoint
+}
+
 // GetDistanceFromCurrentLocation returns the distance matrix from the current location to the destination points
 func (s *MapService) GetDistanceFromCurrentLocation(ctx context.Context, currentLocation schemas.Point, destinationPoints []schemas.Point) (schemas.GoongDistanceMatrixResponse, error) {
 	baseURL, err := url.Parse(fmt.Sprintf("%s/distancematrix", s.cfg.GoongApiURL))
@@ -540,13 +704,159 @@ func (s *MapService) GetRideRequestDetails(ctx context.Context, rideRequestID uu
 }
 
 // SuggestRideRequests returns the suggested ride requests for the given user and ride offer
-func (s *MapService) SuggestRideRequests(ctx context.Context, userID uuid.UUID, rideOfferID uuid.UUID) ([]migration.RideRequest, error) {
-	return s.repo.SuggestRideRequests(userID, rideOfferID)
+// ranked by their match score (keyed by ride request ID)
+func (s *MapService) SuggestRideRequests(ctx context.Context, userID uuid.UUID, rideOfferID uuid.UUID) ([]migration.RideRequest, map[uuid.UUID]schemas.MatchScore, error) {
+	rideOffer, err := s.repo.GetRideOfferDetails(rideOfferID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rideRequests, err := s.repo.SuggestRideRequests(userID, rideOfferID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userIDs := make([]uuid.UUID, len(rideRequests))
+	for i, rideRequest := range rideRequests {
+		userIDs[i] = rideRequest.UserID
+	}
+	ratings, err := s.repo.GetAverageRatings(userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scores := make(map[uuid.UUID]schemas.MatchScore, len(rideRequests))
+	for _, rideRequest := range rideRequests {
+		scores[rideRequest.ID] = helper.ScoreMatch(rideOffer, rideRequest, ratings[rideRequest.UserID], s.matchWeights())
+	}
+
+	// Stable sort keeps the weight order of the repository for equal scores
+	sort.SliceStable(rideRequests, func(i, j int) bool {
+		return scores[rideRequests[i].ID].Score > scores[rideRequests[j].ID].Score
+	})
+
+	return rideRequests, scores, nil
 }
 
 // SuggestRideOffers returns the suggested ride offers for the given user and ride request
-func (s *MapService) SuggestRideOffers(ctx context.Context, userID uuid.UUID, rideRequestID uuid.UUID) ([]migration.RideOffer, error) {
-	return s.repo.SuggestRideOffers(userID, rideRequestID)
+// ranked by their match score (keyed by ride offer ID)
+func (s *MapService) SuggestRideOffers(ctx context.Context, userID uuid.UUID, rideRequestID uuid.UUID) ([]migration.RideOffer, map[uuid.UUID]schemas.MatchScore, error) {
+	rideRequest, err := s.repo.GetRideRequestDetails(rideRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rideOffers, err := s.repo.SuggestRideOffers(userID, rideRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userIDs := make([]uuid.UUID, len(rideOffers))
+	for i, rideOffer := range rideOffers {
+		userIDs[i] = rideOffer.UserID
+	}
+	ratings, err := s.repo.GetAverageRatings(userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scores := make(map[uuid.UUID]schemas.MatchScore, len(rideOffers))
+	for _, rideOffer := range rideOffers {
+		scores[rideOffer.ID] = helper.ScoreMatch(rideOffer, rideRequest, ratings[rideOffer.UserID], s.matchWeights())
+	}
+
+	sort.SliceStable(rideOffers, func(i, j int) bool {
+		return scores[rideOffers[i].ID].Score > scores[rideOffers[j].ID].Score
+	})
+
+	return rideOffers, scores, nil
+}
+
+// ridePreferences converts the preferences given for a ride, nil keeps the defaults of the user profile
+func ridePreferences(preferences *schemas.RidePreferences) *migration.RidePreferences {
+	if preferences == nil {
+		return nil
+	}
+	ridePreferences := helper.ToRidePreferences(*preferences)
+	return &ridePreferences
+}
+
+// matchWeights returns the configured weight of each match score factor
+func (s *MapService) matchWeights() helper.MatchWeights {
+	return helper.MatchWeights{
+		PickupDistance:  s.cfg.MatchWeightPickupDistance,
+		DropoffDistance: s.cfg.MatchWeightDropoffDistance,
+		Detour:          s.cfg.MatchWeightDetour,
+		TimeGap:         s.cfg.MatchWeightTimeGap,
+		SharedRoute:     s.cfg.MatchWeightSharedRoute,
+		Rating:          s.cfg.MatchWeightRating,
+		Preferences:     s.cfg.MatchWeightPreferences,
+	}
+}
+
+// BrowseRideOffers searches the open ride offers going from the origin to the destination within the departure window,
+// it returns a page of the sorted matching ride offers and the cursor of the next page, empty on the last one
+func (s *MapService) BrowseRideOffers(ctx context.Context, input schemas.BrowseRideOffersRequest, userID uuid.UUID) ([]migration.RideOffer, map[uuid.UUID]schemas.RouteProximity, string, error) {
+	departureFrom, err := ParseStartTime(input.DepartureFrom)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	departureTo := departureFrom.Add(defaultBrowseWindow)
+	if input.DepartureTo != "" {
+		if departureTo, err = ParseStartTime(input.DepartureTo); err != nil {
+			return nil, nil, "", err
+		}
+	}
+	if !departureTo.After(departureFrom) || departureTo.Sub(departureFrom) > maxBrowseWindow {
+		return nil, nil, "", ErrInvalidDepartureWindow
+	}
+
+	filter := schemas.BrowseRideOffersFilter{
+		Origin:            input.Origin,
+		Destination:       input.Destination,
+		OriginRadius:      input.OriginRadius,
+		DestinationRadius: input.DestinationRadius,
+		DepartureFrom:     departureFrom,
+		DepartureTo:       departureTo,
+		Seats:             max(input.Seats, 1),
+		SortBy:            input.SortBy,
+		Limit:             input.Limit,
+	}
+	if filter.OriginRadius == 0 {
+		filter.OriginRadius = defaultBrowseRadius
+	}
+	if filter.DestinationRadius == 0 {
+		filter.DestinationRadius = defaultBrowseRadius
+	}
+	if filter.SortBy == "" {
+		filter.SortBy = "start_time"
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 10
+	}
+	if input.Cursor != "" {
+		sortValue, startTime, rideOfferID, err := helper.DecodeBrowseCursor(input.Cursor, filter.SortBy)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		filter.CursorSortValue = sortValue
+		filter.CursorStartTime = &startTime
+		filter.CursorRideOfferID = rideOfferID
+	}
+
+	rideOffers, proximities, err := s.repo.BrowseRideOffers(userID, filter)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	nextCursor := ""
+	if len(rideOffers) > filter.Limit {
+		rideOffers = rideOffers[:filter.Limit]
+		last := rideOffers[len(rideOffers)-1]
+		nextCursor = helper.EncodeBrowseCursor(filter.SortBy, helper.BrowseSortValue(last, filter.SortBy), last.StartTime, last.ID)
+	}
+	return rideOffers, proximities, nextCursor, nil
 }
 
 // GetAllWaypoints returns all waypoints for the given ride offer ID
@@ -554,5 +864,133 @@ func (s *MapService) GetAllWaypoints(rideOfferID uuid.UUID) ([]migration.Waypoin
 	return s.repo.GetAllWaypoints(rideOfferID)
 }
 
+// providerETA is the last ETA Goong gave for a ride, reused until ETA_PROVIDER_INTERVAL has passed
+type providerETA struct {
+	PickupDistance  float64   `json:"pickup_distance"`
+	PickupDuration  int       `json:"pickup_duration"`
+	DropoffDistance float64   `json:"dropoff_distance"`
+	DropoffDuration int       `json:"dropoff_duration"`
+	FetchedAt       time.Time `json:"fetched_at"`
+}
+
+// EstimateRideETA estimates the remaining distance and time of the driver to the pickup and the dropoff of a ride
+// along the planned route of the ride offer. Goong is only asked when the driver has left the route, and no more
+// than once per ETA_PROVIDER_INTERVAL for a ride, in between its last answer is reused.
+// Before the pickup, Goong gives the way to the pickup and the dropoff is reached from there along the route
+func (s *MapService) EstimateRideETA(ctx context.Context, ride migration.Ride, rideOffer migration.RideOffer, rideRequest migration.RideRequest, driverLocation schemas.Point) (schemas.RideETAUpdate, error) {
+	pickup := schemas.Point{Lat: ride.PickupLatitude, Lng: ride.PickupLongitude}
+	if pickup.Lat == 0 && pickup.Lng == 0 { // Rides accepted before the meeting points were stored
+		pickup = schemas.Point{Lat: rideRequest.StartLatitude, Lng: rideRequest.StartLongitude}
+	}
+	dropoff := schemas.Point{Lat: ride.DropoffLatitude, Lng: ride.DropoffLongitude}
+	if dropoff.Lat == 0 && dropoff.Lng == 0 {
+		dropoff = schemas.Point{Lat: rideRequest.EndLatitude, Lng: rideRequest.EndLongitude}
+	}
+	pickedUp := ride.Status == "ongoing"
+
+	route := helper.DecodePolyline(string(rideOffer.EncodedPolyline))
+	eta := helper.EstimateRouteETA(route, rideOffer.Duration, driverLocation, pickup, dropoff)
+
+	res := schemas.RideETAUpdate{
+		RideID:         ride.ID,
+		Status:         ride.Status,
+		DriverLocation: driverLocation,
+		OffRoute:       eta.OffRoute*1000 > s.cfg.ETAOffRouteDistance,
+		Source:         "route",
+		UpdatedAt:      time.Now(),
+	}
+
+	if res.OffRoute {
+		provider, ok, err := s.getProviderETA(ctx, ride.ID, driverLocation, pickedUp, pickup, dropoff, eta.LegDistance, eta.LegDuration)
+		if err != nil {
+			return schemas.RideETAUpdate{}, err
+		}
+		if ok {
+			// The durations count from when Goong was asked, so the ETAs do not move while it is reused
+			elapsed := int(res.UpdatedAt.Sub(provider.FetchedAt).Seconds())
+			eta.PickupDistance = provider.PickupDistance
+			eta.PickupDuration = max(provider.PickupDuration-elapsed, 0)
+			eta.DropoffDistance = provider.DropoffDistance
+			eta.DropoffDuration = max(provider.DropoffDuration-elapsed, 0)
+			res.Source = "provider"
+		}
+	}
+
+	if !pickedUp {
+		pickupETA := res.UpdatedAt.Add(time.Duration(eta.PickupDuration) * time.Second)
+		res.PickupDistance = &eta.PickupDistance
+		res.PickupDuration = &eta.PickupDuration
+		res.PickupETA = &pickupETA
+	}
+	res.DropoffDistance = eta.DropoffDistance
+	res.DropoffDuration = eta.DropoffDuration
+	res.DropoffETA = res.UpdatedAt.Add(time.Duration(eta.DropoffDuration) * time.Second)
+
+	return res, nil
+}
+
+// getProviderETA returns the ETA of a ride from Goong, asking it at most once per ETA_PROVIDER_INTERVAL and
+// reusing its last answer in between. ok is false when Goong has not answered during the interval
+func (s *MapService) getProviderETA(ctx context.Context, rideID uuid.UUID, driverLocation schemas.Point, pickedUp bool, pickup, dropoff schemas.Point, legDistance float64, legDuration int) (providerETA, bool, error) {
+	interval := time.Duration(s.cfg.ETAProviderInterval) * time.Second
+	throttleKey := fmt.Sprintf("ride_eta:provider:%s", rideID)
+	resultKey := fmt.Sprintf("ride_eta:provider_result:%s", rideID)
+
+	allowed, err := s.redisClient.SetNX(ctx, throttleKey, 1, interval).Result()
+	if err != nil {
+		return providerETA{}, false, err
+	}
+
+	if !allowed {
+		cached, err := s.redisClient.Get(ctx, resultKey).Bytes()
+		if err == redis.Nil {
+			return providerETA{}, false, nil
+		}
+		if err != nil {
+			return providerETA{}, false, err
+		}
+		var result providerETA
+		if err := json.Unmarshal(cached, &result); err != nil {
+			return providerETA{}, false, err
+		}
+		return result, true, nil
+	}
+
+	destination := pickup
+	if pickedUp {
+		destination = dropoff
+	}
+	matrix, err := s.GetDistanceFromCurrentLocation(ctx, driverLocation, []schemas.Point{destination})
+	if err != nil {
+		log.Printf("Failed to get the ETA of ride %s from Goong, using the planned route: %v", rideID, err)
+		return providerETA{}, false, nil
+	}
+	if len(matrix.Rows) == 0 || len(matrix.Rows[0].Elements) == 0 {
+		return providerETA{}, false, nil
+	}
+
+	element := matrix.Rows[0].Elements[0]
+	result := providerETA{
+		DropoffDistance: float64(element.Distance.Value) / 1000,
+		DropoffDuration: element.Duration.Value,
+		FetchedAt:       time.Now(),
+	}
+	if !pickedUp {
+		result.PickupDistance = result.DropoffDistance
+		result.PickupDuration = result.DropoffDuration
+		result.DropoffDistance += legDistance
+		result.DropoffDuration += legDuration
+	}
+
+	cached, err := json.Marshal(result)
+	if err != nil {
+		return providerETA{}, false, err
+	}
+	if err := s.redisClient.Set(ctx, resultKey, cached, interval).Err(); err != nil {
+		return providerETA{}, false, err
+	}
+	return result, true, nil
+}
+
 // Make sure MapsService implements IMapsService
 var _ IMapService = (*MapService)(nil)
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"shareway/infra/db/migration"
+	"shareway/infra/task"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+type IModerationService interface {
+	BlockUser(blockerID, blockedID uuid.UUID) error
+	UnblockUser(blockerID, blockedID uuid.UUID) error
+	GetBlockedUsers(userID uuid.UUID) ([]migration.UserBlock, error)
+	CheckNotBlocked(userID1, userID2 uuid.UUID) error
+	ReportUser(req schemas.ReportUserRequest, userID uuid.UUID) (migration.UserReport, error)
+	GetUserReports(req schemas.GetUserReportsRequest) ([]migration.UserReport, error)
+	ReviewUserReport(reportID, adminID uuid.UUID) (migration.UserReport, error)
+	CloseUserReport(req schemas.CloseUserReportRequest, adminID uuid.UUID) (migration.UserReport, error)
+}
+
+type ModerationService struct {
+	repo        repository.IModerationRepository
+	cfg         util.Config
+	asynqClient *task.AsyncClient
+}
+
+func NewModerationService(repo repository.IModerationRepository, cfg util.Config, asynqClient *task.AsyncClient) IModerationService {
+	return &ModerationService{
+		repo:        repo,
+		cfg:         cfg,
+		asynqClient: asynqClient,
+	}
+}
+
+var (
+	ErrSelfModeration = errors.New("users cannot block or report themselves")
+	ErrUserBlocked    = repository.ErrUserBlocked
+)
+
+// BlockUser hides the two users from each other, in the suggestions, the ride requests and the chat
+func (s *ModerationService) BlockUser(blockerID, blockedID uuid.UUID) error {
+	if err := s.checkTarget(blockerID, blockedID); err != nil {
+		return err
+	}
+	return s.repo.BlockUser(blockerID, blockedID)
+}
+
+// UnblockUser lifts a block the user created
+func (s *ModerationService) UnblockUser(blockerID, blockedID uuid.UUID) error {
+	return s.repo.UnblockUser(blockerID, blockedID)
+}
+
+// GetBlockedUsers returns the users the user blocked
+func (s *ModerationService) GetBlockedUsers(userID uuid.UUID) ([]migration.UserBlock, error) {
+	return s.repo.GetBlockedUsers(userID)
+}
+
+// CheckNotBlocked returns ErrUserBlocked if one of the two users blocked the other
+func (s *ModerationService) CheckNotBlocked(userID1, userID2 uuid.UUID) error {
+	blocked, err := s.repo.IsBlocked(userID1, userID2)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrUserBlocked
+	}
+	return nil
+}
+
+// ReportUser opens a case against a user for the admins, the ride and the chat message it refers to must
+// have been shared by the two users
+func (s *ModerationService) ReportUser(req schemas.ReportUserRequest, userID uuid.UUID) (migration.UserReport, error) {
+	if err := s.checkTarget(userID, req.UserID); err != nil {
+		return migration.UserReport{}, err
+	}
+
+	if req.RideID != nil {
+		shared, err := s.repo.IsRideShared(*req.RideID, userID, req.UserID)
+		if err != nil {
+			return migration.UserReport{}, err
+		}
+		if !shared {
+			return migration.UserReport{}, repository.ErrUserReportUnreachable
+		}
+	}
+	if req.ChatID != nil {
+		shared, err := s.repo.IsChatShared(*req.ChatID, userID, req.UserID)
+		if err != nil {
+			return migration.UserReport{}, err
+		}
+		if !shared {
+			return migration.UserReport{}, repository.ErrUserReportUnreachable
+		}
+	}
+
+	report, err := s.repo.CreateUserReport(migration.UserReport{
+		ReporterID:  userID,
+		ReportedID:  req.UserID,
+		Category:    req.Category,
+		Description: strings.TrimSpace(req.Description),
+		RideID:      req.RideID,
+		ChatID:      req.ChatID,
+	})
+	if err != nil {
+		return report, err
+	}
+
+	log.Info().Str("userReportID", report.ID.String()).Str("category", report.Category).Msg("User reported")
+	return report, nil
+}
+
+// GetUserReports returns the latest reports for the admins
+func (s *ModerationService) GetUserReports(req schemas.GetUserReportsRequest) ([]migration.UserReport, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = 50
+	}
+	return s.repo.GetUserReports(req.Status, req.Category, req.ReportedID, limit)
+}
+
+// ReviewUserReport records that an admin is looking into an open report
+func (s *ModerationService) ReviewUserReport(reportID, adminID uuid.UUID) (migration.UserReport, error) {
+	report, err := s.repo.ReviewUserReport(reportID, adminID)
+	if err != nil {
+		return report, err
+	}
+
+	log.Info().Str("userReportID", report.ID.String()).Str("adminID", adminID.String()).Msg("User report reviewed")
+	return report, nil
+}
+
+// CloseUserReport closes a report, a dismissed report needs no action and a warned user is notified
+func (s *ModerationService) CloseUserReport(req schemas.CloseUserReportRequest, adminID uuid.UUID) (migration.UserReport, error) {
+	status, action := "dismissed", "none"
+	if req.Action == "warn" {
+		status, action = "resolved", "warned"
+	}
+
+	report, err := s.repo.CloseUserReport(req.ReportID, adminID, status, action, req.Note)
+	if err != nil {
+		return report, err
+	}
+
+	log.Info().Str("userReportID", report.ID.String()).Str("adminID", adminID.String()).Str("action", action).Msg("User report closed")
+	if action == "warned" {
+		notifyUser(s.asynqClient, report.Reported, "user-warning", schemas.UserWarningNotification{
+			ReportID: report.ID,
+			Category: report.Category,
+		}, "Cảnh báo từ ShareWay", "Tài khoản của bạn đã bị báo cáo vi phạm quy định cộng đồng, vui lòng tuân thủ quy định để tiếp tục sử dụng ShareWay")
+	}
+	return report, nil
+}
+
+// checkTarget makes sure a user blocks or reports another existing user
+func (s *ModerationService) checkTarget(userID, targetID uuid.UUID) error {
+	if userID == targetID {
+		return ErrSelfModeration
+	}
+	exists, err := s.repo.UserExists(targetID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrUserNotFound
+	}
+	return nil
+}
+
+// Make sure the ModerationService implements the IModerationService interface
+var _ IModerationService = (*ModerationService)(nil)
@@ -9,6 +9,7 @@ import (
 	"shareway/util"
 
 	"github.com/twilio/twilio-go"
+	twilioMessageApi "github.com/twilio/twilio-go/rest/api/v2010"
 	twilioApi "github.com/twilio/twilio-go/rest/verify/v2"
 )
 
@@ -21,6 +22,7 @@ import (
 type IOTPService interface {
 	SendOTP(ctx context.Context, phoneNumber string) (string, error)
 	VerifyOTP(ctx context.Context, phoneNumber, code string) error
+	SendSMS(ctx context.Context, phoneNumber, body string) error
 }
 
 // OTPService implements IOTPService and handles OTP-related operations
@@ -118,5 +120,24 @@ func (s *OTPService) VerifyOTP(ctx context.Context, phoneNumber, code string) er
 	return nil
 }
 
+// SendSMS sends a text message to the specified phone number, e.g. the alerts to the emergency contacts
+func (s *OTPService) SendSMS(ctx context.Context, phoneNumber, body string) error {
+	params := &twilioMessageApi.CreateMessageParams{}
+	params.SetTo(phoneNumber) // Phone number in E.164 format already
+	params.SetFrom(s.cfg.TwilioFromNumber)
+	params.SetBody(body)
+
+	resp, err := s.twilioClient.Api.CreateMessage(params)
+	if err != nil {
+		return err
+	}
+
+	if resp.Sid == nil {
+		return errors.New("failed to get message SID")
+	}
+
+	return nil
+}
+
 // Ensure OTPService implements IOTPService
 var _ IOTPService = (*OTPService)(nil)
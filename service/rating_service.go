@@ -0,0 +1,94 @@
+package service
+
+import (
+	"errors"
+	"shareway/infra/db/migration"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type IRatingService interface {
+	CreateRating(req schemas.CreateRatingRequest, userID uuid.UUID) (migration.Rating, migration.User, error)
+	GetRatingsReceived(rateeID uuid.UUID, page, limit int) ([]migration.Rating, migration.User, int64, error)
+}
+
+type RatingService struct {
+	repo     repository.IRatingRepository
+	rideRepo repository.IRideRepository
+	cfg      util.Config
+}
+
+func NewRatingService(repo repository.IRatingRepository, rideRepo repository.IRideRepository, cfg util.Config) IRatingService {
+	return &RatingService{
+		repo:     repo,
+		rideRepo: rideRepo,
+		cfg:      cfg,
+	}
+}
+
+var (
+	ErrRideNotRateable    = errors.New("only completed rides can be rated")
+	ErrRatingWindowClosed = errors.New("the rating window of the ride has closed")
+)
+
+// CreateRating rates the counterpart of the user in a completed ride, the driver rates the passenger and the
+// passenger rates the driver, once each and within the rating window after the end of the ride
+func (s *RatingService) CreateRating(req schemas.CreateRatingRequest, userID uuid.UUID) (migration.Rating, migration.User, error) {
+	ride, err := s.rideRepo.GetRideByID(req.RideID)
+	if err != nil {
+		return migration.Rating{}, migration.User{}, err
+	}
+
+	var rateeID uuid.UUID
+	switch userID {
+	case ride.RideOffer.UserID:
+		rateeID = ride.RideRequest.UserID
+	case ride.RideRequest.UserID:
+		rateeID = ride.RideOffer.UserID
+	default:
+		return migration.Rating{}, migration.User{}, ErrNotRideParticipant
+	}
+
+	if ride.Status != "completed" {
+		return migration.Rating{}, migration.User{}, ErrRideNotRateable
+	}
+	completedAt, err := s.rideRepo.GetRideCompletedAt(ride)
+	if err != nil {
+		return migration.Rating{}, migration.User{}, err
+	}
+	if time.Now().After(completedAt.Add(time.Duration(s.cfg.RatingWindow) * time.Hour)) {
+		return migration.Rating{}, migration.User{}, ErrRatingWindowClosed
+	}
+
+	return s.repo.CreateRating(migration.Rating{
+		Rating:  float64(req.Rating),
+		Comment: strings.TrimSpace(req.Comment),
+		Tags:    strings.Join(req.Tags, ","),
+		RaterID: userID,
+		RateeID: rateeID,
+		RideID:  ride.ID,
+	})
+}
+
+// GetRatingsReceived returns a page of the ratings received by a user, the user with its cached average
+// and the number of ratings over all pages
+func (s *RatingService) GetRatingsReceived(rateeID uuid.UUID, page, limit int) ([]migration.Rating, migration.User, int64, error) {
+	ratee, err := s.repo.GetRatedUser(rateeID)
+	if err != nil {
+		return nil, migration.User{}, 0, err
+	}
+
+	ratings, total, err := s.repo.GetRatingsReceived(rateeID, page, limit)
+	if err != nil {
+		return nil, migration.User{}, 0, err
+	}
+	return ratings, ratee, total, nil
+}
+
+// Make sure the RatingService implements the IRatingService interface
+var _ IRatingService = (*RatingService)(nil)
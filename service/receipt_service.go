@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"shareway/helper"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type IReceiptService interface {
+	GetRideReceipt(rideID, userID uuid.UUID, lang string) (schemas.RideReceipt, error)
+	RenderRideReceipt(receipt schemas.RideReceipt, format string) ([]byte, error)
+}
+
+type ReceiptService struct {
+	rideRepo repository.IRideRepository
+	cfg      util.Config
+
+	// The font of the PDF receipts is read once, on the first PDF
+	fontOnce sync.Once
+	font     []byte
+	fontErr  error
+}
+
+func NewReceiptService(rideRepo repository.IRideRepository, cfg util.Config) IReceiptService {
+	return &ReceiptService{
+		rideRepo: rideRepo,
+		cfg:      cfg,
+	}
+}
+
+var (
+	ErrReceiptNotAvailable = errors.New("the receipt is only available once the ride is completed")
+)
+
+// GetRideReceipt builds the receipt of a completed ride for its driver or its passenger, in the given language
+func (s *ReceiptService) GetRideReceipt(rideID, userID uuid.UUID, lang string) (schemas.RideReceipt, error) {
+	ride, err := s.rideRepo.GetRideForReceipt(rideID)
+	if err != nil {
+		return schemas.RideReceipt{}, err
+	}
+	if ride.RideOffer.UserID != userID && ride.RideRequest.UserID != userID {
+		return schemas.RideReceipt{}, ErrNotRideParticipant
+	}
+	if ride.Status != "completed" {
+		return schemas.RideReceipt{}, ErrReceiptNotAvailable
+	}
+
+	return helper.BuildRideReceipt(ride, lang, helper.UTCOffsetLocation(s.cfg.AppUTCOffset))
+}
+
+// RenderRideReceipt renders a receipt as a PDF or as an HTML page
+func (s *ReceiptService) RenderRideReceipt(receipt schemas.RideReceipt, format string) ([]byte, error) {
+	if format == "html" {
+		return helper.RenderReceiptHTML(receipt)
+	}
+
+	s.fontOnce.Do(func() {
+		s.font, s.fontErr = os.ReadFile(s.cfg.ReceiptFontPath)
+		if s.fontErr != nil {
+			s.fontErr = fmt.Errorf("failed to read the receipt font: %w", s.fontErr)
+		}
+	})
+	if s.fontErr != nil {
+		return nil, s.fontErr
+	}
+	return helper.RenderReceiptPDF(receipt, s.font)
+}
+
+// Make sure the ReceiptService implements the IReceiptService interface
+var _ IReceiptService = (*ReceiptService)(nil)
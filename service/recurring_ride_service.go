@@ -0,0 +1,401 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/infra/task"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	dateLayout          = "2006-01-02"
+	departureTimeLayout = "15:04"
+)
+
+type IRecurringRideService interface {
+	CreateRecurringRideOffer(ctx context.Context, input schemas.CreateRecurringRideOfferRequest, userID uuid.UUID) (migration.RecurringRideOffer, error)
+	GetRecurringRideOffers(userID uuid.UUID) ([]migration.RecurringRideOffer, error)
+	UpdateRecurringRideOffer(ctx context.Context, input schemas.UpdateRecurringRideOfferRequest, userID uuid.UUID) (migration.RecurringRideOffer, error)
+	PauseRecurringRideOffer(recurringRideOfferID, userID uuid.UUID, isPaused bool) error
+	SkipOccurrence(input schemas.SkipRecurringRideOccurrenceRequest, userID uuid.UUID) error
+	MaterializeRecurringRideOffers() error
+}
+
+type RecurringRideService struct {
+	repo        repository.IRecurringRideRepository
+	mapsRepo    repository.IMapsRepository
+	mapService  IMapService
+	cfg         util.Config
+	asynqClient *task.AsyncClient
+}
+
+func NewRecurringRideService(repo repository.IRecurringRideRepository, mapsRepo repository.IMapsRepository, mapService IMapService, cfg util.Config, asynqClient *task.AsyncClient) IRecurringRideService {
+	return &RecurringRideService{
+		repo:        repo,
+		mapsRepo:    mapsRepo,
+		mapService:  mapService,
+		cfg:         cfg,
+		asynqClient: asynqClient,
+	}
+}
+
+// CreateRecurringRideOffer fetches the route once, stores the template and publishes the first occurrences
+func (s *RecurringRideService) CreateRecurringRideOffer(ctx context.Context, input schemas.CreateRecurringRideOfferRequest, userID uuid.UUID) (migration.RecurringRideOffer, error) {
+	startTime, err := ParseStartTime(input.StartTime)
+	if err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+	localStartTime := startTime.In(s.location())
+
+	if input.Frequency == "weekly" && len(input.DaysOfWeek) == 0 {
+		return migration.RecurringRideOffer{}, fmt.Errorf("days_of_week is required for the weekly frequency")
+	}
+
+	untilDate, err := parseOptionalDate(input.UntilDate)
+	if err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+
+	skipDates, err := parseDates(input.SkipDates)
+	if err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+
+	recurringRideOffer := migration.RecurringRideOffer{
+		UserID:        userID,
+		VehicleID:     input.VehicleID,
+		Seats:         input.Seats,
+		DepartureTime: localStartTime.Format(departureTimeLayout),
+		Frequency:     input.Frequency,
+		DaysOfWeek:    formatDaysOfWeek(input.DaysOfWeek),
+		StartDate:     truncateToDay(localStartTime),
+		UntilDate:     untilDate,
+	}
+	if err := s.setRoute(ctx, &recurringRideOffer, input.PlaceList); err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+
+	recurringRideOffer, err = s.repo.CreateRecurringRideOffer(recurringRideOffer, skipDates)
+	if err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+
+	// Publish the occurrences within the horizon right away instead of waiting for the scheduled job
+	recurringRideOffer, err = s.repo.GetRecurringRideOfferByID(recurringRideOffer.ID, userID)
+	if err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+	s.materialize(recurringRideOffer, time.Now().UTC())
+
+	return recurringRideOffer, nil
+}
+
+// GetRecurringRideOffers returns all recurring ride offers of the driver
+func (s *RecurringRideService) GetRecurringRideOffers(userID uuid.UUID) ([]migration.RecurringRideOffer, error) {
+	return s.repo.GetRecurringRideOffersByUserID(userID)
+}
+
+// UpdateRecurringRideOffer edits the series, future occurrences nobody booked are republished with the new settings
+func (s *RecurringRideService) UpdateRecurringRideOffer(ctx context.Context, input schemas.UpdateRecurringRideOfferRequest, userID uuid.UUID) (migration.RecurringRideOffer, error) {
+	recurringRideOffer, err := s.repo.GetRecurringRideOfferByID(input.RecurringRideOfferID, userID)
+	if err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+
+	if len(input.PlaceList) > 0 {
+		if err := s.setRoute(ctx, &recurringRideOffer, input.PlaceList); err != nil {
+			return migration.RecurringRideOffer{}, err
+		}
+	}
+	if input.DepartureTime != "" {
+		recurringRideOffer.DepartureTime = input.DepartureTime
+	}
+	if input.VehicleID != nil {
+		recurringRideOffer.VehicleID = *input.VehicleID
+		// Seats are checked again against the new vehicle
+		recurringRideOffer.Seats = 0
+	}
+	if input.Seats > 0 {
+		recurringRideOffer.Seats = input.Seats
+	}
+	if input.Frequency != "" {
+		recurringRideOffer.Frequency = input.Frequency
+	}
+	if input.DaysOfWeek != nil {
+		recurringRideOffer.DaysOfWeek = formatDaysOfWeek(input.DaysOfWeek)
+	}
+	if recurringRideOffer.Frequency == "weekly" && recurringRideOffer.DaysOfWeek == "" {
+		return migration.RecurringRideOffer{}, fmt.Errorf("days_of_week is required for the weekly frequency")
+	}
+	if input.UntilDate != nil {
+		recurringRideOffer.UntilDate, err = parseOptionalDate(*input.UntilDate)
+		if err != nil {
+			return migration.RecurringRideOffer{}, err
+		}
+	}
+
+	var skipDates []time.Time
+	if input.SkipDates != nil {
+		skipDates, err = parseDates(input.SkipDates)
+		if err != nil {
+			return migration.RecurringRideOffer{}, err
+		}
+		// An empty list clears the skip dates
+		if skipDates == nil {
+			skipDates = []time.Time{}
+		}
+	}
+
+	if err := s.repo.UpdateRecurringRideOffer(recurringRideOffer, skipDates); err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+
+	now := time.Now().UTC()
+	if err := s.repo.RemoveUnbookedOccurrences(recurringRideOffer.ID, now, userID); err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+
+	recurringRideOffer, err = s.repo.GetRecurringRideOfferByID(recurringRideOffer.ID, userID)
+	if err != nil {
+		return migration.RecurringRideOffer{}, err
+	}
+	if !recurringRideOffer.IsPaused {
+		s.materialize(recurringRideOffer, now)
+	}
+
+	return recurringRideOffer, nil
+}
+
+// PauseRecurringRideOffer pauses the series (withdrawing future occurrences nobody booked) or resumes it
+func (s *RecurringRideService) PauseRecurringRideOffer(recurringRideOfferID, userID uuid.UUID, isPaused bool) error {
+	if err := s.repo.SetRecurringRideOfferPaused(recurringRideOfferID, userID, isPaused); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if isPaused {
+		return s.repo.RemoveUnbookedOccurrences(recurringRideOfferID, now, userID)
+	}
+
+	recurringRideOffer, err := s.repo.GetRecurringRideOfferByID(recurringRideOfferID, userID)
+	if err != nil {
+		return err
+	}
+	s.materialize(recurringRideOffer, now)
+
+	return nil
+}
+
+// SkipOccurrence cancels a single occurrence of the series
+func (s *RecurringRideService) SkipOccurrence(input schemas.SkipRecurringRideOccurrenceRequest, userID uuid.UUID) error {
+	day, err := time.Parse(dateLayout, input.Date)
+	if err != nil {
+		return fmt.Errorf("failed to parse date: %w", err)
+	}
+
+	// Make sure the recurring ride offer belongs to the driver
+	if _, err := s.repo.GetRecurringRideOfferByID(input.RecurringRideOfferID, userID); err != nil {
+		return err
+	}
+
+	if err := s.repo.CancelOccurrence(input.RecurringRideOfferID, localDayStart(day, s.location()), userID); err != nil {
+		return err
+	}
+
+	return s.repo.AddSkipDate(input.RecurringRideOfferID, day)
+}
+
+// MaterializeRecurringRideOffers publishes the ride offers of every active series within the configured horizon
+func (s *RecurringRideService) MaterializeRecurringRideOffers() error {
+	now := time.Now().UTC()
+	recurringRideOffers, err := s.repo.GetActiveRecurringRideOffers(truncateToDay(now.In(s.location())))
+	if err != nil {
+		return err
+	}
+
+	for _, recurringRideOffer := range recurringRideOffers {
+		s.materialize(recurringRideOffer, now)
+	}
+
+	return nil
+}
+
+// materialize creates the missing ride offers of a series from now until the horizon,
+// failures only skip the occurrence (for example when the driver already has a ride at that time).
+// The days and the departure time of the series are local, the start times are stored in UTC
+func (s *RecurringRideService) materialize(recurringRideOffer migration.RecurringRideOffer, now time.Time) {
+	location := s.location()
+
+	var route schemas.GoongDirectionsResponse
+	if err := json.Unmarshal([]byte(recurringRideOffer.Route), &route); err != nil {
+		log.Error().Err(err).Str("recurringRideOfferID", recurringRideOffer.ID.String()).Msg("Failed to decode cached route")
+		return
+	}
+
+	departure, err := time.Parse(departureTimeLayout, recurringRideOffer.DepartureTime)
+	if err != nil {
+		log.Error().Err(err).Str("recurringRideOfferID", recurringRideOffer.ID.String()).Msg("Invalid departure time")
+		return
+	}
+
+	skipped := make(map[time.Time]bool, len(recurringRideOffer.SkipDates))
+	for _, skipDate := range recurringRideOffer.SkipDates {
+		skipped[truncateToDay(skipDate.Date.UTC())] = true
+	}
+
+	today := truncateToDay(now.In(location))
+	for i := 0; i <= s.cfg.RecurringRideHorizonDays; i++ {
+		day := today.AddDate(0, 0, i)
+		dayStart := localDayStart(day, location)
+		startTime := dayStart.Add(time.Duration(departure.Hour())*time.Hour + time.Duration(departure.Minute())*time.Minute).UTC()
+
+		if !occursOn(recurringRideOffer, day) || skipped[day] || !startTime.After(now) {
+			continue
+		}
+
+		exists, err := s.repo.HasOccurrence(recurringRideOffer.ID, dayStart)
+		if err != nil {
+			log.Error().Err(err).Str("recurringRideOfferID", recurringRideOffer.ID.String()).Msg("Failed to check occurrence")
+			return
+		}
+		if exists {
+			continue
+		}
+
+		rideOfferID, err := s.mapsRepo.CreateGiveRide(route, "", recurringRideOffer.UserID, routeOrigin(route), startTime, recurringRideOffer.VehicleID, recurringRideOffer.Seats, nil, &recurringRideOffer.ID)
+		if err != nil {
+			log.Warn().Err(err).
+				Str("recurringRideOfferID", recurringRideOffer.ID.String()).
+				Time("startTime", startTime).
+				Msg("Failed to materialise recurring ride offer occurrence")
+			continue
+		}
+
+		if err := s.asynqClient.EnqueueRouteAlertMatch(schemas.RouteAlertMatchPayload{RideOfferID: rideOfferID}); err != nil {
+			log.Error().Err(err).Str("rideOfferID", rideOfferID.String()).Msg("Failed to enqueue route alert match")
+		}
+	}
+}
+
+// setRoute fetches the route from Goong and caches it on the recurring ride offer
+func (s *RecurringRideService) setRoute(ctx context.Context, recurringRideOffer *migration.RecurringRideOffer, placeList []string) error {
+	route, err := s.mapService.GetRoute(ctx, placeList)
+	if err != nil {
+		return err
+	}
+	if len(route.Routes) == 0 || len(route.Routes[0].Legs) == 0 {
+		return fmt.Errorf("invalid route data")
+	}
+
+	encodedRoute, err := json.Marshal(route)
+	if err != nil {
+		return fmt.Errorf("failed to encode route: %w", err)
+	}
+
+	legs := route.Routes[0].Legs
+	recurringRideOffer.Route = string(encodedRoute)
+	recurringRideOffer.StartAddress = legs[0].Start_address
+	recurringRideOffer.EndAddress = legs[len(legs)-1].End_address
+
+	return nil
+}
+
+// occursOn checks if the series has an occurrence on the given local day (skip dates excluded)
+func occursOn(recurringRideOffer migration.RecurringRideOffer, day time.Time) bool {
+	if day.Before(truncateToDay(recurringRideOffer.StartDate.UTC())) {
+		return false
+	}
+	if recurringRideOffer.UntilDate != nil && day.After(truncateToDay(recurringRideOffer.UntilDate.UTC())) {
+		return false
+	}
+
+	switch recurringRideOffer.Frequency {
+	case "daily":
+		return true
+	case "weekdays":
+		return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	case "weekly":
+		for _, weekday := range ParseDaysOfWeek(recurringRideOffer.DaysOfWeek) {
+			if day.Weekday() == time.Weekday(weekday) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ParseDaysOfWeek converts the stored days of the week back to a list
+func ParseDaysOfWeek(daysOfWeek string) []int {
+	days := make([]int, 0, 7)
+	for _, part := range strings.Split(daysOfWeek, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+func formatDaysOfWeek(daysOfWeek []int) string {
+	parts := make([]string, len(daysOfWeek))
+	for i, day := range daysOfWeek {
+		parts[i] = strconv.Itoa(day)
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseOptionalDate(input string) (*time.Time, error) {
+	if input == "" {
+		return nil, nil
+	}
+
+	day, err := time.Parse(dateLayout, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse date %s: %w", input, err)
+	}
+
+	return &day, nil
+}
+
+func parseDates(inputs []string) ([]time.Time, error) {
+	var days []time.Time
+	for _, input := range inputs {
+		day, err := time.Parse(dateLayout, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date %s: %w", input, err)
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// location returns the time zone of the days and the departure time of the series
+func (s *RecurringRideService) location() *time.Location {
+	return helper.UTCOffsetLocation(s.cfg.AppUTCOffset)
+}
+
+// truncateToDay returns the calendar day of t in its own time zone, as midnight UTC like the stored dates
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// localDayStart returns the instant the given calendar day starts in the location
+func localDayStart(day time.Time, location *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, location)
+}
+
+// Make sure the RecurringRideService implements the IRecurringRideService interface
+var _ IRecurringRideService = (*RecurringRideService)(nil)
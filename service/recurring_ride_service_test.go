@@ -0,0 +1,82 @@
+package service
+
+import (
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"testing"
+	"time"
+)
+
+func TestOccursOn(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	until := day(time.November, 15)
+
+	tests := []struct {
+		name      string
+		frequency string
+		days      string
+		until     *time.Time
+		day       time.Time
+		want      bool
+	}{
+		{"daily on a Sunday", "daily", "", nil, day(time.November, 10), true},
+		{"before the start date", "daily", "", nil, day(time.November, 3), false},
+		{"on the start date", "daily", "", nil, day(time.November, 4), true},
+		{"on the until date", "daily", "", &until, day(time.November, 15), true},
+		{"after the until date", "daily", "", &until, day(time.November, 16), false},
+		{"weekdays on a Friday", "weekdays", "", nil, day(time.November, 8), true},
+		{"weekdays on a Saturday", "weekdays", "", nil, day(time.November, 9), false},
+		{"weekdays on a Sunday", "weekdays", "", nil, day(time.November, 10), false},
+		{"weekly on a listed day", "weekly", "1,3", nil, day(time.November, 6), true},
+		{"weekly on Sunday", "weekly", "0", nil, day(time.November, 10), true},
+		{"weekly on another day", "weekly", "1,3", nil, day(time.November, 7), false},
+		{"weekly without days", "weekly", "", nil, day(time.November, 4), false},
+		{"unknown frequency", "monthly", "", nil, day(time.November, 4), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recurringRideOffer := migration.RecurringRideOffer{
+				Frequency:  tt.frequency,
+				DaysOfWeek: tt.days,
+				StartDate:  day(time.November, 4), // Monday
+				UntilDate:  tt.until,
+			}
+			if got := occursOn(recurringRideOffer, tt.day); got != tt.want {
+				t.Errorf("occursOn(%s) = %v, want %v", tt.day.Format(dateLayout), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalDays(t *testing.T) {
+	location := helper.UTCOffsetLocation(7)
+	instant := time.Date(2024, 11, 4, 20, 0, 0, 0, time.UTC) // 03:00 on Tuesday at UTC+7
+
+	day := truncateToDay(instant.In(location))
+	if want := time.Date(2024, 11, 5, 0, 0, 0, 0, time.UTC); !day.Equal(want) {
+		t.Errorf("truncateToDay() = %v, want %v", day, want)
+	}
+
+	start := localDayStart(day, location)
+	if want := time.Date(2024, 11, 4, 17, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("localDayStart() = %v, want %v", start.UTC(), want)
+	}
+}
+
+func TestParseDaysOfWeek(t *testing.T) {
+	got := ParseDaysOfWeek(" 1, 3,x,5")
+	want := []int{1, 3, 5}
+	if len(got) != len(want) {
+		t.Fatalf("ParseDaysOfWeek() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ParseDaysOfWeek() = %v, want %v", got, want)
+		}
+	}
+	if got := formatDaysOfWeek(want); got != "1,3,5" {
+		t.Errorf("formatDaysOfWeek() = %q, want %q", got, "1,3,5")
+	}
+}
@@ -1,19 +1,28 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"shareway/helper"
 	"shareway/infra/db/migration"
+	"shareway/infra/task"
 	"shareway/infra/ws"
 	"shareway/repository"
 	"shareway/schemas"
 	"shareway/util"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 )
 
 type RideService struct {
-	repo repository.IRideRepository
-	hub  *ws.Hub
-	cfg  util.Config
+	repo        repository.IRideRepository
+	trailRepo   repository.IRideTrailRepository
+	hub         *ws.Hub
+	cfg         util.Config
+	asynqClient *task.AsyncClient
 }
 
 type IRideService interface {
@@ -22,20 +31,39 @@ type IRideService interface {
 	GetRideOfferByID(rideOfferID uuid.UUID) (migration.RideOffer, error)
 	GetRideRequestByID(rideRequestID uuid.UUID) (migration.RideRequest, error)
 	GetTransactionByRideID(rideID uuid.UUID) (migration.Transaction, error)
-	AcceptRideRequest(rideOfferID, rideRequestID, vehicleID uuid.UUID) (migration.Ride, error)
+	AcceptRideRequest(rideOfferID, rideRequestID, vehicleID, actorID uuid.UUID, suggestMeetingPoints repository.MeetingPointsFunc) (migration.Ride, error)
 	CreateRideTransaction(rideID uuid.UUID, Fare float64, paymentMethod string, payerID uuid.UUID, receiverID uuid.UUID) (migration.Transaction, error)
+	IssuePickupCode(rideID, userID uuid.UUID) (schemas.IssuePickupCodeResponse, error)
 	StartRide(req schemas.StartRideRequest, userID uuid.UUID) (migration.Ride, error)
 	EndRide(req schemas.EndRideRequest, userID uuid.UUID) (migration.Ride, error)
 	UpdateRideLocation(req schemas.UpdateRideLocationRequest, userID uuid.UUID) (migration.Ride, error)
+	UpdateRiderLocation(req schemas.UpdateRiderLocationRequest, userID uuid.UUID) (migration.Ride, error)
 	CancelRide(req schemas.CancelRideRequest, userID uuid.UUID) (migration.Ride, error)
 	GetAllPendingRide(userID uuid.UUID) ([]migration.RideOffer, []migration.RideRequest, error)
+	ExpireStaleRides() error
+	GetRideHistory(req schemas.GetRideHistoryRequest, userID uuid.UUID) ([]migration.Ride, string, error)
+	GetRideHistorySummary(req schemas.GetRideHistorySummaryRequest, userID uuid.UUID) (schemas.GetRideHistorySummaryResponse, error)
 }
 
-func NewRideService(repo repository.IRideRepository, hub *ws.Hub, cfg util.Config) IRideService {
+var (
+	ErrNotRideDriver       = errors.New("only the driver of the ride can start it")
+	ErrNotRidePassenger    = errors.New("only the passenger of the ride can issue a pickup code")
+	ErrRideNotScheduled    = errors.New("ride is not scheduled")
+	ErrPickupCodeRequired  = errors.New("pickup code is required to start the ride")
+	ErrPickupCodeNotIssued = errors.New("the passenger has not issued a pickup code yet")
+	ErrPickupCodeExpired   = errors.New("pickup code has expired, the passenger must issue a new one")
+	ErrPickupCodeLocked    = errors.New("too many wrong pickup codes, the passenger must issue a new one")
+	ErrInvalidPickupCode   = errors.New("invalid pickup code")
+	ErrInvalidSummaryRange = errors.New("the first month of the summary is after the last one")
+)
+
+func NewRideService(repo repository.IRideRepository, trailRepo repository.IRideTrailRepository, hub *ws.Hub, cfg util.Config, asynqClient *task.AsyncClient) IRideService {
 	return &RideService{
-		repo: repo,
-		hub:  hub,
-		cfg:  cfg,
+		repo:        repo,
+		trailRepo:   trailRepo,
+		hub:         hub,
+		cfg:         cfg,
+		asynqClient: asynqClient,
 	}
 }
 
@@ -54,9 +82,10 @@ func (s *RideService) GetRideRequestByID(rideRequestID uuid.UUID) (migration.Rid
 	return s.repo.GetRideRequestByID(rideRequestID)
 }
 
-// AcceptGiveRideRequest accepts a give ride request
-func (s *RideService) AcceptRideRequest(rideOfferID, rideRequestID, vehicleID uuid.UUID) (migration.Ride, error) {
-	return s.repo.AcceptRideRequest(rideOfferID, rideRequestID, vehicleID)
+// AcceptGiveRideRequest accepts a give ride request, the meeting points are suggested and stored in the same
+// transaction as the ride
+func (s *RideService) AcceptRideRequest(rideOfferID, rideRequestID, vehicleID, actorID uuid.UUID, suggestMeetingPoints repository.MeetingPointsFunc) (migration.Ride, error) {
+	return s.repo.AcceptRideRequest(rideOfferID, rideRequestID, vehicleID, actorID, suggestMeetingPoints)
 }
 
 // CreateRideTransaction creates a transaction for a ride
@@ -64,9 +93,114 @@ func (s *RideService) CreateRideTransaction(rideID uuid.UUID, Fare float64, paym
 	return s.repo.CreateRideTransaction(rideID, Fare, paymentMethod, payerID, receiverID)
 }
 
-// StartRide starts a ride
+// IssuePickupCode issues a new pickup code the hitcher shows to the driver, as a PIN or as a QR code,
+// the previous code of the ride stops working
+func (s *RideService) IssuePickupCode(rideID, userID uuid.UUID) (schemas.IssuePickupCodeResponse, error) {
+	ride, err := s.repo.GetRideByID(rideID)
+	if err != nil {
+		return schemas.IssuePickupCodeResponse{}, err
+	}
+	if ride.RideRequest.UserID != userID {
+		return schemas.IssuePickupCodeResponse{}, ErrNotRidePassenger
+	}
+	if ride.Status != "scheduled" {
+		return schemas.IssuePickupCodeResponse{}, ErrRideNotScheduled
+	}
+
+	pin, err := helper.GeneratePickupPIN()
+	if err != nil {
+		return schemas.IssuePickupCodeResponse{}, err
+	}
+	pinHash := helper.HashPickupPIN(s.pickupCodeSecret(), ride.ID, pin)
+	expiresAt := time.Now().Add(time.Duration(s.cfg.PickupCodeTTL) * time.Minute)
+
+	if err := s.repo.IssuePickupCode(ride.ID, pinHash, expiresAt); err != nil {
+		return schemas.IssuePickupCodeResponse{}, err
+	}
+
+	return schemas.IssuePickupCodeResponse{
+		RideID:    ride.ID,
+		PIN:       pin,
+		QRPayload: helper.SignPickupQR(s.pickupCodeSecret(), ride.ID, pinHash, expiresAt),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// StartRide starts a ride once the driver has verified the hitcher with the pickup code
 func (s *RideService) StartRide(req schemas.StartRideRequest, userID uuid.UUID) (migration.Ride, error) {
-	return s.repo.StartRide(req, userID)
+	verification, err := s.verifyPickupCode(req, userID)
+	if err != nil {
+		return migration.Ride{}, err
+	}
+
+	return s.repo.StartRide(req, userID, verification)
+}
+
+// verifyPickupCode checks the pickup code submitted by the driver and returns how the hitcher was verified.
+// Every code takes one of the attempts of the ride before it is checked, so parallel guesses cannot go past
+// the limit, and the attempt is given back when the code is right
+func (s *RideService) verifyPickupCode(req schemas.StartRideRequest, userID uuid.UUID) (string, error) {
+	ride, err := s.repo.GetRideByID(req.RideID)
+	if err != nil {
+		return "", err
+	}
+	if ride.RideOffer.UserID != userID {
+		return "", ErrNotRideDriver
+	}
+
+	if req.PickupCode == "" {
+		if s.cfg.RideTestMode {
+			return helper.PickupVerificationTestMode, nil
+		}
+		return "", ErrPickupCodeRequired
+	}
+
+	if ride.PickupCodeHash == "" || ride.PickupCodeExpiresAt == nil {
+		return "", ErrPickupCodeNotIssued
+	}
+	reserved, err := s.repo.ReservePickupAttempt(ride.ID, s.cfg.PickupCodeMaxAttempts)
+	if err != nil {
+		return "", err
+	}
+	if !reserved {
+		return "", ErrPickupCodeLocked
+	}
+
+	method, ok := helper.VerifyPickupCode(s.pickupCodeSecret(), ride.ID, ride.PickupCodeHash, *ride.PickupCodeExpiresAt, req.PickupCode)
+	reason := ""
+	switch {
+	case !ok:
+		reason = ErrInvalidPickupCode.Error()
+	case helper.IsPickupCodeExpired(*ride.PickupCodeExpiresAt, time.Now()):
+		reason = ErrPickupCodeExpired.Error()
+	default:
+		if err := s.repo.ReleasePickupAttempt(ride.ID); err != nil {
+			return "", err
+		}
+		return method, nil
+	}
+
+	log.Warn().
+		Str("rideID", ride.ID.String()).
+		Str("driverID", userID.String()).
+		Str("method", method).
+		Msg("Failed pickup code attempt: " + reason)
+	if err := s.repo.RecordFailedPickupAttempt(ride.ID, userID, method, reason); err != nil {
+		return "", err
+	}
+
+	if ok {
+		return "", ErrPickupCodeExpired
+	}
+	return "", ErrInvalidPickupCode
+}
+
+// pickupCodeSecret returns the key signing the pickup codes
+func (s *RideService) pickupCodeSecret() string {
+	if s.cfg.PickupCodeSecret != "" {
+		return s.cfg.PickupCodeSecret
+	}
+	return s.cfg.EncryptionKey
 }
 
 // GetTransactionByID fetches a transaction by its ID
@@ -76,12 +210,165 @@ func (s *RideService) GetTransactionByRideID(rideID uuid.UUID) (migration.Transa
 
 // EndRide ends a ride
 func (s *RideService) EndRide(req schemas.EndRideRequest, userID uuid.UUID) (migration.Ride, error) {
-	return s.repo.EndRide(req, userID)
+	ride, err := s.repo.EndRide(req, userID)
+	if err != nil {
+		return migration.Ride{}, err
+	}
+
+	s.notifyRideReceipt(ride.ID)
+	return ride, nil
+}
+
+// notifyRideReceipt sends the link to the receipt of a completed ride to the driver and the passenger
+func (s *RideService) notifyRideReceipt(rideID uuid.UUID) {
+	ride, err := s.repo.GetRideForReceipt(rideID)
+	if err != nil {
+		log.Error().Err(err).Str("rideID", rideID.String()).Msg("Failed to get ride for receipt")
+		return
+	}
+
+	res := schemas.RideReceiptNotification{
+		RideID:     ride.ID,
+		ReceiptURL: helper.RideReceiptURL(s.cfg.ReceiptBaseURL, ride.ID),
+	}
+	for _, user := range []migration.User{ride.RideOffer.User, ride.RideRequest.User} {
+		notifyUser(s.asynqClient, user, "ride-receipt", res,
+			"Biên lai chuyến đi",
+			"Biên lai chuyến đi của bạn đã sẵn sàng",
+		)
+	}
+}
+
+// UpdateRiderLocation updates the location the passenger shares with the driver, it does not feed the
+// geofences, the trail or the deviations
+func (s *RideService) UpdateRiderLocation(req schemas.UpdateRiderLocationRequest, userID uuid.UUID) (migration.Ride, error) {
+	return s.repo.UpdateRiderLocation(req, userID)
 }
 
 // UpdateRideLocation updates the location of a ride
 func (s *RideService) UpdateRideLocation(req schemas.UpdateRideLocationRequest, userID uuid.UUID) (migration.Ride, error) {
-	return s.repo.UpdateRideLocation(req, userID)
+	ride, err := s.repo.UpdateRideLocation(req, userID)
+	if err != nil {
+		return migration.Ride{}, err
+	}
+
+	// Append the location to the breadcrumb trail of the ride, a lost sample must not fail the update
+	recordedAt := time.Now()
+	if req.RecordedAt != nil {
+		recordedAt = *req.RecordedAt
+	}
+	err = s.trailRepo.BufferLocationSample(context.Background(), migration.RideLocationSample{
+		RideID:     ride.ID,
+		UserID:     userID,
+		RecordedAt: recordedAt,
+		Latitude:   req.CurrentLocation.Lat,
+		Longitude:  req.CurrentLocation.Lng,
+		Accuracy:   req.Accuracy,
+		Speed:      req.Speed,
+		Heading:    req.Heading,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("rideID", ride.ID.String()).Msg("Failed to buffer location sample")
+	}
+
+	if ride.Status == "ongoing" {
+		s.trackDeviation(ride, req.CurrentLocation, recordedAt)
+	}
+
+	return ride, nil
+}
+
+// trackDeviation follows the distance of the driver from the planned route, then records and alerts the
+// deviations that last longer than the thresholds to the hitcher and the admins
+func (s *RideService) trackDeviation(ride migration.Ride, location schemas.Point, at time.Time) {
+	ctx := context.Background()
+	logger := log.With().Str("rideID", ride.ID.String()).Logger()
+
+	state, err := s.trailRepo.GetDeviationState(ctx, ride.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get deviation state")
+		return
+	}
+
+	changes := helper.TrackDeviation(&state, helper.DecodePolyline(string(ride.EncodedPolyline)), location, at, helper.DeviationThresholds{
+		Distance:     s.cfg.DeviationDistance,
+		Duration:     time.Duration(s.cfg.DeviationDuration) * time.Second,
+		StopRadius:   s.cfg.LongStopRadius,
+		StopDuration: time.Duration(s.cfg.LongStopDuration) * time.Second,
+	})
+	if err := s.trailRepo.SaveDeviationState(ctx, ride.ID, state); err != nil {
+		logger.Error().Err(err).Msg("Failed to save deviation state")
+	}
+
+	for _, deviationType := range changes.Resolved {
+		if err := s.trailRepo.ResolveDeviationEvent(ride.ID, deviationType, at, state.MaxDistance); err != nil {
+			logger.Error().Err(err).Str("type", deviationType).Msg("Failed to resolve deviation event")
+		}
+	}
+
+	if len(changes.Raised) == 0 {
+		return
+	}
+
+	rideWithUsers, err := s.repo.GetRideByID(ride.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get ride")
+		return
+	}
+
+	for _, deviationType := range changes.Raised {
+		startedAt := at
+		title, body := "Xe đang đi lệch lộ trình", "Tài xế đã rời khỏi lộ trình dự kiến, hãy kiểm tra chuyến đi của bạn"
+		if deviationType == helper.DeviationLongStop {
+			startedAt = *state.StoppedSince
+			title, body = "Xe đang dừng quá lâu", "Tài xế đã dừng lại khá lâu, hãy kiểm tra chuyến đi của bạn"
+		} else if state.OffRouteSince != nil {
+			startedAt = *state.OffRouteSince
+		}
+
+		event, err := s.trailRepo.CreateDeviationEvent(migration.RideDeviationEvent{
+			RideID:      ride.ID,
+			Type:        deviationType,
+			StartedAt:   startedAt,
+			Latitude:    location.Lat,
+			Longitude:   location.Lng,
+			Distance:    changes.Distance,
+			MaxDistance: state.MaxDistance,
+		})
+		if err != nil {
+			logger.Error().Err(err).Str("type", deviationType).Msg("Failed to create deviation event")
+			continue
+		}
+
+		// The admins are alerted live on the dashboard and review the events from there
+		logger.Warn().
+			Str("eventID", event.ID.String()).
+			Str("type", deviationType).
+			Float64("distance", changes.Distance).
+			Msg("Ride deviation detected")
+		s.publishDeviationEvent(ctx, event)
+
+		res := schemas.RideDeviationAlert{
+			RideID:         ride.ID,
+			Type:           deviationType,
+			DriverLocation: location,
+			Distance:       changes.Distance,
+			StartedAt:      startedAt,
+		}
+		notifyUser(s.asynqClient, rideWithUsers.RideRequest.User, "ride-deviation-alert", res, title, body)
+	}
+}
+
+// publishDeviationEvent sends a deviation event to the admin dashboards
+func (s *RideService) publishDeviationEvent(ctx context.Context, event migration.RideDeviationEvent) {
+	message, err := json.Marshal(helper.ToDeviationEventDetail(event))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal deviation event")
+		return
+	}
+	if err := s.trailRepo.PublishDeviationEvent(ctx, message); err != nil {
+		log.Error().Err(err).Str("deviationEventID", event.ID.String()).Msg("Failed to publish deviation event")
+	}
 }
 
 // CancelRideByDriver cancels a ride by the driver
@@ -97,5 +384,205 @@ func (s *RideService) GetAllPendingRide(userID uuid.UUID) ([]migration.RideOffer
 	return s.repo.GetAllPendingRide(userID)
 }
 
+// GetRideHistory returns a page of the rides of the user as driver or passenger, newest first, and the cursor
+// of the next page, empty on the last one. The dates of the filter are days of the local time zone
+func (s *RideService) GetRideHistory(req schemas.GetRideHistoryRequest, userID uuid.UUID) ([]migration.Ride, string, error) {
+	location := helper.UTCOffsetLocation(s.cfg.AppUTCOffset)
+	filter := schemas.RideHistoryFilter{
+		Role:   req.Role,
+		Status: req.Status,
+		Limit:  req.Limit,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = 20
+	}
+	if req.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", req.From, location)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", req.To, location)
+		if err != nil {
+			return nil, "", err
+		}
+		// The last day is included
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if req.Cursor != "" {
+		startTime, rideID, err := helper.DecodeRideHistoryCursor(req.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		filter.CursorStartTime = &startTime
+		filter.CursorRideID = rideID
+	}
+
+	rides, err := s.repo.GetRideHistory(userID, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(rides) > filter.Limit {
+		rides = rides[:filter.Limit]
+		last := rides[len(rides)-1]
+		nextCursor = helper.EncodeRideHistoryCursor(last.StartTime, last.ID)
+	}
+	return rides, nextCursor, nil
+}
+
+// GetRideHistorySummary totals the completed rides of the user per month of the local time zone, over the last
+// 12 months by default
+func (s *RideService) GetRideHistorySummary(req schemas.GetRideHistorySummaryRequest, userID uuid.UUID) (schemas.GetRideHistorySummaryResponse, error) {
+	location := helper.UTCOffsetLocation(s.cfg.AppUTCOffset)
+
+	now := time.Now().In(location)
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
+	if req.To != "" {
+		month, err := time.ParseInLocation("2006-01", req.To, location)
+		if err != nil {
+			return schemas.GetRideHistorySummaryResponse{}, err
+		}
+		to = month
+	}
+	from := to.AddDate(0, -11, 0)
+	if req.From != "" {
+		month, err := time.ParseInLocation("2006-01", req.From, location)
+		if err != nil {
+			return schemas.GetRideHistorySummaryResponse{}, err
+		}
+		from = month
+	}
+	if from.After(to) {
+		return schemas.GetRideHistorySummaryResponse{}, ErrInvalidSummaryRange
+	}
+
+	// The last month is included
+	months, err := s.repo.GetRideHistorySummary(userID, from, to.AddDate(0, 1, 0))
+	if err != nil {
+		return schemas.GetRideHistorySummaryResponse{}, err
+	}
+
+	res := schemas.GetRideHistorySummaryResponse{
+		From:   from.Format("2006-01"),
+		To:     to.Format("2006-01"),
+		Months: months,
+	}
+	if res.Months == nil {
+		res.Months = []schemas.RideHistoryMonth{}
+	}
+	for _, month := range months {
+		res.Total.Trips += month.Trips
+		res.Total.TripsAsDriver += month.TripsAsDriver
+		res.Total.TripsAsPassenger += month.TripsAsPassenger
+		res.Total.KmShared += month.KmShared
+		res.Total.Earned += month.Earned
+		res.Total.Spent += month.Spent
+	}
+	return res, nil
+}
+
+// ExpireStaleRides expires the ride offers and ride requests nobody matched and marks the rides
+// not started in time as no-show (after the configured grace periods), then notifies the users
+func (s *RideService) ExpireStaleRides() error {
+	now := time.Now().UTC()
+	noShowRides, expiredRideOffers, expiredRideRequests, err := s.repo.ExpireStaleRides(
+		now.Add(-time.Duration(s.cfg.RideExpiryGracePeriod)*time.Minute),
+		now.Add(-time.Duration(s.cfg.RideNoShowGracePeriod)*time.Minute),
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to expire stale rides")
+		return err
+	}
+
+	for _, ride := range noShowRides {
+		res := schemas.RideNoShowResponse{
+			RideID:        ride.ID,
+			RideOfferID:   ride.RideOfferID,
+			RideRequestID: ride.RideRequestID,
+			StartTime:     ride.StartTime,
+		}
+		for _, user := range []migration.User{ride.RideOffer.User, ride.RideRequest.User} {
+			notifyUser(s.asynqClient, user, "ride-no-show", res,
+				"Chuyến đi đã bị hủy",
+				"Chuyến đi không được bắt đầu đúng giờ nên đã bị hủy",
+			)
+		}
+	}
+
+	for _, rideOffer := range expiredRideOffers {
+		res := schemas.RideOfferExpiredResponse{
+			RideOfferID:  rideOffer.ID,
+			StartAddress: rideOffer.StartAddress,
+			EndAddress:   rideOffer.EndAddress,
+			StartTime:    rideOffer.StartTime,
+		}
+		notifyUser(s.asynqClient, rideOffer.User, "ride-offer-expired", res,
+			"Chuyến đi của bạn đã hết hạn",
+			"Không có ai đi cùng trước giờ khởi hành nên chuyến đi đã hết hạn",
+		)
+	}
+
+	for _, rideRequest := range expiredRideRequests {
+		res := schemas.RideRequestExpiredResponse{
+			RideRequestID: rideRequest.ID,
+			StartAddress:  rideRequest.StartAddress,
+			EndAddress:    rideRequest.EndAddress,
+			StartTime:     rideRequest.StartTime,
+		}
+		notifyUser(s.asynqClient, rideRequest.User, "ride-request-expired", res,
+			"Yêu cầu đi nhờ của bạn đã hết hạn",
+			"Không tìm được tài xế trước giờ khởi hành nên yêu cầu đi nhờ đã hết hạn",
+		)
+	}
+
+	return nil
+}
+
+// notifyUser sends the message through the websocket and, if the user has a device token, as a push notification
+func notifyUser(asynqClient *task.AsyncClient, user migration.User, messageType string, res interface{}, title, body string) {
+	wsMessage := schemas.WebSocketMessage{
+		UserID:  user.ID.String(),
+		Type:    messageType,
+		Payload: res,
+	}
+	if err := asynqClient.EnqueueWebsocketMessage(wsMessage); err != nil {
+		log.Error().Err(err).Str("userID", user.ID.String()).Msg("Failed to enqueue websocket message")
+	}
+
+	if user.DeviceToken == "" {
+		return
+	}
+
+	resMap, err := helper.ConvertToStringMap(res)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to convert struct to map")
+		return
+	}
+
+	notificationPayloadMap, err := helper.ConvertToStringMap(schemas.NotificationPayload{
+		Type: messageType,
+		Data: resMap,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to convert struct to map")
+		return
+	}
+
+	notification := schemas.Notification{
+		Title: title,
+		Body:  body,
+		Token: user.DeviceToken,
+		Data:  notificationPayloadMap,
+	}
+	if err := asynqClient.EnqueueFCMNotification(notification); err != nil {
+		log.Error().Err(err).Str("userID", user.ID.String()).Msg("Failed to enqueue FCM notification")
+	}
+}
+
 // Make sure the RideService implements the IRideService interface
 var _ IRideService = (*RideService)(nil)
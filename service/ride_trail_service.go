@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shareway/infra/db/migration"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+type IRideTrailService interface {
+	GetRideTrail(rideID, userID uuid.UUID) ([]migration.RideLocationSample, error)
+	GetRideTrailForAdmin(rideID uuid.UUID) ([]migration.RideLocationSample, error)
+	FlushLocationSamples() error
+	ApplyTrailRetention() error
+	GetDeviationEvents(req schemas.GetDeviationEventsRequest) ([]migration.RideDeviationEvent, error)
+	AcknowledgeDeviationEvent(req schemas.AcknowledgeDeviationEventRequest, adminID uuid.UUID) (migration.RideDeviationEvent, error)
+	SubscribeDeviationEvents(ctx context.Context) *redis.PubSub
+}
+
+type RideTrailService struct {
+	repo     repository.IRideTrailRepository
+	rideRepo repository.IRideRepository
+	cfg      util.Config
+}
+
+func NewRideTrailService(repo repository.IRideTrailRepository, rideRepo repository.IRideRepository, cfg util.Config) IRideTrailService {
+	return &RideTrailService{
+		repo:     repo,
+		rideRepo: rideRepo,
+		cfg:      cfg,
+	}
+}
+
+var (
+	ErrNotRideParticipant = errors.New("only the driver and the passenger of the ride can access it")
+)
+
+// GetRideTrail returns the location samples of a ride to its driver or its passenger
+func (s *RideTrailService) GetRideTrail(rideID, userID uuid.UUID) ([]migration.RideLocationSample, error) {
+	ride, err := s.rideRepo.GetRideByID(rideID)
+	if err != nil {
+		return nil, err
+	}
+	if ride.RideOffer.UserID != userID && ride.RideRequest.UserID != userID {
+		return nil, ErrNotRideParticipant
+	}
+
+	return s.GetRideTrailForAdmin(ride.ID)
+}
+
+// GetRideTrailForAdmin returns the location samples of any ride, to handle the disputes.
+// The samples still in the buffer are added without flushing it, that is left to the periodic flush
+func (s *RideTrailService) GetRideTrailForAdmin(rideID uuid.UUID) ([]migration.RideLocationSample, error) {
+	// The buffer is read first, a sample flushed in between is then found twice rather than missed
+	buffered, err := s.repo.GetBufferedLocationSamples(context.Background(), rideID)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := s.repo.GetLocationSamples(rideID)
+	if err != nil {
+		return nil, err
+	}
+	if len(buffered) == 0 {
+		return samples, nil
+	}
+
+	type sampleKey struct {
+		userID     uuid.UUID
+		recordedAt int64 // in microseconds, the precision of the database
+	}
+	stored := make(map[sampleKey]bool, len(samples))
+	for _, sample := range samples {
+		stored[sampleKey{sample.UserID, sample.RecordedAt.UnixMicro()}] = true
+	}
+	for _, sample := range buffered {
+		if !stored[sampleKey{sample.UserID, sample.RecordedAt.UnixMicro()}] {
+			samples = append(samples, sample)
+		}
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].RecordedAt.Before(samples[j].RecordedAt)
+	})
+	return samples, nil
+}
+
+// FlushLocationSamples writes the buffered location samples to the database
+func (s *RideTrailService) FlushLocationSamples() error {
+	flushed, err := s.repo.FlushLocationSamples(context.Background(), s.cfg.TrailFlushBatchSize)
+	if err != nil {
+		log.Error().Err(err).Int("flushed", flushed).Msg("Failed to flush location samples")
+		return err
+	}
+	if flushed > 0 {
+		log.Debug().Int("flushed", flushed).Msg("Flushed location samples")
+	}
+	return nil
+}
+
+// ApplyTrailRetention downsamples the trails older than TRAIL_DOWNSAMPLE_AFTER days and deletes the ones
+// older than TRAIL_RETENTION days
+func (s *RideTrailService) ApplyTrailRetention() error {
+	now := time.Now()
+
+	purged, err := s.repo.PurgeLocationSamples(now.AddDate(0, 0, -s.cfg.TrailRetention))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to purge location samples")
+		return err
+	}
+
+	downsampled, err := s.repo.DownsampleLocationSamples(
+		now.AddDate(0, 0, -s.cfg.TrailDownsampleAfter),
+		time.Duration(s.cfg.TrailDownsampleInterval)*time.Second,
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to downsample location samples")
+		return err
+	}
+
+	log.Info().Int64("purged", purged).Int64("downsampled", downsampled).Msg("Applied ride trail retention")
+	return nil
+}
+
+// GetDeviationEvents returns the latest deviation events for the admins to review, of a ride when rideID is set
+func (s *RideTrailService) GetDeviationEvents(req schemas.GetDeviationEventsRequest) ([]migration.RideDeviationEvent, error) {
+	var rideID *uuid.UUID
+	if req.RideID != "" {
+		id := uuid.MustParse(req.RideID)
+		rideID = &id
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = 50
+	}
+	return s.repo.GetDeviationEvents(rideID, req.Unacknowledged, limit)
+}
+
+// AcknowledgeDeviationEvent marks a deviation event as reviewed by an admin
+func (s *RideTrailService) AcknowledgeDeviationEvent(req schemas.AcknowledgeDeviationEventRequest, adminID uuid.UUID) (migration.RideDeviationEvent, error) {
+	return s.repo.AcknowledgeDeviationEvent(req.EventID, adminID, req.Note)
+}
+
+// SubscribeDeviationEvents listens to the deviation events as they are detected
+func (s *RideTrailService) SubscribeDeviationEvents(ctx context.Context) *redis.PubSub {
+	return s.repo.SubscribeDeviationEvents(ctx)
+}
+
+// Make sure the RideTrailService implements the IRideTrailService interface
+var _ IRideTrailService = (*RideTrailService)(nil)
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/infra/task"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+type IRouteAlertService interface {
+	CreateRouteAlert(input schemas.CreateRouteAlertRequest, userID uuid.UUID) (migration.RouteAlert, error)
+	GetRouteAlerts(userID uuid.UUID) ([]migration.RouteAlert, error)
+	UpdateRouteAlert(input schemas.UpdateRouteAlertRequest, userID uuid.UUID) (migration.RouteAlert, error)
+	DeleteRouteAlert(routeAlertID, userID uuid.UUID) error
+	MatchRouteAlerts(ctx context.Context, rideOfferID uuid.UUID) error
+}
+
+type RouteAlertService struct {
+	repo        repository.IRouteAlertRepository
+	mapsRepo    repository.IMapsRepository
+	cfg         util.Config
+	asynqClient *task.AsyncClient
+}
+
+func NewRouteAlertService(repo repository.IRouteAlertRepository, mapsRepo repository.IMapsRepository, cfg util.Config, asynqClient *task.AsyncClient) IRouteAlertService {
+	return &RouteAlertService{
+		repo:        repo,
+		mapsRepo:    mapsRepo,
+		cfg:         cfg,
+		asynqClient: asynqClient,
+	}
+}
+
+// CreateRouteAlert saves a route alert of the user
+func (s *RouteAlertService) CreateRouteAlert(input schemas.CreateRouteAlertRequest, userID uuid.UUID) (migration.RouteAlert, error) {
+	routeAlert := migration.RouteAlert{
+		UserID:               userID,
+		Name:                 input.Name,
+		OriginLatitude:       input.Origin.Lat,
+		OriginLongitude:      input.Origin.Lng,
+		OriginAddress:        input.OriginAddress,
+		DestinationLatitude:  input.Destination.Lat,
+		DestinationLongitude: input.Destination.Lng,
+		DestinationAddress:   input.DestinationAddress,
+		DaysOfWeek:           formatDaysOfWeek(input.DaysOfWeek),
+		WindowStart:          input.WindowStart,
+		WindowEnd:            input.WindowEnd,
+		IsActive:             true,
+	}
+	if err := validateRouteAlert(routeAlert); err != nil {
+		return migration.RouteAlert{}, err
+	}
+
+	return s.repo.CreateRouteAlert(routeAlert)
+}
+
+// GetRouteAlerts returns the route alerts of the user
+func (s *RouteAlertService) GetRouteAlerts(userID uuid.UUID) ([]migration.RouteAlert, error) {
+	return s.repo.GetRouteAlertsByUserID(userID)
+}
+
+// UpdateRouteAlert applies the provided fields to a route alert of the user
+func (s *RouteAlertService) UpdateRouteAlert(input schemas.UpdateRouteAlertRequest, userID uuid.UUID) (migration.RouteAlert, error) {
+	routeAlert, err := s.repo.GetRouteAlertByID(input.RouteAlertID, userID)
+	if err != nil {
+		return migration.RouteAlert{}, err
+	}
+
+	if input.Name != nil {
+		routeAlert.Name = *input.Name
+	}
+	if input.Origin != nil {
+		routeAlert.OriginLatitude = input.Origin.Lat
+		routeAlert.OriginLongitude = input.Origin.Lng
+	}
+	if input.OriginAddress != nil {
+		routeAlert.OriginAddress = *input.OriginAddress
+	}
+	if input.Destination != nil {
+		routeAlert.DestinationLatitude = input.Destination.Lat
+		routeAlert.DestinationLongitude = input.Destination.Lng
+	}
+	if input.DestinationAddress != nil {
+		routeAlert.DestinationAddress = *input.DestinationAddress
+	}
+	if input.DaysOfWeek != nil {
+		routeAlert.DaysOfWeek = formatDaysOfWeek(input.DaysOfWeek)
+	}
+	if input.WindowStart != "" {
+		routeAlert.WindowStart = input.WindowStart
+	}
+	if input.WindowEnd != "" {
+		routeAlert.WindowEnd = input.WindowEnd
+	}
+	if input.IsActive != nil {
+		routeAlert.IsActive = *input.IsActive
+	}
+	if err := validateRouteAlert(routeAlert); err != nil {
+		return migration.RouteAlert{}, err
+	}
+
+	if err := s.repo.UpdateRouteAlert(routeAlert); err != nil {
+		return migration.RouteAlert{}, err
+	}
+	return routeAlert, nil
+}
+
+// DeleteRouteAlert deletes a route alert of the user
+func (s *RouteAlertService) DeleteRouteAlert(routeAlertID, userID uuid.UUID) error {
+	return s.repo.DeleteRouteAlert(routeAlertID, userID)
+}
+
+// MatchRouteAlerts notifies the users whose route alerts match a new ride offer. The route is matched
+// the same way as the ride request suggestions, a user is notified once per ride offer even if several
+// of their alerts match, and no more than ROUTE_ALERT_MAX_PER_HOUR times per hour
+func (s *RouteAlertService) MatchRouteAlerts(ctx context.Context, rideOfferID uuid.UUID) error {
+	rideOffer, err := s.mapsRepo.GetRideOfferDetails(rideOfferID)
+	if err != nil {
+		return err
+	}
+
+	// The ride offer may have been booked or cancelled before the task ran
+	if rideOffer.Status != "created" || rideOffer.AvailableSeats <= 0 || !rideOffer.StartTime.After(time.Now()) {
+		return nil
+	}
+
+	routeAlerts, err := s.repo.GetRouteAlertCandidates(rideOffer)
+	if err != nil {
+		return err
+	}
+
+	offerPolyline := helper.DecodePolyline(string(rideOffer.EncodedPolyline))
+	location := helper.UTCOffsetLocation(s.cfg.AppUTCOffset)
+
+	// Group the matching alerts by user so a user is notified only once for the ride offer
+	var users []migration.User
+	matchedAlerts := make(map[uuid.UUID][]uuid.UUID)
+	for _, routeAlert := range routeAlerts {
+		if !isInRouteAlertWindow(routeAlert, rideOffer.StartTime, location) {
+			continue
+		}
+
+		alertRoute := []schemas.Point{
+			{Lat: routeAlert.OriginLatitude, Lng: routeAlert.OriginLongitude},
+			{Lat: routeAlert.DestinationLatitude, Lng: routeAlert.DestinationLongitude},
+		}
+		if !helper.IsMatchRoute(offerPolyline, alertRoute) {
+			continue
+		}
+
+		if _, ok := matchedAlerts[routeAlert.UserID]; !ok {
+			users = append(users, routeAlert.User)
+		}
+		matchedAlerts[routeAlert.UserID] = append(matchedAlerts[routeAlert.UserID], routeAlert.ID)
+	}
+
+	for _, user := range users {
+		count, err := s.repo.CountNotificationsSince(user.ID, time.Now().Add(-time.Hour))
+		if err != nil {
+			return err
+		}
+		if count >= int64(s.cfg.RouteAlertMaxPerHour) {
+			log.Info().Str("userID", user.ID.String()).Str("rideOfferID", rideOffer.ID.String()).Msg("Route alert notification rate limited")
+			continue
+		}
+
+		// Recording the notification first makes a retried task skip the users already notified
+		recorded, err := s.repo.RecordNotification(matchedAlerts[user.ID], rideOffer.ID)
+		if err != nil {
+			return err
+		}
+		if !recorded {
+			continue
+		}
+
+		res := schemas.RouteAlertMatchResponse{
+			RouteAlertIDs:  matchedAlerts[user.ID],
+			RideOfferID:    rideOffer.ID,
+			DeepLink:       fmt.Sprintf("%sride-offer/%s", s.cfg.DeepLinkBaseURL, rideOffer.ID),
+			StartAddress:   rideOffer.StartAddress,
+			EndAddress:     rideOffer.EndAddress,
+			StartTime:      rideOffer.StartTime,
+			Fare:           rideOffer.Fare,
+			AvailableSeats: rideOffer.AvailableSeats,
+		}
+		notifyUser(s.asynqClient, user, "route-alert-match", res,
+			"Có chuyến đi phù hợp với bạn",
+			fmt.Sprintf("Chuyến đi từ %s đến %s vừa được tạo", rideOffer.StartAddress, rideOffer.EndAddress),
+		)
+	}
+
+	return nil
+}
+
+// isInRouteAlertWindow checks if a ride offer leaving at the given time is on one of the days
+// and within the time window of the alert, the window can go past midnight. The days and the window
+// are local to the given location
+func isInRouteAlertWindow(routeAlert migration.RouteAlert, startTime time.Time, location *time.Location) bool {
+	startTime = startTime.In(location)
+
+	if routeAlert.DaysOfWeek != "" {
+		onDay := false
+		for _, weekday := range ParseDaysOfWeek(routeAlert.DaysOfWeek) {
+			if startTime.Weekday() == time.Weekday(weekday) {
+				onDay = true
+				break
+			}
+		}
+		if !onDay {
+			return false
+		}
+	}
+
+	departure := startTime.Format(departureTimeLayout)
+	if routeAlert.WindowStart <= routeAlert.WindowEnd {
+		return departure >= routeAlert.WindowStart && departure <= routeAlert.WindowEnd
+	}
+	return departure >= routeAlert.WindowStart || departure <= routeAlert.WindowEnd
+}
+
+func validateRouteAlert(routeAlert migration.RouteAlert) error {
+	if _, err := time.Parse(departureTimeLayout, routeAlert.WindowStart); err != nil {
+		return fmt.Errorf("failed to parse window start %s: %w", routeAlert.WindowStart, err)
+	}
+	if _, err := time.Parse(departureTimeLayout, routeAlert.WindowEnd); err != nil {
+		return fmt.Errorf("failed to parse window end %s: %w", routeAlert.WindowEnd, err)
+	}
+	if routeAlert.OriginLatitude == routeAlert.DestinationLatitude && routeAlert.OriginLongitude == routeAlert.DestinationLongitude {
+		return fmt.Errorf("origin and destination must be different")
+	}
+	return nil
+}
+
+// Make sure the RouteAlertService implements the IRouteAlertService interface
+var _ IRouteAlertService = (*RouteAlertService)(nil)
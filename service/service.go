@@ -15,16 +15,26 @@ import (
 )
 
 type ServiceContainer struct {
-	OTPService          IOTPService
-	UserService         IUsersService
-	MapService          IMapService
-	VehicleService      IVehicleService
-	RideService         IRideService
-	NotificationService INotificationService
-	ChatService         IChatService
-	AdminService        IAdminService
-	PaymentService      IPaymentService
-	IPNService          IIPNService
+	OTPService              IOTPService
+	UserService             IUsersService
+	MapService              IMapService
+	VehicleService          IVehicleService
+	RideService             IRideService
+	NotificationService     INotificationService
+	ChatService             IChatService
+	AdminService            IAdminService
+	PaymentService          IPaymentService
+	IPNService              IIPNService
+	RecurringRideService    IRecurringRideService
+	RouteAlertService       IRouteAlertService
+	RideTrailService        IRideTrailService
+	SOSService              ISOSService
+	TripShareService        ITripShareService
+	ReceiptService          IReceiptService
+	ImpactService           IImpactService
+	RatingService           IRatingService
+	ModerationService       IModerationService
+	FavoriteLocationService IFavoriteLocationService
 }
 
 type ServiceFactory struct {
@@ -39,6 +49,9 @@ type ServiceFactory struct {
 	asynq        *task.AsyncClient
 	cloudinary   *bucket.CloudinaryService
 	sanctumToken *sanctum.SanctumToken
+	// Services used by other services, created once and shared
+	mapService        IMapService
+	moderationService IModerationService
 }
 
 func NewServiceFactory(db *gorm.DB, cfg util.Config, token *token.PasetoMaker, redisClient *redis.Client, hub *ws.Hub, asynq *task.AsyncClient, cloudinary *bucket.CloudinaryService, sanctumToken *sanctum.SanctumToken) *ServiceFactory {
@@ -66,16 +79,26 @@ func NewServiceFactory(db *gorm.DB, cfg util.Config, token *token.PasetoMaker, r
 
 func (f *ServiceFactory) CreateServices() *ServiceContainer {
 	return &ServiceContainer{
-		OTPService:          f.createOTPService(),
-		UserService:         f.createUserService(),
-		MapService:          f.createMapsService(),
-		VehicleService:      f.createVehicleService(),
-		RideService:         f.createRideService(),
-		NotificationService: f.createNotificationService(),
-		ChatService:         f.createChatService(),
-		AdminService:        f.createAdminService(),
-		PaymentService:      f.createPaymentService(),
-		IPNService:          f.createIPNService(),
+		OTPService:              f.createOTPService(),
+		UserService:             f.createUserService(),
+		MapService:              f.createMapsService(),
+		VehicleService:          f.createVehicleService(),
+		RideService:             f.createRideService(),
+		NotificationService:     f.createNotificationService(),
+		ChatService:             f.createChatService(),
+		AdminService:            f.createAdminService(),
+		PaymentService:          f.createPaymentService(),
+		IPNService:              f.createIPNService(),
+		RecurringRideService:    f.createRecurringRideService(),
+		RouteAlertService:       f.createRouteAlertService(),
+		RideTrailService:        f.createRideTrailService(),
+		SOSService:              f.createSOSService(),
+		TripShareService:        f.createTripShareService(),
+		ReceiptService:          f.createReceiptService(),
+		ImpactService:           f.createImpactService(),
+		RatingService:           f.createRatingService(),
+		ModerationService:       f.createModerationService(),
+		FavoriteLocationService: f.createFavoriteLocationService(),
 	}
 }
 
@@ -88,7 +111,10 @@ func (f *ServiceFactory) createUserService() IUsersService {
 }
 
 func (f *ServiceFactory) createMapsService() IMapService {
-	return NewMapService(f.repos.MapsRepository, f.cfg, f.redis)
+	if f.mapService == nil {
+		f.mapService = NewMapService(f.repos.MapsRepository, f.repos.FavoriteLocationRepository, f.cfg, f.redis, f.asynq)
+	}
+	return f.mapService
 }
 
 func (f *ServiceFactory) createVehicleService() IVehicleService {
@@ -96,7 +122,7 @@ func (f *ServiceFactory) createVehicleService() IVehicleService {
 }
 
 func (f *ServiceFactory) createRideService() IRideService {
-	return NewRideService(f.repos.RideRepository, f.hub, f.cfg)
+	return NewRideService(f.repos.RideRepository, f.repos.RideTrailRepository, f.hub, f.cfg, f.asynq)
 }
 
 func (f *ServiceFactory) createNotificationService() INotificationService {
@@ -104,7 +130,7 @@ func (f *ServiceFactory) createNotificationService() INotificationService {
 }
 
 func (f *ServiceFactory) createChatService() IChatService {
-	return NewChatService(f.repos.ChatRepository, f.hub, f.cfg, f.cloudinary)
+	return NewChatService(f.repos.ChatRepository, f.createModerationService(), f.hub, f.cfg, f.cloudinary)
 }
 
 func (f *ServiceFactory) createAdminService() IAdminService {
@@ -118,3 +144,46 @@ func (f *ServiceFactory) createPaymentService() IPaymentService {
 func (f *ServiceFactory) createIPNService() IIPNService {
 	return NewIPNService(f.repos.IPNRepository, f.hub, f.cfg)
 }
+
+func (f *ServiceFactory) createRecurringRideService() IRecurringRideService {
+	return NewRecurringRideService(f.repos.RecurringRideRepository, f.repos.MapsRepository, f.createMapsService(), f.cfg, f.asynq)
+}
+
+func (f *ServiceFactory) createRouteAlertService() IRouteAlertService {
+	return NewRouteAlertService(f.repos.RouteAlertRepository, f.repos.MapsRepository, f.cfg, f.asynq)
+}
+
+func (f *ServiceFactory) createRideTrailService() IRideTrailService {
+	return NewRideTrailService(f.repos.RideTrailRepository, f.repos.RideRepository, f.cfg)
+}
+
+func (f *ServiceFactory) createSOSService() ISOSService {
+	return NewSOSService(f.repos.SOSRepository, f.repos.TripShareRepository, f.repos.RideRepository, f.repos.RideTrailRepository, f.createOTPService(), f.cfg)
+}
+
+func (f *ServiceFactory) createTripShareService() ITripShareService {
+	return NewTripShareService(f.repos.TripShareRepository, f.repos.RideRepository, f.createMapsService(), f.cfg)
+}
+
+func (f *ServiceFactory) createReceiptService() IReceiptService {
+	return NewReceiptService(f.repos.RideRepository, f.cfg)
+}
+
+func (f *ServiceFactory) createImpactService() IImpactService {
+	return NewImpactService(f.repos.ImpactRepository, f.cfg)
+}
+
+func (f *ServiceFactory) createRatingService() IRatingService {
+	return NewRatingService(f.repos.RatingRepository, f.repos.RideRepository, f.cfg)
+}
+
+func (f *ServiceFactory) createModerationService() IModerationService {
+	if f.moderationService == nil {
+		f.moderationService = NewModerationService(f.repos.ModerationRepository, f.cfg, f.asynq)
+	}
+	return f.moderationService
+}
+
+func (f *ServiceFactory) createFavoriteLocationService() IFavoriteLocationService {
+	return NewFavoriteLocationService(f.repos.FavoriteLocationRepository, f.createMapsService(), f.cfg)
+}
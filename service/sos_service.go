@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"shareway/util/jsonb"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+type ISOSService interface {
+	CreateEmergencyContact(req schemas.CreateEmergencyContactRequest, userID uuid.UUID) (migration.EmergencyContact, error)
+	SendEmergencyContactOTP(contactID, userID uuid.UUID) error
+	VerifyEmergencyContact(req schemas.VerifyEmergencyContactRequest, userID uuid.UUID) (migration.EmergencyContact, error)
+	GetEmergencyContacts(userID uuid.UUID) ([]migration.EmergencyContact, error)
+	DeleteEmergencyContact(contactID, userID uuid.UUID) error
+	TriggerSOS(req schemas.TriggerSOSRequest, userID uuid.UUID) (schemas.TriggerSOSResponse, error)
+	GetSOSEvents(req schemas.GetSOSEventsRequest) ([]migration.SOSEvent, error)
+	AcknowledgeSOSEvent(eventID, adminID uuid.UUID) (migration.SOSEvent, error)
+	ResolveSOSEvent(req schemas.ResolveSOSEventRequest, adminID uuid.UUID) (migration.SOSEvent, error)
+	SubscribeSOSEvents(ctx context.Context) *redis.PubSub
+}
+
+type SOSService struct {
+	repo          repository.ISOSRepository
+	tripShareRepo repository.ITripShareRepository
+	rideRepo      repository.IRideRepository
+	trailRepo     repository.IRideTrailRepository
+	otpService    IOTPService
+	cfg           util.Config
+}
+
+func NewSOSService(repo repository.ISOSRepository, tripShareRepo repository.ITripShareRepository, rideRepo repository.IRideRepository, trailRepo repository.IRideTrailRepository, otpService IOTPService, cfg util.Config) ISOSService {
+	return &SOSService{
+		repo:          repo,
+		tripShareRepo: tripShareRepo,
+		rideRepo:      rideRepo,
+		trailRepo:     trailRepo,
+		otpService:    otpService,
+		cfg:           cfg,
+	}
+}
+
+var (
+	ErrTooManyEmergencyContacts = errors.New("maximum number of emergency contacts reached")
+	ErrEmergencyContactVerified = errors.New("emergency contact is already verified")
+	ErrSOSRideNotActive         = errors.New("SOS can only be raised during a scheduled or ongoing ride")
+)
+
+// CreateEmergencyContact saves an emergency contact of the user and sends an OTP to verify its phone number
+func (s *SOSService) CreateEmergencyContact(req schemas.CreateEmergencyContactRequest, userID uuid.UUID) (migration.EmergencyContact, error) {
+	count, err := s.repo.CountEmergencyContacts(userID)
+	if err != nil {
+		return migration.EmergencyContact{}, err
+	}
+	if count >= int64(s.cfg.EmergencyContactMax) {
+		return migration.EmergencyContact{}, ErrTooManyEmergencyContacts
+	}
+
+	contact, err := s.repo.CreateEmergencyContact(migration.EmergencyContact{
+		UserID:       userID,
+		FullName:     req.FullName,
+		PhoneNumber:  req.PhoneNumber,
+		Relationship: req.Relationship,
+	})
+	if err != nil {
+		return migration.EmergencyContact{}, err
+	}
+
+	if _, err := s.otpService.SendOTP(context.Background(), contact.PhoneNumber); err != nil {
+		// The contact is kept, the OTP can be sent again
+		return contact, err
+	}
+	return contact, nil
+}
+
+// SendEmergencyContactOTP sends again an OTP to verify the phone number of an emergency contact
+func (s *SOSService) SendEmergencyContactOTP(contactID, userID uuid.UUID) error {
+	contact, err := s.repo.GetEmergencyContactByID(contactID, userID)
+	if err != nil {
+		return err
+	}
+	if contact.VerifiedAt != nil {
+		return ErrEmergencyContactVerified
+	}
+
+	_, err = s.otpService.SendOTP(context.Background(), contact.PhoneNumber)
+	return err
+}
+
+// VerifyEmergencyContact checks the OTP sent to an emergency contact, the contact is alerted of the SOS once verified
+func (s *SOSService) VerifyEmergencyContact(req schemas.VerifyEmergencyContactRequest, userID uuid.UUID) (migration.EmergencyContact, error) {
+	contact, err := s.repo.GetEmergencyContactByID(req.EmergencyContactID, userID)
+	if err != nil {
+		return migration.EmergencyContact{}, err
+	}
+	if contact.VerifiedAt != nil {
+		return contact, ErrEmergencyContactVerified
+	}
+
+	if err := s.otpService.VerifyOTP(context.Background(), contact.PhoneNumber, req.OTP); err != nil {
+		return contact, err
+	}
+
+	now := time.Now()
+	if err := s.repo.MarkEmergencyContactVerified(contact.ID, userID, now); err != nil {
+		return contact, err
+	}
+	contact.VerifiedAt = &now
+	return contact, nil
+}
+
+// GetEmergencyContacts returns the emergency contacts of the user
+func (s *SOSService) GetEmergencyContacts(userID uuid.UUID) ([]migration.EmergencyContact, error) {
+	return s.repo.GetEmergencyContactsByUserID(userID)
+}
+
+// DeleteEmergencyContact deletes an emergency contact of the user
+func (s *SOSService) DeleteEmergencyContact(contactID, userID uuid.UUID) error {
+	return s.repo.DeleteEmergencyContact(contactID, userID)
+}
+
+// TriggerSOS records an SOS raised during a ride with the state and the last positions of the ride, alerts
+// the admins right away and sends the verified emergency contacts a time-limited link to the live trip
+func (s *SOSService) TriggerSOS(req schemas.TriggerSOSRequest, userID uuid.UUID) (schemas.TriggerSOSResponse, error) {
+	ctx := context.Background()
+
+	ride, err := s.rideRepo.GetRideByID(req.RideID)
+	if err != nil {
+		return schemas.TriggerSOSResponse{}, err
+	}
+	if ride.RideOffer.UserID != userID && ride.RideRequest.UserID != userID {
+		return schemas.TriggerSOSResponse{}, ErrNotRideParticipant
+	}
+	if ride.Status != "scheduled" && ride.Status != "ongoing" {
+		return schemas.TriggerSOSResponse{}, ErrSOSRideNotActive
+	}
+
+	snapshot, err := s.rideSnapshot(ctx, ride)
+	if err != nil {
+		return schemas.TriggerSOSResponse{}, err
+	}
+
+	event, err := s.repo.CreateSOSEvent(migration.SOSEvent{
+		UserID:       userID,
+		RideID:       ride.ID,
+		Latitude:     req.CurrentLocation.Lat,
+		Longitude:    req.CurrentLocation.Lng,
+		Message:      req.Message,
+		RideSnapshot: snapshot,
+	})
+	if err != nil {
+		return schemas.TriggerSOSResponse{}, err
+	}
+
+	logger := log.With().Str("sosEventID", event.ID.String()).Str("rideID", ride.ID.String()).Logger()
+	logger.Warn().Str("userID", userID.String()).Msg("SOS raised")
+
+	// From here the SOS is recorded, the admins must be alerted even if the contacts cannot be
+	res := schemas.TriggerSOSResponse{
+		SOSEventID: event.ID,
+		Status:     event.Status,
+	}
+
+	token, err := helper.GenerateTripShareToken()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to generate trip share token")
+	} else {
+		tripShare, err := s.tripShareRepo.CreateTripShare(migration.TripShare{
+			RideID:     ride.ID,
+			UserID:     userID,
+			TokenHash:  helper.HashTripShareToken(token),
+			ExpiresAt:  time.Now().Add(time.Duration(s.cfg.SOSTripShareTTL) * time.Minute),
+			SOSEventID: &event.ID,
+		})
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to create trip share")
+		} else {
+			res.TripShareURL = helper.TripShareURL(s.cfg.TripShareBaseURL, token)
+			res.TripShareExpiry = tripShare.ExpiresAt
+			res.ContactsAlerted = s.alertEmergencyContacts(ctx, userID, res.TripShareURL)
+		}
+	}
+
+	if err := s.repo.SetSOSEventContactsAlerted(event.ID, res.ContactsAlerted); err != nil {
+		logger.Error().Err(err).Msg("Failed to record the alerted emergency contacts")
+	}
+
+	s.publishSOSEvent(ctx, event.ID)
+	return res, nil
+}
+
+// rideSnapshot freezes the state of a ride and its last positions
+func (s *SOSService) rideSnapshot(ctx context.Context, ride migration.Ride) (jsonb.JSONB, error) {
+	// Write the buffered samples first so the last positions are included
+	if _, err := s.trailRepo.FlushLocationSamples(ctx, s.cfg.TrailFlushBatchSize); err != nil {
+		log.Error().Err(err).Msg("Failed to flush location samples")
+	}
+	samples, err := s.trailRepo.GetLatestLocationSamples(ride.ID, s.cfg.SOSSnapshotPositions)
+	if err != nil {
+		// The SOS must not fail for want of the positions
+		log.Error().Err(err).Str("rideID", ride.ID.String()).Msg("Failed to get the last positions of the ride")
+	}
+
+	snapshot := schemas.SOSRideSnapshot{
+		RideID:        ride.ID,
+		Status:        ride.Status,
+		RideOfferID:   ride.RideOfferID,
+		RideRequestID: ride.RideRequestID,
+		DriverID:      ride.RideOffer.UserID,
+		HitcherID:     ride.RideRequest.UserID,
+		VehicleID:     ride.RideOffer.VehicleID,
+		StartAddress:  ride.StartAddress,
+		EndAddress:    ride.EndAddress,
+		StartTime:     ride.StartTime,
+		DriverLocation: schemas.Point{
+			Lat: ride.RideOffer.DriverCurrentLatitude,
+			Lng: ride.RideOffer.DriverCurrentLongitude,
+		},
+		LocationAt:    ride.RideOffer.DriverLocationAt,
+		LastPositions: make([]schemas.SOSPosition, len(samples)),
+		TakenAt:       time.Now(),
+	}
+	for i, sample := range samples {
+		snapshot.LastPositions[i] = schemas.SOSPosition{
+			Point:      schemas.Point{Lat: sample.Latitude, Lng: sample.Longitude},
+			RecordedAt: sample.RecordedAt,
+		}
+	}
+
+	return helper.ConvertToJSONB(snapshot)
+}
+
+// alertEmergencyContacts texts the link to the live trip to the verified emergency contacts of the user
+// and returns how many were reached
+func (s *SOSService) alertEmergencyContacts(ctx context.Context, userID uuid.UUID, tripShareURL string) int {
+	contacts, err := s.repo.GetVerifiedEmergencyContacts(userID)
+	if err != nil {
+		log.Error().Err(err).Str("userID", userID.String()).Msg("Failed to get emergency contacts")
+		return 0
+	}
+
+	alerted := 0
+	for _, contact := range contacts {
+		name := contact.User.FullName
+		if name == "" {
+			name = "Người thân của bạn"
+		}
+		body := fmt.Sprintf(
+			"[ShareWay] %s cần trợ giúp khẩn cấp trong một chuyến đi. Theo dõi chuyến đi trực tiếp tại: %s",
+			name, tripShareURL,
+		)
+
+		if err := s.otpService.SendSMS(ctx, contact.PhoneNumber, body); err != nil {
+			log.Error().Err(err).Str("emergencyContactID", contact.ID.String()).Msg("Failed to alert emergency contact")
+			continue
+		}
+		alerted++
+	}
+	return alerted
+}
+
+// GetSOSEvents returns the latest SOS events for the admins, with the given status when set
+func (s *SOSService) GetSOSEvents(req schemas.GetSOSEventsRequest) ([]migration.SOSEvent, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = 50
+	}
+	return s.repo.GetSOSEvents(req.Status, limit)
+}
+
+// AcknowledgeSOSEvent records that an admin is handling an open SOS event
+func (s *SOSService) AcknowledgeSOSEvent(eventID, adminID uuid.UUID) (migration.SOSEvent, error) {
+	event, err := s.repo.AcknowledgeSOSEvent(eventID, adminID)
+	if err != nil {
+		return event, err
+	}
+
+	log.Info().Str("sosEventID", event.ID.String()).Str("adminID", adminID.String()).Msg("SOS acknowledged")
+	s.publishSOSEvent(context.Background(), event.ID)
+	return event, nil
+}
+
+// ResolveSOSEvent closes an SOS event with a note on how it was handled
+func (s *SOSService) ResolveSOSEvent(req schemas.ResolveSOSEventRequest, adminID uuid.UUID) (migration.SOSEvent, error) {
+	event, err := s.repo.ResolveSOSEvent(req.SOSEventID, adminID, req.Note)
+	if err != nil {
+		return event, err
+	}
+
+	log.Info().Str("sosEventID", event.ID.String()).Str("adminID", adminID.String()).Msg("SOS resolved")
+	s.publishSOSEvent(context.Background(), event.ID)
+	return event, nil
+}
+
+// SubscribeSOSEvents listens to the SOS events as they are raised, acknowledged and resolved
+func (s *SOSService) SubscribeSOSEvents(ctx context.Context) *redis.PubSub {
+	return s.repo.SubscribeSOSEvents(ctx)
+}
+
+// publishSOSEvent sends the current state of an SOS event to the admin dashboards
+func (s *SOSService) publishSOSEvent(ctx context.Context, eventID uuid.UUID) {
+	event, err := s.repo.GetSOSEventByID(eventID)
+	if err != nil {
+		log.Error().Err(err).Str("sosEventID", eventID.String()).Msg("Failed to get SOS event")
+		return
+	}
+
+	message, err := json.Marshal(helper.ToSOSEventDetail(event))
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to marshal SOS event")
+		return
+	}
+	if err := s.repo.PublishSOSEvent(ctx, message); err != nil {
+		log.Error().Err(err).Str("sosEventID", eventID.String()).Msg("Failed to publish SOS event")
+	}
+}
+
+// Make sure the SOSService implements the ISOSService interface
+var _ ISOSService = (*SOSService)(nil)
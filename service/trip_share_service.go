@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shareway/helper"
+	"shareway/infra/db/migration"
+	"shareway/repository"
+	"shareway/schemas"
+	"shareway/util"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+type ITripShareService interface {
+	ShareTrip(req schemas.ShareTripRequest, userID uuid.UUID) (schemas.ShareTripResponse, error)
+	GetTripShares(rideID, userID uuid.UUID) ([]migration.TripShare, error)
+	RevokeTripShare(tripShareID, userID uuid.UUID) error
+	GetSharedTrip(ctx context.Context, token string) (schemas.SharedTripResponse, error)
+}
+
+type TripShareService struct {
+	repo       repository.ITripShareRepository
+	rideRepo   repository.IRideRepository
+	mapService IMapService
+	cfg        util.Config
+}
+
+func NewTripShareService(repo repository.ITripShareRepository, rideRepo repository.IRideRepository, mapService IMapService, cfg util.Config) ITripShareService {
+	return &TripShareService{
+		repo:       repo,
+		rideRepo:   rideRepo,
+		mapService: mapService,
+		cfg:        cfg,
+	}
+}
+
+var (
+	ErrTripShareExpired    = errors.New("trip share link has expired or was revoked")
+	ErrTripShareRideEnded  = errors.New("the shared ride has ended")
+	ErrTripShareNotAllowed = errors.New("only the passenger of the ride can share it")
+)
+
+// ShareTrip issues a public link to follow a ride of the passenger live, valid until it expires, it is revoked
+// or the ride ends
+func (s *TripShareService) ShareTrip(req schemas.ShareTripRequest, userID uuid.UUID) (schemas.ShareTripResponse, error) {
+	ride, err := s.rideRepo.GetRideByID(req.RideID)
+	if err != nil {
+		return schemas.ShareTripResponse{}, err
+	}
+	if ride.RideRequest.UserID != userID {
+		return schemas.ShareTripResponse{}, ErrTripShareNotAllowed
+	}
+	if rideEnded(ride) {
+		return schemas.ShareTripResponse{}, ErrTripShareRideEnded
+	}
+
+	expiresIn := req.ExpiresIn
+	if expiresIn == 0 {
+		expiresIn = s.cfg.TripShareTTL
+	}
+	if expiresIn > s.cfg.TripShareMaxTTL {
+		expiresIn = s.cfg.TripShareMaxTTL
+	}
+
+	token, err := helper.GenerateTripShareToken()
+	if err != nil {
+		return schemas.ShareTripResponse{}, err
+	}
+	tripShare, err := s.repo.CreateTripShare(migration.TripShare{
+		RideID:    ride.ID,
+		UserID:    userID,
+		TokenHash: helper.HashTripShareToken(token),
+		ExpiresAt: time.Now().Add(time.Duration(expiresIn) * time.Minute),
+	})
+	if err != nil {
+		return schemas.ShareTripResponse{}, err
+	}
+
+	return schemas.ShareTripResponse{
+		TripShareID:  tripShare.ID,
+		TripShareURL: helper.TripShareURL(s.cfg.TripShareBaseURL, token),
+		ExpiresAt:    tripShare.ExpiresAt,
+	}, nil
+}
+
+// GetTripShares returns the public links the user created for a ride
+func (s *TripShareService) GetTripShares(rideID, userID uuid.UUID) ([]migration.TripShare, error) {
+	return s.repo.GetTripSharesByRideID(rideID, userID)
+}
+
+// RevokeTripShare stops a public link of the user from working
+func (s *TripShareService) RevokeTripShare(tripShareID, userID uuid.UUID) error {
+	return s.repo.RevokeTripShare(tripShareID, userID)
+}
+
+// GetSharedTrip returns the live state of the ride a public link points to, as long as the link is valid
+// and the ride is not over
+func (s *TripShareService) GetSharedTrip(ctx context.Context, token string) (schemas.SharedTripResponse, error) {
+	tripShare, err := s.repo.GetTripShareByTokenHash(helper.HashTripShareToken(token))
+	if err != nil {
+		return schemas.SharedTripResponse{}, err
+	}
+	if tripShare.RevokedAt != nil || time.Now().After(tripShare.ExpiresAt) {
+		return schemas.SharedTripResponse{}, ErrTripShareExpired
+	}
+
+	ride := tripShare.Ride
+	if rideEnded(ride) {
+		return schemas.SharedTripResponse{}, ErrTripShareRideEnded
+	}
+
+	driverLocation := schemas.Point{
+		Lat: ride.RideOffer.DriverCurrentLatitude,
+		Lng: ride.RideOffer.DriverCurrentLongitude,
+	}
+	res := schemas.SharedTripResponse{
+		RideID:          ride.ID,
+		Status:          ride.Status,
+		StartAddress:    ride.StartAddress,
+		EndAddress:      ride.EndAddress,
+		StartTime:       ride.StartTime,
+		DriverLocation:  driverLocation,
+		LocationAt:      ride.RideOffer.DriverLocationAt,
+		ExpiresAt:       tripShare.ExpiresAt,
+		EncodedPolyline: string(ride.EncodedPolyline),
+		LicensePlate:    ride.RideOffer.Vehicle.LicensePlate,
+		VehicleName:     ride.RideOffer.Vehicle.Name,
+	}
+
+	// The ETA is only meaningful once the driver has reported a location
+	if ride.RideOffer.DriverLocationAt != nil {
+		eta, err := s.mapService.EstimateRideETA(ctx, ride, ride.RideOffer, ride.RideRequest, driverLocation)
+		if err != nil {
+			log.Error().Err(err).Str("rideID", ride.ID.String()).Msg("Failed to estimate the ETA of a shared trip")
+		} else {
+			res.ETA = &eta
+		}
+	}
+
+	return res, nil
+}
+
+// rideEnded tells if a ride is over and can no longer be followed
+func rideEnded(ride migration.Ride) bool {
+	return ride.Status == "completed" || ride.Status == "cancelled" || ride.Status == "no_show"
+}
+
+// Make sure the TripShareService implements the ITripShareService interface
+var _ ITripShareService = (*TripShareService)(nil)
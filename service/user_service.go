@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"mime/multipart"
+	"shareway/helper"
 	"shareway/infra/bucket"
 	"shareway/infra/db/migration"
 	"shareway/infra/fpt"
@@ -36,6 +37,7 @@ type IUsersService interface {
 	RegisterDeviceToken(userID uuid.UUID, deviceToken string) error
 	DeleteUser(phoneNumber string) error
 	UpdateUserProfile(userID uuid.UUID, fullName string, email string, gender string) error
+	UpdateRidePreferences(userID uuid.UUID, preferences schemas.RidePreferences) error
 	UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarImage *multipart.FileHeader) (string, error)
 }
 
@@ -189,6 +191,11 @@ func (s *UsersService) UpdateUserProfile(userID uuid.UUID, fullName, email, gend
 	return s.repo.UpdateUserProfile(userID, fullName, email, gender)
 }
 
+// UpdateRidePreferences updates the default ride preferences of the user with the given user ID
+func (s *UsersService) UpdateRidePreferences(userID uuid.UUID, preferences schemas.RidePreferences) error {
+	return s.repo.UpdateRidePreferences(userID, helper.ToRidePreferences(preferences))
+}
+
 // UpdateAvatar updates the user avatar with the given user ID
 func (s *UsersService) UpdateAvatar(ctx context.Context, userID uuid.UUID, avatarImage *multipart.FileHeader) (string, error) {
 	avatarURL, err := s.cloudinary.UploadChatImage(ctx, avatarImage)
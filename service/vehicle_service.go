@@ -11,7 +11,7 @@ import (
 
 type IVehicleService interface {
 	GetVehicles(ctx context.Context, limit int, page int, input string) ([]schemas.Vehicle, error)
-	RegisterVehicle(userID uuid.UUID, vehicleID uuid.UUID, licensePlate string, caVet string) error
+	RegisterVehicle(userID uuid.UUID, vehicleID uuid.UUID, licensePlate string, caVet string, seatCapacity int, fuelType string) error
 	LicensePlateExists(licensePlate string) (bool, error)
 	CaVetExists(caVet string) (bool, error)
 	GetVehicleFromID(vehicleID uuid.UUID) (schemas.VehicleDetail, error)
@@ -39,8 +39,8 @@ func (s *VehicleService) GetVehicles(ctx context.Context, limit int, page int, i
 	return vehicles, nil
 }
 
-func (s *VehicleService) RegisterVehicle(userID uuid.UUID, vehicleID uuid.UUID, licensePlate string, caVet string) error {
-	return s.repo.RegisterVehicle(userID, vehicleID, licensePlate, caVet)
+func (s *VehicleService) RegisterVehicle(userID uuid.UUID, vehicleID uuid.UUID, licensePlate string, caVet string, seatCapacity int, fuelType string) error {
+	return s.repo.RegisterVehicle(userID, vehicleID, licensePlate, caVet, seatCapacity, fuelType)
 }
 
 func (s *VehicleService) LicensePlateExists(licensePlate string) (bool, error) {
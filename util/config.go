@@ -10,65 +10,121 @@ type Config struct {
 
 	SwaggerURL string `mapstructure:"SWAGGER_URL"`
 
-	LogFilename                    string `mapstructure:"LOG_FILENAME"`
-	LogMaxSize                     int    `mapstructure:"LOG_MAX_SIZE"` // in megabytes
-	LogMaxBackups                  int    `mapstructure:"LOG_MAX_BACKUPS"`
-	LogMaxAge                      int    `mapstructure:"LOG_MAX_AGE"`
-	LogCompress                    bool   `mapstructure:"LOG_COMPRESS"`
-	GinMode                        string `mapstructure:"GIN_MODE"`
-	DatabaseUsername               string `mapstructure:"DB_USER"`
-	DatabasePassword               string `mapstructure:"DB_PASSWORD"`
-	DatabaseName                   string `mapstructure:"DB_NAME"`
-	DatabaseHost                   string `mapstructure:"DB_HOST"`
-	DatabasePort                   int    `mapstructure:"DB_PORT"`
-	PasetoSercetKey                string `mapstructure:"PASETO_SECRET_KEY"`
-	PasetoExpiredDuration          int    `mapstructure:"PASETO_EXPIRED_DURATION"`
-	TwilioAccountSID               string `mapstructure:"TWILIO_ACCOUNT_SID"`
-	TwilioAuthToken                string `mapstructure:"TWILIO_AUTH_TOKEN"`
-	TwilioServiceSID               string `mapstructure:"TWILIO_SERVICE_SID"`
-	RedisHost                      string `mapstructure:"REDIS_HOST"`
-	RedisPort                      int    `mapstructure:"REDIS_PORT"`
-	RedisPassword                  string `mapstructure:"REDIS_PASSWORD"`
-	RedisDB                        int    `mapstructure:"REDIS_DB"`
-	RedisExpiredDuration           int    `mapstructure:"REDIS_EXPIRED_DURATION"`
-	RedisProtocol                  int    `mapstructure:"REDIS_PROTOCOL"`
-	FptAiApiKey                    string `mapstructure:"FPT_AI_API_KEY"`
-	FptAiApiUrl                    string `mapstructure:"FPT_AI_API_URL"`
-	EncryptionKey                  string `mapstructure:"ENCRYPTION_KEY"`
-	AccessTokenExpiredDuration     int    `mapstructure:"ACCESS_TOKEN_EXPIRED_DURATION"`
-	RefreshTokenExpiredDuration    int    `mapstructure:"REFRESH_TOKEN_EXPIRED_DURATION"`
-	MaxOTPAttempts                 int    `mapstructure:"MAX_OTP_ATTEMPTS"`
-	MaxOTPSendCount                int    `mapstructure:"MAX_OTP_SEND_COUNT"`
-	OtpExpiredDuration             int    `mapstructure:"OTP_EXPIRED_DURATION"`
-	OtpCooldownDuration            int    `mapstructure:"OTP_COOLDOWN_DURATION"`
-	OTPSendCountDuration           int    `mapstructure:"OTP_SEND_COUNT_DURATION"`
-	AmqpServerURL                  string `mapstructure:"AMQP_SERVER_URL"`
-	AmqpNotificationQueue          string `mapstructure:"AMQP_NOTIFICATION_QUEUE"`
-	GoongApiURL                    string `mapstructure:"GOONG_API_URL"`
-	GoongAPIKey                    string `mapstructure:"GOONG_API_KEY"`
-	GoongCacheExpiredDuration      int    `mapstructure:"GOONG_CACHE_EXPIRED_DURATION"`
-	GoongCacheAutocompleteDuration int    `mapstructure:"GOONG_CACHE_AUTOCOMPLETE_DURATION"` // 24 hours
-	GoongCachePlaceDetailDuration  int    `mapstructure:"GOONG_CACHE_PLACE_DETAIL_DURATION"` // 7 days
-	GoongCacheRouteDuration        int    `mapstructure:"GOONG_CACHE_ROUTE_DURATION"`        // 12 hours
-	GoongCacheDefaultDuration      int    `mapstructure:"GOONG_CACHE_DEFAULT_DURATION"`      // 1 hour (for backward compatibility)
-	FCMConfigPath                  string `mapstructure:"FCM_CONFIG_PATH"`
-	MaxNotificationRetries         int    `mapstructure:"MAX_NOTIFICATION_RETRIES"`
-	NotificationTimeout            int    `mapstructure:"NOTIFICATION_TIMEOUT"`
-	AmqpWebSocketQueue             string `mapstructure:"AMQP_WEBSOCKET_QUEUE"`
-	MaxWebSocketRetries            int    `mapstructure:"MAX_WEBSOCKET_RETRIES"`
-	CloudinaryCloudName            string `mapstructure:"CLOUDINARY_CLOUD_NAME"`
-	CloudinaryAPIKey               string `mapstructure:"CLOUDINARY_API_KEY"`
-	CloudinaryAPISecret            string `mapstructure:"CLOUDINARY_API_SECRET"`
-	AgoraAppID                     string `mapstructure:"AGORA_APP_ID"`
-	AgoraAppCertificate            string `mapstructure:"AGORA_APP_CERTIFICATE"`
-	BcryptCost                     int    `mapstructure:"BCRYPT_COST"`
-	MomoPartnerCode                string `mapstructure:"MOMO_PARTNER_CODE"`
-	MomoAccessKey                  string `mapstructure:"MOMO_ACCESS_KEY"`
-	MomoSecretKey                  string `mapstructure:"MOMO_SECRET_KEY"`
-	MomoPublicKey                  string `mapstructure:"MOMO_PUBLIC_KEY"`
-	MomoPaymentURL                 string `mapstructure:"MOMO_PAYMENT_URL"`
-	MomoPaymentNotifyURL           string `mapstructure:"MOMO_PAYMENT_NOTIFY_URL"`
-	MomoPaymentRedirectURL         string `mapstructure:"MOMO_PAYMENT_REDIRECT_URL"`
+	LogFilename                    string  `mapstructure:"LOG_FILENAME"`
+	LogMaxSize                     int     `mapstructure:"LOG_MAX_SIZE"` // in megabytes
+	LogMaxBackups                  int     `mapstructure:"LOG_MAX_BACKUPS"`
+	LogMaxAge                      int     `mapstructure:"LOG_MAX_AGE"`
+	LogCompress                    bool    `mapstructure:"LOG_COMPRESS"`
+	GinMode                        string  `mapstructure:"GIN_MODE"`
+	DatabaseUsername               string  `mapstructure:"DB_USER"`
+	DatabasePassword               string  `mapstructure:"DB_PASSWORD"`
+	DatabaseName                   string  `mapstructure:"DB_NAME"`
+	DatabaseHost                   string  `mapstructure:"DB_HOST"`
+	DatabasePort                   int     `mapstructure:"DB_PORT"`
+	PasetoSercetKey                string  `mapstructure:"PASETO_SECRET_KEY"`
+	PasetoExpiredDuration          int     `mapstructure:"PASETO_EXPIRED_DURATION"`
+	TwilioAccountSID               string  `mapstructure:"TWILIO_ACCOUNT_SID"`
+	TwilioAuthToken                string  `mapstructure:"TWILIO_AUTH_TOKEN"`
+	TwilioServiceSID               string  `mapstructure:"TWILIO_SERVICE_SID"`
+	RedisHost                      string  `mapstructure:"REDIS_HOST"`
+	RedisPort                      int     `mapstructure:"REDIS_PORT"`
+	RedisPassword                  string  `mapstructure:"REDIS_PASSWORD"`
+	RedisDB                        int     `mapstructure:"REDIS_DB"`
+	RedisExpiredDuration           int     `mapstructure:"REDIS_EXPIRED_DURATION"`
+	RedisProtocol                  int     `mapstructure:"REDIS_PROTOCOL"`
+	FptAiApiKey                    string  `mapstructure:"FPT_AI_API_KEY"`
+	FptAiApiUrl                    string  `mapstructure:"FPT_AI_API_URL"`
+	EncryptionKey                  string  `mapstructure:"ENCRYPTION_KEY"`
+	AccessTokenExpiredDuration     int     `mapstructure:"ACCESS_TOKEN_EXPIRED_DURATION"`
+	RefreshTokenExpiredDuration    int     `mapstructure:"REFRESH_TOKEN_EXPIRED_DURATION"`
+	MaxOTPAttempts                 int     `mapstructure:"MAX_OTP_ATTEMPTS"`
+	MaxOTPSendCount                int     `mapstructure:"MAX_OTP_SEND_COUNT"`
+	OtpExpiredDuration             int     `mapstructure:"OTP_EXPIRED_DURATION"`
+	OtpCooldownDuration            int     `mapstructure:"OTP_COOLDOWN_DURATION"`
+	OTPSendCountDuration           int     `mapstructure:"OTP_SEND_COUNT_DURATION"`
+	AmqpServerURL                  string  `mapstructure:"AMQP_SERVER_URL"`
+	AmqpNotificationQueue          string  `mapstructure:"AMQP_NOTIFICATION_QUEUE"`
+	GoongApiURL                    string  `mapstructure:"GOONG_API_URL"`
+	GoongAPIKey                    string  `mapstructure:"GOONG_API_KEY"`
+	GoongCacheExpiredDuration      int     `mapstructure:"GOONG_CACHE_EXPIRED_DURATION"`
+	GoongCacheAutocompleteDuration int     `mapstructure:"GOONG_CACHE_AUTOCOMPLETE_DURATION"` // 24 hours
+	GoongCachePlaceDetailDuration  int     `mapstructure:"GOONG_CACHE_PLACE_DETAIL_DURATION"` // 7 days
+	GoongCacheRouteDuration        int     `mapstructure:"GOONG_CACHE_ROUTE_DURATION"`        // 12 hours
+	GoongCacheDefaultDuration      int     `mapstructure:"GOONG_CACHE_DEFAULT_DURATION"`      // 1 hour (for backward compatibility)
+	FCMConfigPath                  string  `mapstructure:"FCM_CONFIG_PATH"`
+	MaxNotificationRetries         int     `mapstructure:"MAX_NOTIFICATION_RETRIES"`
+	NotificationTimeout            int     `mapstructure:"NOTIFICATION_TIMEOUT"`
+	AmqpWebSocketQueue             string  `mapstructure:"AMQP_WEBSOCKET_QUEUE"`
+	MaxWebSocketRetries            int     `mapstructure:"MAX_WEBSOCKET_RETRIES"`
+	CloudinaryCloudName            string  `mapstructure:"CLOUDINARY_CLOUD_NAME"`
+	CloudinaryAPIKey               string  `mapstructure:"CLOUDINARY_API_KEY"`
+	CloudinaryAPISecret            string  `mapstructure:"CLOUDINARY_API_SECRET"`
+	AgoraAppID                     string  `mapstructure:"AGORA_APP_ID"`
+	AgoraAppCertificate            string  `mapstructure:"AGORA_APP_CERTIFICATE"`
+	BcryptCost                     int     `mapstructure:"BCRYPT_COST"`
+	MomoPartnerCode                string  `mapstructure:"MOMO_PARTNER_CODE"`
+	MomoAccessKey                  string  `mapstructure:"MOMO_ACCESS_KEY"`
+	MomoSecretKey                  string  `mapstructure:"MOMO_SECRET_KEY"`
+	MomoPublicKey                  string  `mapstructure:"MOMO_PUBLIC_KEY"`
+	MomoPaymentURL                 string  `mapstructure:"MOMO_PAYMENT_URL"`
+	MomoPaymentNotifyURL           string  `mapstructure:"MOMO_PAYMENT_NOTIFY_URL"`
+	MomoPaymentRedirectURL         string  `mapstructure:"MOMO_PAYMENT_REDIRECT_URL"`
+	MatchWeightPickupDistance      float64 `mapstructure:"MATCH_WEIGHT_PICKUP_DISTANCE"`
+	MatchWeightDropoffDistance     float64 `mapstructure:"MATCH_WEIGHT_DROPOFF_DISTANCE"`
+	MatchWeightDetour              float64 `mapstructure:"MATCH_WEIGHT_DETOUR"`
+	MatchWeightTimeGap             float64 `mapstructure:"MATCH_WEIGHT_TIME_GAP"`
+	MatchWeightSharedRoute         float64 `mapstructure:"MATCH_WEIGHT_SHARED_ROUTE"`
+	MatchWeightRating              float64 `mapstructure:"MATCH_WEIGHT_RATING"`
+	MatchWeightPreferences         float64 `mapstructure:"MATCH_WEIGHT_PREFERENCES"`
+	RecurringRideHorizonDays       int     `mapstructure:"RECURRING_RIDE_HORIZON_DAYS"`
+	RideTestMode                   bool    `mapstructure:"RIDE_TEST_MODE"`
+	RideExpiryGracePeriod          int     `mapstructure:"RIDE_EXPIRY_GRACE_PERIOD"`   // in minutes
+	RideNoShowGracePeriod          int     `mapstructure:"RIDE_NO_SHOW_GRACE_PERIOD"`  // in minutes
+	AppUTCOffset                   float64 `mapstructure:"APP_UTC_OFFSET"`             // in hours, time zone of the days and times shown to the users
+	FareStrategy                   string  `mapstructure:"FARE_STRATEGY"`              // fuel_cost or per_km
+	FareDefaultPerKmRate           float64 `mapstructure:"FARE_DEFAULT_PER_KM_RATE"`   // per kilometer, for vehicle types without a rate
+	FareDefaultFuelConsumed        float64 `mapstructure:"FARE_DEFAULT_FUEL_CONSUMED"` // liters per 100 kilometers, for quotes without a vehicle
+	FarePeakHours                  string  `mapstructure:"FARE_PEAK_HOURS"`            // e.g. 7-9,17-19
+	FarePeakMultiplier             float64 `mapstructure:"FARE_PEAK_MULTIPLIER"`
+	FareUTCOffset                  float64 `mapstructure:"FARE_UTC_OFFSET"` // in hours, time zone of the peak hours, APP_UTC_OFFSET when unset
+	FareMinimum                    float64 `mapstructure:"FARE_MINIMUM"`
+	FareCommissionRate             float64 `mapstructure:"FARE_COMMISSION_RATE"`     // between 0 and 1
+	FareElectricityTariff          float64 `mapstructure:"FARE_ELECTRICITY_TARIFF"`  // per kWh, used for electric vehicles
+	RouteAlertMaxPerHour           int     `mapstructure:"ROUTE_ALERT_MAX_PER_HOUR"` // route alert notifications a user can receive per hour
+	DeepLinkBaseURL                string  `mapstructure:"DEEP_LINK_BASE_URL"`       // e.g. shareway://
+	PickupCodeSecret               string  `mapstructure:"PICKUP_CODE_SECRET"`       // signs the pickup PINs and QR codes, ENCRYPTION_KEY is used when empty
+	PickupCodeTTL                  int     `mapstructure:"PICKUP_CODE_TTL"`          // in minutes
+	PickupCodeMaxAttempts          int     `mapstructure:"PICKUP_CODE_MAX_ATTEMPTS"`
+	GeofencePickupRadius           float64 `mapstructure:"GEOFENCE_PICKUP_RADIUS"`  // in meters
+	GeofenceDropoffRadius          float64 `mapstructure:"GEOFENCE_DROPOFF_RADIUS"` // in meters
+	LocationMaxAge                 int     `mapstructure:"LOCATION_MAX_AGE"`        // in seconds
+	LocationMaxSpeed               float64 `mapstructure:"LOCATION_MAX_SPEED"`      // in km/h
+	TrailFlushInterval             int     `mapstructure:"TRAIL_FLUSH_INTERVAL"`    // in seconds
+	TrailFlushBatchSize            int     `mapstructure:"TRAIL_FLUSH_BATCH_SIZE"`
+	TrailDownsampleAfter           int     `mapstructure:"TRAIL_DOWNSAMPLE_AFTER"`    // in days
+	TrailDownsampleInterval        int     `mapstructure:"TRAIL_DOWNSAMPLE_INTERVAL"` // in seconds, one sample is kept per interval
+	TrailRetention                 int     `mapstructure:"TRAIL_RETENTION"`           // in days
+	ETAOffRouteDistance            float64 `mapstructure:"ETA_OFF_ROUTE_DISTANCE"`    // in meters
+	ETAProviderInterval            int     `mapstructure:"ETA_PROVIDER_INTERVAL"`     // in seconds, per ride
+	DeviationDistance              float64 `mapstructure:"DEVIATION_DISTANCE"`        // in meters from the planned route
+	DeviationDuration              int     `mapstructure:"DEVIATION_DURATION"`        // in seconds
+	LongStopRadius                 float64 `mapstructure:"LONG_STOP_RADIUS"`          // in meters
+	LongStopDuration               int     `mapstructure:"LONG_STOP_DURATION"`        // in seconds
+	TwilioFromNumber               string  `mapstructure:"TWILIO_FROM_NUMBER"`        // Sender of the SMS that are not OTPs, E.164 format
+	TripShareBaseURL               string  `mapstructure:"TRIP_SHARE_BASE_URL"`       // Page showing a shared trip, the token is appended as a query parameter
+	EmergencyContactMax            int     `mapstructure:"EMERGENCY_CONTACT_MAX"`
+	SOSTripShareTTL                int     `mapstructure:"SOS_TRIP_SHARE_TTL"`          // in minutes
+	SOSSnapshotPositions           int     `mapstructure:"SOS_SNAPSHOT_POSITIONS"`      // Last positions of the ride kept with the SOS
+	TripShareTTL                   int     `mapstructure:"TRIP_SHARE_TTL"`              // in minutes
+	TripShareMaxTTL                int     `mapstructure:"TRIP_SHARE_MAX_TTL"`          // in minutes
+	TripShareStreamInterval        int     `mapstructure:"TRIP_SHARE_STREAM_INTERVAL"`  // in seconds
+	ReceiptBaseURL                 string  `mapstructure:"RECEIPT_BASE_URL"`            // Receipt endpoint, the ride ID is appended as a query parameter
+	ReceiptFontPath                string  `mapstructure:"RECEIPT_FONT_PATH"`           // TrueType font of the PDF receipts, it must cover Vietnamese
+	EmissionFactorRON95            float64 `mapstructure:"EMISSION_FACTOR_RON95"`       // kg of CO2 per liter
+	EmissionFactorE5RON92          float64 `mapstructure:"EMISSION_FACTOR_E5_RON92"`    // kg of CO2 per liter
+	EmissionFactorDiesel           float64 `mapstructure:"EMISSION_FACTOR_DIESEL"`      // kg of CO2 per liter
+	EmissionFactorElectricity      float64 `mapstructure:"EMISSION_FACTOR_ELECTRICITY"` // kg of CO2 per kWh of the grid
+	RatingWindow                   int     `mapstructure:"RATING_WINDOW"`               // in hours after the end of the ride
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -89,6 +145,89 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetDefault("LOG_MAX_AGE", 28)
 	viper.SetDefault("LOG_COMPRESS", true)
 
+	viper.SetDefault("MATCH_WEIGHT_PICKUP_DISTANCE", 0.2)
+	viper.SetDefault("MATCH_WEIGHT_DROPOFF_DISTANCE", 0.2)
+	viper.SetDefault("MATCH_WEIGHT_DETOUR", 0.15)
+	viper.SetDefault("MATCH_WEIGHT_TIME_GAP", 0.15)
+	viper.SetDefault("MATCH_WEIGHT_SHARED_ROUTE", 0.15)
+	viper.SetDefault("MATCH_WEIGHT_RATING", 0.15)
+	viper.SetDefault("MATCH_WEIGHT_PREFERENCES", 0.1)
+
+	viper.SetDefault("RECURRING_RIDE_HORIZON_DAYS", 7)
+
+	// Test mode skips the location checks and only logs illegal ride status transitions
+	viper.SetDefault("RIDE_TEST_MODE", false)
+
+	viper.SetDefault("RIDE_EXPIRY_GRACE_PERIOD", 30)
+	viper.SetDefault("RIDE_NO_SHOW_GRACE_PERIOD", 30)
+
+	// Time zone of the users, the days, the departure times and the months are in it
+	viper.SetDefault("APP_UTC_OFFSET", 7)
+
+	// The default pricing only shares the fuel cost of the trip, the other strategies are disabled
+	viper.SetDefault("FARE_STRATEGY", "fuel_cost")
+	viper.SetDefault("FARE_DEFAULT_PER_KM_RATE", 5000)
+	viper.SetDefault("FARE_DEFAULT_FUEL_CONSUMED", 7)
+	viper.SetDefault("FARE_PEAK_HOURS", "7-9,17-19")
+	viper.SetDefault("FARE_PEAK_MULTIPLIER", 1)
+	viper.SetDefault("FARE_MINIMUM", 0)
+	viper.SetDefault("FARE_COMMISSION_RATE", 0)
+	viper.SetDefault("FARE_ELECTRICITY_TARIFF", 3858)
+
+	viper.SetDefault("ROUTE_ALERT_MAX_PER_HOUR", 5)
+	viper.SetDefault("DEEP_LINK_BASE_URL", "shareway://")
+
+	// A pickup code is locked after too many wrong attempts, the hitcher has to issue a new one
+	viper.SetDefault("PICKUP_CODE_TTL", 30)
+	viper.SetDefault("PICKUP_CODE_MAX_ATTEMPTS", 5)
+
+	// The driver must be near the meeting points with a recent and plausible location to start and end a ride
+	viper.SetDefault("GEOFENCE_PICKUP_RADIUS", 100)
+	viper.SetDefault("GEOFENCE_DROPOFF_RADIUS", 200)
+	viper.SetDefault("LOCATION_MAX_AGE", 120)
+	viper.SetDefault("LOCATION_MAX_SPEED", 160)
+
+	// The location samples are buffered in Redis and written in batches, old trails are downsampled then purged
+	viper.SetDefault("TRAIL_FLUSH_INTERVAL", 15)
+	viper.SetDefault("TRAIL_FLUSH_BATCH_SIZE", 500)
+	viper.SetDefault("TRAIL_DOWNSAMPLE_AFTER", 30)
+	viper.SetDefault("TRAIL_DOWNSAMPLE_INTERVAL", 60)
+	viper.SetDefault("TRAIL_RETENTION", 365)
+
+	// The ETA is estimated along the planned route, Goong is only asked when the driver leaves it
+	viper.SetDefault("ETA_OFF_ROUTE_DISTANCE", 200)
+	viper.SetDefault("ETA_PROVIDER_INTERVAL", 60)
+
+	// The passenger and the admins are alerted when the driver leaves the route or stops for long during a ride
+	viper.SetDefault("DEVIATION_DISTANCE", 500)
+	viper.SetDefault("DEVIATION_DURATION", 120)
+	viper.SetDefault("LONG_STOP_RADIUS", 50)
+	viper.SetDefault("LONG_STOP_DURATION", 600)
+
+	// The emergency contacts get a link to the live trip when the user raises an SOS
+	viper.SetDefault("TRIP_SHARE_BASE_URL", "http://localhost:8080/trip-share/live")
+	viper.SetDefault("EMERGENCY_CONTACT_MAX", 5)
+	viper.SetDefault("SOS_TRIP_SHARE_TTL", 360)
+	viper.SetDefault("SOS_SNAPSHOT_POSITIONS", 20)
+
+	// The passengers share a link to follow their ride live without the app, until the ride ends
+	viper.SetDefault("TRIP_SHARE_TTL", 240)
+	viper.SetDefault("TRIP_SHARE_MAX_TTL", 1440)
+	viper.SetDefault("TRIP_SHARE_STREAM_INTERVAL", 5)
+
+	// Receipts of the completed rides, the font is the one of the font-dejavu Alpine package
+	viper.SetDefault("RECEIPT_BASE_URL", "http://localhost:8080/ride/receipt")
+	viper.SetDefault("RECEIPT_FONT_PATH", "/usr/share/fonts/dejavu/DejaVuSans.ttf")
+
+	// CO2 released by the fuels, the impact statistics are computed with the current factors on every request
+	viper.SetDefault("EMISSION_FACTOR_RON95", 2.31)
+	viper.SetDefault("EMISSION_FACTOR_E5_RON92", 2.23)
+	viper.SetDefault("EMISSION_FACTOR_DIESEL", 2.68)
+	viper.SetDefault("EMISSION_FACTOR_ELECTRICITY", 0.68)
+
+	// Ratings
+	viper.SetDefault("RATING_WINDOW", 168)
+
 	// Read config
 	err = viper.ReadInConfig()
 	if err != nil {
@@ -97,5 +236,15 @@ func LoadConfig(path string) (config Config, err error) {
 
 	// Unmarshal config
 	err = viper.Unmarshal(&config)
+	if err != nil {
+		return
+	}
+
+	// The peak hours are in the time zone of the users unless told otherwise. It has no default
+	// to tell when it is unset, so it is read directly rather than by Unmarshal
+	config.FareUTCOffset = config.AppUTCOffset
+	if viper.IsSet("FARE_UTC_OFFSET") {
+		config.FareUTCOffset = viper.GetFloat64("FARE_UTC_OFFSET")
+	}
 	return
 }
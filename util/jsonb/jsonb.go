@@ -14,10 +14,14 @@ func (jsonField JSONB) Value() (driver.Value, error) {
 }
 
 // Scan Unmarshal
-func (jsonField JSONB) Scan(value interface{}) error {
+func (jsonField *JSONB) Scan(value interface{}) error {
+	if value == nil {
+		*jsonField = nil
+		return nil
+	}
 	data, ok := value.([]byte)
 	if !ok {
 		return errors.New("type assertion to []byte failed")
 	}
-	return json.Unmarshal(data, &jsonField)
+	return json.Unmarshal(data, jsonField)
 }
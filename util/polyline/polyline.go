@@ -3,6 +3,9 @@ package polyline
 import (
 	"database/sql/driver"
 	"fmt"
+	"math"
+
+	"github.com/twpayne/go-polyline"
 )
 
 type Polyline string
@@ -27,3 +30,25 @@ func (p *Polyline) Scan(value interface{}) error {
 func (p Polyline) Value() (driver.Value, error) {
 	return string(p), nil
 }
+
+// Bounds returns the bounding box (min/max latitude and longitude) of the encoded polyline
+func (p Polyline) Bounds() (minLat, maxLat, minLng, maxLng float64, err error) {
+	coords, _, err := polyline.DecodeCoords([]byte(p))
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	if len(coords) == 0 {
+		return 0, 0, 0, 0, fmt.Errorf("empty polyline")
+	}
+
+	minLat, maxLat = coords[0][0], coords[0][0]
+	minLng, maxLng = coords[0][1], coords[0][1]
+	for _, coord := range coords[1:] {
+		minLat = math.Min(minLat, coord[0])
+		maxLat = math.Max(maxLat, coord[0])
+		minLng = math.Min(minLng, coord[1])
+		maxLng = math.Max(maxLng, coord[1])
+	}
+
+	return minLat, maxLat, minLng, maxLng, nil
+}